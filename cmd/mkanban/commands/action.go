@@ -0,0 +1,1213 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/application/usecase/action"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/mapper"
+)
+
+var actionIDInvalidRE = regexp.MustCompile(`[^a-z0-9]+`)
+
+// actionCmd represents the action command
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "Manage automated actions",
+	Long: `Manage actions - automations that run when tasks change or on a schedule.
+
+An action combines a trigger (an event or a time schedule), optional
+conditions on the task, and what to do: send a notification, run a script,
+update, move or create a task.
+
+Examples:
+  # List all actions
+  mkanban action list
+
+  # Show an action and its definition
+  mkanban action show due-tomorrow-reminder
+
+  # Create an action from flags
+  mkanban action create "Notify on done" --event task.moved --condition "column eq done" \
+    --type notification --title "Task finished" --message "A task was moved to Done"
+
+  # Create an action in your editor
+  mkanban action create --edit
+
+  # Check whether an action would fire for a task, without running it
+  mkanban action test notify-on-done TASK-123
+
+  # Show when time-triggered actions would fire over the next week
+  mkanban action simulate
+
+  # Enable, disable or delete an action
+  mkanban action disable notify-on-done
+  mkanban action enable notify-on-done
+  mkanban action delete notify-on-done
+
+  # Show the most recent action executions
+  mkanban action history`,
+}
+
+// actionListCmd lists actions
+var actionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List actions",
+	Long: `List all actions with their scope, trigger and action type.
+
+Examples:
+  # List all actions
+  mkanban action list
+
+  # List only enabled event-triggered actions
+  mkanban action list --enabled --trigger event
+
+  # List actions of a board
+  mkanban action list --scope board --scope-id my-project`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		scope, _ := cmd.Flags().GetString("scope")
+		scopeID, _ := cmd.Flags().GetString("scope-id")
+		enabledOnly, _ := cmd.Flags().GetBool("enabled")
+		trigger, _ := cmd.Flags().GetString("trigger")
+
+		req := action.ListActionsRequest{
+			ScopeID:     scopeID,
+			EnabledOnly: enabledOnly,
+		}
+		if scope != "" {
+			actionScope := valueobject.ActionScope(scope)
+			if !actionScope.IsValid() {
+				return fmt.Errorf("invalid scope '%s' (use global, board, column or task)", scope)
+			}
+			req.Scope = &actionScope
+		}
+		if trigger != "" {
+			triggerType := entity.TriggerType(trigger)
+			if triggerType != entity.TriggerTypeEvent && triggerType != entity.TriggerTypeTime {
+				return fmt.Errorf("invalid trigger type '%s' (use event or time)", trigger)
+			}
+			req.TriggerType = &triggerType
+		}
+
+		actions, err := container.ListActionsUseCase.Execute(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		actionDTOs := make([]dto.ActionDTO, 0, len(actions))
+		for _, a := range actions {
+			if enabledOnly && !a.Enabled() {
+				continue
+			}
+			actionDTOs = append(actionDTOs, dto.ActionToDTO(a))
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(actionDTOs)
+		default:
+			if len(actionDTOs) == 0 {
+				printer.Info("No actions found")
+				printer.Info("Create one with: mkanban action create <name>")
+				return nil
+			}
+
+			printer.Header("Actions")
+			fmt.Println()
+
+			headers := []string{"ID", "Name", "Scope", "Trigger", "Type", "Enabled", "Last Run"}
+			rows := make([][]string, 0, len(actionDTOs))
+			for _, a := range actionDTOs {
+				scope := a.Scope
+				if a.ScopeID != "" {
+					scope = fmt.Sprintf("%s:%s", a.Scope, a.ScopeID)
+				}
+				lastRun := "never"
+				if a.LastRun != nil {
+					lastRun = a.LastRun.Format("2006-01-02 15:04")
+				}
+
+				rows = append(rows, []string{
+					a.ID,
+					a.Name,
+					scope,
+					a.TriggerType,
+					a.ActionType,
+					strconv.FormatBool(a.Enabled),
+					lastRun,
+				})
+			}
+
+			printer.Table(headers, rows)
+			return nil
+		}
+	},
+}
+
+// actionDetails is the structured output of action show
+type actionDetails struct {
+	dto.ActionDTO `yaml:",inline"`
+	Definition    config.ActionTemplate `json:"definition" yaml:"definition"`
+}
+
+// actionShowCmd shows a single action
+var actionShowCmd = &cobra.Command{
+	Use:   "show <action-id>",
+	Short: "Show action details",
+	Long: `Show an action's state and its full definition.
+
+The definition is printed in the same YAML shape used by action templates in
+the config file and by 'mkanban action create --edit'.
+
+Examples:
+  # Show an action
+  mkanban action show notify-on-done
+
+  # Show an action in JSON format
+  mkanban action show notify-on-done --output json`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+
+		a, err := container.GetActionUseCase.Execute(ctx, resolvedArgs[0])
+		if err != nil {
+			return err
+		}
+
+		details := actionDetails{
+			ActionDTO:  dto.ActionToDTO(a),
+			Definition: mapper.ActionToTemplate(a),
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(details)
+		default:
+			printer.Header("%s", details.Name)
+			fmt.Println()
+			printer.Println("ID:          %s", details.ID)
+			if details.Description != "" {
+				printer.Println("Description: %s", details.Description)
+			}
+			scope := details.Scope
+			if details.ScopeID != "" {
+				scope = fmt.Sprintf("%s (%s)", details.Scope, details.ScopeID)
+			}
+			printer.Println("Scope:       %s", scope)
+			printer.Println("Enabled:     %t", details.Enabled)
+			printer.Println("Created:     %s", details.CreatedAt.Format("2006-01-02 15:04"))
+			printer.Println("Modified:    %s", details.ModifiedAt.Format("2006-01-02 15:04"))
+			if details.LastRun != nil {
+				printer.Println("Last run:    %s", details.LastRun.Format("2006-01-02 15:04"))
+			}
+			if details.ConsecutiveFailures > 0 {
+				printer.Warning("Failures:    %d in a row", details.ConsecutiveFailures)
+			}
+			fmt.Println()
+
+			definition, err := yaml.Marshal(details.Definition)
+			if err != nil {
+				return fmt.Errorf("failed to render action definition: %w", err)
+			}
+			printer.Bold("Definition:")
+			fmt.Print(string(definition))
+			return nil
+		}
+	},
+}
+
+// actionCreateCmd creates a new action
+var actionCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a new action",
+	Long: `Create a new action from flags, from a config template, or in your editor.
+
+With --edit the action is opened in $EDITOR as YAML in the action template
+shape, pre-filled from any other flags given. --from-template starts from one
+of the templates in the 'actions.templates' section of the config file.
+
+Trigger flags:
+  --event <type>        fire on an event (task.created, task.moved, task.updated, ...)
+  --schedule <type>     fire on a schedule: absolute, relative_due_date,
+                        relative_creation or recurring
+  --time, --offset, --cron   schedule parameters
+
+Action flags (--type notification|script|command|webhook|task_mutation|
+task_movement|task_creation):
+  notification    --title, --message
+  script          --script, --env KEY=VALUE
+  command         --command, --arg (repeatable), --env KEY=VALUE, --timeout
+  webhook         --url, --header KEY=VALUE, --secret, --timeout, --retries
+  task_mutation   --set-priority, --bump-priority, --set-status, --add-tag, --remove-tag
+  task_movement   --target-column
+  task_creation   --task-title, --task-column
+
+Conditions are given as "<field> <operator> <value>", e.g. "priority eq high"
+or "column in todo,doing". All conditions must match.
+
+Aggregate actions run once per board or column (--group-by) over all the tasks
+their conditions match, instead of once per task. --aggregate adds a condition
+over those tasks: "count <op> <value>" or "<sum|avg|min|max> <field> <op>
+<value>", where op is eq, ne, gt, lt, gte or lte and value a number or
+wip_limit. Fields are estimated_hours, tracked_hours, age_days, overdue_days
+or a numeric metadata key. Notification and task templates can iterate over
+the matched tasks, e.g. "{{.Count}} overdue:{{range .Tasks}} {{.ID}}{{end}}".
+
+Examples:
+  # Notify when a high priority task is created
+  mkanban action create "High priority alert" --event task.created \
+    --condition "priority eq high" --type notification \
+    --title "High priority task" --message "A high priority task was added"
+
+  # Run a script one day before a task is due, on the current board only
+  mkanban action create "Due soon" --schedule relative_due_date --offset 1d \
+    --type script --script ~/bin/remind.sh --scope board
+
+  # Post moved tasks to a chat bot, signed with a shared secret
+  mkanban action create "Moved hook" --event task.moved --type webhook \
+    --url https://hooks.example.com/mkanban --secret s3cret --retries 3
+
+  # Open the task's note in a new tmux window when work starts
+  mkanban action create "Open note" --event task.moved --condition "column eq doing" \
+    --type command --command tmux --arg new-window --arg "nvim {{.Task.ID}}.md"
+
+  # Every morning, one notification listing the overdue tasks of each board
+  mkanban action create "Overdue digest" --schedule recurring --cron "0 9 * * *" \
+    --group-by board --condition "is_overdue eq true" --type notification \
+    --title "{{.Count}} overdue on {{.Board.Name}}" \
+    --message "{{range .Tasks}}{{.ID}} {{.Title}}\n{{end}}"
+
+  # Raise the priority of tasks that outstay their column's aging policy
+  mkanban action create "Escalate" --event task.escalated \
+    --type task_mutation --bump-priority
+
+  # Tag the tasks of a column when it goes over its WIP limit
+  mkanban action create "WIP exceeded" --event task.moved --group-by column \
+    --aggregate "count gt wip_limit" --type task_mutation --add-tag over-wip
+
+  # Start from a config template and finish in the editor
+  mkanban action create --from-template due-tomorrow-reminder --edit`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		template, err := actionTemplateFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		edit, _ := cmd.Flags().GetBool("edit")
+		if edit {
+			template, err = editActionTemplate(template)
+			if err != nil {
+				return err
+			}
+			if template == nil {
+				printer.Info("Action creation cancelled")
+				return nil
+			}
+		}
+
+		if template.Name == "" {
+			return fmt.Errorf("action name is required")
+		}
+		if template.ID == "" {
+			template.ID = actionIDFromName(template.Name)
+		}
+
+		scope, scopeID, err := actionScopeFromFlags(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
+		if template.Trigger.Type == "" {
+			return fmt.Errorf("a trigger is required (use --event or --schedule)")
+		}
+		trigger, err := mapper.TriggerFromConfig(template.Trigger)
+		if err != nil {
+			return err
+		}
+		actionType, err := mapper.ActionTypeFromConfig(template.ActionType)
+		if err != nil {
+			return err
+		}
+		conditions, err := mapper.ConditionsFromConfig(template.Conditions)
+		if err != nil {
+			return fmt.Errorf("invalid conditions: %w", err)
+		}
+		aggregation, err := mapper.AggregationFromConfig(template.Aggregate)
+		if err != nil {
+			return err
+		}
+
+		created, err := container.CreateActionUseCase.Execute(ctx, action.CreateActionRequest{
+			ID:          template.ID,
+			Name:        template.Name,
+			Description: template.Description,
+			Scope:       scope,
+			ScopeID:     scopeID,
+			Trigger:     trigger,
+			ActionType:  actionType,
+			Conditions:  conditions,
+			Aggregation: aggregation,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(dto.ActionToDTO(created))
+		default:
+			printer.Success("Created action: %s", created.Name())
+			printer.Println("  ID:      %s", created.ID())
+			printer.Println("  Trigger: %s", created.Trigger().Type())
+			printer.Println("  Type:    %s", created.ActionType().Type())
+			return nil
+		}
+	},
+}
+
+// actionEnableCmd enables an action
+var actionEnableCmd = &cobra.Command{
+	Use:   "enable <action-id>",
+	Short: "Enable an action",
+	Long: `Enable an action so it runs when triggered.
+
+Enabling an action also clears its consecutive failure count after it was
+disabled automatically.
+
+Examples:
+  mkanban action enable notify-on-done`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+
+		a, err := container.EnableActionUseCase.Execute(ctx, resolvedArgs[0])
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" || outputFormat == "yaml" {
+			return formatter.Print(dto.ActionToDTO(a))
+		}
+		printer.Success("Enabled action: %s", a.Name())
+		return nil
+	},
+}
+
+// actionDisableCmd disables an action
+var actionDisableCmd = &cobra.Command{
+	Use:   "disable <action-id>",
+	Short: "Disable an action",
+	Long: `Disable an action. It is kept, but no longer runs until enabled again.
+
+Examples:
+  mkanban action disable notify-on-done`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+
+		a, err := container.DisableActionUseCase.Execute(ctx, resolvedArgs[0])
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" || outputFormat == "yaml" {
+			return formatter.Print(dto.ActionToDTO(a))
+		}
+		printer.Success("Disabled action: %s", a.Name())
+		return nil
+	},
+}
+
+// actionDeleteCmd deletes an action
+var actionDeleteCmd = &cobra.Command{
+	Use:   "delete <action-id>",
+	Short: "Delete an action",
+	Long: `Delete an action together with its execution history.
+
+WARNING: This action cannot be undone.
+
+Examples:
+  # Delete an action (with confirmation)
+  mkanban action delete notify-on-done
+
+  # Delete without confirmation
+  mkanban action delete notify-on-done --force`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+		actionID := resolvedArgs[0]
+
+		force, _ := cmd.Flags().GetBool("force")
+
+		a, err := container.GetActionUseCase.Execute(ctx, actionID)
+		if err != nil {
+			return err
+		}
+
+		// Confirm deletion unless --force is used
+		if !force {
+			printer.Warning("About to delete action: %s - %s", a.ID(), a.Name())
+			printer.Warning("This action cannot be undone!")
+			fmt.Print("\nType the action ID to confirm: ")
+
+			var confirmation string
+			fmt.Scanln(&confirmation)
+
+			if confirmation != actionID {
+				printer.Info("Deletion cancelled")
+				return nil
+			}
+		}
+
+		if err := container.DeleteActionUseCase.Execute(ctx, actionID); err != nil {
+			return err
+		}
+
+		printer.Success("Deleted action: %s", a.Name())
+		return nil
+	},
+}
+
+// actionTestCmd evaluates an action against a task without running it
+var actionTestCmd = &cobra.Command{
+	Use:   "test <action-id> <task-id>",
+	Short: "Check whether an action would fire for a task",
+	Long: `Evaluate an action's scope, trigger and conditions against a task and
+show whether it would fire and what it would do. Nothing is executed and
+nothing is changed.
+
+Event triggers are tested against their own event unless --event simulates a
+different one. Time triggers are evaluated at the current time, or at --at.
+
+Examples:
+  # Would this action fire for TASK-123?
+  mkanban action test notify-on-done TASK-123
+
+  # Evaluate a time trigger at a specific moment
+  mkanban action test due-soon TASK-123 --at "2025-06-01 09:00"
+
+  # Simulate a different event
+  mkanban action test notify-on-done TASK-123 --event task.updated`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 2)
+		if err != nil {
+			return err
+		}
+		actionID, taskRef := resolvedArgs[0], resolvedArgs[1]
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		task, err := findTaskDTO(ctx, boardID, taskRef)
+		if err != nil {
+			return err
+		}
+
+		req := action.TestActionRequest{
+			ActionID: actionID,
+			BoardID:  boardID,
+			TaskID:   task.ID,
+		}
+
+		event, _ := cmd.Flags().GetString("event")
+		if event != "" {
+			req.EventType = valueobject.EventType(event)
+			if !req.EventType.IsValid() {
+				return fmt.Errorf("invalid event type '%s'", event)
+			}
+		}
+
+		at, _ := cmd.Flags().GetString("at")
+		if at != "" {
+			req.CurrentTime, err = parseActionTime(at)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err := container.TestActionUseCase.Execute(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		default:
+			printer.Header("Testing %s against %s", result.Action.Name, task.ShortID)
+			fmt.Println()
+			printer.Subtle("Task in column %s, evaluated at %s", result.ColumnName, result.EvaluatedAt.Format("2006-01-02 15:04"))
+			if result.EventType != "" {
+				printer.Subtle("Simulated event: %s", result.EventType)
+			}
+			fmt.Println()
+
+			printActionCheck("Enabled", result.Enabled)
+			printActionCheck("Scope matches", result.ScopeMatches)
+			printActionCheck("Trigger fires", result.TriggerFires)
+			printActionCheck("Conditions met", result.ConditionsMet)
+			fmt.Println()
+
+			if len(result.MatchedTasks) > 0 {
+				printer.Subtle("Matched %d tasks: %s", len(result.MatchedTasks), strings.Join(result.MatchedTasks, ", "))
+				fmt.Println()
+			}
+
+			if result.WouldFire {
+				printer.Success("Would fire: %s", result.Effect)
+			} else {
+				printer.Info("Would not fire (would have: %s)", result.Effect)
+			}
+			return nil
+		}
+	},
+}
+
+// actionSimulateCmd replays time triggers over a time window
+var actionSimulateCmd = &cobra.Command{
+	Use:   "simulate [action-id...]",
+	Short: "Show when time-triggered actions would fire",
+	Long: `Replay the time triggers of actions across all tasks over a time window
+and print a timeline of the executions that would happen. Nothing is executed
+and nothing is changed.
+
+Triggers are evaluated every --step (default: the daemon's check interval),
+tracking each action's last run the same way the daemon does. Disabled
+actions are simulated too, so rules can be checked before enabling them.
+Without action IDs every time-triggered action is simulated.
+
+Examples:
+  # What will fire on the current board in the next 7 days?
+  mkanban action simulate
+
+  # Simulate one action across all boards for the next 30 days
+  mkanban action simulate due-tomorrow-reminder --all-boards --for 30d
+
+  # Simulate a specific window with a coarser step
+  mkanban action simulate --from 2025-06-01 --to 2025-06-15 --step 1h`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		req := action.SimulateActionsRequest{ActionIDs: args}
+
+		allBoards, _ := cmd.Flags().GetBool("all-boards")
+		if !allBoards {
+			boardID, err := getBoardID(ctx)
+			if err != nil {
+				return err
+			}
+			req.BoardID = boardID
+		}
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		window, _ := cmd.Flags().GetString("for")
+		step, _ := cmd.Flags().GetString("step")
+
+		var err error
+		req.From = time.Now()
+		if from != "" {
+			if req.From, err = parseActionTime(from); err != nil {
+				return err
+			}
+		}
+		if to != "" {
+			if req.To, err = parseActionTime(to); err != nil {
+				return err
+			}
+		} else {
+			duration, err := mapper.ParseOffset(window)
+			if err != nil {
+				return fmt.Errorf("invalid --for: %w", err)
+			}
+			req.To = req.From.Add(duration)
+		}
+		if step != "" {
+			if req.Step, err = mapper.ParseOffset(step); err != nil {
+				return fmt.Errorf("invalid --step: %w", err)
+			}
+		}
+
+		timeline, err := container.SimulateActionsUseCase.Execute(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(timeline)
+		default:
+			printer.Header("Simulated runs %s - %s", req.From.Format("2006-01-02 15:04"), req.To.Format("2006-01-02 15:04"))
+			fmt.Println()
+
+			if len(timeline) == 0 {
+				printer.Info("No time-triggered actions would fire in this window")
+				return nil
+			}
+
+			headers := []string{"Time", "Action", "Task", "Effect"}
+			rows := make([][]string, 0, len(timeline))
+			for _, run := range timeline {
+				target := run.TaskID
+				if len(run.TaskIDs) > 0 {
+					target = fmt.Sprintf("%d tasks", len(run.TaskIDs))
+				}
+				if target == "" {
+					target = run.BoardID
+				}
+				rows = append(rows, []string{
+					run.At.Format("2006-01-02 15:04"),
+					run.ActionName,
+					target,
+					run.Effect,
+				})
+			}
+
+			printer.Table(headers, rows)
+			fmt.Println()
+			printer.Info("Total: %d runs", len(timeline))
+			return nil
+		}
+	},
+}
+
+// printActionCheck prints a single pass/fail line of action test
+func printActionCheck(label string, ok bool) {
+	if ok {
+		printer.Success("%-15s yes", label)
+	} else {
+		printer.Error("%-15s no", label)
+	}
+}
+
+// findTaskDTO finds a task on a board by its short or full ID
+func findTaskDTO(ctx context.Context, boardID, taskID string) (*dto.TaskDTO, error) {
+	tasks, err := container.ListTasksUseCase.Execute(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	for i, task := range tasks {
+		if task.ShortID == taskID || task.ID == taskID {
+			return &tasks[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("task '%s' not found", taskID)
+}
+
+// parseActionTime parses the --at flag of action test
+func parseActionTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use YYYY-MM-DD, 'YYYY-MM-DD HH:MM' or RFC 3339)", value)
+}
+
+// actionTemplateFromFlags builds an action template from create flags,
+// starting from a config template if --from-template is given
+func actionTemplateFromFlags(cmd *cobra.Command, args []string) (*config.ActionTemplate, error) {
+	template := &config.ActionTemplate{}
+
+	fromTemplate, _ := cmd.Flags().GetString("from-template")
+	if fromTemplate != "" {
+		found := false
+		for _, t := range cfg.Actions.Templates {
+			if t.ID == fromTemplate {
+				*template = t
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("action template '%s' not found in config", fromTemplate)
+		}
+	}
+
+	flags := cmd.Flags()
+	if len(args) > 0 {
+		template.Name = args[0]
+	}
+	if flags.Changed("id") {
+		template.ID, _ = flags.GetString("id")
+	}
+	if flags.Changed("description") {
+		template.Description, _ = flags.GetString("description")
+	}
+
+	// Trigger
+	event, _ := flags.GetString("event")
+	schedule, _ := flags.GetString("schedule")
+	if event != "" && schedule != "" {
+		return nil, fmt.Errorf("use either --event or --schedule, not both")
+	}
+	if event != "" {
+		template.Trigger = config.TriggerConfig{Type: string(entity.TriggerTypeEvent), Event: event}
+	}
+	if schedule != "" {
+		scheduleCfg := &config.ScheduleConfig{Type: schedule}
+		scheduleCfg.Time, _ = flags.GetString("time")
+		scheduleCfg.Offset, _ = flags.GetString("offset")
+		scheduleCfg.CronExpr, _ = flags.GetString("cron")
+		template.Trigger = config.TriggerConfig{Type: string(entity.TriggerTypeTime), Schedule: scheduleCfg}
+	}
+
+	// Action type
+	actionType := &template.ActionType
+	if flags.Changed("type") {
+		actionType.Type, _ = flags.GetString("type")
+	}
+	setString := func(flag string, target *string) {
+		if flags.Changed(flag) {
+			*target, _ = flags.GetString(flag)
+		}
+	}
+	setString("title", &actionType.Title)
+	setString("message", &actionType.Message)
+	setString("script", &actionType.ScriptPath)
+	setString("command", &actionType.Command)
+	setString("url", &actionType.URL)
+	setString("secret", &actionType.Secret)
+	setString("timeout", &actionType.Timeout)
+	setString("set-priority", &actionType.UpdatePriority)
+	setString("set-status", &actionType.UpdateStatus)
+	setString("target-column", &actionType.TargetColumn)
+	setString("task-title", &actionType.TaskTitle)
+	setString("task-column", &actionType.TaskColumn)
+	if flags.Changed("env") {
+		actionType.ScriptEnv, _ = flags.GetStringToString("env")
+	}
+	if flags.Changed("arg") {
+		actionType.Args, _ = flags.GetStringArray("arg")
+	}
+	if flags.Changed("header") {
+		actionType.Headers, _ = flags.GetStringToString("header")
+	}
+	if flags.Changed("retries") {
+		actionType.Retries, _ = flags.GetInt("retries")
+	}
+	if flags.Changed("bump-priority") {
+		actionType.BumpPriority, _ = flags.GetBool("bump-priority")
+	}
+	if flags.Changed("add-tag") {
+		actionType.AddTags, _ = flags.GetStringSlice("add-tag")
+	}
+	if flags.Changed("remove-tag") {
+		actionType.RemoveTags, _ = flags.GetStringSlice("remove-tag")
+	}
+
+	// Conditions
+	conditions, _ := flags.GetStringArray("condition")
+	for _, c := range conditions {
+		condition, err := parseConditionFlag(c)
+		if err != nil {
+			return nil, err
+		}
+		template.Conditions = append(template.Conditions, condition)
+	}
+
+	// Aggregation
+	groupBy, _ := flags.GetString("group-by")
+	aggregates, _ := flags.GetStringArray("aggregate")
+	if groupBy != "" || len(aggregates) > 0 {
+		if template.Aggregate == nil {
+			template.Aggregate = &config.AggregateConfig{GroupBy: string(entity.AggregateGroupBoard)}
+		}
+		if groupBy != "" {
+			template.Aggregate.GroupBy = groupBy
+		}
+		for _, a := range aggregates {
+			condition, err := parseAggregateFlag(a)
+			if err != nil {
+				return nil, err
+			}
+			template.Aggregate.Conditions = append(template.Aggregate.Conditions, condition)
+		}
+	}
+
+	return template, nil
+}
+
+// parseAggregateFlag parses a "count <operator> <value>" or
+// "<function> <field> <operator> <value>" aggregate condition
+func parseAggregateFlag(value string) (config.AggregateConditionConfig, error) {
+	parts := strings.Fields(value)
+	if len(parts) == 3 && parts[0] == string(entity.AggregateCount) {
+		return config.AggregateConditionConfig{Function: parts[0], Operator: parts[1], Value: aggregateValue(parts[2])}, nil
+	}
+	if len(parts) == 4 {
+		return config.AggregateConditionConfig{Function: parts[0], Field: parts[1], Operator: parts[2], Value: aggregateValue(parts[3])}, nil
+	}
+	return config.AggregateConditionConfig{}, fmt.Errorf("invalid aggregate condition '%s' (use \"count <operator> <value>\" or \"<function> <field> <operator> <value>\")", value)
+}
+
+// parseConditionFlag parses a "<field> <operator> <value>" condition
+func parseConditionFlag(value string) (config.ConditionConfig, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(parts) != 3 {
+		return config.ConditionConfig{}, fmt.Errorf("invalid condition '%s' (use \"<field> <operator> <value>\")", value)
+	}
+
+	condition := config.ConditionConfig{
+		Field:    parts[0],
+		Operator: parts[1],
+		Value:    strings.TrimSpace(parts[2]),
+	}
+
+	switch entity.ConditionOperator(condition.Operator) {
+	case entity.OperatorIn, entity.OperatorNotIn:
+		condition.Value = parseTagsString(parts[2])
+	case entity.OperatorEquals, entity.OperatorNotEquals:
+		// has_due_date and is_overdue compare against booleans
+		if b, err := strconv.ParseBool(parts[2]); err == nil {
+			condition.Value = b
+		}
+	case entity.OperatorContains, entity.OperatorNotContains:
+	default:
+		return config.ConditionConfig{}, fmt.Errorf("invalid condition operator '%s'", condition.Operator)
+	}
+
+	return condition, nil
+}
+
+// aggregateValue keeps numbers numeric so they are stored as such
+func aggregateValue(value string) interface{} {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+// actionScopeFromFlags resolves the scope of a new action; board scope
+// defaults to the current board
+func actionScopeFromFlags(ctx context.Context, cmd *cobra.Command) (valueobject.ActionScope, string, error) {
+	scopeFlag, _ := cmd.Flags().GetString("scope")
+	scopeID, _ := cmd.Flags().GetString("scope-id")
+
+	scope := valueobject.ActionScope(scopeFlag)
+	if !scope.IsValid() {
+		return "", "", fmt.Errorf("invalid scope '%s' (use global, board, column or task)", scopeFlag)
+	}
+
+	switch scope {
+	case valueobject.ActionScopeGlobal:
+		return scope, "", nil
+	case valueobject.ActionScopeBoard:
+		if scopeID == "" {
+			boardID, err := getBoardID(ctx)
+			if err != nil {
+				return "", "", err
+			}
+			scopeID = boardID
+		}
+	default:
+		if scopeID == "" {
+			return "", "", fmt.Errorf("--scope-id is required for %s scope", scope)
+		}
+	}
+
+	return scope, scopeID, nil
+}
+
+// actionIDFromName derives an action ID from its name
+func actionIDFromName(name string) string {
+	id := actionIDInvalidRE.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(id, "-")
+}
+
+// editActionTemplate opens the template in $EDITOR and returns the edited
+// version, or nil if the user emptied the file
+func editActionTemplate(template *config.ActionTemplate) (*config.ActionTemplate, error) {
+	tmpFile, err := os.CreateTemp("", "mkanban-action-*.yml")
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		tmpFile.Close()
+		return nil, err
+	}
+
+	header := "# New mkanban action. Remove everything to cancel.\n" +
+		"# trigger.type: event (with event) or time (with schedule)\n" +
+		"# action_type.type: notification, script, command, webhook, task_mutation, task_movement or task_creation\n"
+	if _, err := tmpFile.WriteString(header + string(data)); err != nil {
+		tmpFile.Close()
+		return nil, err
+	}
+	tmpFile.Close()
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	editorCmd := buildEditorCommand(editor, tmpPath, 0)
+	cleanup, err := attachEditorIO(editorCmd)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if err := editorCmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run editor: %w", err)
+	}
+
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(edited)) == 0 {
+		return nil, nil
+	}
+
+	result := &config.ActionTemplate{}
+	if err := yaml.Unmarshal(edited, result); err != nil {
+		return nil, fmt.Errorf("invalid action YAML: %w", err)
+	}
+	return result, nil
+}
+
+// actionHistoryCmd lists recorded action executions
+var actionHistoryCmd = &cobra.Command{
+	Use:   "history [action-id]",
+	Short: "Show action execution history",
+	Long: `Show recorded action executions, newest first.
+
+Every attempt to run an action is recorded with its trigger, the event and task
+it ran for, its outcome, error and script output. Without an action ID the most
+recent executions of all actions are shown.
+
+Examples:
+  # Show recent executions of all actions
+  mkanban action history
+
+  # Show executions of a specific action including script output
+  mkanban action history <action-id> --show-output
+
+  # Show history in JSON format
+  mkanban action history --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		actionID := ""
+		if len(args) > 0 {
+			actionID = args[0]
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		showOutput, _ := cmd.Flags().GetBool("show-output")
+
+		executions, err := container.GetActionHistoryUseCase.Execute(ctx, actionID, limit)
+		if err != nil {
+			return err
+		}
+
+		history := make([]dto.ActionExecutionDTO, 0, len(executions))
+		for _, execution := range executions {
+			history = append(history, dto.ActionExecutionToDTO(execution))
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(history)
+		default:
+			if len(history) == 0 {
+				printer.Info("No action executions recorded")
+				return nil
+			}
+
+			printer.Header("Action History")
+			fmt.Println()
+
+			if showOutput {
+				printExecutionDetails(history)
+				return nil
+			}
+
+			headers := []string{"Started", "Action", "Trigger", "Task", "Attempt", "Outcome", "Duration", "Error"}
+			rows := make([][]string, 0, len(history))
+			for _, execution := range history {
+				errMsg := firstLine(execution.Error)
+				if len(errMsg) > 50 {
+					errMsg = errMsg[:47] + "..."
+				}
+
+				rows = append(rows, []string{
+					execution.StartedAt.Format("2006-01-02 15:04:05"),
+					execution.ActionName,
+					executionTrigger(execution),
+					execution.TaskID,
+					strconv.Itoa(execution.Attempt),
+					execution.Outcome,
+					execution.FinishedAt.Sub(execution.StartedAt).Round(time.Millisecond).String(),
+					errMsg,
+				})
+			}
+
+			printer.Table(headers, rows)
+			return nil
+		}
+	},
+}
+
+// printExecutionDetails prints each execution with its full error and output
+func printExecutionDetails(history []dto.ActionExecutionDTO) {
+	for _, execution := range history {
+		printer.Bold("%s  %s (attempt %d)", execution.StartedAt.Format("2006-01-02 15:04:05"), execution.ActionName, execution.Attempt)
+		printer.Println("  ID:       %s", execution.ID)
+		printer.Println("  Trigger:  %s", executionTrigger(execution))
+		if execution.TaskID != "" {
+			printer.Println("  Task:     %s", execution.TaskID)
+		}
+		if execution.Success {
+			printer.Success("  Outcome:  %s", execution.Outcome)
+		} else {
+			printer.Error("  Outcome:  %s", execution.Outcome)
+			printer.Println("  Error:    %s", execution.Error)
+		}
+		if execution.Output != "" {
+			printer.Println("  Output:")
+			for _, line := range strings.Split(strings.TrimRight(execution.Output, "\n"), "\n") {
+				printer.Subtle("    %s", line)
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// executionTrigger describes what triggered an execution
+func executionTrigger(execution dto.ActionExecutionDTO) string {
+	if execution.EventType != "" {
+		return execution.EventType
+	}
+	return execution.TriggerType
+}
+
+// firstLine returns the first line of s
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func init() {
+	rootCmd.AddCommand(actionCmd)
+
+	// Add subcommands
+	actionCmd.AddCommand(actionListCmd)
+	actionCmd.AddCommand(actionShowCmd)
+	actionCmd.AddCommand(actionCreateCmd)
+	actionCmd.AddCommand(actionEnableCmd)
+	actionCmd.AddCommand(actionDisableCmd)
+	actionCmd.AddCommand(actionDeleteCmd)
+	actionCmd.AddCommand(actionTestCmd)
+	actionCmd.AddCommand(actionSimulateCmd)
+	actionCmd.AddCommand(actionHistoryCmd)
+
+	// actionListCmd flags
+	actionListCmd.Flags().String("scope", "", "Filter by scope (global, board, column, task)")
+	actionListCmd.Flags().String("scope-id", "", "Filter by scope ID (with --scope)")
+	actionListCmd.Flags().Bool("enabled", false, "Only show enabled actions")
+	actionListCmd.Flags().String("trigger", "", "Filter by trigger type (event, time)")
+
+	// actionCreateCmd flags
+	actionCreateCmd.Flags().String("id", "", "Action ID (default: derived from the name)")
+	actionCreateCmd.Flags().String("description", "", "Action description")
+	actionCreateCmd.Flags().String("scope", "global", "Action scope (global, board, column, task)")
+	actionCreateCmd.Flags().String("scope-id", "", "Board ID, column name or task ID the action applies to")
+	actionCreateCmd.Flags().String("event", "", "Event that triggers the action")
+	actionCreateCmd.Flags().String("schedule", "", "Schedule type that triggers the action")
+	actionCreateCmd.Flags().String("time", "", "Time for absolute schedules (RFC 3339)")
+	actionCreateCmd.Flags().String("offset", "", "Offset for relative schedules (e.g. 30m, 2h, 1d)")
+	actionCreateCmd.Flags().String("cron", "", "Cron expression for recurring schedules")
+	actionCreateCmd.Flags().String("type", "", "Action type")
+	actionCreateCmd.Flags().String("title", "", "Notification title")
+	actionCreateCmd.Flags().String("message", "", "Notification message")
+	actionCreateCmd.Flags().String("script", "", "Script to run")
+	actionCreateCmd.Flags().StringToString("env", nil, "Script or command environment variables (KEY=VALUE)")
+	actionCreateCmd.Flags().String("command", "", "Command to run")
+	actionCreateCmd.Flags().StringArray("arg", nil, "Command argument (repeatable)")
+	actionCreateCmd.Flags().String("url", "", "Webhook URL to POST to")
+	actionCreateCmd.Flags().StringToString("header", nil, "Webhook request headers (KEY=VALUE)")
+	actionCreateCmd.Flags().String("secret", "", "Secret to sign webhook payloads with (HMAC-SHA256)")
+	actionCreateCmd.Flags().String("timeout", "", "Command or webhook timeout (e.g. 10s)")
+	actionCreateCmd.Flags().Int("retries", 0, "Webhook retries on connection errors, 429 and 5xx")
+	actionCreateCmd.Flags().String("set-priority", "", "Priority to set on the task")
+	actionCreateCmd.Flags().Bool("bump-priority", false, "Raise the task's priority one level")
+	actionCreateCmd.Flags().String("set-status", "", "Status to set on the task")
+	actionCreateCmd.Flags().StringSlice("add-tag", nil, "Tags to add to the task")
+	actionCreateCmd.Flags().StringSlice("remove-tag", nil, "Tags to remove from the task")
+	actionCreateCmd.Flags().String("target-column", "", "Column to move the task to")
+	actionCreateCmd.Flags().String("task-title", "", "Title of the task to create")
+	actionCreateCmd.Flags().String("task-column", "", "Column to create the task in")
+	actionCreateCmd.Flags().StringArray("condition", nil, "Condition as \"<field> <operator> <value>\" (repeatable)")
+	actionCreateCmd.Flags().String("group-by", "", "Run once per board or column over the matching tasks")
+	actionCreateCmd.Flags().StringArray("aggregate", nil, "Condition over the matching tasks, e.g. \"count gt 5\" (repeatable)")
+	actionCreateCmd.Flags().String("from-template", "", "Start from an action template in the config")
+	actionCreateCmd.Flags().Bool("edit", false, "Open the action in $EDITOR before creating it")
+
+	// actionDeleteCmd flags
+	actionDeleteCmd.Flags().Bool("force", false, "Delete without confirmation")
+
+	// actionTestCmd flags
+	actionTestCmd.Flags().String("event", "", "Simulate this event instead of the trigger's own")
+	actionTestCmd.Flags().String("at", "", "Evaluate time triggers at this time")
+
+	// actionSimulateCmd flags
+	actionSimulateCmd.Flags().String("from", "", "Start of the window (default: now)")
+	actionSimulateCmd.Flags().String("to", "", "End of the window (overrides --for)")
+	actionSimulateCmd.Flags().String("for", "7d", "Length of the window (e.g. 12h, 7d)")
+	actionSimulateCmd.Flags().String("step", "", "Evaluation interval (default: actions.check_interval)")
+	actionSimulateCmd.Flags().Bool("all-boards", false, "Simulate across all boards instead of the current one")
+
+	// actionHistoryCmd flags
+	actionHistoryCmd.Flags().Int("limit", 20, "Maximum number of executions to show (0 = all)")
+	actionHistoryCmd.Flags().Bool("show-output", false, "Show full error and script output of each execution")
+}
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/daemon"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/persistence/snapshot"
+)
+
+// backupCmd groups the snapshot commands
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Snapshot the data directory and restore from snapshots",
+	Long: `Take compressed snapshots of the data directory and restore all of it,
+a project or a single board to how it was in one of them.
+
+The daemon takes a snapshot every backup.interval seconds while changes
+are held off, keeping the newest of each hour for backup.keep_hourly hours
+and of each day for backup.keep_daily days. Snapshots taken with 'backup
+create' are kept until deleted by hand.
+
+Every restore first takes a pre-restore snapshot, so it can be undone by
+restoring that one.
+
+Examples:
+  # List snapshots
+  mkanban backup list
+
+  # Take a snapshot now
+  mkanban backup create
+
+  # See what changed on a board since the newest snapshot
+  mkanban backup diff --board-id shop/main
+
+  # Put the board back the way it was
+  mkanban backup restore 20260301-140000 --board-id shop/main`,
+}
+
+// backupListCmd lists the snapshots kept
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snapshots, newest first",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots, err := container.DataSnapshotter.List(getContext())
+		if err != nil {
+			return err
+		}
+		snapshotDTOs := dto.DataSnapshotsToDTO(snapshots)
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(snapshotDTOs)
+		}
+
+		if len(snapshotDTOs) == 0 {
+			printer.Info("No snapshots yet. Take one with: mkanban backup create")
+			return nil
+		}
+
+		headers := []string{"ID", "Kind", "Taken", "Size"}
+		rows := make([][]string, 0, len(snapshotDTOs))
+		for _, snapshot := range snapshotDTOs {
+			rows = append(rows, []string{
+				snapshot.ID,
+				snapshot.Kind,
+				snapshot.CreatedAt.Format("2006-01-02 15:04:05"),
+				formatSize(snapshot.Size),
+			})
+		}
+		printer.Table(headers, rows)
+		return nil
+	},
+}
+
+// backupCreateCmd takes a snapshot
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Take a snapshot of the data directory now",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotDTO, err := createSnapshot(getContext())
+		if err != nil {
+			return err
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(snapshotDTO)
+		}
+		if !quiet {
+			printer.Success("Took snapshot %s (%s)", snapshotDTO.ID, formatSize(snapshotDTO.Size))
+		}
+		return nil
+	},
+}
+
+// backupDiffCmd shows what changed since a snapshot
+var backupDiffCmd = &cobra.Command{
+	Use:   "diff [snapshot]",
+	Short: "Show the files changed since a snapshot",
+	Long: `List the files added, removed and modified since a snapshot, the newest
+one when none is given, within a board (--board-id), a project (--project)
+or the whole data directory.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotID := snapshot.LatestSnapshot
+		if len(args) == 1 {
+			snapshotID = args[0]
+		}
+		scope, err := snapshotScope(cmd)
+		if err != nil {
+			return err
+		}
+
+		changes, err := container.DataSnapshotter.Diff(getContext(), snapshotID, scope)
+		if err != nil {
+			return err
+		}
+		changeDTOs := dto.DataFileChangesToDTO(changes)
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(changeDTOs)
+		}
+
+		if len(changeDTOs) == 0 {
+			printer.Success("Nothing changed since snapshot %s", snapshotID)
+			return nil
+		}
+		printFileChanges(changeDTOs)
+		return nil
+	},
+}
+
+// backupRestoreCmd restores from a snapshot
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Restore the data directory, a project or a board from a snapshot",
+	Long: `Put the files of a board (--board-id), a project (--project) or the whole
+data directory back the way they were in a snapshot. Files added since are
+removed, others outside of what is restored are left alone.
+
+A pre-restore snapshot is taken first; restore it to undo.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scope, err := snapshotScope(cmd)
+		if err != nil {
+			return err
+		}
+
+		result, err := restoreSnapshot(getContext(), args[0], scope)
+		if err != nil {
+			return err
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		}
+		if quiet {
+			return nil
+		}
+
+		if len(result.Changes) == 0 {
+			printer.Success("Nothing to restore, no changes since snapshot %s", result.Snapshot.ID)
+			return nil
+		}
+		printFileChanges(result.Changes)
+		fmt.Println()
+		printer.Success("Restored %d files from snapshot %s", len(result.Changes), result.Snapshot.ID)
+		if result.Undo != nil {
+			printer.Info("Undo with: mkanban backup restore %s%s", result.Undo.ID, scopeFlags(scope))
+		}
+		return nil
+	},
+}
+
+// createSnapshot takes the snapshot through the daemon when it runs, so
+// its changes are held off meanwhile, and directly otherwise
+func createSnapshot(ctx context.Context) (*dto.DataSnapshotDTO, error) {
+	client := daemon.NewClient(cfg)
+	if client.IsHealthy() {
+		if err := client.Connect(); err == nil {
+			defer client.Close()
+			return client.CreateSnapshot(ctx)
+		}
+	}
+
+	snapshot, err := container.DataSnapshotter.Create(ctx, service.SnapshotManual)
+	if err != nil {
+		return nil, err
+	}
+	snapshotDTO := dto.DataSnapshotToDTO(*snapshot)
+	return &snapshotDTO, nil
+}
+
+// restoreSnapshot restores through the daemon when it runs, so its changes
+// are held off meanwhile, and directly otherwise
+func restoreSnapshot(ctx context.Context, snapshotID string, scope service.SnapshotScope) (*dto.SnapshotRestoreDTO, error) {
+	client := daemon.NewClient(cfg)
+	if client.IsHealthy() {
+		if err := client.Connect(); err == nil {
+			defer client.Close()
+			return client.RestoreSnapshot(ctx, snapshotID, scope.ProjectSlug, scope.BoardID)
+		}
+	}
+
+	result, err := container.DataSnapshotter.Restore(ctx, snapshotID, scope)
+	if err != nil {
+		return nil, err
+	}
+	restoreDTO := dto.SnapshotRestoreToDTO(result)
+	return &restoreDTO, nil
+}
+
+// snapshotScope reads the board or project a diff or restore is narrowed
+// to from the flags
+func snapshotScope(cmd *cobra.Command) (service.SnapshotScope, error) {
+	project, _ := cmd.Flags().GetString("project")
+	if boardID != "" && project != "" {
+		return service.SnapshotScope{}, fmt.Errorf("use either --board-id or --project")
+	}
+	return service.SnapshotScope{ProjectSlug: project, BoardID: boardID}, nil
+}
+
+// scopeFlags renders a scope back as flags
+func scopeFlags(scope service.SnapshotScope) string {
+	switch {
+	case scope.BoardID != "":
+		return " --board-id " + scope.BoardID
+	case scope.ProjectSlug != "":
+		return " --project " + scope.ProjectSlug
+	}
+	return ""
+}
+
+func printFileChanges(changes []dto.DataFileChangeDTO) {
+	for _, change := range changes {
+		printer.Println("  %-9s %s", change.Change, change.Path)
+	}
+}
+
+// formatSize renders a byte count for people
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupDiffCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+
+	backupDiffCmd.Flags().String("project", "", "Only show changes to this project")
+	backupRestoreCmd.Flags().String("project", "", "Only restore this project")
+}
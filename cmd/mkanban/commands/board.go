@@ -1,10 +1,17 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"mkanban/cmd/mkanban/output"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
 	"mkanban/pkg/slug"
 )
 
@@ -37,7 +44,13 @@ Examples:
   mkanban board current
 
   # Switch to a different board (for current session)
-  mkanban board switch my-project`,
+  mkanban board switch my-project
+
+  # Split the board into swimlanes by team tag
+  mkanban board swimlanes tag:team:
+
+  # Export a board for a status report
+  mkanban board export my-project --format md`,
 }
 
 // boardListCmd lists all boards
@@ -116,19 +129,51 @@ Examples:
   mkanban board get my-project
 
   # Get board in JSON format
-  mkanban board get my-project --output json`,
+  mkanban board get my-project --output json
+
+  # Show the board in swimlanes by priority
+  mkanban board get my-project --swimlanes priority
+
+  # Show every board at once, one swimlane per project
+  mkanban board get --all-boards`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := getContext()
-		resolvedArgs, err := resolveArgs(args, 1)
-		if err != nil {
-			return err
+		allBoards, _ := cmd.Flags().GetBool("all-boards")
+		lanesSpec, _ := cmd.Flags().GetString("swimlanes")
+
+		var board *dto.BoardDTO
+		if allBoards {
+			var err error
+			board, err = aggregateBoards(ctx)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("swimlanes") {
+				lanesSpec = string(entity.SwimlaneByProject)
+			}
+		} else {
+			resolvedArgs, err := resolveArgs(args, 1)
+			if err != nil {
+				return err
+			}
+			boardID := resolvedArgs[0]
+
+			board, err = container.GetBoardUseCase.Execute(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("failed to get board: %w", err)
+			}
+			if !cmd.Flags().Changed("swimlanes") {
+				lanesSpec = board.Swimlanes
+			}
 		}
-		boardID := resolvedArgs[0]
 
-		board, err := container.GetBoardUseCase.Execute(ctx, boardID)
+		if lanesSpec == "none" {
+			lanesSpec = ""
+		}
+		swimlanes, err := entity.ParseSwimlanes(lanesSpec)
 		if err != nil {
-			return fmt.Errorf("failed to get board: %w", err)
+			return err
 		}
 
 		// Format output
@@ -137,7 +182,7 @@ Examples:
 			return formatter.Print(board)
 		default:
 			// Text format
-			printer.Header(board.Name)
+			printer.Header("%s", board.Name)
 			fmt.Println()
 			printer.Println("ID:          %s", board.ID)
 			printer.Println("Description: %s", board.Description)
@@ -148,8 +193,16 @@ Examples:
 				taskCount += len(col.Tasks)
 			}
 			printer.Println("Tasks:       %d", taskCount)
+			if swimlanes != nil {
+				printer.Println("Swimlanes:   %s", swimlanes)
+			}
 			fmt.Println()
 
+			if swimlanes != nil {
+				printSwimlanes(dto.GroupSwimlanes(*board, swimlanes))
+				return nil
+			}
+
 			// List columns
 			if len(board.Columns) > 0 {
 				printer.Bold("Columns:")
@@ -163,6 +216,276 @@ Examples:
 	},
 }
 
+// boardSwimlanesCmd sets how a board is split into swimlanes
+var boardSwimlanesCmd = &cobra.Command{
+	Use:   "swimlanes [tag:<prefix>|priority|parent|project]",
+	Short: "Split a board into swimlanes",
+	Long: `Split the board into swimlanes: horizontal lanes running across all of its
+columns. The TUI and 'mkanban board get' show the board in these lanes.
+
+Tasks can be grouped by:
+  tag:<prefix>  the value of a tag with the prefix, e.g. tag:team: puts
+                team:backend and team:frontend tasks in separate lanes
+  priority      their priority
+  parent        their parent task, keeping each task's subtasks together
+  project       the project of their board, for views spanning boards
+
+Moving a task to another lane ('mkanban task lane' or J/K in the TUI)
+updates the tag, priority or parent it is grouped by.
+
+Without an argument the current swimlanes are shown.
+
+Examples:
+  # One lane per team
+  mkanban board swimlanes tag:team:
+
+  # One lane per epic
+  mkanban board swimlanes parent
+
+  # Back to plain columns
+  mkanban board swimlanes --clear`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		remove, _ := cmd.Flags().GetBool("clear")
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 && !remove {
+			board, err := container.GetBoardUseCase.Execute(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("failed to get board: %w", err)
+			}
+			if board.Swimlanes == "" {
+				printer.Info("Board %s has no swimlanes", board.Name)
+				return nil
+			}
+			printer.Println("%s", board.Swimlanes)
+			return nil
+		}
+
+		spec := ""
+		if !remove {
+			spec = args[0]
+		}
+		board, err := container.SetSwimlanesUseCase.Execute(ctx, boardID, spec)
+		if err != nil {
+			return fmt.Errorf("failed to set swimlanes: %w", err)
+		}
+
+		if board.Swimlanes == "" {
+			printer.Success("Removed swimlanes from board %s", board.Name)
+		} else {
+			printer.Success("Board %s is now in swimlanes by %s", board.Name, board.Swimlanes)
+		}
+		return nil
+	},
+}
+
+// aggregateBoards combines every board into one, merging columns of the same
+// name in the order they first appear
+func aggregateBoards(ctx context.Context) (*dto.BoardDTO, error) {
+	boards, err := container.ListBoardsUseCase.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+
+	combined := &dto.BoardDTO{ID: "all", Name: "All boards", Description: fmt.Sprintf("%d boards", len(boards))}
+	columnIndex := make(map[string]int)
+	for _, listed := range boards {
+		board, err := container.GetBoardUseCase.Execute(ctx, listed.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get board %s: %w", listed.ID, err)
+		}
+		for _, col := range board.Columns {
+			i, ok := columnIndex[col.Name]
+			if !ok {
+				i = len(combined.Columns)
+				columnIndex[col.Name] = i
+				combined.Columns = append(combined.Columns, dto.ColumnDTO{Name: col.Name, Order: i, Tasks: []dto.TaskDTO{}})
+			}
+			combined.Columns[i].Tasks = append(combined.Columns[i].Tasks, col.Tasks...)
+			combined.Columns[i].TaskCount += len(col.Tasks)
+		}
+	}
+	return combined, nil
+}
+
+// printSwimlanes prints a board's lanes as a grid, one column of the board
+// per table column and one task per cell
+func printSwimlanes(lanes []dto.SwimlaneDTO) {
+	if len(lanes) == 0 {
+		return
+	}
+
+	headers := []string{"Lane"}
+	for _, col := range lanes[0].Columns {
+		headers = append(headers, col.Name)
+	}
+
+	rows := make([][]string, 0)
+	for _, lane := range lanes {
+		height := 1
+		for _, col := range lane.Columns {
+			if len(col.Tasks) > height {
+				height = len(col.Tasks)
+			}
+		}
+		for i := 0; i < height; i++ {
+			row := make([]string, 0, len(headers))
+			if i == 0 {
+				row = append(row, fmt.Sprintf("%s (%d)", lane.Title, lane.TaskCount))
+			} else {
+				row = append(row, "")
+			}
+			for _, col := range lane.Columns {
+				cell := ""
+				if i < len(col.Tasks) {
+					title := col.Tasks[i].Title
+					if len(title) > 30 {
+						title = title[:27] + "..."
+					}
+					cell = col.Tasks[i].ShortID + " " + title
+				} else if i == 0 {
+					cell = "-"
+				}
+				row = append(row, cell)
+			}
+			rows = append(rows, row)
+		}
+	}
+	printer.Table(headers, rows)
+}
+
+// boardExportCmd exports a board to a document or snapshot
+var boardExportCmd = &cobra.Command{
+	Use:   "export <board-id>",
+	Short: "Export a board to Markdown, HTML, CSV or JSON",
+	Long: `Export a whole board.
+
+Formats:
+  md    A Markdown document, a section per column with its tasks as a checklist
+  html  A static HTML page of the board that needs no other files
+  csv   A row per task, which 'mkanban import --format csv' reads back
+  json  A full snapshot of the board, with its columns' settings, the tasks'
+        metadata and activity, and the time logged on them, which
+        'mkanban board import' restores
+
+The export is written to standard output unless --file is given.
+
+Examples:
+  # Markdown status report
+  mkanban board export my-project --format md > status.md
+
+  # Static HTML board
+  mkanban board export my-project --format html --file board.html
+
+  # Back up a board and restore it on another machine
+  mkanban board export my-project --format json --file my-project.json
+  mkanban board import my-project.json`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+		boardID := resolvedArgs[0]
+
+		format, _ := cmd.Flags().GetString("format")
+		file, _ := cmd.Flags().GetString("file")
+
+		snapshot, err := container.ExportBoardUseCase.Execute(ctx, boardID)
+		if err != nil {
+			return fmt.Errorf("failed to export board: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := output.ExportBoard(&buf, snapshot, format); err != nil {
+			return err
+		}
+
+		if file == "" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(file, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		printer.Success("Exported board %s to %s", snapshot.Name, file)
+		return nil
+	},
+}
+
+// boardImportCmd restores a board from a JSON export
+var boardImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore a board from a JSON export",
+	Long: `Restore a board from a snapshot written by 'mkanban board export --format json'.
+
+The board keeps its ID, columns, task IDs, timestamps and metadata. Its
+project is created if it doesn't exist, and the time logged on its tasks is
+added unless it is there already.
+
+A board that already exists is only overwritten with --replace, and then
+loses the columns and tasks the snapshot doesn't have.
+
+To import the exports of other tools, see 'mkanban import'.
+
+Use - as the file to read standard input.
+
+Examples:
+  # Restore a board
+  mkanban board import my-project.json
+
+  # Roll a board back to an earlier export
+  mkanban board import my-project.json --replace`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		replace, _ := cmd.Flags().GetBool("replace")
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+
+		snapshot, err := dto.ParseBoardSnapshot(data)
+		if err != nil {
+			return err
+		}
+
+		result, err := container.RestoreBoardUseCase.Execute(ctx, snapshot, replace)
+		if err != nil {
+			return fmt.Errorf("failed to import board: %w", err)
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		default:
+			action := "Restored"
+			if result.Replaced {
+				action = "Replaced"
+			}
+			printer.Success("%s board %s (%s): %d columns, %d tasks", action, result.Name, result.ID, result.Columns, result.Tasks)
+			if result.TimeLogs > 0 {
+				printer.Info("Added %d time logs", result.TimeLogs)
+			}
+			return nil
+		}
+	},
+}
+
 // boardCreateCmd creates a new board
 var boardCreateCmd = &cobra.Command{
 	Use:   "create <board-id>",
@@ -412,6 +735,23 @@ func init() {
 	boardCmd.AddCommand(boardDeleteCmd)
 	boardCmd.AddCommand(boardCurrentCmd)
 	boardCmd.AddCommand(boardSwitchCmd)
+	boardCmd.AddCommand(boardSwimlanesCmd)
+	boardCmd.AddCommand(boardExportCmd)
+	boardCmd.AddCommand(boardImportCmd)
+
+	// boardGetCmd flags
+	boardGetCmd.Flags().String("swimlanes", "", "Show the board in swimlanes: tag:<prefix>, priority, parent, project or none (default: the board's)")
+	boardGetCmd.Flags().Bool("all-boards", false, "Show all boards combined, in swimlanes by project unless --swimlanes is given")
+
+	// boardSwimlanesCmd flags
+	boardSwimlanesCmd.Flags().Bool("clear", false, "Remove the swimlanes")
+
+	// boardExportCmd flags
+	boardExportCmd.Flags().String("format", "md", "Export format: "+strings.Join(output.ExportFormats, ", "))
+	boardExportCmd.Flags().String("file", "", "File to write the export to (default: standard output)")
+
+	// boardImportCmd flags
+	boardImportCmd.Flags().Bool("replace", false, "Overwrite a board with the same ID")
 
 	// boardCreateCmd flags
 	boardCreateCmd.Flags().String("name", "", "Board name (default: board-id)")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
 )
 
 // columnCmd represents the column command
@@ -33,6 +34,12 @@ Examples:
   # Update column properties
   mkanban column update "In Progress" --wip-limit 5
 
+  # Flag tasks that sit in review for more than 2 working days
+  mkanban column aging "In Review" --stale-after 2 --escalate-after 4
+
+  # Refuse tasks without an estimate in "In Progress"
+  mkanban column rule add "In Progress" --on enter --require estimate
+
   # Delete a column
   mkanban column delete "Archived"
 
@@ -80,7 +87,7 @@ Examples:
 			printer.Header("Columns in %s", board.Name)
 			fmt.Println()
 
-			headers := []string{"Name", "Order", "WIP Limit", "Tasks"}
+			headers := []string{"Name", "Order", "WIP Limit", "Aging", "Tasks"}
 			rows := make([][]string, 0, len(board.Columns))
 
 			for _, col := range board.Columns {
@@ -93,6 +100,7 @@ Examples:
 					col.Name,
 					strconv.Itoa(col.Order),
 					wipLimit,
+					formatAgingPolicy(col),
 					strconv.Itoa(len(col.Tasks)),
 				})
 			}
@@ -152,7 +160,7 @@ Examples:
 		case "json", "yaml":
 			return formatter.Print(foundColumn)
 		default:
-			printer.Header(foundColumn.Name)
+			printer.Header("%s", foundColumn.Name)
 			fmt.Println()
 			printer.Println("Order:       %d", foundColumn.Order)
 			printer.Println("WIP Limit:   %s", func() string {
@@ -161,6 +169,13 @@ Examples:
 				}
 				return "Unlimited"
 			}())
+			printer.Println("Aging:       %s", formatAgingPolicy(*foundColumn))
+			if len(foundColumn.Rules) > 0 {
+				printer.Println("Rules:")
+				for i, rule := range foundColumn.Rules {
+					printer.Println("  %d. %s", i+1, formatColumnRule(rule))
+				}
+			}
 			printer.Println("Tasks:       %d", len(foundColumn.Tasks))
 			printer.Println("Description: %s", foundColumn.Description)
 			return nil
@@ -187,7 +202,10 @@ Examples:
   mkanban column create "In Progress" --wip-limit 5
 
   # Create a column with description
-  mkanban column create "Done" --description "Completed tasks"`,
+  mkanban column create "Done" --description "Completed tasks"
+
+  # Create a column with an aging policy (in working days)
+  mkanban column create "In Review" --stale-after 2 --escalate-after 4`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := getContext()
@@ -206,6 +224,8 @@ Examples:
 		description, _ := cmd.Flags().GetString("description")
 		position, _ := cmd.Flags().GetInt("position")
 		wipLimit, _ := cmd.Flags().GetInt("wip-limit")
+		staleAfter, _ := cmd.Flags().GetInt("stale-after")
+		escalateAfter, _ := cmd.Flags().GetInt("escalate-after")
 
 		// If position is not specified, add to end
 		if position == 0 {
@@ -238,6 +258,18 @@ Examples:
 			printer.Info("WIP limit: %d", wipLimit)
 		}
 
+		if staleAfter > 0 || escalateAfter > 0 {
+			_, err = container.SetAgingPolicyUseCase.Execute(ctx, boardID, dto.SetAgingPolicyRequest{
+				ColumnName:    columnName,
+				StaleAfter:    staleAfter,
+				EscalateAfter: escalateAfter,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to set aging policy: %w", err)
+			}
+			printer.Info("Aging: %s", formatAgingPolicy(dto.ColumnDTO{StaleAfter: staleAfter, EscalateAfter: escalateAfter}))
+		}
+
 		return nil
 	},
 }
@@ -311,6 +343,315 @@ Examples:
 	},
 }
 
+// columnAgingCmd sets the aging policy of a column
+var columnAgingCmd = &cobra.Command{
+	Use:   "aging <column-name>",
+	Short: "Set the aging policy of a column",
+	Long: `Set how long tasks may stay in a column before they are flagged.
+
+Thresholds are in working days, counted with the work schedule from the
+config. A task that stays longer than --stale-after becomes stale and one that
+stays longer than --escalate-after is escalated. The daemon publishes a
+task.stale or task.escalated event as each level is reached, which actions can
+react to, and the TUI marks aging tasks on their cards. Moving a task to
+another column resets its age.
+
+Examples:
+  # Flag tasks in review after 2 working days, escalate after 4
+  mkanban column aging "In Review" --stale-after 2 --escalate-after 4
+
+  # Only escalate
+  mkanban column aging "Blocked" --escalate-after 3
+
+  # Remove the policy
+  mkanban column aging "In Review" --clear`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+		columnName := resolvedArgs[0]
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		staleAfter, _ := cmd.Flags().GetInt("stale-after")
+		escalateAfter, _ := cmd.Flags().GetInt("escalate-after")
+		remove, _ := cmd.Flags().GetBool("clear")
+
+		if remove {
+			staleAfter, escalateAfter = 0, 0
+		} else if staleAfter == 0 && escalateAfter == 0 {
+			return fmt.Errorf("no policy specified. Use --stale-after, --escalate-after, or --clear")
+		}
+
+		_, err = container.SetAgingPolicyUseCase.Execute(ctx, boardID, dto.SetAgingPolicyRequest{
+			ColumnName:    columnName,
+			StaleAfter:    staleAfter,
+			EscalateAfter: escalateAfter,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set aging policy: %w", err)
+		}
+
+		if remove {
+			printer.Success("Removed aging policy from column: %s", columnName)
+		} else {
+			printer.Success("Set aging policy of column %s: %s", columnName,
+				formatAgingPolicy(dto.ColumnDTO{StaleAfter: staleAfter, EscalateAfter: escalateAfter}))
+		}
+		return nil
+	},
+}
+
+// formatAgingPolicy describes a column's aging policy for display
+func formatAgingPolicy(col dto.ColumnDTO) string {
+	parts := make([]string, 0, 2)
+	if col.StaleAfter > 0 {
+		parts = append(parts, fmt.Sprintf("stale after %dd", col.StaleAfter))
+	}
+	if col.EscalateAfter > 0 {
+		parts = append(parts, fmt.Sprintf("escalate after %dd", col.EscalateAfter))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// columnRuleCmd manages the entry and exit rules of a column
+var columnRuleCmd = &cobra.Command{
+	Use:   "rule",
+	Short: "Manage column entry and exit rules",
+	Long: `Manage the rules a task has to pass to enter or leave a column.
+
+Rules are checked whenever a task is moved, from the CLI, the TUI or an
+action. A rule either blocks the move (the default) or lets it go ahead with
+a warning (--warn). Each rule checks one of:
+
+  --require <field>      a field is set: description, due_date, estimate,
+                         tags, tag:<prefix> (a tag starting with prefix) or
+                         a metadata key
+  --condition "<field> <operator> <value>"
+                         a condition holds, as in action conditions
+  --subtasks-complete    every subtask checkbox in the description is checked
+  --script <path>        a script exits 0; it gets TASK_*, BOARD_*,
+                         COLUMN_NAME, FROM_COLUMN, TO_COLUMN and RULE_ON
+
+Examples:
+  # Definition of done: subtasks complete and the CI script passing
+  mkanban column rule add Done --on enter --subtasks-complete
+  mkanban column rule add Done --on enter --script ci-green.sh \
+    --message "CI must be green"
+
+  # Warn when work starts on a task nobody is assigned to
+  mkanban column rule add "In Progress" --on enter --require tag:@ --warn
+
+  # Don't let high priority tasks leave review without a reviewer
+  mkanban column rule add Review --on exit --require reviewer
+
+  # List and remove rules
+  mkanban column rule list Done
+  mkanban column rule remove Done 2`,
+}
+
+// columnRuleListCmd lists the rules of a column
+var columnRuleListCmd = &cobra.Command{
+	Use:   "list <column-name>",
+	Short: "List the rules of a column",
+	Args:  cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+		columnName := resolvedArgs[0]
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		board, err := container.GetBoardUseCase.Execute(ctx, boardID)
+		if err != nil {
+			return fmt.Errorf("failed to get board: %w", err)
+		}
+
+		var foundColumn *dto.ColumnDTO
+		for i, col := range board.Columns {
+			if col.Name == columnName {
+				foundColumn = &board.Columns[i]
+				break
+			}
+		}
+		if foundColumn == nil {
+			return fmt.Errorf("column '%s' not found in board '%s'", columnName, board.Name)
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(foundColumn.Rules)
+		default:
+			if len(foundColumn.Rules) == 0 {
+				printer.Info("No rules on column '%s'", foundColumn.Name)
+				printer.Info("Add one with: mkanban column rule add %s --on enter --require estimate", foundColumn.Name)
+				return nil
+			}
+
+			headers := []string{"#", "On", "Severity", "Rule"}
+			rows := make([][]string, 0, len(foundColumn.Rules))
+			for i, rule := range foundColumn.Rules {
+				description := rule.Description
+				if rule.Message != "" {
+					description += " (" + rule.Message + ")"
+				}
+				rows = append(rows, []string{strconv.Itoa(i + 1), rule.On, rule.Severity, description})
+			}
+			printer.Table(headers, rows)
+			return nil
+		}
+	},
+}
+
+// columnRuleAddCmd adds a rule to a column
+var columnRuleAddCmd = &cobra.Command{
+	Use:   "add <column-name>",
+	Short: "Add a rule to a column",
+	Long: `Add an entry or exit rule to a column.
+
+Give --on and exactly one of --require, --condition, --subtasks-complete or
+--script. See 'mkanban column rule --help' for what each checks.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 1)
+		if err != nil {
+			return err
+		}
+		columnName := resolvedArgs[0]
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		rule, err := columnRuleFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, err = container.AddColumnRuleUseCase.Execute(ctx, boardID, dto.AddColumnRuleRequest{
+			ColumnName: columnName,
+			Rule:       rule,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add rule: %w", err)
+		}
+
+		printer.Success("Added %s rule to column %s", rule.On, columnName)
+		return nil
+	},
+}
+
+// columnRuleRemoveCmd removes a rule from a column
+var columnRuleRemoveCmd = &cobra.Command{
+	Use:   "remove <column-name> <rule-number>",
+	Short: "Remove a rule from a column",
+	Long:  `Remove a rule from a column by its number in 'mkanban column rule list'.`,
+	Args:  cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		resolvedArgs, err := resolveArgs(args, 2)
+		if err != nil {
+			return err
+		}
+		columnName := resolvedArgs[0]
+		number, err := strconv.Atoi(resolvedArgs[1])
+		if err != nil {
+			return fmt.Errorf("invalid rule number '%s'", resolvedArgs[1])
+		}
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = container.RemoveColumnRuleUseCase.Execute(ctx, boardID, columnName, number-1)
+		if err != nil {
+			return fmt.Errorf("failed to remove rule: %w", err)
+		}
+
+		printer.Success("Removed rule %d from column %s", number, columnName)
+		return nil
+	},
+}
+
+// columnRuleFromFlags builds a column rule from the flags of 'column rule add'
+func columnRuleFromFlags(cmd *cobra.Command) (dto.ColumnRuleDTO, error) {
+	flags := cmd.Flags()
+	on, _ := flags.GetString("on")
+	warn, _ := flags.GetBool("warn")
+	message, _ := flags.GetString("message")
+
+	rule := dto.ColumnRuleDTO{
+		On:       on,
+		Severity: string(entity.RuleBlock),
+		Message:  message,
+	}
+	if warn {
+		rule.Severity = string(entity.RuleWarn)
+	}
+
+	checks := 0
+	if flags.Changed("require") {
+		checks++
+		rule.Type = string(entity.ColumnRuleRequire)
+		rule.Field, _ = flags.GetString("require")
+	}
+	if flags.Changed("condition") {
+		checks++
+		value, _ := flags.GetString("condition")
+		condition, err := parseConditionFlag(value)
+		if err != nil {
+			return rule, err
+		}
+		rule.Type = string(entity.ColumnRuleCondition)
+		rule.Condition = &dto.RuleConditionDTO{
+			Field:    condition.Field,
+			Operator: condition.Operator,
+			Value:    condition.Value,
+		}
+	}
+	if subtasks, _ := flags.GetBool("subtasks-complete"); subtasks {
+		checks++
+		rule.Type = string(entity.ColumnRuleSubtasksComplete)
+	}
+	if flags.Changed("script") {
+		checks++
+		rule.Type = string(entity.ColumnRuleScript)
+		rule.Script, _ = flags.GetString("script")
+	}
+
+	if checks != 1 {
+		return rule, fmt.Errorf("give exactly one of --require, --condition, --subtasks-complete or --script")
+	}
+	return rule, nil
+}
+
+// formatColumnRule describes a column rule for display
+func formatColumnRule(rule dto.ColumnRuleDTO) string {
+	text := fmt.Sprintf("on %s, %s: %s", rule.On, rule.Severity, rule.Description)
+	if rule.Message != "" {
+		text += fmt.Sprintf(" (%s)", rule.Message)
+	}
+	return text
+}
+
 // columnDeleteCmd deletes a column
 var columnDeleteCmd = &cobra.Command{
 	Use:   "delete <column-name>",
@@ -435,6 +776,11 @@ func init() {
 	columnCmd.AddCommand(columnGetCmd)
 	columnCmd.AddCommand(columnCreateCmd)
 	columnCmd.AddCommand(columnUpdateCmd)
+	columnCmd.AddCommand(columnAgingCmd)
+	columnCmd.AddCommand(columnRuleCmd)
+	columnRuleCmd.AddCommand(columnRuleListCmd)
+	columnRuleCmd.AddCommand(columnRuleAddCmd)
+	columnRuleCmd.AddCommand(columnRuleRemoveCmd)
 	columnCmd.AddCommand(columnDeleteCmd)
 	columnCmd.AddCommand(columnReorderCmd)
 
@@ -442,6 +788,8 @@ func init() {
 	columnCreateCmd.Flags().String("description", "", "Column description")
 	columnCreateCmd.Flags().Int("position", 0, "Column position (default: end)")
 	columnCreateCmd.Flags().Int("wip-limit", 0, "WIP limit (0 = unlimited)")
+	columnCreateCmd.Flags().Int("stale-after", 0, "Working days before a task in the column is stale")
+	columnCreateCmd.Flags().Int("escalate-after", 0, "Working days before a task in the column is escalated")
 
 	// columnUpdateCmd flags
 	columnUpdateCmd.Flags().String("name", "", "New column name")
@@ -449,6 +797,20 @@ func init() {
 	columnUpdateCmd.Flags().Int("position", 0, "Column position")
 	columnUpdateCmd.Flags().Int("wip-limit", 0, "WIP limit (0 = unlimited)")
 
+	// columnAgingCmd flags
+	columnAgingCmd.Flags().Int("stale-after", 0, "Working days before a task is stale (0 = never)")
+	columnAgingCmd.Flags().Int("escalate-after", 0, "Working days before a task is escalated (0 = never)")
+	columnAgingCmd.Flags().Bool("clear", false, "Remove the aging policy")
+
+	// columnRuleAddCmd flags
+	columnRuleAddCmd.Flags().String("on", "", "When the rule is checked: enter or exit")
+	columnRuleAddCmd.Flags().String("require", "", "Field that must be set (description, due_date, estimate, tags, tag:<prefix> or a metadata key)")
+	columnRuleAddCmd.Flags().String("condition", "", "Condition as \"<field> <operator> <value>\" that must hold")
+	columnRuleAddCmd.Flags().Bool("subtasks-complete", false, "Require every subtask checkbox in the description to be checked")
+	columnRuleAddCmd.Flags().String("script", "", "Script that must exit 0")
+	columnRuleAddCmd.Flags().Bool("warn", false, "Warn instead of blocking the move")
+	columnRuleAddCmd.Flags().String("message", "", "Message shown when the rule is violated")
+
 	// columnDeleteCmd flags
 	columnDeleteCmd.Flags().Bool("force", false, "Force delete even if column has tasks")
 	columnDeleteCmd.Flags().String("move-tasks-to", "", "Move tasks to this column before deletion")
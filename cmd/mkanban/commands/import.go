@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"mkanban/internal/application/dto"
+)
+
+// importCmd imports the exports of other tools
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import boards from Trello, GitHub, Taskwarrior or CSV",
+	Long: `Import boards and tasks from the export file of another tool.
+
+Formats:
+  trello       Trello board JSON (Menu > Print, export and share > Export as JSON)
+  github       gh issue list --json number,title,body,state,labels,comments,createdAt,closedAt,milestone,url
+               or gh project item-list <number> --owner <owner> --format json
+  taskwarrior  task export
+  csv          A header row and a task per row
+
+Projects, boards and columns are created as needed. Labels become tags,
+checklists subtasks and comments the task's activity; due dates are kept.
+Each task remembers its ID in the source, so importing the same export
+again updates the tasks instead of adding them twice.
+
+CSV fields are read from the headers of the same name (id, title,
+description, column, board, project, priority, tags, due, created,
+checklist) or from the headers given with --map.
+
+Use - as the file to read standard input.
+
+Examples:
+  # Preview an import
+  mkanban import board.json --format trello --dry-run
+
+  # Import GitHub issues into a board of its own
+  gh issue list --state all --json number,title,body,state,labels,comments,createdAt,closedAt,milestone,url \
+    | mkanban import - --format github --project shop --board issues
+
+  # Import a Jira CSV export
+  mkanban import jira.csv --format csv --map id="Issue key" --map column=Status`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		format, _ := cmd.Flags().GetString("format")
+		project, _ := cmd.Flags().GetString("project")
+		boardName, _ := cmd.Flags().GetString("board")
+		fields, _ := cmd.Flags().GetStringToString("map")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if format == "" {
+			return fmt.Errorf("--format is required: trello, github, taskwarrior or csv")
+		}
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+
+		result, err := container.ImportBoardsUseCase.Execute(ctx, dto.ImportRequest{
+			Format:  format,
+			Data:    data,
+			Project: project,
+			Board:   boardName,
+			Fields:  fields,
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import: %w", err)
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		default:
+			printImportResult(result)
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("%d task(s) could not be imported", len(result.Errors))
+			}
+			return nil
+		}
+	},
+}
+
+// printImportResult prints what an import did, or would do on a dry run
+func printImportResult(result *dto.ImportResultDTO) {
+	for _, board := range result.Boards {
+		action := "Board"
+		if board.Created {
+			action = "New board"
+		}
+		printer.Header("%s %s", action, board.ID)
+		if len(board.ColumnsAdded) > 0 {
+			printer.Println("Columns added: %s", strings.Join(board.ColumnsAdded, ", "))
+		}
+
+		rows := make([][]string, 0, len(board.Tasks))
+		for _, task := range board.Tasks {
+			action := task.Action
+			if len(task.Changes) > 0 {
+				action += " (" + strings.Join(task.Changes, ", ") + ")"
+			}
+			rows = append(rows, []string{action, task.Column, task.Title, task.TaskID})
+		}
+		if len(rows) > 0 {
+			printer.Table([]string{"Action", "Column", "Title", "Task"}, rows)
+		}
+		fmt.Println()
+	}
+
+	for _, message := range result.Errors {
+		printer.Error("%s", message)
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged",
+		result.TasksCreated, result.TasksUpdated, result.TasksUnchanged)
+	if result.DryRun {
+		printer.Info("Dry run, nothing was imported: %s", summary)
+	} else {
+		printer.Success("Imported: %s", summary)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().String("format", "", "Export format: trello, github, taskwarrior or csv")
+	importCmd.Flags().String("project", "", "Project to import into (default: named after the export)")
+	importCmd.Flags().String("board", "", "Board to import into, merging all imported boards (default: named after the export)")
+	importCmd.Flags().StringToString("map", nil, "Map a CSV field to a header, e.g. --map title=Summary")
+	importCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing anything")
+}
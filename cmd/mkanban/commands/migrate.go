@@ -4,100 +4,132 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/daemon"
+	"mkanban/internal/domain/service"
 )
 
-// migrateCmd represents the migrate command
+// migrateCmd upgrades the data directory to the current layout
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Migrate data to new formats",
-	Long: `Migrate boards and tasks to new data formats.
+	Long: `Upgrade the data directory to the layout this mkanban reads.
 
-This command is used when upgrading mkanban to handle schema changes and data format updates.
+The data directory records its schema version in schema.yml. Each release
+that changes how data is laid out adds a numbered migration, and this
+command applies the ones the data directory hasn't had yet, in order. The
+data directory is archived to backups/ first.
+
+The daemon refuses to start on an outdated data directory, or migrates it
+itself with storage.auto_migrate set.
 
 Examples:
-  # Migrate all boards to new format
-  mkanban migrate
+  # Show the schema version and pending migrations
+  mkanban migrate status
+
+  # See what migrating would change without changing anything
+  mkanban migrate --dry-run
 
-  # Migrate specific board
-  mkanban migrate --board-id my-project`,
+  # Migrate
+  mkanban migrate`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := getContext()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noBackup, _ := cmd.Flags().GetBool("no-backup")
 
-		if !quiet {
-			printer.Info("Starting column migration to new format...")
+		if !dryRun && daemon.NewClient(cfg).IsHealthy() {
+			return fmt.Errorf("the daemon is running, stop it before migrating")
 		}
 
-		// Get all boards or specific board
-		var boardsToMigrate []struct {
-			ID   string
-			Name string
+		result, err := container.DataMigrator.Migrate(ctx, service.DataMigrateOptions{
+			DryRun: dryRun,
+			Backup: !noBackup,
+		})
+		if err != nil {
+			return err
 		}
+		migrateDTO := dto.DataMigrateToDTO(result)
 
-		if boardID != "" {
-			// Migrate specific board
-			board, err := container.GetBoardUseCase.Execute(ctx, boardID)
-			if err != nil {
-				return fmt.Errorf("failed to get board: %w", err)
-			}
-			boardsToMigrate = append(boardsToMigrate, struct {
-				ID   string
-				Name string
-			}{ID: board.ID, Name: board.Name})
-		} else {
-			// Migrate all boards
-			boards, err := container.ListBoardsUseCase.Execute(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to list boards: %w", err)
-			}
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(migrateDTO)
+		}
+		if quiet {
+			return nil
+		}
 
-			if len(boards) == 0 {
-				printer.Info("No boards found. Nothing to migrate.")
-				return nil
-			}
+		if len(migrateDTO.Applied) == 0 {
+			printer.Success("Data directory is up to date (schema version %d)", migrateDTO.To)
+			return nil
+		}
+		for _, migration := range migrateDTO.Applied {
+			printer.Println("  %3d  %-22s %s", migration.Version, migration.ID, migration.Description)
+		}
+		if len(migrateDTO.Changes) > 0 {
+			fmt.Println()
+			printFileChanges(migrateDTO.Changes)
+		}
+		fmt.Println()
 
-			for _, b := range boards {
-				boardsToMigrate = append(boardsToMigrate, struct {
-					ID   string
-					Name string
-				}{ID: b.ID, Name: b.Name})
-			}
+		if dryRun {
+			printer.Info("Dry run: would migrate from schema version %d to %d, changing %d files", migrateDTO.From, migrateDTO.To, len(migrateDTO.Changes))
+			return nil
 		}
+		printer.Success("Migrated from schema version %d to %d, changing %d files", migrateDTO.From, migrateDTO.To, len(migrateDTO.Changes))
+		if migrateDTO.Backup != "" {
+			printer.Info("Backup: %s", migrateDTO.Backup)
+		}
+		return nil
+	},
+}
 
-		// Migrate each board
-		migratedCount := 0
-		for _, board := range boardsToMigrate {
-			if !quiet {
-				printer.Info("Migrating board: %s (%s)", board.Name, board.ID)
-			}
+// migrateStatusCmd shows the schema version of the data directory
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		status, err := container.DataMigrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		statusDTO := dto.DataSchemaStatusToDTO(status)
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(statusDTO)
+		}
 
-			// Get the board repository from the container
-			boardRepo := container.BoardRepo
-
-			// Cast to filesystem implementation to access migration method
-			if fsRepo, ok := boardRepo.(interface {
-				MigrateColumnsToNewFormat(ctx interface{}, boardID string) error
-			}); ok {
-				err := fsRepo.MigrateColumnsToNewFormat(ctx, board.ID)
-				if err != nil {
-					printer.Error("Error migrating board %s: %v", board.ID, err)
-				} else {
-					printer.Success("Board %s migrated successfully", board.ID)
-					migratedCount++
+		printer.Println("Schema version: %d of %d", statusDTO.Version, statusDTO.Latest)
+		if len(statusDTO.Applied) > 0 {
+			fmt.Println()
+			printer.Header("Applied")
+			for _, migration := range statusDTO.Applied {
+				appliedAt := "-"
+				if migration.AppliedAt != nil {
+					appliedAt = migration.AppliedAt.Local().Format("2006-01-02 15:04")
 				}
-			} else {
-				printer.Warning("Board repository does not support migration")
+				printer.Println("  %3d  %-22s %s", migration.Version, migration.ID, appliedAt)
 			}
 		}
-
-		if !quiet {
+		if len(statusDTO.Pending) > 0 {
 			fmt.Println()
-			printer.Success("Migration complete. Migrated %d board(s).", migratedCount)
+			printer.Header("Pending")
+			for _, migration := range statusDTO.Pending {
+				printer.Println("  %3d  %-22s %s", migration.Version, migration.ID, migration.Description)
+			}
+			fmt.Println()
+			printer.Info("Run 'mkanban migrate' to apply them")
 		}
-
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+
+	migrateCmd.Flags().Bool("dry-run", false, "Show what migrating would change without changing anything")
+	migrateCmd.Flags().Bool("no-backup", false, "Migrate without archiving the data directory first")
 }
@@ -50,7 +50,7 @@ Features:
   - Task management with priorities, tags, and due dates
   - Git integration for branch-per-task workflows
   - Automated actions and reminders
-  - Tmux session awareness
+  - Terminal session awareness (tmux, zellij, screen or a shell hook)
   - Interactive TUI and comprehensive CLI
 
 Examples:
@@ -99,6 +99,11 @@ Examples:
 			return fmt.Errorf("failed to initialize container: %w", err)
 		}
 
+		// Record a new data directory as up to date before anything is written to it
+		if err := container.DataMigrator.Init(getContext()); err != nil {
+			return fmt.Errorf("failed to initialize data schema: %w", err)
+		}
+
 		// Initialize output formatter
 		format, err := output.ParseFormat(outputFormat)
 		if err != nil {
@@ -292,10 +297,22 @@ func splitFields(input string, split func(rune) bool) []string {
 }
 
 // getActiveBoardFromSession attempts to get the active board ID from the current session
+// inTerminalSession reports whether mkanban runs in a session a tracker
+// can see
+func inTerminalSession() bool {
+	for _, env := range []string{"TMUX", "ZELLIJ", "STY", "MKANBAN_SHELL_PID"} {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func getActiveBoardFromSession(ctx context.Context) (string, error) {
-	// Check if running in tmux
-	if os.Getenv("TMUX") == "" {
-		return "", fmt.Errorf("not in tmux session")
+	// Check if running in a tracked terminal: a multiplexer session or a
+	// shell set up with shell-init
+	if !inTerminalSession() {
+		return "", fmt.Errorf("not in a terminal session")
 	}
 
 	// Create daemon client
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"mkanban/internal/daemon"
+	"mkanban/internal/infrastructure/config"
+)
+
+// shellHooks are the prompt hooks of shell-init. Each reports the shell's
+// directory in the background, so a slow or missing daemon never holds up
+// the prompt.
+var shellHooks = map[string]string{
+	"bash": `export MKANBAN_SHELL_PID=$$
+__mkanban_report() {
+  (command mkanban shell-report --pid "$MKANBAN_SHELL_PID" --dir "$PWD" >/dev/null 2>&1 &)
+}
+case ";${PROMPT_COMMAND:-};" in
+  *";__mkanban_report;"*) ;;
+  *) PROMPT_COMMAND="__mkanban_report${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`,
+	"zsh": `export MKANBAN_SHELL_PID=$$
+__mkanban_report() {
+  (command mkanban shell-report --pid "$MKANBAN_SHELL_PID" --dir "$PWD" >/dev/null 2>&1 &)
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook precmd __mkanban_report
+`,
+	"fish": `set -gx MKANBAN_SHELL_PID $fish_pid
+function __mkanban_report --on-event fish_prompt
+    command mkanban shell-report --pid $MKANBAN_SHELL_PID --dir "$PWD" >/dev/null 2>&1 &
+    disown 2>/dev/null
+end
+`,
+}
+
+// shellInitCmd represents the shell-init command
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init [bash|zsh|fish]",
+	Short: "Print the hook that tracks sessions in plain terminals",
+	Long: `Print a prompt hook that reports the shell's working directory to the
+daemon, so the active board, branch-based task detection and automatic time
+tracking work without tmux, zellij or screen. The shell that showed a prompt
+last is taken as the focused one.
+
+Bash (~/.bashrc):
+  eval "$(mkanban shell-init bash)"
+
+Zsh (~/.zshrc):
+  eval "$(mkanban shell-init zsh)"
+
+Fish (~/.config/fish/config.fish):
+  mkanban shell-init fish | source
+
+The shell tracker is picked when no multiplexer has sessions, or always with
+session_tracking.tracker_type set to "shell".`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	Args:                  cobra.ExactArgs(1),
+	// Shells source the hook at startup, so skip loading boards
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hook, ok := shellHooks[args[0]]
+		if !ok {
+			return fmt.Errorf("invalid shell: %s (expected bash, zsh or fish)", args[0])
+		}
+		fmt.Fprint(os.Stdout, hook)
+		return nil
+	},
+}
+
+var (
+	shellReportPID int
+	shellReportDir string
+)
+
+// shellReportCmd is what the shell-init hooks run on every prompt
+var shellReportCmd = &cobra.Command{
+	Use:    "shell-report",
+	Short:  "Report a shell's prompt to the daemon",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	// Runs on every prompt, so only the config is needed to reach the daemon
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		loader, err := config.NewLoader()
+		if err != nil {
+			return fmt.Errorf("failed to create config loader: %w", err)
+		}
+		cfg, err = loader.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if shellReportDir == "" {
+			dir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			shellReportDir = dir
+		}
+		if shellReportPID == 0 {
+			shellReportPID = os.Getppid()
+		}
+
+		client := daemon.NewClient(cfg)
+		if err := client.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to daemon: %w", err)
+		}
+		defer client.Close()
+
+		return client.ReportShell(shellReportPID, shellReportDir)
+	},
+}
+
+func init() {
+	shellReportCmd.Flags().IntVar(&shellReportPID, "pid", 0, "PID of the shell (default: the parent process)")
+	shellReportCmd.Flags().StringVar(&shellReportDir, "dir", "", "Working directory of the shell (default: current directory)")
+
+	rootCmd.AddCommand(shellInitCmd)
+	rootCmd.AddCommand(shellReportCmd)
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mkanban/internal/daemon"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence"
+)
+
+// storageCmd shows where boards are stored
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Show and change where boards are stored",
+	Long: `Show the storage backend boards, notes, time logs and actions are kept in.
+
+mkanban stores data as folders of YAML and Markdown files by default, or
+in a single SQLite database with storage.backend set to "sqlite". Use
+'mkanban storage migrate' to move the data from one to the other.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backend := persistence.Backend(cfg)
+		location := cfg.Storage.DataPath
+		if backend == config.StorageBackendSQLite {
+			location = persistence.DatabasePath(cfg)
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(map[string]string{"backend": backend, "location": location})
+		}
+		printer.Println("Backend:  %s", backend)
+		printer.Println("Location: %s", location)
+		return nil
+	},
+}
+
+// storageMigrateCmd copies the data to another backend and switches to it
+var storageMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move the data to another storage backend",
+	Long: `Copy boards, projects, notes, time logs and actions from the current
+storage backend to another one, then switch the config to it.
+
+The data of the current backend is left in place, so switching back with
+storage.backend restores it as it was. Migrating again overwrites what an
+earlier migration wrote. Stop the daemon first, so nothing changes during
+the copy, and start it again afterwards.
+
+Examples:
+  # Move to a SQLite database
+  mkanban storage migrate --to sqlite
+
+  # Move back to folders of files
+  mkanban storage migrate --to filesystem`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		target, _ := cmd.Flags().GetString("to")
+		noSwitch, _ := cmd.Flags().GetBool("no-switch")
+
+		if target == "" {
+			return fmt.Errorf("--to is required: filesystem or sqlite")
+		}
+		current := persistence.Backend(cfg)
+		if target == current {
+			return fmt.Errorf("already using the %s backend", current)
+		}
+		if daemon.NewClient(cfg).IsHealthy() {
+			return fmt.Errorf("the daemon is running, stop it before migrating")
+		}
+
+		from, err := persistence.NewRepositories(cfg, current)
+		if err != nil {
+			return err
+		}
+		defer from.Close()
+		to, err := persistence.NewRepositories(cfg, target)
+		if err != nil {
+			return err
+		}
+		defer to.Close()
+
+		result, err := persistence.Copy(ctx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to migrate: %w", err)
+		}
+
+		if !noSwitch {
+			loader, err := config.NewLoader()
+			if err != nil {
+				return fmt.Errorf("failed to create config loader: %w", err)
+			}
+			cfg.Storage.Backend = target
+			if err := loader.Save(cfg); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		}
+		if quiet {
+			return nil
+		}
+		printer.Success("Copied %d projects, %d boards with %d tasks, %d notes, %d time logs and %d actions to %s",
+			result.Projects, result.Boards, result.Tasks, result.Notes, result.TimeLogs, result.Actions, target)
+		if noSwitch {
+			printer.Info("Still using %s, set storage.backend to %s to switch", current, target)
+		} else {
+			printer.Info("Now using %s", target)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(storageCmd)
+	storageCmd.AddCommand(storageMigrateCmd)
+
+	storageMigrateCmd.Flags().String("to", "", "Backend to move to: filesystem or sqlite")
+	storageMigrateCmd.Flags().Bool("no-switch", false, "Copy the data without switching the config to the new backend")
+}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/daemon"
+	"mkanban/internal/infrastructure/config"
+)
+
+// syncCmd syncs the data directory with other machines through git
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync boards with other machines through git",
+	Long: `Sync the data directory with other machines through a git remote.
+
+The data directory is kept in a git repository. Syncing commits local
+changes, merges the remote's and pushes the result. With sync enabled, the
+daemon also commits its changes as they happen and syncs on an interval.
+
+Tasks edited on two machines are merged field by field: a field changed on
+one side keeps that change, lists like tags keep the additions of both,
+and a field changed on both takes the value of the side modified last.
+Tasks created on two machines with the same number keep it for the one
+pushed first; the others get the next free number and references to them
+are updated.
+
+Examples:
+  # Start syncing with a shared repository
+  mkanban sync init --remote git@example.com:me/mkanban-data.git
+
+  # Sync now
+  mkanban sync
+
+  # Show what is waiting to be synced
+  mkanban sync status`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		result, err := syncData(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to sync: %w", err)
+		}
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(result)
+		default:
+			printSyncResult(result)
+			return nil
+		}
+	},
+}
+
+// syncInitCmd sets the data directory up for syncing
+var syncInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set the data directory up for syncing",
+	Long: `Make the data directory a git repository and enable syncing.
+
+With --remote the repository syncs with the given git URL, which is saved
+in the config. On a second machine, point it at the same remote and run
+'mkanban sync' to merge the boards already there.
+
+Examples:
+  mkanban sync init --remote git@example.com:me/mkanban-data.git`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		remote, _ := cmd.Flags().GetString("remote")
+
+		if err := container.DataSyncer.Init(ctx, remote); err != nil {
+			return fmt.Errorf("failed to set up sync: %w", err)
+		}
+
+		loader, err := config.NewLoader()
+		if err != nil {
+			return fmt.Errorf("failed to create config loader: %w", err)
+		}
+		cfg.Sync.Enabled = true
+		if remote != "" {
+			cfg.Sync.Remote = remote
+		}
+		if err := loader.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		if !quiet {
+			printer.Success("Syncing %s", cfg.Storage.DataPath)
+			if cfg.Sync.Remote == "" {
+				printer.Info("No remote yet, changes are only committed; add one with --remote")
+			} else {
+				printer.Info("Remote: %s", cfg.Sync.Remote)
+			}
+		}
+		return nil
+	},
+}
+
+// syncStatusCmd shows the sync state of the data directory
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the sync state of the data directory",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+
+		status, err := container.DataSyncer.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get sync status: %w", err)
+		}
+		statusDTO := dto.DataSyncStatusToDTO(status)
+
+		switch outputFormat {
+		case "json", "yaml":
+			return formatter.Print(statusDTO)
+		}
+
+		if !statusDTO.Initialized {
+			printer.Info("Not syncing, run 'mkanban sync init' to start")
+			return nil
+		}
+		remote := statusDTO.Remote
+		if remote == "" {
+			remote = "none"
+		}
+		printer.Println("Remote:  %s (%s)", remote, statusDTO.Branch)
+		printer.Println("Pending: %d changed files", statusDTO.Pending)
+		printer.Println("Ahead:   %d commits", statusDTO.Ahead)
+		printer.Println("Behind:  %d commits (as of the last sync)", statusDTO.Behind)
+		if statusDTO.LastCommit != nil {
+			printer.Println("Last commit: %s", statusDTO.LastCommit.Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+// syncData syncs through the daemon when it runs with sync enabled, so
+// its changes are held off meanwhile, and directly otherwise
+func syncData(ctx context.Context) (*dto.DataSyncDTO, error) {
+	client := daemon.NewClient(cfg)
+	if cfg.Sync.Enabled && client.IsHealthy() {
+		if err := client.Connect(); err == nil {
+			defer client.Close()
+			return client.SyncData(ctx)
+		}
+	}
+
+	result, err := container.DataSyncer.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	syncDTO := dto.DataSyncToDTO(result)
+	return &syncDTO, nil
+}
+
+// printSyncResult prints what a sync did
+func printSyncResult(result *dto.DataSyncDTO) {
+	if !result.Committed && result.Pulled == 0 && result.Pushed == 0 {
+		printer.Success("Already in sync")
+		return
+	}
+
+	var done []string
+	if result.Committed {
+		done = append(done, "committed local changes")
+	}
+	if result.Pulled > 0 {
+		done = append(done, fmt.Sprintf("pulled %d commits", result.Pulled))
+	}
+	if result.Pushed > 0 {
+		done = append(done, fmt.Sprintf("pushed %d commits", result.Pushed))
+	}
+	printer.Success("Synced: %s", strings.Join(done, ", "))
+
+	for _, path := range result.Merged {
+		printer.Info("Merged %s", path)
+	}
+	for _, task := range result.Renumbered {
+		printer.Warning("Renumbered %s to %s on %s, its number was taken elsewhere", task.OldID, task.NewID, task.BoardID)
+		if task.Branch != "" {
+			printer.Info("Branch %s keeps the old ID, as do commit messages and sessions naming it", task.Branch)
+		} else {
+			printer.Info("Commit messages and sessions naming %s keep the old ID", task.OldID)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncInitCmd)
+	syncCmd.AddCommand(syncStatusCmd)
+
+	syncInitCmd.Flags().String("remote", "", "Git URL of the repository to sync with")
+}
@@ -1,15 +1,21 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"mkanban/internal/application/dto"
+	taskUseCase "mkanban/internal/application/usecase/task"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
 	"mkanban/internal/infrastructure/serialization"
 )
 
@@ -285,7 +291,7 @@ func printTaskColumns(tasks []dto.TaskDTO) {
 	}
 
 	for colName, colTasks := range columns {
-		printer.Header(colName)
+		printer.Header("%s", colName)
 		fmt.Println()
 
 		for _, task := range colTasks {
@@ -395,7 +401,7 @@ Examples:
 			return nil
 		default:
 			// Text format
-			printer.Header(foundTask.Title)
+			printer.Header("%s", foundTask.Title)
 			fmt.Println()
 			printer.Println("ID:          %s", foundTask.ShortID)
 			printer.Println("Full ID:     %s", foundTask.ID)
@@ -408,12 +414,43 @@ Examples:
 			if foundTask.DueDate != nil {
 				printer.Println("Due:         %s", foundTask.DueDate.Format("2006-01-02"))
 			}
+			if foundTask.EstimatedTime != nil {
+				printer.Println("Estimate:    %s", formatDuration(*foundTask.EstimatedTime))
+			}
+			if foundTask.ParentID != "" {
+				parent := foundTask.ParentID
+				if foundTask.ParentBoardID != "" {
+					parent += " (" + foundTask.ParentBoardID + ")"
+				}
+				printer.Println("Parent:      %s", parent)
+			}
+			if foundTask.SubtasksTotal > 0 {
+				printer.Println("Subtasks:    %s %d/%d done", progressBar(foundTask.SubtasksDone, foundTask.SubtasksTotal, 10),
+					foundTask.SubtasksDone, foundTask.SubtasksTotal)
+			}
 			printer.Println("Path:        %s", foundTask.FilePath)
 			fmt.Println()
 			if foundTask.Description != "" {
 				printer.Bold("Description:")
 				fmt.Println(foundTask.Description)
 			}
+			if commits := taskCommits(*foundTask); len(commits) > 0 {
+				if foundTask.Description != "" {
+					fmt.Println()
+				}
+				printer.Bold("Commits:")
+				for _, commit := range commits {
+					fmt.Println(formatCommit(commit))
+				}
+			}
+			if comments := taskComments(*foundTask); len(comments) > 0 {
+				fmt.Println()
+				printer.Bold("Comments:")
+				for _, comment := range comments {
+					printer.Subtle("%s  %s", comment.Time.Local().Format("2006-01-02 15:04"), comment.Author)
+					fmt.Println(comment.Summary)
+				}
+			}
 			return nil
 		}
 	},
@@ -585,7 +622,9 @@ var taskUpdateCmd = &cobra.Command{
 	Short: "Update task properties",
 	Long: `Update properties of an existing task.
 
-You can update title, description, priority, status, tags, and due date.
+You can update title, description, priority, status, tags, due date and
+estimate. Checking or unchecking a subtask's checkbox in the description
+moves the subtask to the first Done, In Progress or todo column of its board.
 
 Examples:
   # Update task title
@@ -606,6 +645,9 @@ Examples:
   # Update due date
   mkanban task update TASK-123 --due "2025-12-31"
 
+  # Estimate the work
+  mkanban task update TASK-123 --estimate 3h
+
   # Edit description in editor
   mkanban task update TASK-123 --edit`,
 	Args: cobra.RangeArgs(0, 1),
@@ -631,48 +673,97 @@ Examples:
 		addTags, _ := cmd.Flags().GetStringSlice("add-tag")
 		removeTags, _ := cmd.Flags().GetStringSlice("remove-tag")
 		dueStr, _ := cmd.Flags().GetString("due")
+		estimateStr, _ := cmd.Flags().GetString("estimate")
 		useEditor, _ := cmd.Flags().GetBool("edit")
 
 		// Check if any flags were provided
 		if !cmd.Flags().Changed("title") && !cmd.Flags().Changed("description") &&
 			!cmd.Flags().Changed("priority") && !cmd.Flags().Changed("status") &&
 			!cmd.Flags().Changed("tags") && len(addTags) == 0 && len(removeTags) == 0 &&
-			!cmd.Flags().Changed("due") && !useEditor {
+			!cmd.Flags().Changed("due") && !cmd.Flags().Changed("estimate") && !useEditor {
 			return fmt.Errorf("no updates specified")
 		}
 
-		// TODO: Implement task update use case
-		printer.Error("Task update not yet implemented")
-		printer.Info("Would update task '%s' in board '%s':", taskID, boardID)
-		if title != "" {
-			printer.Info("  Title: %s", title)
+		current, err := findTaskDTO(ctx, boardID, taskID)
+		if err != nil {
+			return err
+		}
+
+		req := dto.UpdateTaskRequest{}
+		if cmd.Flags().Changed("title") {
+			req.Title = &title
 		}
 		if cmd.Flags().Changed("description") {
-			printer.Info("  Description: %s", description)
+			req.Description = &description
+		}
+		if useEditor {
+			editTitle, editDescription := current.Title, current.Description
+			if req.Title != nil {
+				editTitle = *req.Title
+			}
+			if req.Description != nil {
+				editDescription = *req.Description
+			}
+			editedContent, err := openEditorForTask(editTitle, editDescription)
+			if err != nil {
+				return fmt.Errorf("failed to open editor: %w", err)
+			}
+			parsedTitle, parsedDesc, err := parseMarkdownTask(editedContent)
+			if err != nil {
+				return fmt.Errorf("failed to parse task: %w", err)
+			}
+			req.Title = &parsedTitle
+			req.Description = &parsedDesc
 		}
 		if priority != "" {
-			printer.Info("  Priority: %s", priority)
+			if _, err := valueobject.ParsePriority(priority); err != nil {
+				return err
+			}
+			req.Priority = &priority
 		}
 		if status != "" {
-			printer.Info("  Status: %s", status)
-		}
-		if tagsStr != "" {
-			printer.Info("  Tags: %s", tagsStr)
+			if _, err := valueobject.ParseStatus(status); err != nil {
+				return err
+			}
+			req.Status = &status
 		}
-		if len(addTags) > 0 {
-			printer.Info("  Add tags: %s", strings.Join(addTags, ", "))
+		if dueStr != "" {
+			dueDate, err := time.ParseInLocation("2006-01-02", dueStr, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid due date format. Use YYYY-MM-DD: %w", err)
+			}
+			req.DueDate = &dueDate
 		}
-		if len(removeTags) > 0 {
-			printer.Info("  Remove tags: %s", strings.Join(removeTags, ", "))
+		if estimateStr != "" {
+			estimate, err := time.ParseDuration(estimateStr)
+			if err != nil || estimate < 0 {
+				return fmt.Errorf("invalid estimate '%s' (use e.g. 90m or 2h30m)", estimateStr)
+			}
+			req.Estimate = &estimate
 		}
-		if dueStr != "" {
-			printer.Info("  Due: %s", dueStr)
+
+		// Work out the new tags from the current ones
+		if cmd.Flags().Changed("tags") || len(addTags) > 0 || len(removeTags) > 0 {
+			tags := current.Tags
+			if cmd.Flags().Changed("tags") {
+				tags = parseTagsString(tagsStr)
+			}
+			tags = append(tags, addTags...)
+			req.Tags = make([]string, 0, len(tags))
+			for _, tag := range tags {
+				if !slices.Contains(removeTags, tag) && !slices.Contains(req.Tags, tag) {
+					req.Tags = append(req.Tags, tag)
+				}
+			}
 		}
-		if useEditor {
-			printer.Info("  Open editor: yes")
+
+		task, err := container.UpdateTaskUseCase.Execute(ctx, boardID, current.ID, req)
+		if err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
 		}
 
-		return fmt.Errorf("task update not yet implemented")
+		printer.Success("Updated task: %s - %s", task.ShortID, task.Title)
+		return nil
 	},
 }
 
@@ -705,22 +796,354 @@ Examples:
 			return err
 		}
 
-		// Execute move task use case
-		moveReq := dto.MoveTaskRequest{
-			TaskID:           taskID,
-			TargetColumnName: targetColumn,
+		if err := moveTask(ctx, boardID, taskID, targetColumn); err != nil {
+			return err
+		}
+
+		printer.Success("Moved task %s to %s", taskID, targetColumn)
+		return nil
+	},
+}
+
+// moveTask moves a task to another column, printing the column rules the
+// move broke
+func moveTask(ctx context.Context, boardID, taskID, targetColumn string) error {
+	moveReq := dto.MoveTaskRequest{
+		TaskID:           taskID,
+		TargetColumnName: targetColumn,
+	}
+
+	_, warnings, err := container.MoveTaskUseCase.Execute(ctx, boardID, moveReq)
+	var ruleErr *entity.ColumnRuleError
+	if errors.As(err, &ruleErr) {
+		printRuleViolations(dto.RuleViolationsToDTO(ruleErr.Violations))
+		return fmt.Errorf("task %s can't move to %s: %w", taskID, targetColumn, entity.ErrColumnRuleViolated)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	printRuleViolations(warnings)
+	return nil
+}
+
+// printRuleViolations prints column rule violations, blocking ones as errors
+func printRuleViolations(violations []dto.RuleViolationDTO) {
+	for _, violation := range violations {
+		if violation.Severity == string(entity.RuleBlock) {
+			printer.Error("%s (%s %s)", violation.Message, violation.On, violation.Column)
+		} else {
+			printer.Warning("%s (%s %s)", violation.Message, violation.On, violation.Column)
 		}
+	}
+}
 
-		_, err = container.MoveTaskUseCase.Execute(ctx, boardID, moveReq)
+// taskLaneCmd moves a task to a different swimlane
+var taskLaneCmd = &cobra.Command{
+	Use:   "lane <task-id> [lane]",
+	Short: "Move task to another swimlane",
+	Long: `Move a task to another swimlane of the board, by updating the field the
+lanes group by:
+
+  tag:<prefix>  replaces the task's tag with the prefix, e.g. lane backend
+                sets team:backend for tag:team: lanes
+  priority      sets the priority
+  parent        makes the task a subtask of the lane's task, moving its
+                checkbox to the new parent's description
+
+--none moves the task out of every tag or parent lane. Lanes grouped by
+project can't be moved between.
+
+This is the CLI equivalent of the TUI J and K keys.
+
+Examples:
+  # Hand a task over to the frontend team
+  mkanban task lane TASK-123 frontend
+
+  # Raise a task into the high priority lane of a board without swimlanes
+  mkanban task lane TASK-123 high --swimlanes priority
+
+  # Move a subtask to another epic
+  mkanban task lane TASK-123 TASK-100-checkout-flow --swimlanes parent`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		none, _ := cmd.Flags().GetBool("none")
+		spec, _ := cmd.Flags().GetString("swimlanes")
+
+		expected := 2
+		if none {
+			expected = 1
+		}
+		resolvedArgs, err := resolveArgs(args, expected)
+		if err != nil {
+			return err
+		}
+		taskID := resolvedArgs[0]
+		lane := ""
+		if !none {
+			lane = resolvedArgs[1]
+		}
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = container.MoveTaskToSwimlaneUseCase.Execute(ctx, boardID, dto.MoveTaskToSwimlaneRequest{
+			TaskID:    taskID,
+			Swimlanes: spec,
+			Lane:      lane,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to move task: %w", err)
 		}
 
-		printer.Success("Moved task %s to %s", taskID, targetColumn)
+		if lane == "" {
+			printer.Success("Moved task %s out of its lane", taskID)
+		} else {
+			printer.Success("Moved task %s to lane %s", taskID, lane)
+		}
+		return nil
+	},
+}
+
+// taskParentCmd makes a task a subtask of an epic
+var taskParentCmd = &cobra.Command{
+	Use:   "parent <task-id> [parent-id]",
+	Short: "Make a task a subtask of another",
+	Long: `Make a task a subtask of a parent task (an epic), which may be on another
+board. A checkbox linking to the subtask is added to the parent's description
+and follows the subtask: moving it to a Done or In Progress column checks the
+box, and checking the box moves the subtask. When all of them are checked the
+parent moves to Done.
+
+--none detaches the task from its parent.
+
+Examples:
+  # Put a task under an epic on the same board
+  mkanban task parent TASK-123 TASK-100-checkout-flow
+
+  # Put a task under an epic on another board
+  mkanban task parent API-7-payment-endpoint TASK-100-checkout-flow --parent-board shop/web
+
+  # Detach a task from its epic
+  mkanban task parent TASK-123 --none`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		none, _ := cmd.Flags().GetBool("none")
+		parentBoardID, _ := cmd.Flags().GetString("parent-board")
+
+		expected := 2
+		if none {
+			expected = 1
+		}
+		resolvedArgs, err := resolveArgs(args, expected)
+		if err != nil {
+			return err
+		}
+
+		boardID, err := getBoardID(ctx)
+		if err != nil {
+			return err
+		}
+		task, err := findTaskDTO(ctx, boardID, resolvedArgs[0])
+		if err != nil {
+			return err
+		}
+
+		req := dto.SetTaskParentRequest{TaskID: task.ID}
+		var parent *dto.TaskDTO
+		if !none {
+			if parentBoardID == "" {
+				parentBoardID = boardID
+			}
+			parent, err = findTaskDTO(ctx, parentBoardID, resolvedArgs[1])
+			if err != nil {
+				return err
+			}
+			req.ParentID = parent.ID
+			req.ParentBoardID = parentBoardID
+		}
+
+		if _, err := container.SetTaskParentUseCase.Execute(ctx, boardID, req); err != nil {
+			return fmt.Errorf("failed to set parent: %w", err)
+		}
+
+		if parent == nil {
+			printer.Success("Task %s is no longer a subtask", task.ShortID)
+		} else {
+			printer.Success("Task %s is now a subtask of %s - %s", task.ShortID, parent.ShortID, parent.Title)
+		}
 		return nil
 	},
 }
 
+// taskChildrenCmd lists the subtasks of a task from all boards
+var taskChildrenCmd = &cobra.Command{
+	Use:   "children <task-id>",
+	Short: "List the subtasks of a task",
+	Long: `List the direct subtasks of a task, from all boards.
+
+Examples:
+  # List the subtasks of an epic
+  mkanban task children TASK-100
+
+  # List them as JSON
+  mkanban task children TASK-100 --output json`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		tree, err := taskTreeFromArgs(ctx, args)
+		if err != nil {
+			return err
+		}
+
+		children := make([]dto.TaskTreeDTO, 0, len(tree.Children))
+		for _, child := range tree.Children {
+			child.Children = nil
+			children = append(children, child)
+		}
+
+		if outputFormat == "json" || outputFormat == "yaml" {
+			return formatter.Print(children)
+		}
+
+		if len(children) == 0 {
+			printer.Info("Task %s has no subtasks", tree.Task.ShortID)
+			return nil
+		}
+
+		headers := []string{"ID", "Title", "Board", "Column", "State"}
+		rows := make([][]string, 0, len(children))
+		for _, child := range children {
+			rows = append(rows, []string{
+				child.Task.ShortID,
+				child.Task.Title,
+				child.BoardID,
+				child.Task.ColumnName,
+				child.State,
+			})
+		}
+		printer.Table(headers, rows)
+		return nil
+	},
+}
+
+// taskTreeCmd shows a task with its subtasks and their progress
+var taskTreeCmd = &cobra.Command{
+	Use:   "tree <task-id>",
+	Short: "Show a task with its subtasks",
+	Long: `Show a task with its subtasks from all boards, and theirs in turn. Each task
+with subtasks shows how many of them are done, and the estimated and tracked
+time of the task and everything under it.
+
+Examples:
+  # Show an epic
+  mkanban task tree TASK-100
+
+  # Show it as JSON
+  mkanban task tree TASK-100 --output json`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := getContext()
+		tree, err := taskTreeFromArgs(ctx, args)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" || outputFormat == "yaml" {
+			return formatter.Print(tree)
+		}
+
+		printTaskTree(*tree, "", "", tree.BoardID)
+		return nil
+	},
+}
+
+// taskTreeFromArgs loads the tree of the task named by the arguments
+func taskTreeFromArgs(ctx context.Context, args []string) (*dto.TaskTreeDTO, error) {
+	resolvedArgs, err := resolveArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	boardID, err := getBoardID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := findTaskDTO(ctx, boardID, resolvedArgs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := container.GetTaskTreeUseCase.Execute(ctx, boardID, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subtasks: %w", err)
+	}
+	return tree, nil
+}
+
+// printTaskTree prints a task tree, one task per line under its parent.
+// Tasks on other boards than rootBoardID are marked with their board.
+func printTaskTree(node dto.TaskTreeDTO, indent, branch, rootBoardID string) {
+	checkbox := map[string]string{"done": "[x]", "in_progress": "[~]"}[node.State]
+	if checkbox == "" {
+		checkbox = "[ ]"
+	}
+
+	line := fmt.Sprintf("%s%s%s %s %s", indent, branch, checkbox, node.Task.ShortID, node.Task.Title)
+	if node.BoardID != rootBoardID {
+		line += fmt.Sprintf(" (%s)", node.BoardID)
+	}
+	if node.Total > 0 {
+		line += fmt.Sprintf("  %s %d/%d", progressBar(node.Done, node.Total, 10), node.Done, node.Total)
+	}
+	if node.Estimated > 0 || node.Tracked > 0 {
+		line += fmt.Sprintf("  %s / %s", formatDuration(node.Tracked), formatDuration(node.Estimated))
+	}
+	printer.Println("%s", line)
+
+	// Children line up under the parent's title
+	switch branch {
+	case "├─ ":
+		indent += "│  "
+	case "└─ ":
+		indent += "   "
+	}
+	for i, child := range node.Children {
+		childBranch := "├─ "
+		if i == len(node.Children)-1 {
+			childBranch = "└─ "
+		}
+		printTaskTree(child, indent, childBranch, rootBoardID)
+	}
+}
+
+// progressBar draws done out of total as a bar of width cells
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// formatDuration formats a duration in hours and minutes, e.g. 2h30m
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours > 0 && minutes > 0 {
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
 // taskAdvanceCmd moves a task to the next column
 var taskAdvanceCmd = &cobra.Command{
 	Use:   "advance <task-id>",
@@ -786,14 +1209,8 @@ Examples:
 		}
 
 		// Move task
-		moveReq := dto.MoveTaskRequest{
-			TaskID:           taskID,
-			TargetColumnName: nextColumn,
-		}
-
-		_, err = container.MoveTaskUseCase.Execute(ctx, boardID, moveReq)
-		if err != nil {
-			return fmt.Errorf("failed to move task: %w", err)
+		if err := moveTask(ctx, boardID, taskID, nextColumn); err != nil {
+			return err
 		}
 
 		printer.Success("Moved task %s from %s to %s", taskID, currentColumn, nextColumn)
@@ -864,14 +1281,8 @@ Examples:
 		}
 
 		// Move task
-		moveReq := dto.MoveTaskRequest{
-			TaskID:           taskID,
-			TargetColumnName: prevColumn,
-		}
-
-		_, err = container.MoveTaskUseCase.Execute(ctx, boardID, moveReq)
-		if err != nil {
-			return fmt.Errorf("failed to move task: %w", err)
+		if err := moveTask(ctx, boardID, taskID, prevColumn); err != nil {
+			return err
 		}
 
 		printer.Success("Moved task %s from %s to %s", taskID, currentColumn, prevColumn)
@@ -960,6 +1371,12 @@ var taskCheckoutCmd = &cobra.Command{
 
 The branch name is generated from the task ID and title using a configurable format.
 
+With --worktree the branch is checked out in a git worktree of its own instead
+of the current checkout, so several tasks can be in progress side by side. The
+worktree is reused on later checkouts. Worktrees go in the git_sync worktree_dir
+setting ({repo} is the repository name), by default <repo>.worktrees next to
+the repository. --tmux opens the worktree in a tmux session or window.
+
 Default branch format: {id}
 Available placeholders:
   {id}       - Full task ID (e.g., TASK-123-fix-bug)
@@ -974,7 +1391,10 @@ Examples:
   mkanban task checkout TASK-123 --branch-format "feature/{short-id}-{slug}"
 
   # Create new branch if it doesn't exist
-  mkanban task checkout TASK-123 --create`,
+  mkanban task checkout TASK-123 --create
+
+  # Work on the task in its own worktree and tmux session
+  mkanban task checkout TASK-123 --worktree --tmux session`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := getContext()
@@ -995,6 +1415,10 @@ Examples:
 			branchFormat = "{id}" // Default format
 		}
 
+		if worktree, _ := cmd.Flags().GetBool("worktree"); worktree {
+			return checkoutWorktree(cmd, boardID, taskID, branchFormat)
+		}
+
 		// Execute checkout use case
 		err = container.CheckoutTaskUseCase.Execute(ctx, boardID, taskID, branchFormat)
 		if err != nil {
@@ -1006,6 +1430,44 @@ Examples:
 	},
 }
 
+// checkoutWorktree checks out a task in its own worktree, with the worktree
+// settings of the config unless the flags override them
+func checkoutWorktree(cmd *cobra.Command, boardID, taskID, branchFormat string) error {
+	opts := taskUseCase.WorktreeOptions{
+		Dir:  cfg.SessionTracking.GitSync.WorktreeDir,
+		Tmux: cfg.SessionTracking.GitSync.WorktreeTmux,
+	}
+	if cmd.Flags().Changed("worktree-dir") {
+		opts.Dir, _ = cmd.Flags().GetString("worktree-dir")
+	}
+	if cmd.Flags().Changed("tmux") {
+		opts.Tmux, _ = cmd.Flags().GetString("tmux")
+		if opts.Tmux == "none" {
+			opts.Tmux = taskUseCase.WorktreeTmuxNone
+		}
+	}
+
+	result, err := container.CheckoutTaskUseCase.ExecuteWorktree(getContext(), boardID, taskID, branchFormat, opts)
+	if result == nil {
+		return fmt.Errorf("failed to checkout task: %w", err)
+	}
+
+	if result.Created {
+		printer.Success("Created worktree for branch %s", result.Branch)
+	} else {
+		printer.Success("Reusing worktree of branch %s", result.Branch)
+	}
+	printer.Println("%s", result.Path)
+	if err != nil {
+		return err
+	}
+
+	if opts.Tmux == taskUseCase.WorktreeTmuxSession && !result.Switched {
+		printer.Info("Attach with: tmux attach -t %s", result.Session)
+	}
+	return nil
+}
+
 var taskCurrentCmd = &cobra.Command{
 	Use:   "current",
 	Short: "Show current in-progress task(s)",
@@ -1081,9 +1543,16 @@ var taskShowCmd = &cobra.Command{
 	Short: "Open task in editor",
 	Long: `Open a task file in your configured editor ($EDITOR).
 
+With --commits, list the commits linked to the task instead. The daemon links
+commits that mention the task's ID, or were made on its branch, as they
+appear in the project's repository.
+
 Examples:
   # Open task for editing
-  mkanban task show TASK-123`,
+  mkanban task show TASK-123
+
+  # List the commits made for a task
+  mkanban task show TASK-123 --commits`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := getContext()
@@ -1115,6 +1584,10 @@ Examples:
 			return fmt.Errorf("task '%s' not found", taskID)
 		}
 
+		if commits, _ := cmd.Flags().GetBool("commits"); commits {
+			return printTaskCommits(*foundTask)
+		}
+
 		if foundTask.FilePath == "" {
 			return fmt.Errorf("task '%s' has no file path", taskID)
 		}
@@ -1129,6 +1602,59 @@ Examples:
 
 // Helper functions
 
+// taskCommits returns the commits in a task's activity, merges included,
+// newest first
+func taskCommits(task dto.TaskDTO) []dto.ActivityDTO {
+	var commits []dto.ActivityDTO
+	for i := len(task.Activity) - 1; i >= 0; i-- {
+		kind := entity.ActivityKind(task.Activity[i].Kind)
+		if kind == entity.ActivityCommit || kind == entity.ActivityMerge {
+			commits = append(commits, task.Activity[i])
+		}
+	}
+	return commits
+}
+
+// taskComments returns the comments in a task's activity, oldest first
+func taskComments(task dto.TaskDTO) []dto.ActivityDTO {
+	var comments []dto.ActivityDTO
+	for _, entry := range task.Activity {
+		if entity.ActivityKind(entry.Kind) == entity.ActivityComment {
+			comments = append(comments, entry)
+		}
+	}
+	return comments
+}
+
+// printTaskCommits lists the commits linked to a task
+func printTaskCommits(task dto.TaskDTO) error {
+	commits := taskCommits(task)
+	if outputFormat == "json" || outputFormat == "yaml" {
+		if commits == nil {
+			commits = []dto.ActivityDTO{}
+		}
+		return formatter.Print(commits)
+	}
+
+	if len(commits) == 0 {
+		printer.Info("No commits linked to %s", task.ShortID)
+		return nil
+	}
+	for _, commit := range commits {
+		fmt.Println(formatCommit(commit))
+	}
+	return nil
+}
+
+// formatCommit formats a commit as its short hash, time, author and subject
+func formatCommit(commit dto.ActivityDTO) string {
+	hash := commit.Ref
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	return fmt.Sprintf("%s  %s  %-16s  %s", hash, commit.Time.Local().Format("2006-01-02 15:04"), commit.Author, commit.Summary)
+}
+
 // openEditorForTask opens an editor for creating/editing task content
 func openEditorForTask(title, description string) (string, error) {
 	// Create temporary file
@@ -1398,6 +1924,10 @@ func init() {
 	taskCmd.AddCommand(taskCreateCmd)
 	taskCmd.AddCommand(taskUpdateCmd)
 	taskCmd.AddCommand(taskMoveCmd)
+	taskCmd.AddCommand(taskLaneCmd)
+	taskCmd.AddCommand(taskParentCmd)
+	taskCmd.AddCommand(taskChildrenCmd)
+	taskCmd.AddCommand(taskTreeCmd)
 	taskCmd.AddCommand(taskAdvanceCmd)
 	taskCmd.AddCommand(taskRetreatCmd)
 	taskCmd.AddCommand(taskDeleteCmd)
@@ -1414,6 +1944,14 @@ func init() {
 	taskListCmd.Flags().String("due-before", "", "Show tasks due before date (YYYY-MM-DD)")
 	taskListCmd.Flags().Bool("all-boards", false, "List tasks from all boards")
 
+	// taskLaneCmd flags
+	taskLaneCmd.Flags().String("swimlanes", "", "Swimlanes to move in: tag:<prefix>, priority or parent (default: the board's)")
+	taskLaneCmd.Flags().Bool("none", false, "Move the task out of its tag or parent lane")
+
+	// taskParentCmd flags
+	taskParentCmd.Flags().String("parent-board", "", "Board of the parent task (default: the task's board)")
+	taskParentCmd.Flags().Bool("none", false, "Detach the task from its parent")
+
 	// taskCreateCmd flags
 	taskCreateCmd.Flags().String("title", "", "Task title (optional; opens editor if omitted)")
 	taskCreateCmd.Flags().String("description", "", "Task description")
@@ -1433,12 +1971,19 @@ func init() {
 	taskUpdateCmd.Flags().StringSlice("add-tag", []string{}, "Add tag")
 	taskUpdateCmd.Flags().StringSlice("remove-tag", []string{}, "Remove tag")
 	taskUpdateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD)")
+	taskUpdateCmd.Flags().String("estimate", "", "Estimated time, e.g. 90m or 2h30m")
 	taskUpdateCmd.Flags().Bool("edit", false, "Open editor for description")
 
 	// taskDeleteCmd flags
 	taskDeleteCmd.Flags().Bool("force", false, "Delete without confirmation")
 
+	// taskShowCmd flags
+	taskShowCmd.Flags().Bool("commits", false, "List the commits linked to the task instead of opening it")
+
 	// taskCheckoutCmd flags
 	taskCheckoutCmd.Flags().String("branch-format", "", "Branch name format (default: {id})")
 	taskCheckoutCmd.Flags().Bool("create", false, "Create branch if it doesn't exist")
+	taskCheckoutCmd.Flags().Bool("worktree", false, "Check out the branch in a worktree of its own")
+	taskCheckoutCmd.Flags().String("worktree-dir", "", "Directory for worktrees, {repo} is the repository name (default: <repo>.worktrees)")
+	taskCheckoutCmd.Flags().String("tmux", "", "Open the worktree in a tmux session or window (session, window, none)")
 }
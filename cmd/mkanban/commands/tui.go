@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"mkanban/internal/daemon"
+	"mkanban/internal/domain/entity"
 	"mkanban/tui"
 	"mkanban/tui/style"
 )
@@ -30,6 +31,8 @@ Keyboard shortcuts:
   a        - Add new task to current column
   m/Enter  - Move task to next column
   d        - Delete selected task
+  K/J      - Move task to the lane above/below (with swimlanes)
+  z        - Collapse or expand the focused lane
   q/Ctrl+C - Quit application
 
 Examples:
@@ -39,6 +42,9 @@ Examples:
   # Launch TUI with specific board
   mkanban tui --board-id my-project
 
+  # Launch TUI in swimlanes by priority
+  mkanban tui --swimlanes priority
+
   # Launch TUI (shorthand - default command)
   mkanban`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -84,6 +90,19 @@ Examples:
 		// Create TUI model with daemon client
 		m := tui.NewModel(boardDTO, daemonClient, cfg, selectedBoardID)
 
+		// Override the board's swimlanes if asked to
+		if cmd.Flags().Changed("swimlanes") {
+			lanesSpec, _ := cmd.Flags().GetString("swimlanes")
+			if lanesSpec == "none" {
+				lanesSpec = ""
+			}
+			swimlanes, err := entity.ParseSwimlanes(lanesSpec)
+			if err != nil {
+				return err
+			}
+			m.SetSwimlanes(swimlanes)
+		}
+
 		// Start the program
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
@@ -99,4 +118,6 @@ Examples:
 
 func init() {
 	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().String("swimlanes", "", "Show the board in swimlanes: tag:<prefix>, priority, parent, project or none (default: the board's)")
 }
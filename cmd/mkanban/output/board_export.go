@@ -0,0 +1,271 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"mkanban/internal/application/dto"
+)
+
+// ExportFormats are the formats a board can be exported to
+var ExportFormats = []string{"md", "html", "csv", "json"}
+
+// ExportBoard writes a board snapshot in one of ExportFormats: a Markdown
+// document with a section per column and a checklist of its tasks, a
+// self-contained HTML page of the board, a CSV file with a row per task, or
+// the snapshot itself as JSON
+func ExportBoard(w io.Writer, snapshot *dto.BoardSnapshotDTO, format string) error {
+	switch format {
+	case "md", "markdown":
+		return exportMarkdown(w, snapshot)
+	case "html":
+		return exportHTML(w, snapshot)
+	case "csv":
+		return exportCSV(w, snapshot)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(snapshot)
+	default:
+		return fmt.Errorf("unknown export format %q, expected one of %s", format, strings.Join(ExportFormats, ", "))
+	}
+}
+
+// exportMarkdown writes a board as a Markdown document
+func exportMarkdown(w io.Writer, snapshot *dto.BoardSnapshotDTO) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", snapshot.Name)
+	if description := strings.TrimSpace(snapshot.Description); description != "" {
+		fmt.Fprintf(&b, "%s\n\n", description)
+	}
+	for _, line := range boardSummary(snapshot) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	for _, column := range snapshot.Columns {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", column.Name, len(column.Tasks))
+		if description := strings.TrimSpace(column.Description); description != "" {
+			fmt.Fprintf(&b, "> %s\n\n", strings.ReplaceAll(description, "\n", "\n> "))
+		}
+		if len(column.Tasks) == 0 {
+			b.WriteString("_No tasks_\n")
+			continue
+		}
+
+		for _, task := range column.Tasks {
+			check := " "
+			if taskDone(task) {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] **%s** %s\n", check, task.ShortID, task.Title)
+			if details := taskDetails(task); len(details) > 0 {
+				fmt.Fprintf(&b, "  %s\n", strings.Join(details, " · "))
+			}
+			// Indented under its task, the description's own checkboxes
+			// become a nested checklist of the subtasks
+			if description := strings.TrimSpace(task.Description); description != "" {
+				b.WriteString("\n")
+				for _, line := range strings.Split(description, "\n") {
+					if line == "" {
+						b.WriteString("\n")
+					} else {
+						fmt.Fprintf(&b, "  %s\n", line)
+					}
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exportCSV writes the tasks of a board as CSV, with headers the CSV import
+// reads back
+func exportCSV(w io.Writer, snapshot *dto.BoardSnapshotDTO) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"id", "title", "description", "column", "board", "project", "status", "priority", "tags",
+		"due", "created", "completed", "parent", "estimate_hours", "tracked_hours",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	project := snapshot.ProjectName
+	if project == "" {
+		project = snapshot.ProjectID
+	}
+	for _, column := range snapshot.Columns {
+		for _, task := range column.Tasks {
+			estimate := ""
+			if task.EstimatedTime != nil {
+				estimate = fmt.Sprintf("%.2f", task.EstimatedTime.Hours())
+			}
+			record := []string{
+				task.ShortID,
+				task.Title,
+				task.Description,
+				column.Name,
+				snapshot.Name,
+				project,
+				task.Status,
+				task.Priority,
+				strings.Join(task.Tags, ", "),
+				formatTimestamp(task.DueDate),
+				formatTimestamp(&task.CreatedAt),
+				formatTimestamp(task.CompletedDate),
+				task.ParentID,
+				estimate,
+				fmt.Sprintf("%.2f", task.TrackedTime.Hours()),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportHTML writes a board as a static HTML page that needs no other files
+func exportHTML(w io.Writer, snapshot *dto.BoardSnapshotDTO) error {
+	return boardHTML.Execute(w, snapshot)
+}
+
+// boardHTML is the page of an HTML board export
+var boardHTML = template.Must(template.New("board").Funcs(template.FuncMap{
+	"summary": boardSummary,
+	"details": taskDetails,
+	"done":    taskDone,
+	"join":    strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}}</title>
+<style>
+body { margin: 0; padding: 1.5rem; font: 14px/1.4 system-ui, sans-serif; background: #f4f5f7; color: #172b4d; }
+h1 { margin: 0 0 .25rem; font-size: 1.5rem; }
+.description { margin: 0 0 .5rem; white-space: pre-wrap; }
+.summary { margin: 0 0 1rem; color: #5e6c84; }
+.board { display: flex; gap: 1rem; align-items: flex-start; overflow-x: auto; }
+.column { flex: 0 0 18rem; background: #ebecf0; border-radius: 6px; padding: .5rem; }
+.column h2 { margin: .25rem .25rem .5rem; font-size: 1rem; }
+.column h2 .count { color: #5e6c84; font-weight: normal; }
+.column > p { margin: 0 .25rem .5rem; color: #5e6c84; }
+.task { background: #fff; border-radius: 4px; padding: .5rem; margin-bottom: .5rem; box-shadow: 0 1px 1px rgba(9, 30, 66, .25); }
+.task.done .title { text-decoration: line-through; color: #5e6c84; }
+.task .id { color: #5e6c84; font-size: .85em; }
+.task .details { color: #5e6c84; font-size: .85em; margin-top: .25rem; }
+.task details { margin-top: .25rem; }
+.task pre { white-space: pre-wrap; font: inherit; margin: .25rem 0 0; }
+.tag { display: inline-block; background: #dfe1e6; border-radius: 3px; padding: 0 .3rem; margin: .25rem .25rem 0 0; font-size: .8em; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{with .Description}}<p class="description">{{.}}</p>
+{{end}}<p class="summary">{{join (summary .) " · "}}</p>
+<div class="board">
+{{- range .Columns}}
+<section class="column">
+<h2>{{.Name}} <span class="count">{{len .Tasks}}</span></h2>
+{{with .Description}}<p>{{.}}</p>
+{{end -}}
+{{range .Tasks}}<article class="task{{if done .}} done{{end}}">
+<div><span class="id">{{.ShortID}}</span> <span class="title">{{.Title}}</span></div>
+{{with details .}}<div class="details">{{join . " · "}}</div>
+{{end}}{{range .Tags}}<span class="tag">{{.}}</span>{{end}}
+{{with .Description}}<details><summary>Description</summary><pre>{{.}}</pre></details>
+{{end}}</article>
+{{end}}</section>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+// boardSummary returns the lines summarising a board at the top of an export
+func boardSummary(snapshot *dto.BoardSnapshotDTO) []string {
+	total, done := 0, 0
+	for _, column := range snapshot.Columns {
+		for _, task := range column.Tasks {
+			total++
+			if taskDone(task) {
+				done++
+			}
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Board: %s", snapshot.ID),
+		fmt.Sprintf("Exported: %s", snapshot.ExportedAt.Format("2006-01-02 15:04")),
+		fmt.Sprintf("Tasks: %d (%d done)", total, done),
+	}
+	if snapshot.TrackedTime > 0 {
+		lines = append(lines, fmt.Sprintf("Tracked: %s", formatHours(snapshot.TrackedTime)))
+	}
+	return lines
+}
+
+// taskDetails returns what an export shows of a task besides its title and
+// description
+func taskDetails(task dto.TaskSnapshotDTO) []string {
+	var details []string
+	if task.Priority != "" && task.Priority != "none" {
+		details = append(details, "Priority: "+task.Priority)
+	}
+	if len(task.Tags) > 0 {
+		details = append(details, "Tags: "+strings.Join(task.Tags, ", "))
+	}
+	if task.DueDate != nil {
+		details = append(details, "Due: "+task.DueDate.Format("2006-01-02"))
+	}
+	if task.SubtasksTotal > 0 {
+		details = append(details, fmt.Sprintf("Subtasks: %d/%d", task.SubtasksDone, task.SubtasksTotal))
+	}
+	if task.EstimatedTime != nil {
+		details = append(details, "Estimate: "+formatHours(*task.EstimatedTime))
+	}
+	if task.TrackedTime > 0 {
+		details = append(details, "Tracked: "+formatHours(task.TrackedTime))
+	}
+	return details
+}
+
+// taskDone reports whether a task is checked off in an export
+func taskDone(task dto.TaskSnapshotDTO) bool {
+	return task.Done || task.CompletedDate != nil
+}
+
+// formatHours formats a duration in hours and minutes, e.g. 2h30m
+func formatHours(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours > 0 && minutes > 0 {
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// formatTimestamp formats an optional time for CSV, empty when unset
+func formatTimestamp(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
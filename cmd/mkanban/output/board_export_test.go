@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/application/dto"
+)
+
+func exportSnapshot() *dto.BoardSnapshotDTO {
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &dto.BoardSnapshotDTO{
+		Version:     dto.BoardSnapshotVersion,
+		ExportedAt:  time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+		ID:          "shop/web",
+		ProjectID:   "shop",
+		ProjectName: "Shop",
+		Name:        "Web",
+		Description: "Web shop",
+		TrackedTime: 90 * time.Minute,
+		Columns: []dto.ColumnSnapshotDTO{
+			{Key: "todo", Name: "Todo", Tasks: []dto.TaskSnapshotDTO{{
+				TaskDTO: dto.TaskDTO{
+					ID: "WEB-001-checkout", ShortID: "WEB-001", Title: "Checkout <form>",
+					Description: "Pay by card\n- [ ] Refunds", Priority: "high", Status: "todo",
+					Tags: []string{"payments"}, DueDate: &due, TrackedTime: 90 * time.Minute,
+				},
+			}}},
+			{Key: "done", Name: "Done", Tasks: []dto.TaskSnapshotDTO{{
+				TaskDTO: dto.TaskDTO{ID: "WEB-002-login", ShortID: "WEB-002", Title: "Login", Priority: "none", Status: "todo"},
+				Done:    true,
+			}}},
+		},
+	}
+}
+
+func TestExportBoardMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportBoard(&buf, exportSnapshot(), "md"); err != nil {
+		t.Fatal(err)
+	}
+	doc := buf.String()
+
+	for _, want := range []string{
+		"# Web\n\nWeb shop\n",
+		"- Tasks: 2 (1 done)\n- Tracked: 1h30m\n",
+		"## Todo (1)\n\n- [ ] **WEB-001** Checkout <form>\n  Priority: high · Tags: payments · Due: 2024-03-01 · Tracked: 1h30m\n",
+		"  Pay by card\n  - [ ] Refunds\n",
+		"## Done (1)\n\n- [x] **WEB-002** Login\n",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, doc)
+		}
+	}
+}
+
+func TestExportBoardHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportBoard(&buf, exportSnapshot(), "html"); err != nil {
+		t.Fatal(err)
+	}
+	page := buf.String()
+
+	if !strings.Contains(page, "<style>") || strings.Contains(page, "<link") || strings.Contains(page, "<script") {
+		t.Errorf("page is not self-contained:\n%s", page)
+	}
+	if !strings.Contains(page, "Checkout &lt;form&gt;") {
+		t.Errorf("task title is not escaped:\n%s", page)
+	}
+	if !strings.Contains(page, `<article class="task done">`) {
+		t.Errorf("done task is not marked:\n%s", page)
+	}
+}
+
+func TestExportBoardCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportBoard(&buf, exportSnapshot(), "csv"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("records = %d, want a header and a row per task", len(records))
+	}
+
+	row := make(map[string]string)
+	for i, header := range records[0] {
+		row[header] = records[1][i]
+	}
+	if row["id"] != "WEB-001" || row["column"] != "Todo" || row["board"] != "Web" || row["project"] != "Shop" {
+		t.Errorf("row = %v", row)
+	}
+	if row["description"] != "Pay by card\n- [ ] Refunds" || row["due"] != "2024-03-01T00:00:00Z" || row["tracked_hours"] != "1.50" {
+		t.Errorf("row = %v", row)
+	}
+}
+
+func TestExportBoardJSONRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportBoard(&buf, exportSnapshot(), "json"); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := dto.ParseBoardSnapshot(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.ID != "shop/web" || len(snapshot.Columns) != 2 || snapshot.Columns[0].Tasks[0].Description != "Pay by card\n- [ ] Refunds" {
+		t.Errorf("snapshot = %+v", snapshot)
+	}
+
+	if _, err := dto.ParseBoardSnapshot([]byte(`{"version": 2, "id": "shop/web"}`)); err == nil {
+		t.Error("snapshot of a later version was accepted")
+	}
+	if _, err := dto.ParseBoardSnapshot([]byte(`[{"id": 1}]`)); err == nil {
+		t.Error("non-snapshot JSON was accepted")
+	}
+}
+
+func TestExportBoardUnknownFormat(t *testing.T) {
+	if err := ExportBoard(&bytes.Buffer{}, exportSnapshot(), "pdf"); err == nil {
+		t.Error("unknown format was accepted")
+	}
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// ActionDTO represents an action data transfer object
+type ActionDTO struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Scope       string     `json:"scope"`
+	ScopeID     string     `json:"scope_id,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	TriggerType string     `json:"trigger_type"`
+	ActionType  string     `json:"action_type"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty"`
+}
+
+// ActionExecutionDTO describes a single recorded attempt to run an action
+type ActionExecutionDTO struct {
+	ID          string     `json:"id"`
+	ActionID    string     `json:"action_id"`
+	ActionName  string     `json:"action_name"`
+	TriggerType string     `json:"trigger_type"`
+	EventType   string     `json:"event_type,omitempty"`
+	EventID     string     `json:"event_id,omitempty"`
+	BoardID     string     `json:"board_id,omitempty"`
+	TaskID      string     `json:"task_id,omitempty"`
+	Attempt     int        `json:"attempt"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  time.Time  `json:"finished_at"`
+	Outcome     string     `json:"outcome"`
+	Success     bool       `json:"success"`
+	Error       string     `json:"error,omitempty"`
+	Output      string     `json:"output,omitempty"`
+	Action      *ActionDTO `json:"action,omitempty"`
+}
+
+// ActionTestDTO is the result of evaluating an action against a task without running it
+type ActionTestDTO struct {
+	Action        ActionDTO `json:"action"`
+	BoardID       string    `json:"board_id"`
+	TaskID        string    `json:"task_id"`
+	ColumnName    string    `json:"column_name"`
+	EventType     string    `json:"event_type,omitempty"`
+	EvaluatedAt   time.Time `json:"evaluated_at"`
+	Enabled       bool      `json:"enabled"`
+	ScopeMatches  bool      `json:"scope_matches"`
+	TriggerFires  bool      `json:"trigger_fires"`
+	ConditionsMet bool      `json:"conditions_met"`
+	WouldFire     bool      `json:"would_fire"`
+	Effect        string    `json:"effect"`
+	// MatchedTasks lists the tasks of the group an aggregate action matched
+	MatchedTasks []string `json:"matched_tasks,omitempty"`
+}
+
+// ScheduledRunDTO is a would-be execution found by simulating time triggers
+type ScheduledRunDTO struct {
+	At         time.Time `json:"at"`
+	ActionID   string    `json:"action_id"`
+	ActionName string    `json:"action_name"`
+	BoardID    string    `json:"board_id,omitempty"`
+	ColumnName string    `json:"column_name,omitempty"`
+	TaskID     string    `json:"task_id,omitempty"`
+	TaskTitle  string    `json:"task_title,omitempty"`
+	Effect     string    `json:"effect"`
+	// TaskIDs lists the tasks an aggregate run matched
+	TaskIDs []string `json:"task_ids,omitempty"`
+}
@@ -10,6 +10,7 @@ type BoardDTO struct {
 	Prefix      string      `json:"prefix"`
 	Description string      `json:"description"`
 	Columns     []ColumnDTO `json:"columns"`
+	Swimlanes   string      `json:"swimlanes,omitempty"` // tag:<prefix>, priority, parent or project
 	CreatedAt   time.Time   `json:"created_at"`
 	ModifiedAt  time.Time   `json:"modified_at"`
 }
@@ -21,6 +22,23 @@ type CreateBoardRequest struct {
 	Description string `json:"description"`
 }
 
+// SwimlaneDTO represents one lane of a board: the board's columns holding
+// only the lane's tasks
+type SwimlaneDTO struct {
+	Key       string      `json:"key"`
+	Title     string      `json:"title"`
+	Columns   []ColumnDTO `json:"columns"`
+	TaskCount int         `json:"task_count"`
+}
+
+// MoveTaskToSwimlaneRequest represents a request to move a task into another
+// lane. Swimlanes is a lane spec; empty uses the board's.
+type MoveTaskToSwimlaneRequest struct {
+	TaskID    string `json:"task_id"`
+	Swimlanes string `json:"swimlanes,omitempty"`
+	Lane      string `json:"lane"`
+}
+
 // UpdateBoardRequest represents a request to update a board
 type UpdateBoardRequest struct {
 	Name        *string `json:"name,omitempty"`
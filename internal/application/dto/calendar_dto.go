@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// CalendarSyncDTO summarises the result of a calendar synchronisation run
+type CalendarSyncDTO struct {
+	EventsCreated int       `json:"events_created"`
+	EventsUpdated int       `json:"events_updated"`
+	EventsDeleted int       `json:"events_deleted"`
+	TasksCreated  int       `json:"tasks_created"`
+	TasksUpdated  int       `json:"tasks_updated"`
+	Conflicts     int       `json:"conflicts"`
+	Errors        []string  `json:"errors,omitempty"`
+	SyncedAt      time.Time `json:"synced_at"`
+}
@@ -2,13 +2,43 @@ package dto
 
 // ColumnDTO represents a column data transfer object
 type ColumnDTO struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Order       int       `json:"order"`
-	WIPLimit    int       `json:"wip_limit"`
-	Color       *string   `json:"color,omitempty"`
-	Tasks       []TaskDTO `json:"tasks"`
-	TaskCount   int       `json:"task_count"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Order         int             `json:"order"`
+	WIPLimit      int             `json:"wip_limit"`
+	Color         *string         `json:"color,omitempty"`
+	StaleAfter    int             `json:"stale_after,omitempty"`
+	EscalateAfter int             `json:"escalate_after,omitempty"`
+	Rules         []ColumnRuleDTO `json:"rules,omitempty"`
+	Tasks         []TaskDTO       `json:"tasks"`
+	TaskCount     int             `json:"task_count"`
+}
+
+// ColumnRuleDTO represents an entry or exit rule of a column
+type ColumnRuleDTO struct {
+	On          string            `json:"on"`       // enter or exit
+	Type        string            `json:"type"`     // require, condition, subtasks_complete or script
+	Severity    string            `json:"severity"` // block or warn
+	Field       string            `json:"field,omitempty"`
+	Condition   *RuleConditionDTO `json:"condition,omitempty"`
+	Script      string            `json:"script,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+// RuleConditionDTO represents the condition of a condition rule
+type RuleConditionDTO struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// RuleViolationDTO represents a column rule a move violated
+type RuleViolationDTO struct {
+	Column   string `json:"column"`
+	On       string `json:"on"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
 }
 
 // CreateColumnRequest represents a request to create a column
@@ -27,3 +57,17 @@ type UpdateColumnRequest struct {
 	WIPLimit    *int    `json:"wip_limit,omitempty"`
 	Color       *string `json:"color,omitempty"`
 }
+
+// AddColumnRuleRequest represents a request to add a rule to a column
+type AddColumnRuleRequest struct {
+	ColumnName string        `json:"column_name"`
+	Rule       ColumnRuleDTO `json:"rule"`
+}
+
+// SetAgingPolicyRequest represents a request to set a column's aging policy.
+// Thresholds are in working days; zero for both removes the policy.
+type SetAgingPolicyRequest struct {
+	ColumnName    string `json:"column_name"`
+	StaleAfter    int    `json:"stale_after"`
+	EscalateAfter int    `json:"escalate_after"`
+}
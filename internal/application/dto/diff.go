@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FieldChange represents the old and new value of a single changed field
+type FieldChange struct {
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// Diff compares two DTOs field by field using their JSON representation and
+// returns the fields that differ, keyed by JSON field name. A nil before or
+// after is treated as an empty object, so creations and deletions report
+// every populated field.
+func Diff(before, after interface{}) map[string]FieldChange {
+	beforeFields := toFieldMap(before)
+	afterFields := toFieldMap(after)
+
+	changes := make(map[string]FieldChange)
+	for key, newValue := range afterFields {
+		oldValue, existed := beforeFields[key]
+		if !existed || !reflect.DeepEqual(oldValue, newValue) {
+			changes[key] = FieldChange{From: oldValue, To: newValue}
+		}
+	}
+	for key, oldValue := range beforeFields {
+		if _, exists := afterFields[key]; !exists {
+			changes[key] = FieldChange{From: oldValue}
+		}
+	}
+
+	return changes
+}
+
+// toFieldMap flattens a DTO into a map of its top-level JSON fields
+func toFieldMap(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if v == nil {
+		return fields
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return fields
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
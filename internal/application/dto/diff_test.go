@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := &TaskDTO{ID: "ship-1", Title: "Ship it", Priority: "low", Tags: []string{"release"}}
+	after := &TaskDTO{ID: "ship-1", Title: "Ship it", Priority: "high", Tags: []string{"release", "urgent"}}
+
+	changes := Diff(before, after)
+	want := map[string]FieldChange{
+		"priority": {From: "low", To: "high"},
+		"tags":     {From: []interface{}{"release"}, To: []interface{}{"release", "urgent"}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %v, want %v", changes, want)
+	}
+
+	if changes := Diff(after, after); len(changes) != 0 {
+		t.Errorf("Diff() of a task with itself = %v, want none", changes)
+	}
+}
+
+func TestDiffCreationAndDeletion(t *testing.T) {
+	task := &TaskDTO{ID: "ship-1", Title: "Ship it"}
+
+	created := Diff(nil, task)
+	if change, ok := created["title"]; !ok || change.From != nil || change.To != "Ship it" {
+		t.Errorf("Diff(nil, task)[title] = %v, want from nothing to the title", change)
+	}
+
+	var deletedTask *TaskDTO
+	deleted := Diff(task, deletedTask)
+	if change, ok := deleted["id"]; !ok || change.From != "ship-1" || change.To != nil {
+		t.Errorf("Diff(task, nil)[id] = %v, want from the ID to nothing", change)
+	}
+	if len(deleted) != len(created) {
+		t.Errorf("deletion reports %d fields, creation %d", len(deleted), len(created))
+	}
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BoardSnapshotVersion is the version of the board snapshot format. Board
+// imports refuse snapshots of a later version.
+const BoardSnapshotVersion = 1
+
+// BoardSnapshotDTO is a full copy of a board: its columns with their
+// settings, its tasks with their metadata and activity, and the time logged
+// on them. It is what a JSON board export writes and a board import
+// restores.
+type BoardSnapshotDTO struct {
+	Version     int                 `json:"version"`
+	ExportedAt  time.Time           `json:"exported_at"`
+	ID          string              `json:"id"`
+	ProjectID   string              `json:"project_id,omitempty"`
+	ProjectName string              `json:"project_name,omitempty"`
+	Name        string              `json:"name"`
+	Prefix      string              `json:"prefix"`
+	Description string              `json:"description"`
+	Swimlanes   string              `json:"swimlanes,omitempty"`
+	NextTaskNum int                 `json:"next_task_num"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ModifiedAt  time.Time           `json:"modified_at"`
+	Columns     []ColumnSnapshotDTO `json:"columns"`
+	TrackedTime time.Duration       `json:"tracked_time,omitempty"` // of all tasks
+	TimeLogs    []TimeLogDTO        `json:"time_logs,omitempty"`
+}
+
+// ColumnSnapshotDTO is a column of a board snapshot. Key is the column's
+// folder name, which may differ from its display name.
+type ColumnSnapshotDTO struct {
+	Key           string            `json:"key"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Order         int               `json:"order"`
+	WIPLimit      int               `json:"wip_limit"`
+	Color         *string           `json:"color,omitempty"`
+	StaleAfter    int               `json:"stale_after,omitempty"`
+	EscalateAfter int               `json:"escalate_after,omitempty"`
+	Rules         []ColumnRuleDTO   `json:"rules,omitempty"`
+	Tasks         []TaskSnapshotDTO `json:"tasks"`
+}
+
+// TaskSnapshotDTO is a task of a board snapshot, with the metadata a
+// TaskDTO leaves out. TrackedTime totals the task's time logs.
+type TaskSnapshotDTO struct {
+	TaskDTO
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Done     bool              `json:"done"` // marked done or in a Done column
+}
+
+// RestoredBoardDTO summarises a board restored from a snapshot
+type RestoredBoardDTO struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id,omitempty"`
+	Replaced  bool   `json:"replaced"` // whether an existing board was overwritten
+	Columns   int    `json:"columns"`
+	Tasks     int    `json:"tasks"`
+	TimeLogs  int    `json:"time_logs"` // added; logs already present are kept
+}
+
+// ParseBoardSnapshot reads a board snapshot written by a JSON board export
+func ParseBoardSnapshot(data []byte) (*BoardSnapshotDTO, error) {
+	var snapshot BoardSnapshotDTO
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid board snapshot: %w", err)
+	}
+	if snapshot.Version == 0 || snapshot.ID == "" {
+		return nil, fmt.Errorf("invalid board snapshot: not a JSON board export")
+	}
+	if snapshot.Version > BoardSnapshotVersion {
+		return nil, fmt.Errorf("board snapshot version %d is newer than the supported version %d", snapshot.Version, BoardSnapshotVersion)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,42 @@
+package dto
+
+// ImportRequest represents a request to import an export of another tool
+type ImportRequest struct {
+	Format  string            `json:"format"`
+	Data    []byte            `json:"-"`
+	Project string            `json:"project,omitempty"`
+	Board   string            `json:"board,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	DryRun  bool              `json:"dry_run"`
+}
+
+// ImportResultDTO summarises an import, or what it would do on a dry run
+type ImportResultDTO struct {
+	DryRun         bool               `json:"dry_run"`
+	Boards         []ImportedBoardDTO `json:"boards"`
+	TasksCreated   int                `json:"tasks_created"`
+	TasksUpdated   int                `json:"tasks_updated"`
+	TasksUnchanged int                `json:"tasks_unchanged"`
+	Errors         []string           `json:"errors,omitempty"`
+}
+
+// ImportedBoardDTO is a board an import created or added to
+type ImportedBoardDTO struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	ProjectID    string            `json:"project_id"`
+	Created      bool              `json:"created"`
+	ColumnsAdded []string          `json:"columns_added,omitempty"`
+	Tasks        []ImportedTaskDTO `json:"tasks"`
+}
+
+// ImportedTaskDTO is what an import did to a task: "create", "update" or
+// "unchanged"
+type ImportedTaskDTO struct {
+	SourceID string   `json:"source_id"`
+	TaskID   string   `json:"task_id,omitempty"`
+	Title    string   `json:"title"`
+	Column   string   `json:"column"`
+	Action   string   `json:"action"`
+	Changes  []string `json:"changes,omitempty"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
 )
 
 // BoardToDTO converts a Board entity to BoardDTO
@@ -11,6 +12,13 @@ func BoardToDTO(board *entity.Board) BoardDTO {
 		columns = append(columns, ColumnToDTO(col))
 	}
 
+	// Tasks carry the board's project for views mixing several boards
+	for i := range columns {
+		for j := range columns[i].Tasks {
+			columns[i].Tasks[j].ProjectID = board.ProjectID()
+		}
+	}
+
 	return BoardDTO{
 		ID:          board.ID(),
 		ProjectID:   board.ProjectID(),
@@ -18,6 +26,7 @@ func BoardToDTO(board *entity.Board) BoardDTO {
 		Prefix:      board.Prefix(),
 		Description: board.Description(),
 		Columns:     columns,
+		Swimlanes:   board.Swimlanes().String(),
 		CreatedAt:   board.CreatedAt(),
 		ModifiedAt:  board.ModifiedAt(),
 	}
@@ -49,7 +58,7 @@ func ColumnToDTO(column *entity.Column) ColumnDTO {
 		color = &colorStr
 	}
 
-	return ColumnDTO{
+	columnDTO := ColumnDTO{
 		Name:        column.DisplayName(), // Use display name for UI
 		Description: column.Description(),
 		Order:       column.Order(),
@@ -58,6 +67,68 @@ func ColumnToDTO(column *entity.Column) ColumnDTO {
 		Tasks:       tasks,
 		TaskCount:   column.TaskCount(),
 	}
+	if policy := column.AgingPolicy(); policy != nil {
+		columnDTO.StaleAfter = policy.StaleAfter
+		columnDTO.EscalateAfter = policy.EscalateAfter
+	}
+	for _, rule := range column.Rules() {
+		columnDTO.Rules = append(columnDTO.Rules, ColumnRuleToDTO(rule))
+	}
+	return columnDTO
+}
+
+// ColumnRuleToDTO converts a ColumnRule entity to ColumnRuleDTO
+func ColumnRuleToDTO(rule *entity.ColumnRule) ColumnRuleDTO {
+	ruleDTO := ColumnRuleDTO{
+		On:          string(rule.On),
+		Type:        string(rule.Type),
+		Severity:    string(rule.Severity),
+		Field:       rule.Field,
+		Script:      rule.Script,
+		Message:     rule.Message,
+		Description: rule.Describe(),
+	}
+	if rule.Condition != nil {
+		ruleDTO.Condition = &RuleConditionDTO{
+			Field:    rule.Condition.Field,
+			Operator: string(rule.Condition.Operator),
+			Value:    rule.Condition.Value,
+		}
+	}
+	return ruleDTO
+}
+
+// ColumnRuleFromDTO converts a ColumnRuleDTO to a validated ColumnRule entity
+func ColumnRuleFromDTO(ruleDTO ColumnRuleDTO) (*entity.ColumnRule, error) {
+	rule := &entity.ColumnRule{
+		On:       entity.ColumnRuleHook(ruleDTO.On),
+		Type:     entity.ColumnRuleType(ruleDTO.Type),
+		Severity: entity.RuleSeverity(ruleDTO.Severity),
+		Field:    ruleDTO.Field,
+		Script:   ruleDTO.Script,
+		Message:  ruleDTO.Message,
+	}
+	if ruleDTO.Condition != nil {
+		rule.Condition = entity.NewCondition(ruleDTO.Condition.Field, entity.ConditionOperator(ruleDTO.Condition.Operator), ruleDTO.Condition.Value)
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// RuleViolationsToDTO converts rule violations to RuleViolationDTOs
+func RuleViolationsToDTO(violations []entity.RuleViolation) []RuleViolationDTO {
+	dtos := make([]RuleViolationDTO, 0, len(violations))
+	for _, violation := range violations {
+		dtos = append(dtos, RuleViolationDTO{
+			Column:   violation.Column,
+			On:       string(violation.Rule.On),
+			Severity: string(violation.Rule.Severity),
+			Message:  violation.Message(),
+		})
+	}
+	return dtos
 }
 
 // TaskToDTO converts a Task entity to TaskDTO
@@ -79,10 +150,69 @@ func TaskToDTO(task *entity.Task) TaskDTO {
 		ScheduledTime: task.ScheduledTime(),
 		TimeBlock:     task.TimeBlock(),
 		TaskType:      string(task.TaskType()),
+
+		ColumnEnteredAt: task.ColumnEnteredAt(),
+		Aging:           string(task.AgingLevel()),
+	}
+	if task.ParentID() != nil {
+		dto.ParentID = task.ParentID().String()
+		dto.ParentBoardID = task.ParentBoardID()
+	}
+	dto.EstimatedTime = task.EstimatedTime()
+	dto.TrackedTime = task.TrackedTime()
+	for _, entry := range task.Activity() {
+		dto.Activity = append(dto.Activity, ActivityDTO{
+			Kind:    string(entry.Kind),
+			Ref:     entry.Ref,
+			Summary: entry.Summary,
+			Author:  entry.Author,
+			Time:    entry.Time,
+		})
+	}
+
+	// Subtask checkboxes follow their subtasks, wherever they are, so the
+	// description has the progress without loading other boards
+	for _, state := range service.GetCheckboxStates(task.Description()) {
+		dto.SubtasksTotal++
+		if state == service.CheckboxDone {
+			dto.SubtasksDone++
+		}
+	}
+	return dto
+}
+
+// TaskTreeToDTO converts a task tree to TaskTreeDTO
+func TaskTreeToDTO(tree *service.TaskTree) TaskTreeDTO {
+	progress := tree.Progress()
+	dto := TaskTreeDTO{
+		Task:       TaskToDTOWithPath(tree.Task, "", tree.Column.DisplayName()),
+		BoardID:    tree.BoardID,
+		State:      checkboxStateName(tree.State()),
+		Done:       progress.Done,
+		InProgress: progress.InProgress,
+		Total:      progress.Total,
+		Percent:    progress.Percent(),
+		Estimated:  tree.EstimatedTime(),
+		Tracked:    tree.TrackedTime(),
+	}
+	for _, child := range tree.Children {
+		dto.Children = append(dto.Children, TaskTreeToDTO(child))
 	}
 	return dto
 }
 
+// checkboxStateName names a subtask checkbox state
+func checkboxStateName(state service.CheckboxState) string {
+	switch state {
+	case service.CheckboxDone:
+		return "done"
+	case service.CheckboxInProgress:
+		return "in_progress"
+	default:
+		return "todo"
+	}
+}
+
 // TaskToDTOWithPath converts a Task entity to TaskDTO with file path and column name
 func TaskToDTOWithPath(task *entity.Task, filePath string, columnName string) TaskDTO {
 	dto := TaskToDTO(task)
@@ -90,3 +220,109 @@ func TaskToDTOWithPath(task *entity.Task, filePath string, columnName string) Ta
 	dto.ColumnName = columnName
 	return dto
 }
+
+// ProjectToDTO converts a Project entity to ProjectDTO
+func ProjectToDTO(project *entity.Project) ProjectDTO {
+	var color *string
+	if project.Color() != nil {
+		colorStr := project.Color().String()
+		color = &colorStr
+	}
+
+	return ProjectDTO{
+		ID:          project.ID(),
+		Name:        project.Name(),
+		Slug:        project.Slug(),
+		Description: project.Description(),
+		WorkingDir:  project.WorkingDir(),
+		Color:       color,
+		Archived:    project.Archived(),
+		CreatedAt:   project.CreatedAt(),
+		ModifiedAt:  project.ModifiedAt(),
+	}
+}
+
+// TimeLogToDTO converts a TimeLog entity to TimeLogDTO
+func TimeLogToDTO(log *entity.TimeLog) TimeLogDTO {
+	dto := TimeLogDTO{
+		ID:          log.ID(),
+		ProjectID:   log.ProjectID(),
+		Source:      log.Source().String(),
+		StartTime:   log.StartTime(),
+		EndTime:     log.EndTime(),
+		Duration:    log.Duration().Seconds(),
+		Description: log.Description(),
+		Running:     log.IsRunning(),
+		Metadata:    log.Metadata(),
+	}
+	if log.TaskID() != nil {
+		dto.TaskID = log.TaskID().String()
+	}
+	return dto
+}
+
+// NoteToDTO converts a Note entity to NoteDTO
+func NoteToDTO(note *entity.Note) NoteDTO {
+	linked := make([]string, 0, len(note.LinkedTasks()))
+	for _, taskID := range note.LinkedTasks() {
+		linked = append(linked, taskID.String())
+	}
+
+	return NoteDTO{
+		ID:          note.ID(),
+		ProjectID:   note.ProjectID(),
+		Title:       note.Title(),
+		Content:     note.Content(),
+		NoteType:    string(note.NoteType()),
+		Tags:        note.Tags(),
+		LinkedTasks: linked,
+		Date:        note.Date(),
+		CreatedAt:   note.CreatedAt(),
+		ModifiedAt:  note.ModifiedAt(),
+	}
+}
+
+// ActionToDTO converts an Action entity to ActionDTO
+func ActionToDTO(action *entity.Action) ActionDTO {
+	dto := ActionDTO{
+		ID:          action.ID(),
+		Name:        action.Name(),
+		Description: action.Description(),
+		Scope:       action.Scope().String(),
+		ScopeID:     action.ScopeID(),
+		Enabled:     action.Enabled(),
+		CreatedAt:   action.CreatedAt(),
+		ModifiedAt:  action.ModifiedAt(),
+		LastRun:     action.LastRun(),
+
+		ConsecutiveFailures: action.ConsecutiveFailures(),
+	}
+	if action.Trigger() != nil {
+		dto.TriggerType = string(action.Trigger().Type())
+	}
+	if action.ActionType() != nil {
+		dto.ActionType = string(action.ActionType().Type())
+	}
+	return dto
+}
+
+// ActionExecutionToDTO converts an ActionExecution record to ActionExecutionDTO
+func ActionExecutionToDTO(execution *entity.ActionExecution) ActionExecutionDTO {
+	return ActionExecutionDTO{
+		ID:          execution.ID,
+		ActionID:    execution.ActionID,
+		ActionName:  execution.ActionName,
+		TriggerType: string(execution.TriggerType),
+		EventType:   string(execution.EventType),
+		EventID:     execution.EventID,
+		BoardID:     execution.BoardID,
+		TaskID:      execution.TaskID,
+		Attempt:     execution.Attempt,
+		StartedAt:   execution.StartedAt,
+		FinishedAt:  execution.FinishedAt,
+		Outcome:     string(execution.Outcome),
+		Success:     execution.Succeeded(),
+		Error:       execution.Error,
+		Output:      execution.Output,
+	}
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// DataMigrationDTO is one versioned migration of the data directory
+type DataMigrationDTO struct {
+	Version     int        `json:"version"`
+	ID          string     `json:"id"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// DataSchemaStatusDTO describes how far the data directory is migrated
+type DataSchemaStatusDTO struct {
+	Version int                `json:"version"`
+	Latest  int                `json:"latest"`
+	Applied []DataMigrationDTO `json:"applied"`
+	Pending []DataMigrationDTO `json:"pending"`
+}
+
+// DataFileChangeDTO is a file changed by a migration
+type DataFileChangeDTO struct {
+	Change string `json:"change"`
+	Path   string `json:"path"`
+}
+
+// DataMigrateDTO summarises a run of the pending migrations
+type DataMigrateDTO struct {
+	From    int                 `json:"from"`
+	To      int                 `json:"to"`
+	DryRun  bool                `json:"dry_run"`
+	Backup  string              `json:"backup,omitempty"`
+	Applied []DataMigrationDTO  `json:"applied"`
+	Changes []DataFileChangeDTO `json:"changes"`
+}
+
+// DataSchemaStatusToDTO converts the schema state of the data directory to
+// its DTO
+func DataSchemaStatusToDTO(status *service.DataSchemaStatus) DataSchemaStatusDTO {
+	return DataSchemaStatusDTO{
+		Version: status.Version,
+		Latest:  status.Latest,
+		Applied: dataMigrationsToDTO(status.Applied),
+		Pending: dataMigrationsToDTO(status.Pending),
+	}
+}
+
+// DataMigrateToDTO converts the result of a migration run to its DTO
+func DataMigrateToDTO(result *service.DataMigrateResult) DataMigrateDTO {
+	return DataMigrateDTO{
+		From:    result.From,
+		To:      result.To,
+		DryRun:  result.DryRun,
+		Backup:  result.Backup,
+		Applied: dataMigrationsToDTO(result.Applied),
+		Changes: DataFileChangesToDTO(result.Changes),
+	}
+}
+
+// DataFileChangesToDTO converts files changed in the data directory to
+// DTOs
+func DataFileChangesToDTO(changes []service.DataFileChange) []DataFileChangeDTO {
+	changeDTOs := make([]DataFileChangeDTO, 0, len(changes))
+	for _, change := range changes {
+		changeDTOs = append(changeDTOs, DataFileChangeDTO{Change: change.Change, Path: change.Path})
+	}
+	return changeDTOs
+}
+
+func dataMigrationsToDTO(migrations []service.DataMigration) []DataMigrationDTO {
+	migrationDTOs := make([]DataMigrationDTO, 0, len(migrations))
+	for _, migration := range migrations {
+		migrationDTO := DataMigrationDTO{
+			Version:     migration.Version,
+			ID:          migration.ID,
+			Description: migration.Description,
+		}
+		if !migration.AppliedAt.IsZero() {
+			appliedAt := migration.AppliedAt
+			migrationDTO.AppliedAt = &appliedAt
+		}
+		migrationDTOs = append(migrationDTOs, migrationDTO)
+	}
+	return migrationDTOs
+}
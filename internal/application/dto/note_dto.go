@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// NoteDTO represents a note data transfer object
+type NoteDTO struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	NoteType    string    `json:"note_type"`
+	Tags        []string  `json:"tags,omitempty"`
+	LinkedTasks []string  `json:"linked_tasks,omitempty"`
+	Date        time.Time `json:"date"`
+	CreatedAt   time.Time `json:"created_at"`
+	ModifiedAt  time.Time `json:"modified_at"`
+}
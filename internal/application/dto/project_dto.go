@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// ProjectDTO represents a project data transfer object
+type ProjectDTO struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	WorkingDir  string    `json:"working_dir,omitempty"`
+	Color       *string   `json:"color,omitempty"`
+	Archived    bool      `json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	ModifiedAt  time.Time `json:"modified_at"`
+}
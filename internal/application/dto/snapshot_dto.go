@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// DataSnapshotDTO is a snapshot of the data directory
+type DataSnapshotDTO struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+// SnapshotRestoreDTO summarises a restore from a snapshot
+type SnapshotRestoreDTO struct {
+	Snapshot DataSnapshotDTO     `json:"snapshot"`
+	Undo     *DataSnapshotDTO    `json:"undo,omitempty"`
+	Changes  []DataFileChangeDTO `json:"changes"`
+}
+
+// DataSnapshotToDTO converts a snapshot to its DTO
+func DataSnapshotToDTO(snapshot service.DataSnapshot) DataSnapshotDTO {
+	return DataSnapshotDTO{
+		ID:        snapshot.ID,
+		Kind:      snapshot.Kind,
+		CreatedAt: snapshot.CreatedAt,
+		Size:      snapshot.Size,
+	}
+}
+
+// DataSnapshotsToDTO converts a list of snapshots to DTOs
+func DataSnapshotsToDTO(snapshots []service.DataSnapshot) []DataSnapshotDTO {
+	snapshotDTOs := make([]DataSnapshotDTO, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotDTOs = append(snapshotDTOs, DataSnapshotToDTO(snapshot))
+	}
+	return snapshotDTOs
+}
+
+// SnapshotRestoreToDTO converts the result of a restore to its DTO
+func SnapshotRestoreToDTO(result *service.SnapshotRestoreResult) SnapshotRestoreDTO {
+	restoreDTO := SnapshotRestoreDTO{
+		Snapshot: DataSnapshotToDTO(result.Snapshot),
+		Changes:  DataFileChangesToDTO(result.Changes),
+	}
+	if result.Undo != nil {
+		undo := DataSnapshotToDTO(*result.Undo)
+		restoreDTO.Undo = &undo
+	}
+	return restoreDTO
+}
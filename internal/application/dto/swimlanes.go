@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// GroupSwimlanes splits the columns of a board into the lanes of swimlanes.
+// Every lane has all of the board's columns, in the board's order. Lanes
+// tasks can be moved into are listed even when empty: every priority, and
+// the lane of tasks without a tag or parent.
+func GroupSwimlanes(board BoardDTO, swimlanes *entity.Swimlanes) []SwimlaneDTO {
+	lanes := make(map[string]*SwimlaneDTO)
+	keys := make([]string, 0)
+	lane := func(key string) *SwimlaneDTO {
+		if existing, ok := lanes[key]; ok {
+			return existing
+		}
+		columns := make([]ColumnDTO, len(board.Columns))
+		for i, col := range board.Columns {
+			columns[i] = col
+			columns[i].Tasks = make([]TaskDTO, 0)
+			columns[i].TaskCount = 0
+		}
+		lanes[key] = &SwimlaneDTO{Key: key, Title: swimlanes.LaneTitle(key), Columns: columns}
+		keys = append(keys, key)
+		return lanes[key]
+	}
+
+	switch swimlanes.By {
+	case entity.SwimlaneByPriority:
+		for _, priority := range []valueobject.Priority{
+			valueobject.PriorityCritical,
+			valueobject.PriorityHigh,
+			valueobject.PriorityMedium,
+			valueobject.PriorityLow,
+			valueobject.PriorityNone,
+		} {
+			lane(priority.String())
+		}
+	case entity.SwimlaneByTag, entity.SwimlaneByParent:
+		lane("")
+	}
+
+	titles := make(map[string]string)
+	for i, col := range board.Columns {
+		for _, task := range col.Tasks {
+			titles[task.ID] = task.ShortID + " " + task.Title
+			l := lane(swimlanes.LaneOf(task.Tags, task.Priority, task.ParentID, task.ProjectID))
+			l.Columns[i].Tasks = append(l.Columns[i].Tasks, task)
+			l.Columns[i].TaskCount++
+			l.TaskCount++
+		}
+	}
+
+	swimlanes.SortLanes(keys)
+	result := make([]SwimlaneDTO, 0, len(keys))
+	for _, key := range keys {
+		l := lanes[key]
+		if title, ok := titles[key]; ok && swimlanes.By == entity.SwimlaneByParent {
+			l.Title = title
+		}
+		result = append(result, *l)
+	}
+	return result
+}
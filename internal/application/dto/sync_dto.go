@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// DataSyncDTO summarises a sync of the data directory with its remote
+type DataSyncDTO struct {
+	Committed  bool                `json:"committed"`
+	Pulled     int                 `json:"pulled"`
+	Pushed     int                 `json:"pushed"`
+	Merged     []string            `json:"merged,omitempty"`
+	Renumbered []RenumberedTaskDTO `json:"renumbered,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	SyncedAt   time.Time           `json:"synced_at"`
+}
+
+// RenumberedTaskDTO is a task given a new number by a sync
+type RenumberedTaskDTO struct {
+	BoardID string `json:"board_id"`
+	OldID   string `json:"old_id"`
+	NewID   string `json:"new_id"`
+	Branch  string `json:"branch,omitempty"`
+}
+
+// DataSyncStatusDTO describes the sync state of the data directory
+type DataSyncStatusDTO struct {
+	Initialized bool       `json:"initialized"`
+	Remote      string     `json:"remote,omitempty"`
+	Branch      string     `json:"branch"`
+	Pending     int        `json:"pending"`
+	Ahead       int        `json:"ahead"`
+	Behind      int        `json:"behind"`
+	LastCommit  *time.Time `json:"last_commit,omitempty"`
+}
+
+// DataSyncToDTO converts the result of a data sync to its DTO
+func DataSyncToDTO(result *service.DataSyncResult) DataSyncDTO {
+	syncDTO := DataSyncDTO{
+		Committed: result.Committed,
+		Pulled:    result.Pulled,
+		Pushed:    result.Pushed,
+		Merged:    result.Merged,
+		SyncedAt:  result.SyncedAt,
+	}
+	for _, task := range result.Renumbered {
+		syncDTO.Renumbered = append(syncDTO.Renumbered, RenumberedTaskDTO{
+			BoardID: task.BoardID,
+			OldID:   task.OldID,
+			NewID:   task.NewID,
+			Branch:  task.Branch,
+		})
+	}
+	return syncDTO
+}
+
+// DataSyncStatusToDTO converts the sync state of the data directory to its
+// DTO
+func DataSyncStatusToDTO(status *service.DataSyncStatus) DataSyncStatusDTO {
+	statusDTO := DataSyncStatusDTO{
+		Initialized: status.Initialized,
+		Remote:      status.Remote,
+		Branch:      status.Branch,
+		Pending:     status.Pending,
+		Ahead:       status.Ahead,
+		Behind:      status.Behind,
+	}
+	if !status.LastCommit.IsZero() {
+		lastCommit := status.LastCommit
+		statusDTO.LastCommit = &lastCommit
+	}
+	return statusDTO
+}
@@ -19,6 +19,10 @@ type TaskDTO struct {
 	IsOverdue     bool       `json:"is_overdue"`
 	FilePath      string     `json:"file_path,omitempty"`
 	ColumnName    string     `json:"column_name,omitempty"`
+	ParentID      string     `json:"parent_id,omitempty"`
+	ParentBoardID string     `json:"parent_board_id,omitempty"` // when not on the task's board
+	SubtasksDone  int        `json:"subtasks_done,omitempty"`
+	SubtasksTotal int        `json:"subtasks_total,omitempty"`
 
 	EstimatedTime *time.Duration `json:"estimated_time,omitempty"`
 	TrackedTime   time.Duration  `json:"tracked_time,omitempty"`
@@ -30,6 +34,41 @@ type TaskDTO struct {
 
 	TaskType    string       `json:"task_type,omitempty"`
 	MeetingData *MeetingDTO  `json:"meeting_data,omitempty"`
+
+	ColumnEnteredAt time.Time `json:"column_entered_at"`
+	DaysInColumn    int       `json:"days_in_column,omitempty"` // working days
+	Aging           string    `json:"aging,omitempty"`
+
+	Activity []ActivityDTO `json:"activity,omitempty"`
+}
+
+// ActivityDTO represents an activity entry of a task, such as a commit
+type ActivityDTO struct {
+	Kind    string    `json:"kind"`
+	Ref     string    `json:"ref"`
+	Summary string    `json:"summary,omitempty"`
+	Author  string    `json:"author,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// CommitLinkDTO represents a commit attached to a task by a commit scan
+type CommitLinkDTO struct {
+	BoardID    string `json:"board_id"`
+	TaskID     string `json:"task_id"`
+	Hash       string `json:"hash"`
+	Subject    string `json:"subject"`
+	AdvancedTo string `json:"advanced_to,omitempty"` // column the commit moved the task to
+	Error      string `json:"error,omitempty"`       // why a closing commit couldn't move the task
+}
+
+// WorktreeCheckoutDTO represents a task checked out in its own worktree
+type WorktreeCheckoutDTO struct {
+	TaskID   string `json:"task_id"`
+	Branch   string `json:"branch"`
+	Path     string `json:"path"`
+	Created  bool   `json:"created"`           // false when an existing worktree was reused
+	Session  string `json:"session,omitempty"` // tmux session or window opened for the worktree
+	Switched bool   `json:"switched"`          // whether the terminal switched to it
 }
 
 type MeetingDTO struct {
@@ -51,12 +90,36 @@ type CreateTaskRequest struct {
 
 // UpdateTaskRequest represents a request to update a task
 type UpdateTaskRequest struct {
-	Title       *string   `json:"title,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	Priority    *string   `json:"priority,omitempty"`
-	Status      *string   `json:"status,omitempty"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	Tags        []string  `json:"tags,omitempty"`
+	Title       *string        `json:"title,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Priority    *string        `json:"priority,omitempty"`
+	Status      *string        `json:"status,omitempty"`
+	DueDate     *time.Time     `json:"due_date,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Estimate    *time.Duration `json:"estimate,omitempty"`
+}
+
+// SetTaskParentRequest represents a request to make a task a subtask of
+// another, or a top level task for an empty ParentID
+type SetTaskParentRequest struct {
+	TaskID        string `json:"task_id"`
+	ParentID      string `json:"parent_id,omitempty"`
+	ParentBoardID string `json:"parent_board_id,omitempty"` // default: the task's board
+}
+
+// TaskTreeDTO represents a task with its subtasks, which may be on other
+// boards
+type TaskTreeDTO struct {
+	Task       TaskDTO       `json:"task"`
+	BoardID    string        `json:"board_id"`
+	State      string        `json:"state"` // todo, in_progress or done
+	Done       int           `json:"done"`
+	InProgress int           `json:"in_progress"`
+	Total      int           `json:"total"`
+	Percent    int           `json:"percent"`
+	Estimated  time.Duration `json:"estimated,omitempty"` // of the task and all its subtasks
+	Tracked    time.Duration `json:"tracked,omitempty"`   // of the task and all its subtasks
+	Children   []TaskTreeDTO `json:"children,omitempty"`
 }
 
 // MoveTaskRequest represents a request to move a task
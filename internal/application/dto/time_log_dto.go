@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// TimeLogDTO represents a time log entry data transfer object
+type TimeLogDTO struct {
+	ID          string            `json:"id"`
+	ProjectID   string            `json:"project_id"`
+	TaskID      string            `json:"task_id,omitempty"`
+	Source      string            `json:"source"`
+	StartTime   time.Time         `json:"start_time"`
+	EndTime     *time.Time        `json:"end_time,omitempty"`
+	Duration    float64           `json:"duration"` // in seconds
+	Description string            `json:"description,omitempty"`
+	Running     bool              `json:"running"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
@@ -1,6 +1,9 @@
 package strategy
 
-import "mkanban/internal/domain/entity"
+import (
+	"context"
+	"mkanban/internal/domain/entity"
+)
 
 // BoardSyncStrategy defines the interface for different board synchronization strategies
 // This allows for pluggable logic based on session type (git repo, general, future: svn, etc.)
@@ -10,7 +13,7 @@ type BoardSyncStrategy interface {
 
 	// Sync synchronizes the board state based on the session
 	// This may create tasks, update task positions, modify metadata, etc.
-	Sync(session *entity.Session, board *entity.Board) error
+	Sync(ctx context.Context, session *entity.Session, board *entity.Board) error
 
 	// ShouldWatch returns true if this strategy needs file system watching
 	// (e.g., git strategies watch for branch changes)
@@ -1,6 +1,9 @@
 package strategy
 
-import "mkanban/internal/domain/entity"
+import (
+	"context"
+	"mkanban/internal/domain/entity"
+)
 
 // GeneralSyncStrategy handles non-git sessions
 // All non-git sessions share a single "General Tasks" board
@@ -28,7 +31,7 @@ func (s *GeneralSyncStrategy) CanHandle(session *entity.Session) bool {
 // Sync does nothing for general sessions
 // The board exists, but no automatic tasks are created
 // Users can manually add tasks to this shared board
-func (s *GeneralSyncStrategy) Sync(session *entity.Session, board *entity.Board) error {
+func (s *GeneralSyncStrategy) Sync(ctx context.Context, session *entity.Session, board *entity.Board) error {
 	// No automatic synchronization needed for general sessions
 	// The board is just a shared space for manual task management
 	return nil
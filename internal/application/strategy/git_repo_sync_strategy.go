@@ -1,6 +1,7 @@
 package strategy
 
 import (
+	"context"
 	"fmt"
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/service"
@@ -12,15 +13,33 @@ import (
 )
 
 // GitRepoSyncStrategy synchronizes boards for git repository sessions
-// Creates tasks for each git branch, with the current branch in "In Progress"
+// Creates tasks for each git branch, with the current branch and the branches
+// checked out in worktrees in "In Progress"
 type GitRepoSyncStrategy struct {
-	vcsProvider service.VCSProvider
+	vcsProvider  service.VCSProvider
+	boardService *service.BoardService
+	merges       MergeDetection
+}
+
+// MergeDetection configures how GitRepoSyncStrategy completes the tasks of
+// merged branches
+type MergeDetection struct {
+	Enabled        bool
+	SquashMerges   bool   // also match squashed and rebased branches by patch ID
+	DeleteBranches bool   // delete the local branch once its task is done
+	DefaultBranch  string // branch merged into, detected when empty
 }
 
 // NewGitRepoSyncStrategy creates a new GitRepoSyncStrategy
-func NewGitRepoSyncStrategy(vcsProvider service.VCSProvider) *GitRepoSyncStrategy {
+func NewGitRepoSyncStrategy(
+	vcsProvider service.VCSProvider,
+	boardService *service.BoardService,
+	merges MergeDetection,
+) *GitRepoSyncStrategy {
 	return &GitRepoSyncStrategy{
-		vcsProvider: vcsProvider,
+		vcsProvider:  vcsProvider,
+		boardService: boardService,
+		merges:       merges,
 	}
 }
 
@@ -30,7 +49,7 @@ func (s *GitRepoSyncStrategy) CanHandle(session *entity.Session) bool {
 }
 
 // Sync synchronizes the board with the git repository state
-func (s *GitRepoSyncStrategy) Sync(session *entity.Session, board *entity.Board) error {
+func (s *GitRepoSyncStrategy) Sync(ctx context.Context, session *entity.Session, board *entity.Board) error {
 	workingDir := session.WorkingDir()
 
 	// Get repository root
@@ -51,6 +70,12 @@ func (s *GitRepoSyncStrategy) Sync(session *entity.Session, board *entity.Board)
 		return fmt.Errorf("failed to list branches: %w", err)
 	}
 
+	// Branches checked out in other worktrees are being worked on as well
+	worktrees, err := s.vcsProvider.ListWorktrees(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
 	// Build a set of existing branch tasks for tracking
 	existingBranchTasks := make(map[string]*entity.Task)
 	for _, column := range board.Columns() {
@@ -69,12 +94,13 @@ func (s *GitRepoSyncStrategy) Sync(session *entity.Session, board *entity.Board)
 
 	// Process each branch
 	for _, branch := range branches {
-		isCurrent := branch == currentBranch
+		_, inWorktree := worktrees[branch]
+		isCurrent := branch == currentBranch || inWorktree
 
 		// Check if task already exists for this branch
 		if existingTask, exists := existingBranchTasks[branch]; exists {
 			// Update existing task
-			if err := s.updateBranchTask(board, existingTask, branch, isCurrent, repoRoot); err != nil {
+			if err := s.updateBranchTask(ctx, board, existingTask, branch, isCurrent, repoRoot); err != nil {
 				return fmt.Errorf("failed to update branch task %s: %w", branch, err)
 			}
 		} else {
@@ -85,10 +111,19 @@ func (s *GitRepoSyncStrategy) Sync(session *entity.Session, board *entity.Board)
 		}
 	}
 
+	// Move merged branches to "Done" column
+	if s.merges.Enabled {
+		current := map[string]bool{currentBranch: true}
+		for branch := range worktrees {
+			current[branch] = true
+		}
+		s.completeMergedBranches(ctx, board, repoRoot, current)
+	}
+
 	// Move deleted branches to "Done" column
 	for branchName, task := range existingBranchTasks {
 		if !currentBranches[branchName] {
-			if err := s.moveTaskToDone(board, task); err != nil {
+			if err := s.moveTaskToDone(ctx, board, task); err != nil {
 				// Log error but don't fail the sync
 				// We'll retry on next sync
 				continue
@@ -280,6 +315,7 @@ func (s *GitRepoSyncStrategy) createBranchTask(
 
 // updateBranchTask updates an existing branch task's position and metadata
 func (s *GitRepoSyncStrategy) updateBranchTask(
+	ctx context.Context,
 	board *entity.Board,
 	task *entity.Task,
 	branchName string,
@@ -306,8 +342,8 @@ func (s *GitRepoSyncStrategy) updateBranchTask(
 		// Don't move tasks that are in "Done" column
 		// They may have been manually completed
 		if currentColumn.Name() != "Done" {
-			if err := board.MoveTask(task.ID(), targetColumnName); err != nil {
-				// If move fails due to WIP limit, skip for now
+			if _, err := s.boardService.MoveTaskOnBoard(ctx, board, task.ID(), targetColumnName); err != nil {
+				// If move fails due to WIP limit or column rules, skip for now
 				// It will be retried on next sync
 				return nil
 			}
@@ -317,8 +353,69 @@ func (s *GitRepoSyncStrategy) updateBranchTask(
 	return nil
 }
 
+// completeMergedBranches moves the tasks of branches merged into the default
+// branch to "Done", recording the merge in their activity. Checked out
+// branches are still being worked on and stay. So does a branch at the head
+// of the default branch, which is as likely new as fast-forwarded, until the
+// default branch moves on; one without commits of its own isn't merged at
+// all.
+func (s *GitRepoSyncStrategy) completeMergedBranches(ctx context.Context, board *entity.Board, repoRoot string, current map[string]bool) {
+	target := s.merges.DefaultBranch
+	if target == "" {
+		var err error
+		if target, err = s.vcsProvider.GetDefaultBranch(repoRoot); err != nil {
+			// Nothing to merge into
+			return
+		}
+	}
+
+	heads, err := s.vcsProvider.ListBranchHeads(repoRoot)
+	if err != nil || heads[target] == "" {
+		return
+	}
+
+	for _, column := range board.Columns() {
+		if column.Name() == "Done" {
+			continue
+		}
+		for _, task := range column.Tasks() {
+			branch, ok := task.GetMetadata("git_branch")
+			if !ok || branch == target || current[branch] || heads[branch] == "" || heads[branch] == heads[target] {
+				continue
+			}
+
+			// Errors are retried on next sync
+			merge, err := s.vcsProvider.FindMergeCommit(repoRoot, branch, target)
+			if err != nil {
+				continue
+			}
+			squash := false
+			if merge == "" && s.merges.SquashMerges {
+				if merge, err = s.vcsProvider.FindSquashMerge(repoRoot, branch, target); err != nil {
+					continue
+				}
+				squash = merge != ""
+			}
+			if merge == "" {
+				continue
+			}
+
+			if err := s.moveTaskToDone(ctx, board, task); err != nil {
+				continue
+			}
+			if commits, err := s.vcsProvider.ListCommits(repoRoot, []string{merge}, nil, 1); err == nil && len(commits) == 1 {
+				task.AddActivity(service.MergeActivity(commits[0], target, squash))
+			}
+			if s.merges.DeleteBranches {
+				// Squashed branches don't look merged to git
+				_ = s.vcsProvider.DeleteBranch(repoRoot, branch, squash)
+			}
+		}
+	}
+}
+
 // moveTaskToDone moves a task to the "Done" column (for deleted branches)
-func (s *GitRepoSyncStrategy) moveTaskToDone(board *entity.Board, task *entity.Task) error {
+func (s *GitRepoSyncStrategy) moveTaskToDone(ctx context.Context, board *entity.Board, task *entity.Task) error {
 	_, currentColumn, err := board.FindTask(task.ID())
 	if err != nil {
 		return fmt.Errorf("task not found: %w", err)
@@ -330,7 +427,7 @@ func (s *GitRepoSyncStrategy) moveTaskToDone(board *entity.Board, task *entity.T
 	}
 
 	// Move to Done
-	if err := board.MoveTask(task.ID(), "Done"); err != nil {
+	if _, err := s.boardService.MoveTaskOnBoard(ctx, board, task.ID(), "Done"); err != nil {
 		return fmt.Errorf("failed to move task to Done: %w", err)
 	}
 
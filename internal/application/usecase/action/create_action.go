@@ -31,6 +31,8 @@ type CreateActionRequest struct {
 	Trigger     entity.Trigger
 	ActionType  entity.ActionType
 	Conditions  *entity.ConditionGroup
+	// Aggregation makes the action run per board or column; nil runs it per task
+	Aggregation *entity.Aggregation
 }
 
 // Execute creates a new action
@@ -60,6 +62,9 @@ func (uc *CreateActionUseCase) Execute(ctx context.Context, req CreateActionRequ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action entity: %w", err)
 	}
+	if req.Aggregation != nil {
+		action.UpdateAggregation(req.Aggregation)
+	}
 
 	// Persist action
 	if err := uc.actionRepo.Create(ctx, action); err != nil {
@@ -9,13 +9,18 @@ import (
 
 // DeleteActionUseCase handles deleting actions
 type DeleteActionUseCase struct {
-	actionRepo repository.ActionRepository
+	actionRepo    repository.ActionRepository
+	executionRepo repository.ActionExecutionRepository
 }
 
 // NewDeleteActionUseCase creates a new DeleteActionUseCase
-func NewDeleteActionUseCase(actionRepo repository.ActionRepository) *DeleteActionUseCase {
+func NewDeleteActionUseCase(
+	actionRepo repository.ActionRepository,
+	executionRepo repository.ActionExecutionRepository,
+) *DeleteActionUseCase {
 	return &DeleteActionUseCase{
-		actionRepo: actionRepo,
+		actionRepo:    actionRepo,
+		executionRepo: executionRepo,
 	}
 }
 
@@ -25,5 +30,10 @@ func (uc *DeleteActionUseCase) Execute(ctx context.Context, actionID string) err
 		return fmt.Errorf("failed to delete action: %w", err)
 	}
 
+	// History of a deleted action is no longer reachable, so drop it too
+	if err := uc.executionRepo.DeleteByAction(ctx, actionID); err != nil {
+		return fmt.Errorf("failed to delete action history: %w", err)
+	}
+
 	return nil
 }
@@ -6,22 +6,27 @@ import (
 
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
 )
 
 // EvaluateActionsUseCase handles evaluating which actions should trigger
 type EvaluateActionsUseCase struct {
-	actionRepo  repository.ActionRepository
-	boardRepo   repository.BoardRepository
+	actionRepo   repository.ActionRepository
+	boardRepo    repository.BoardRepository
+	workSchedule *entity.WorkSchedule
 }
 
 // NewEvaluateActionsUseCase creates a new EvaluateActionsUseCase
 func NewEvaluateActionsUseCase(
 	actionRepo repository.ActionRepository,
 	boardRepo repository.BoardRepository,
+	workSchedule *entity.WorkSchedule,
 ) *EvaluateActionsUseCase {
 	return &EvaluateActionsUseCase{
-		actionRepo: actionRepo,
-		boardRepo:  boardRepo,
+		actionRepo:   actionRepo,
+		boardRepo:    boardRepo,
+		workSchedule: workSchedule,
 	}
 }
 
@@ -68,11 +73,29 @@ func (uc *EvaluateActionsUseCase) Execute(ctx context.Context, evalCtx Evaluatio
 	}
 
 	if board != nil && evalCtx.TaskID != "" {
-		task, column, _ = board.FindTask(nil) // TODO: Need TaskID parsing
+		if taskID, err := valueobject.ParseTaskID(evalCtx.TaskID); err == nil {
+			if found, col, err := board.FindTask(taskID); err == nil {
+				task, column = found, col
+			}
+		}
 	}
 
 	// Evaluate each action
 	for _, action := range actions {
+		if action.Aggregation() != nil {
+			base := entity.TriggerContext{
+				CurrentTime:     evalCtx.CurrentTime,
+				Event:           evalCtx.Event,
+				LastRun:         action.LastRun(),
+				ActionCreatedAt: action.CreatedAt(),
+				WorkSchedule:    uc.workSchedule,
+			}
+			if result := evaluateAggregate(action, base, board, column); result != nil {
+				results = append(results, result)
+			}
+			continue
+		}
+
 		// Check if action scope matches
 		if !action.MatchesScope(evalCtx.BoardID, evalCtx.ColumnID, evalCtx.TaskID) {
 			continue
@@ -80,12 +103,14 @@ func (uc *EvaluateActionsUseCase) Execute(ctx context.Context, evalCtx Evaluatio
 
 		// Build trigger context
 		triggerCtx := &entity.TriggerContext{
-			CurrentTime: evalCtx.CurrentTime,
-			Task:        task,
-			Column:      column,
-			Board:       board,
-			Event:       evalCtx.Event,
-			LastRun:     action.LastRun(),
+			CurrentTime:     evalCtx.CurrentTime,
+			Task:            task,
+			Column:          column,
+			Board:           board,
+			Event:           evalCtx.Event,
+			LastRun:         action.LastRun(),
+			ActionCreatedAt: action.CreatedAt(),
+			WorkSchedule:    uc.workSchedule,
 		}
 
 		// Check if action should execute
@@ -99,3 +124,79 @@ func (uc *EvaluateActionsUseCase) Execute(ctx context.Context, evalCtx Evaluatio
 
 	return results, nil
 }
+
+// EvaluateTimeTriggers evaluates all enabled time-triggered actions at
+// currentTime against the tasks in their scope and returns the runs that are due
+func (uc *EvaluateActionsUseCase) EvaluateTimeTriggers(ctx context.Context, currentTime time.Time) ([]*EvaluationResult, error) {
+	results := make([]*EvaluationResult, 0)
+
+	actions, err := uc.actionRepo.ListByTriggerType(ctx, entity.TriggerTypeTime)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make([]*entity.Action, 0, len(actions))
+	for _, action := range actions {
+		if action.Enabled() {
+			enabled = append(enabled, action)
+		}
+	}
+	if len(enabled) == 0 {
+		return results, nil
+	}
+
+	boards, err := uc.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, action := range enabled {
+		for _, run := range service.DueTimeTriggerRuns(action, boards, currentTime, action.LastRun(), uc.workSchedule) {
+			results = append(results, &EvaluationResult{
+				Action:  action,
+				Context: run.TriggerContext(action.LastRun(), uc.workSchedule),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// evaluateAggregate evaluates an aggregate action for the group the evaluation
+// context falls in: its board, or its column when grouping by column
+func evaluateAggregate(action *entity.Action, base entity.TriggerContext, board *entity.Board, column *entity.Column) *EvaluationResult {
+	if board == nil {
+		return nil
+	}
+
+	var group *entity.Column
+	if action.Aggregation().GroupBy == entity.AggregateGroupColumn {
+		if column == nil {
+			return nil
+		}
+		group = column
+	}
+
+	inScope := false
+	for _, candidate := range service.AggregateGroups(action, board) {
+		if candidate == group {
+			inScope = true
+			break
+		}
+	}
+	if !inScope {
+		return nil
+	}
+
+	tasks, fires := service.EvaluateAggregate(action, base, board, group)
+	if !fires {
+		return nil
+	}
+
+	triggerCtx := base
+	triggerCtx.Board, triggerCtx.Column, triggerCtx.Tasks = board, group, tasks
+	return &EvaluationResult{
+		Action:  action,
+		Context: &triggerCtx,
+	}
+}
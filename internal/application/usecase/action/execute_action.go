@@ -3,63 +3,270 @@ package action
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
 )
 
 // ExecuteActionUseCase handles executing a single action
 type ExecuteActionUseCase struct {
-	actionRepo  repository.ActionRepository
-	notifier    entity.Notifier
-	scriptRunner entity.ScriptRunner
-	taskMutator entity.TaskMutator
+	actionRepo    repository.ActionRepository
+	executionRepo repository.ActionExecutionRepository
+	notifier      entity.Notifier
+	scriptRunner  entity.ScriptRunner
+	commandRunner entity.CommandRunner
+	webhookSender entity.WebhookSender
+	taskMutator   entity.TaskMutator
+	eventBus      entity.EventBus
+	config        *config.Config
+
+	// schedule runs a retry after a delay, off the caller's goroutine
+	schedule func(delay time.Duration, retry func())
+
+	locksMu sync.Mutex
+	locks   map[string]*sync.Mutex // action ID -> lock over its run state
 }
 
 // NewExecuteActionUseCase creates a new ExecuteActionUseCase
 func NewExecuteActionUseCase(
 	actionRepo repository.ActionRepository,
+	executionRepo repository.ActionExecutionRepository,
 	notifier entity.Notifier,
 	scriptRunner entity.ScriptRunner,
+	commandRunner entity.CommandRunner,
+	webhookSender entity.WebhookSender,
 	taskMutator entity.TaskMutator,
+	eventBus entity.EventBus,
+	cfg *config.Config,
 ) *ExecuteActionUseCase {
 	return &ExecuteActionUseCase{
-		actionRepo:   actionRepo,
-		notifier:     notifier,
-		scriptRunner: scriptRunner,
-		taskMutator:  taskMutator,
+		actionRepo:    actionRepo,
+		executionRepo: executionRepo,
+		notifier:      notifier,
+		scriptRunner:  scriptRunner,
+		commandRunner: commandRunner,
+		webhookSender: webhookSender,
+		taskMutator:   taskMutator,
+		eventBus:      eventBus,
+		config:        cfg,
+		schedule: func(delay time.Duration, retry func()) {
+			time.AfterFunc(delay, retry)
+		},
+		locks: make(map[string]*sync.Mutex),
 	}
 }
 
 // ExecutionRequest contains the action and context to execute
 type ExecutionRequest struct {
-	Action        *entity.Action
+	Action         *entity.Action
 	TriggerContext *entity.TriggerContext
 }
 
-// Execute executes a single action
+// Execute executes a single action. Every attempt is recorded in the
+// execution history and as the action's last run. A failed attempt is
+// retried with exponential backoff, scheduled rather than waited for so the
+// caller isn't held up. After too many consecutive failed executions the
+// action is disabled.
 func (uc *ExecuteActionUseCase) Execute(ctx context.Context, req ExecutionRequest) error {
+	if !req.Action.Enabled() {
+		return fmt.Errorf("action execution failed: %w", entity.ErrActionDisabled)
+	}
+
+	return uc.run(ctx, req, 1)
+}
+
+// run makes an attempt and either schedules the next one or settles the
+// outcome of the execution
+func (uc *ExecuteActionUseCase) run(ctx context.Context, req ExecutionRequest, attempt int) error {
+	execution, err := uc.attempt(ctx, req, attempt)
+
+	if err != nil && attempt <= uc.config.Actions.MaxRetries && ctx.Err() == nil {
+		backoff := uc.retryBackoff(attempt)
+		uc.recordRunState(ctx, req.Action, false, err)
+
+		fmt.Printf("Action %s failed (attempt %d/%d), retrying in %s: %v\n",
+			req.Action.Name(), attempt, uc.config.Actions.MaxRetries+1, backoff, err)
+		uc.schedule(backoff, func() { uc.retry(ctx, req, attempt+1) })
+
+		return fmt.Errorf("action execution failed, retrying in %s: %w", backoff, err)
+	}
+
+	uc.recordRunState(ctx, req.Action, true, err)
+	uc.publishResult(req, execution, err)
+
+	if err != nil {
+		return fmt.Errorf("action execution failed: %w", err)
+	}
+	return nil
+}
+
+// retry makes a scheduled attempt, unless the action was disabled or
+// deleted meanwhile
+func (uc *ExecuteActionUseCase) retry(ctx context.Context, req ExecutionRequest, attempt int) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	action, err := uc.actionRepo.GetByID(ctx, req.Action.ID())
+	if err != nil || !action.Enabled() {
+		return
+	}
+	req.Action = action
+
+	if err := uc.run(ctx, req, attempt); err != nil {
+		fmt.Printf("Failed to execute action %s: %v\n", action.Name(), err)
+	}
+}
+
+// retryBackoff returns the delay before the attempt after the given one,
+// doubling with each attempt
+func (uc *ExecuteActionUseCase) retryBackoff(attempt int) time.Duration {
+	backoff := time.Duration(uc.config.Actions.RetryBackoff) * time.Second
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	return backoff << (attempt - 1)
+}
+
+// attempt runs the action once and stores the execution record
+func (uc *ExecuteActionUseCase) attempt(ctx context.Context, req ExecutionRequest, attempt int) (*entity.ActionExecution, error) {
 	// Build action context
 	actionCtx := &entity.ActionContext{
-		Task:         req.TriggerContext.Task,
-		Column:       req.TriggerContext.Column,
-		Board:        req.TriggerContext.Board,
-		Event:        req.TriggerContext.Event,
-		Notifier:     uc.notifier,
-		ScriptRunner: uc.scriptRunner,
-		TaskMutator:  uc.taskMutator,
+		Notifier:      uc.notifier,
+		ScriptRunner:  uc.scriptRunner,
+		CommandRunner: uc.commandRunner,
+		WebhookSender: uc.webhookSender,
+		TaskMutator:   uc.taskMutator,
+	}
+	if tc := req.TriggerContext; tc != nil {
+		actionCtx.Task = tc.Task
+		actionCtx.Column = tc.Column
+		actionCtx.Board = tc.Board
+		actionCtx.Event = tc.Event
+		actionCtx.Tasks = tc.Tasks
 	}
 
-	// Execute the action
-	if err := req.Action.Execute(actionCtx); err != nil {
-		return fmt.Errorf("action execution failed: %w", err)
+	execution := entity.NewActionExecution(uuid.New().String(), req.Action, req.TriggerContext, attempt)
+	err := req.Action.Execute(actionCtx)
+	execution.Finish(err, actionCtx.Output)
+
+	if uc.executionRepo != nil {
+		if saveErr := uc.executionRepo.Save(ctx, execution); saveErr != nil {
+			// Log error but don't fail the execution
+			fmt.Printf("Failed to record execution of action %s: %v\n", req.Action.ID(), saveErr)
+		}
+	}
+
+	return execution, err
+}
+
+// recordRunState persists the time of an attempt as the action's last run,
+// so a failing schedule isn't fired again on every check. Once the
+// execution is settled, with no attempts left, its success resets the
+// failure count and too many failures in a row disable the action.
+//
+// Executions of an action may finish concurrently, so its state is reloaded
+// and updated under a lock per action rather than from the caller's copy.
+func (uc *ExecuteActionUseCase) recordRunState(ctx context.Context, action *entity.Action, settled bool, execErr error) {
+	lock := uc.actionLock(action.ID())
+	lock.Lock()
+	defer lock.Unlock()
+
+	if current, err := uc.actionRepo.GetByID(ctx, action.ID()); err == nil {
+		action = current
+	}
+
+	action.MarkAsRun()
+	disabled := false
+	if settled {
+		if execErr == nil {
+			action.RecordSuccess()
+		} else {
+			failures := action.RecordFailure()
+			limit := uc.config.Actions.DisableAfterFailures
+			if limit > 0 && failures >= limit && action.Enabled() {
+				action.Disable()
+				disabled = true
+			}
+		}
 	}
 
-	// Update last run time
-	if err := uc.actionRepo.UpdateLastRun(ctx, req.Action.ID()); err != nil {
+	if err := uc.actionRepo.Update(ctx, action); err != nil {
 		// Log error but don't fail the execution
-		fmt.Printf("Failed to update last run time for action %s: %v\n", req.Action.ID(), err)
+		fmt.Printf("Failed to update run state for action %s: %v\n", action.ID(), err)
 	}
 
-	return nil
+	if disabled {
+		uc.announceDisabled(action, execErr)
+	}
+}
+
+// actionLock returns the lock over the run state of an action
+func (uc *ExecuteActionUseCase) actionLock(actionID string) *sync.Mutex {
+	uc.locksMu.Lock()
+	defer uc.locksMu.Unlock()
+
+	lock, ok := uc.locks[actionID]
+	if !ok {
+		lock = &sync.Mutex{}
+		uc.locks[actionID] = lock
+	}
+	return lock
+}
+
+// announceDisabled tells the user an action was switched off after repeated failures
+func (uc *ExecuteActionUseCase) announceDisabled(action *entity.Action, lastErr error) {
+	message := fmt.Sprintf("Disabled after %d consecutive failures. Last error: %v",
+		action.ConsecutiveFailures(), lastErr)
+	fmt.Printf("Action %s: %s\n", action.Name(), message)
+
+	if uc.notifier != nil {
+		title := fmt.Sprintf("mkanban action disabled: %s", action.Name())
+		if err := uc.notifier.SendNotification(title, message, map[string]string{"action_id": action.ID()}); err != nil {
+			fmt.Printf("Failed to send notification for disabled action %s: %v\n", action.ID(), err)
+		}
+	}
+
+	if uc.eventBus != nil {
+		uc.eventBus.Publish(entity.NewDomainEvent(valueobject.EventActionDisabled, "", "", nil, map[string]interface{}{
+			"action": action,
+			"error":  lastErr.Error(),
+		}))
+	}
+}
+
+// publishResult announces the outcome of an execution on the event bus
+func (uc *ExecuteActionUseCase) publishResult(req ExecutionRequest, execution *entity.ActionExecution, execErr error) {
+	if uc.eventBus == nil {
+		return
+	}
+
+	eventType := valueobject.EventActionExecuted
+	metadata := map[string]interface{}{
+		"action":    req.Action,
+		"execution": execution,
+	}
+	if execErr != nil {
+		eventType = valueobject.EventActionFailed
+		metadata["error"] = execErr.Error()
+	}
+
+	var boardID string
+	var taskID *valueobject.TaskID
+	if tc := req.TriggerContext; tc != nil {
+		if tc.Board != nil {
+			boardID = tc.Board.ID()
+		}
+		if tc.Task != nil {
+			taskID = tc.Task.ID()
+		}
+	}
+
+	uc.eventBus.Publish(entity.NewDomainEvent(eventType, boardID, "", taskID, metadata))
 }
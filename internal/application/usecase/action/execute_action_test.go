@@ -0,0 +1,177 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+)
+
+// flakyRunner fails the first failures commands it runs
+type flakyRunner struct {
+	failures int
+	runs     int
+}
+
+func (r *flakyRunner) RunCommand(command string, args []string, env map[string]string, timeout time.Duration) (string, error) {
+	r.runs++
+	if r.runs <= r.failures {
+		return "", errors.New("exit status 1")
+	}
+	return "ok", nil
+}
+
+// retryQueue holds scheduled retries until the test runs them
+type retryQueue struct {
+	delays  []time.Duration
+	pending []func()
+}
+
+func (q *retryQueue) schedule(delay time.Duration, retry func()) {
+	q.delays = append(q.delays, delay)
+	q.pending = append(q.pending, retry)
+}
+
+func (q *retryQueue) runAll() {
+	for len(q.pending) > 0 {
+		retry := q.pending[0]
+		q.pending = q.pending[1:]
+		retry()
+	}
+}
+
+func TestExecuteSchedulesRetries(t *testing.T) {
+	ctx := context.Background()
+	runner := &flakyRunner{failures: 2}
+	uc, actions, executions, queue := newExecuteTestUseCase(t, runner, config.ActionsConfig{
+		MaxRetries:   2,
+		RetryBackoff: 5,
+	})
+	action := createCommandAction(t, actions)
+
+	err := uc.Execute(ctx, ExecutionRequest{Action: action})
+	if err == nil || !strings.Contains(err.Error(), "retrying") {
+		t.Fatalf("Execute() = %v, want a failure to be retried", err)
+	}
+	if runner.runs != 1 || len(queue.pending) != 1 {
+		t.Fatalf("Execute() ran %d attempts and scheduled %d, want 1 of each", runner.runs, len(queue.pending))
+	}
+	// The failed attempt counts as a run, so the schedule doesn't fire again
+	if stored := getAction(t, actions, action.ID()); stored.LastRun() == nil || stored.ConsecutiveFailures() != 0 {
+		t.Errorf("after a failed attempt: last run %v, %d failures, want a last run and no failure yet",
+			stored.LastRun(), stored.ConsecutiveFailures())
+	}
+
+	queue.runAll()
+	if runner.runs != 3 {
+		t.Errorf("ran %d attempts, want 3", runner.runs)
+	}
+	if want := []time.Duration{5 * time.Second, 10 * time.Second}; !reflect.DeepEqual(queue.delays, want) {
+		t.Errorf("retry delays = %v, want %v", queue.delays, want)
+	}
+
+	history, err := executions.ListByAction(ctx, action.ID(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[0].Attempt != 3 || history[0].Outcome != entity.ExecutionOutcomeSuccess {
+		t.Errorf("history = %+v, want 3 attempts, the last one successful", history)
+	}
+	if stored := getAction(t, actions, action.ID()); stored.ConsecutiveFailures() != 0 || !stored.Enabled() {
+		t.Errorf("after succeeding: %d failures, enabled %v", stored.ConsecutiveFailures(), stored.Enabled())
+	}
+}
+
+func TestExecuteDisablesAfterConsecutiveFailures(t *testing.T) {
+	ctx := context.Background()
+	runner := &flakyRunner{failures: 100}
+	uc, actions, _, queue := newExecuteTestUseCase(t, runner, config.ActionsConfig{
+		MaxRetries:           1,
+		DisableAfterFailures: 2,
+	})
+	action := createCommandAction(t, actions)
+
+	for execution := 1; execution <= 2; execution++ {
+		current := getAction(t, actions, action.ID())
+		if err := uc.Execute(ctx, ExecutionRequest{Action: current}); err == nil {
+			t.Fatalf("execution %d succeeded", execution)
+		}
+		queue.runAll()
+
+		stored := getAction(t, actions, action.ID())
+		if stored.ConsecutiveFailures() != execution {
+			t.Errorf("after execution %d: %d consecutive failures", execution, stored.ConsecutiveFailures())
+		}
+		if wantEnabled := execution < 2; stored.Enabled() != wantEnabled {
+			t.Errorf("after execution %d: enabled %v, want %v", execution, stored.Enabled(), wantEnabled)
+		}
+	}
+	if runner.runs != 4 {
+		t.Errorf("ran %d attempts, want 2 per execution", runner.runs)
+	}
+
+	// A retry scheduled before the action was disabled doesn't run
+	if err := uc.Execute(ctx, ExecutionRequest{Action: action}); err == nil {
+		t.Fatal("execution of the stale enabled copy succeeded")
+	}
+	stored := getAction(t, actions, action.ID())
+	stored.Disable()
+	if err := actions.Update(ctx, stored); err != nil {
+		t.Fatal(err)
+	}
+	queue.runAll()
+	if runner.runs != 5 {
+		t.Errorf("ran %d attempts, want the retry of a disabled action skipped", runner.runs)
+	}
+}
+
+func newExecuteTestUseCase(t *testing.T, runner entity.CommandRunner, actionsConfig config.ActionsConfig) (
+	*ExecuteActionUseCase, repository.ActionRepository, repository.ActionExecutionRepository, *retryQueue,
+) {
+	t.Helper()
+	cfg := &config.Config{
+		Storage: config.StorageConfig{DataPath: t.TempDir()},
+		Actions: actionsConfig,
+	}
+	actions := filesystem.NewActionRepository(cfg)
+	executions := filesystem.NewActionExecutionRepository(cfg)
+
+	queue := &retryQueue{}
+	uc := NewExecuteActionUseCase(actions, executions, nil, nil, runner, nil, nil, nil, cfg)
+	uc.schedule = queue.schedule
+	return uc, actions, executions, queue
+}
+
+func createCommandAction(t *testing.T, actions repository.ActionRepository) *entity.Action {
+	t.Helper()
+	trigger, err := entity.NewEventTrigger(valueobject.EventTaskCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := entity.NewAction("deploy", "Deploy", "", valueobject.ActionScopeGlobal, "", trigger,
+		entity.NewCommandAction("deploy.sh", nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := actions.Create(context.Background(), action); err != nil {
+		t.Fatal(err)
+	}
+	return action
+}
+
+func getAction(t *testing.T, actions repository.ActionRepository, id string) *entity.Action {
+	t.Helper()
+	action, err := actions.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return action
+}
@@ -0,0 +1,39 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+)
+
+// GetActionHistoryUseCase handles retrieving action execution history
+type GetActionHistoryUseCase struct {
+	executionRepo repository.ActionExecutionRepository
+}
+
+// NewGetActionHistoryUseCase creates a new GetActionHistoryUseCase
+func NewGetActionHistoryUseCase(executionRepo repository.ActionExecutionRepository) *GetActionHistoryUseCase {
+	return &GetActionHistoryUseCase{
+		executionRepo: executionRepo,
+	}
+}
+
+// Execute retrieves the newest executions, of one action if actionID is set
+// or across all actions otherwise
+func (uc *GetActionHistoryUseCase) Execute(ctx context.Context, actionID string, limit int) ([]*entity.ActionExecution, error) {
+	var executions []*entity.ActionExecution
+	var err error
+
+	if actionID != "" {
+		executions, err = uc.executionRepo.ListByAction(ctx, actionID, limit)
+	} else {
+		executions, err = uc.executionRepo.ListRecent(ctx, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve action history: %w", err)
+	}
+
+	return executions, nil
+}
@@ -0,0 +1,138 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+)
+
+// SimulateActionsUseCase handles dry runs of time-triggered actions over a
+// time window. Nothing is executed or persisted.
+type SimulateActionsUseCase struct {
+	actionRepo   repository.ActionRepository
+	boardRepo    repository.BoardRepository
+	workSchedule *entity.WorkSchedule
+	config       *config.Config
+}
+
+// NewSimulateActionsUseCase creates a new SimulateActionsUseCase
+func NewSimulateActionsUseCase(
+	actionRepo repository.ActionRepository,
+	boardRepo repository.BoardRepository,
+	workSchedule *entity.WorkSchedule,
+	cfg *config.Config,
+) *SimulateActionsUseCase {
+	return &SimulateActionsUseCase{
+		actionRepo:   actionRepo,
+		boardRepo:    boardRepo,
+		workSchedule: workSchedule,
+		config:       cfg,
+	}
+}
+
+// SimulateActionsRequest describes what to simulate
+type SimulateActionsRequest struct {
+	// ActionIDs limits the simulation to these actions; empty means every
+	// time-triggered action, enabled or not
+	ActionIDs []string
+	// BoardID limits the simulation to one board; empty means all boards
+	BoardID string
+	From    time.Time
+	To      time.Time
+	// Step is the evaluation interval; zero uses the daemon's check interval
+	Step time.Duration
+}
+
+// Execute replays the time triggers over the window and returns the timeline
+func (uc *SimulateActionsUseCase) Execute(ctx context.Context, req SimulateActionsRequest) ([]dto.ScheduledRunDTO, error) {
+	if !req.To.After(req.From) {
+		return nil, fmt.Errorf("simulation window must end after it starts")
+	}
+
+	actions, err := uc.loadActions(ctx, req.ActionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []*entity.Board
+	if req.BoardID != "" {
+		board, err := uc.boardRepo.FindByID(ctx, req.BoardID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find board: %w", err)
+		}
+		boards = []*entity.Board{board}
+	} else {
+		boards, err = uc.boardRepo.FindAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list boards: %w", err)
+		}
+	}
+
+	step := req.Step
+	if step <= 0 {
+		step = time.Duration(uc.config.Actions.CheckInterval) * time.Second
+	}
+
+	runs := service.SimulateTimeTriggers(actions, boards, req.From, req.To, step, uc.workSchedule)
+
+	timeline := make([]dto.ScheduledRunDTO, 0, len(runs))
+	for _, run := range runs {
+		item := dto.ScheduledRunDTO{
+			At:         run.At,
+			ActionID:   run.Action.ID(),
+			ActionName: run.Action.Name(),
+			Effect: run.Action.ActionType().Describe(&entity.ActionContext{
+				Task:   run.Task,
+				Column: run.Column,
+				Board:  run.Board,
+				Tasks:  run.Tasks,
+			}),
+		}
+		if run.Board != nil {
+			item.BoardID = run.Board.ID()
+		}
+		if run.Column != nil {
+			item.ColumnName = run.Column.Name()
+		}
+		if run.Task != nil {
+			item.TaskID = run.Task.ID().String()
+			item.TaskTitle = run.Task.Title()
+		}
+		for _, task := range run.Tasks {
+			item.TaskIDs = append(item.TaskIDs, task.ID().String())
+		}
+		timeline = append(timeline, item)
+	}
+
+	return timeline, nil
+}
+
+// loadActions returns the requested actions, or all time-triggered ones
+func (uc *SimulateActionsUseCase) loadActions(ctx context.Context, actionIDs []string) ([]*entity.Action, error) {
+	if len(actionIDs) == 0 {
+		actions, err := uc.actionRepo.ListByTriggerType(ctx, entity.TriggerTypeTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list time-based actions: %w", err)
+		}
+		return actions, nil
+	}
+
+	actions := make([]*entity.Action, 0, len(actionIDs))
+	for _, id := range actionIDs {
+		action, err := uc.actionRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve action %s: %w", id, err)
+		}
+		if action.Trigger().Type() != entity.TriggerTypeTime {
+			return nil, fmt.Errorf("action %s is not time-triggered", id)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
@@ -0,0 +1,145 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// TestActionUseCase handles dry evaluation of an action against a task.
+// Nothing is executed, persisted or published.
+type TestActionUseCase struct {
+	actionRepo   repository.ActionRepository
+	boardRepo    repository.BoardRepository
+	workSchedule *entity.WorkSchedule
+}
+
+// NewTestActionUseCase creates a new TestActionUseCase
+func NewTestActionUseCase(
+	actionRepo repository.ActionRepository,
+	boardRepo repository.BoardRepository,
+	workSchedule *entity.WorkSchedule,
+) *TestActionUseCase {
+	return &TestActionUseCase{
+		actionRepo:   actionRepo,
+		boardRepo:    boardRepo,
+		workSchedule: workSchedule,
+	}
+}
+
+// TestActionRequest describes the situation to evaluate the action in
+type TestActionRequest struct {
+	ActionID string
+	BoardID  string
+	TaskID   string
+	// EventType simulates an event; when empty an event trigger is tested
+	// against its own event type
+	EventType valueobject.EventType
+	// CurrentTime is the moment time triggers are evaluated at
+	CurrentTime time.Time
+}
+
+// Execute evaluates the action's scope, trigger and conditions for the task
+func (uc *TestActionUseCase) Execute(ctx context.Context, req TestActionRequest) (*dto.ActionTestDTO, error) {
+	action, err := uc.actionRepo.GetByID(ctx, req.ActionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve action: %w", err)
+	}
+
+	board, err := uc.boardRepo.FindByID(ctx, req.BoardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find board: %w", err)
+	}
+
+	taskID, err := valueobject.ParseTaskID(req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+	task, column, err := board.FindTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	currentTime := req.CurrentTime
+	if currentTime.IsZero() {
+		currentTime = time.Now()
+	}
+
+	eventType := req.EventType
+	if eventType == "" {
+		if trigger, ok := action.Trigger().(*entity.EventTrigger); ok {
+			eventType = trigger.EventType()
+		}
+	}
+
+	triggerCtx := &entity.TriggerContext{
+		CurrentTime:     currentTime,
+		Task:            task,
+		Column:          column,
+		Board:           board,
+		LastRun:         action.LastRun(),
+		ActionCreatedAt: action.CreatedAt(),
+		WorkSchedule:    uc.workSchedule,
+	}
+	if eventType != "" {
+		triggerCtx.Event = entity.NewDomainEvent(eventType, board.ID(), column.Name(), task.ID(), nil)
+		triggerCtx.Event.Timestamp = currentTime
+	}
+
+	result := &dto.ActionTestDTO{
+		Action:        dto.ActionToDTO(action),
+		BoardID:       board.ID(),
+		TaskID:        task.ID().String(),
+		ColumnName:    column.Name(),
+		EventType:     string(eventType),
+		EvaluatedAt:   currentTime,
+		Enabled:       action.Enabled(),
+		ScopeMatches:  action.MatchesScope(board.ID(), column.Name(), task.ID().String()),
+		TriggerFires:  action.Trigger().ShouldTrigger(triggerCtx),
+		ConditionsMet: action.Conditions() == nil || action.Conditions().Evaluate(task, column),
+	}
+	actionCtx := &entity.ActionContext{
+		Task:   task,
+		Column: column,
+		Board:  board,
+		Event:  triggerCtx.Event,
+	}
+
+	// Aggregate actions are evaluated for the task's group; the task only
+	// needs to be one of the tasks they match
+	if action.Aggregation() != nil {
+		var group *entity.Column
+		if action.Aggregation().GroupBy == entity.AggregateGroupColumn {
+			group = column
+		}
+		base := *triggerCtx
+		tasks, fires := service.EvaluateAggregate(action, base, board, group)
+
+		result.ScopeMatches = false
+		for _, candidate := range service.AggregateGroups(action, board) {
+			if candidate == group {
+				result.ScopeMatches = true
+			}
+		}
+		result.TriggerFires = fires
+		result.ConditionsMet = false
+		for _, matched := range tasks {
+			result.MatchedTasks = append(result.MatchedTasks, matched.ID().String())
+			if matched == task {
+				result.ConditionsMet = true
+			}
+		}
+		actionCtx.Task, actionCtx.Column, actionCtx.Tasks = nil, group, tasks
+	}
+
+	result.WouldFire = result.Enabled && result.ScopeMatches && result.TriggerFires && result.ConditionsMet
+	result.Effect = action.ActionType().Describe(actionCtx)
+
+	return result, nil
+}
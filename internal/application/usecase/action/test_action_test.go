@@ -0,0 +1,306 @@
+package action
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	pkgfs "mkanban/pkg/filesystem"
+)
+
+var testActionNow = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func TestTestActionEvaluates(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cfg := &config.Config{Storage: config.StorageConfig{DataPath: root}}
+	boards := filesystem.NewBoardRepository(root)
+	actions := filesystem.NewActionRepository(cfg)
+
+	// Ship it is overdue and high priority; Write docs is neither
+	board := newTestActionBoard(t)
+	shipIt := addTestActionTask(t, board, "todo", "Ship it", valueobject.PriorityHigh, -24*time.Hour)
+	writeDocs := addTestActionTask(t, board, "todo", "Write docs", valueobject.PriorityNone, 0)
+	if err := boards.Save(ctx, board); err != nil {
+		t.Fatal(err)
+	}
+
+	moved := mustEventTrigger(t, valueobject.EventTaskMoved)
+	highPriority := entity.NewConditionGroup(entity.LogicalAnd,
+		entity.NewCondition("priority", entity.OperatorEquals, "high"))
+	countOverOne, err := entity.NewAggregateCondition(entity.AggregateCount, "", entity.OperatorGreaterThan, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dueSoon, err := entity.NewTimeTrigger(valueobject.NewRelativeDueDateSchedule(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		scope       valueobject.ActionScope
+		scopeID     string
+		trigger     entity.Trigger
+		conditions  *entity.ConditionGroup
+		aggregation []*entity.AggregateCondition // an aggregate action over the column when set
+		taskID      string
+		eventType   valueobject.EventType
+		wantEvent   string
+		wantScope   bool
+		wantTrigger bool
+		wantConds   bool
+		wantMatched []string
+	}{
+		{
+			name:        "event trigger tested against its own event",
+			scope:       valueobject.ActionScopeBoard,
+			scopeID:     "shop/main",
+			trigger:     moved,
+			conditions:  highPriority,
+			taskID:      shipIt,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   true,
+			wantTrigger: true,
+			wantConds:   true,
+		},
+		{
+			name:        "event trigger with another event",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     moved,
+			taskID:      shipIt,
+			eventType:   valueobject.EventTaskCreated,
+			wantEvent:   string(valueobject.EventTaskCreated),
+			wantScope:   true,
+			wantTrigger: false,
+			wantConds:   true,
+		},
+		{
+			name:        "board out of scope",
+			scope:       valueobject.ActionScopeBoard,
+			scopeID:     "shop/other",
+			trigger:     moved,
+			taskID:      shipIt,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   false,
+			wantTrigger: true,
+			wantConds:   true,
+		},
+		{
+			name:        "conditions not met",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     moved,
+			conditions:  highPriority,
+			taskID:      writeDocs,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   true,
+			wantTrigger: true,
+			wantConds:   false,
+		},
+		{
+			name:        "time trigger due",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     dueSoon,
+			taskID:      shipIt,
+			wantScope:   true,
+			wantTrigger: true,
+			wantConds:   true,
+		},
+		{
+			name:        "time trigger without a due date",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     dueSoon,
+			taskID:      writeDocs,
+			wantScope:   true,
+			wantTrigger: false,
+			wantConds:   true,
+		},
+		{
+			name:        "aggregate over the task's column",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     moved,
+			aggregation: []*entity.AggregateCondition{countOverOne},
+			taskID:      writeDocs,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   true,
+			wantTrigger: true,
+			wantConds:   true,
+			wantMatched: []string{shipIt, writeDocs},
+		},
+		{
+			name:        "aggregate not matching the task",
+			scope:       valueobject.ActionScopeGlobal,
+			trigger:     moved,
+			conditions:  highPriority,
+			aggregation: []*entity.AggregateCondition{countOverOne},
+			taskID:      writeDocs,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   true,
+			wantTrigger: false,
+			wantConds:   false,
+			wantMatched: []string{shipIt},
+		},
+		{
+			// The task's column is out of scope, so its group isn't evaluated
+			name:        "aggregate over another column",
+			scope:       valueobject.ActionScopeColumn,
+			scopeID:     "done",
+			trigger:     moved,
+			aggregation: []*entity.AggregateCondition{},
+			taskID:      shipIt,
+			wantEvent:   string(valueobject.EventTaskMoved),
+			wantScope:   false,
+			wantTrigger: false,
+			wantConds:   false,
+		},
+	}
+
+	uc := NewTestActionUseCase(actions, boards, nil)
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := entity.NewAction(string(rune('a'+i)), tt.name, "", tt.scope, tt.scopeID, tt.trigger,
+				entity.NewTaskMovementAction("done"), tt.conditions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.aggregation != nil {
+				aggregation, err := entity.NewAggregation(entity.AggregateGroupColumn, tt.aggregation...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				action.UpdateAggregation(aggregation)
+			}
+			if err := actions.Create(ctx, action); err != nil {
+				t.Fatal(err)
+			}
+			before := hashTestActionData(t, root)
+
+			result, err := uc.Execute(ctx, TestActionRequest{
+				ActionID:    action.ID(),
+				BoardID:     "shop/main",
+				TaskID:      tt.taskID,
+				EventType:   tt.eventType,
+				CurrentTime: testActionNow,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.EventType != tt.wantEvent {
+				t.Errorf("event type = %q, want %q", result.EventType, tt.wantEvent)
+			}
+			if result.ScopeMatches != tt.wantScope || result.TriggerFires != tt.wantTrigger || result.ConditionsMet != tt.wantConds {
+				t.Errorf("scope %t, trigger %t, conditions %t, want %t, %t, %t",
+					result.ScopeMatches, result.TriggerFires, result.ConditionsMet, tt.wantScope, tt.wantTrigger, tt.wantConds)
+			}
+			if wantFire := tt.wantScope && tt.wantTrigger && tt.wantConds; result.WouldFire != wantFire {
+				t.Errorf("would fire = %t, want %t", result.WouldFire, wantFire)
+			}
+			if !reflect.DeepEqual(result.MatchedTasks, tt.wantMatched) {
+				t.Errorf("matched tasks = %v, want %v", result.MatchedTasks, tt.wantMatched)
+			}
+			if !strings.Contains(result.Effect, `column "done"`) {
+				t.Errorf("effect = %q, want a move to done", result.Effect)
+			}
+
+			// Testing neither runs the action nor saves anything
+			if after := hashTestActionData(t, root); !reflect.DeepEqual(after, before) {
+				t.Error("the data directory changed")
+			}
+			if stored := getAction(t, actions, action.ID()); stored.LastRun() != nil {
+				t.Errorf("last run = %v, want none", stored.LastRun())
+			}
+			stored, err := boards.FindByID(ctx, "shop/main")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := testActionLayout(stored); !reflect.DeepEqual(got, map[string][]string{"todo": {shipIt, writeDocs}}) {
+				t.Errorf("board = %v, want both tasks left in todo", got)
+			}
+		})
+	}
+}
+
+func newTestActionBoard(t *testing.T) *entity.Board {
+	t.Helper()
+	board, err := entity.NewBoard("shop/main", "Main", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range []string{"todo", "done"} {
+		column, err := entity.NewColumn(name, "", i, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := board.AddColumn(column); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return board
+}
+
+// addTestActionTask adds a task due at the offset from testActionNow, or
+// without a due date for a zero offset, and returns its ID
+func addTestActionTask(t *testing.T, board *entity.Board, columnName, title string, priority valueobject.Priority, due time.Duration) string {
+	t.Helper()
+	taskID, err := board.GenerateNextTaskID(valueobject.GenerateSlug(title))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := entity.NewTask(taskID, title, "", priority, valueobject.StatusTodo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dueDate *time.Time
+	if due != 0 {
+		d := testActionNow.Add(due)
+		dueDate = &d
+	}
+	created := testActionNow.Add(-48 * time.Hour)
+	task.RestoreTimestamps(created, created, dueDate, nil)
+
+	column, err := board.GetColumn(columnName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := column.AddTask(task); err != nil {
+		t.Fatal(err)
+	}
+	return taskID.String()
+}
+
+func mustEventTrigger(t *testing.T, eventType valueobject.EventType) *entity.EventTrigger {
+	t.Helper()
+	trigger, err := entity.NewEventTrigger(eventType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trigger
+}
+
+func hashTestActionData(t *testing.T, root string) pkgfs.TreeHashes {
+	t.Helper()
+	hashes, err := pkgfs.HashTree(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hashes
+}
+
+// testActionLayout lists the task IDs of each non-empty column
+func testActionLayout(board *entity.Board) map[string][]string {
+	layout := make(map[string][]string)
+	for _, column := range board.Columns() {
+		for _, task := range column.Tasks() {
+			layout[column.Name()] = append(layout[column.Name()], task.ID().String())
+		}
+	}
+	return layout
+}
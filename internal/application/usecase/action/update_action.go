@@ -27,6 +27,7 @@ type UpdateActionRequest struct {
 	Trigger     entity.Trigger
 	ActionType  entity.ActionType
 	Conditions  *entity.ConditionGroup
+	Aggregation *entity.Aggregation
 }
 
 // Execute updates an existing action
@@ -64,6 +65,10 @@ func (uc *UpdateActionUseCase) Execute(ctx context.Context, actionID string, req
 		action.UpdateConditions(req.Conditions)
 	}
 
+	if req.Aggregation != nil {
+		action.UpdateAggregation(req.Aggregation)
+	}
+
 	// Persist updates
 	if err := uc.actionRepo.Update(ctx, action); err != nil {
 		return nil, fmt.Errorf("failed to persist action updates: %w", err)
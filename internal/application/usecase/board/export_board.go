@@ -0,0 +1,125 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+)
+
+// ExportBoardUseCase takes a full snapshot of a board for export
+type ExportBoardUseCase struct {
+	boardRepo   repository.BoardRepository
+	projectRepo repository.ProjectRepository
+	timeLogRepo repository.TimeLogRepository
+}
+
+// NewExportBoardUseCase creates a new ExportBoardUseCase
+func NewExportBoardUseCase(
+	boardRepo repository.BoardRepository,
+	projectRepo repository.ProjectRepository,
+	timeLogRepo repository.TimeLogRepository,
+) *ExportBoardUseCase {
+	return &ExportBoardUseCase{
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		timeLogRepo: timeLogRepo,
+	}
+}
+
+// Execute takes a snapshot of a board. Tasks get the time of the stopped
+// time logs of their project that are logged on them, which the snapshot
+// carries too; timers still running are left out.
+func (uc *ExportBoardUseCase) Execute(ctx context.Context, boardID string) (*dto.BoardSnapshotDTO, error) {
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &dto.BoardSnapshotDTO{
+		Version:     dto.BoardSnapshotVersion,
+		ExportedAt:  time.Now(),
+		ID:          board.ID(),
+		ProjectID:   board.ProjectID(),
+		Name:        board.Name(),
+		Prefix:      board.Prefix(),
+		Description: board.Description(),
+		Swimlanes:   board.Swimlanes().String(),
+		NextTaskNum: board.NextTaskNum(),
+		CreatedAt:   board.CreatedAt(),
+		ModifiedAt:  board.ModifiedAt(),
+		Columns:     make([]dto.ColumnSnapshotDTO, 0, board.ColumnCount()),
+	}
+
+	tracked := make(map[string]time.Duration)
+	if board.ProjectID() != "" {
+		if project, err := uc.projectRepo.FindByID(ctx, board.ProjectID()); err == nil {
+			snapshot.ProjectName = project.Name()
+		}
+		if snapshot.TimeLogs, err = uc.boardTimeLogs(ctx, board); err != nil {
+			return nil, err
+		}
+		for _, log := range snapshot.TimeLogs {
+			tracked[log.TaskID] += time.Duration(log.Duration * float64(time.Second))
+		}
+	}
+
+	for _, column := range board.Columns() {
+		columnDTO := dto.ColumnToDTO(column)
+		columnSnapshot := dto.ColumnSnapshotDTO{
+			Key:           column.Name(),
+			Name:          columnDTO.Name,
+			Description:   columnDTO.Description,
+			Order:         columnDTO.Order,
+			WIPLimit:      columnDTO.WIPLimit,
+			Color:         columnDTO.Color,
+			StaleAfter:    columnDTO.StaleAfter,
+			EscalateAfter: columnDTO.EscalateAfter,
+			Rules:         columnDTO.Rules,
+			Tasks:         make([]dto.TaskSnapshotDTO, 0, column.TaskCount()),
+		}
+		for i, task := range column.Tasks() {
+			taskSnapshot := dto.TaskSnapshotDTO{TaskDTO: columnDTO.Tasks[i]}
+			taskSnapshot.ProjectID = board.ProjectID()
+			taskSnapshot.ColumnName = column.DisplayName()
+			taskSnapshot.TrackedTime = tracked[task.ID().String()]
+			taskSnapshot.Done = service.IsTaskDone(column, task)
+			if metadata := task.Metadata(); len(metadata) > 0 {
+				taskSnapshot.Metadata = metadata
+			}
+			snapshot.TrackedTime += taskSnapshot.TrackedTime
+			columnSnapshot.Tasks = append(columnSnapshot.Tasks, taskSnapshot)
+		}
+		snapshot.Columns = append(snapshot.Columns, columnSnapshot)
+	}
+
+	return snapshot, nil
+}
+
+// boardTimeLogs returns the stopped time logs of the board's project that
+// are logged on one of its tasks
+func (uc *ExportBoardUseCase) boardTimeLogs(ctx context.Context, board *entity.Board) ([]dto.TimeLogDTO, error) {
+	logs, err := uc.timeLogRepo.FindByProject(ctx, board.ProjectID())
+	if err == entity.ErrProjectNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time logs: %w", err)
+	}
+
+	logDTOs := make([]dto.TimeLogDTO, 0)
+	for _, log := range logs {
+		if log.TaskID() == nil || log.IsRunning() {
+			continue
+		}
+		if _, _, err := board.FindTask(log.TaskID()); err != nil {
+			continue
+		}
+		logDTOs = append(logDTOs, dto.TimeLogToDTO(log))
+	}
+	return logDTOs, nil
+}
@@ -3,22 +3,28 @@ package board
 import (
 	"context"
 	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"time"
 )
 
 // GetBoardUseCase handles retrieving a single board
 type GetBoardUseCase struct {
-	boardRepo repository.BoardRepository
+	boardRepo    repository.BoardRepository
+	workSchedule *entity.WorkSchedule
 }
 
 // NewGetBoardUseCase creates a new GetBoardUseCase
-func NewGetBoardUseCase(boardRepo repository.BoardRepository) *GetBoardUseCase {
+func NewGetBoardUseCase(boardRepo repository.BoardRepository, workSchedule *entity.WorkSchedule) *GetBoardUseCase {
 	return &GetBoardUseCase{
-		boardRepo: boardRepo,
+		boardRepo:    boardRepo,
+		workSchedule: workSchedule,
 	}
 }
 
-// Execute retrieves a board by ID
+// Execute retrieves a board by ID, with how long each task has been in its
+// column and the aging level that puts it at
 func (uc *GetBoardUseCase) Execute(ctx context.Context, boardID string) (*dto.BoardDTO, error) {
 	board, err := uc.boardRepo.FindByID(ctx, boardID)
 	if err != nil {
@@ -26,5 +32,15 @@ func (uc *GetBoardUseCase) Execute(ctx context.Context, boardID string) (*dto.Bo
 	}
 
 	boardDTO := dto.BoardToDTO(board)
+
+	now := time.Now()
+	for i, column := range board.Columns() {
+		for j, task := range column.Tasks() {
+			age := service.AgeInColumn(task, column, now, uc.workSchedule)
+			boardDTO.Columns[i].Tasks[j].DaysInColumn = age.WorkingDays
+			boardDTO.Columns[i].Tasks[j].Aging = string(age.Level)
+		}
+	}
+
 	return &boardDTO, nil
 }
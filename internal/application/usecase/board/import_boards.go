@@ -0,0 +1,480 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/pkg/slug"
+)
+
+// importMetadataKey is the task metadata holding the source and ID a task
+// was imported from, as in "trello:5f1a..."
+const importMetadataKey = "import_id"
+
+// ImportBoardsUseCase imports the exports of other tools into projects,
+// boards, columns and tasks. Tasks remember the ID they have in the source,
+// so importing an export again updates the tasks it created instead of
+// adding them twice.
+type ImportBoardsUseCase struct {
+	boardService *service.BoardService
+	boardRepo    repository.BoardRepository
+	projectRepo  repository.ProjectRepository
+	importer     service.BoardImporter
+}
+
+// NewImportBoardsUseCase creates a new ImportBoardsUseCase
+func NewImportBoardsUseCase(
+	boardService *service.BoardService,
+	boardRepo repository.BoardRepository,
+	projectRepo repository.ProjectRepository,
+	importer service.BoardImporter,
+) *ImportBoardsUseCase {
+	return &ImportBoardsUseCase{
+		boardService: boardService,
+		boardRepo:    boardRepo,
+		projectRepo:  projectRepo,
+		importer:     importer,
+	}
+}
+
+// Execute imports an export. Each board goes into the project and board of
+// its name, which are created when missing, as are its columns. Tasks that
+// can't be imported are reported in the result's errors without stopping
+// the import. A dry run reports the same without changing anything.
+func (uc *ImportBoardsUseCase) Execute(ctx context.Context, req dto.ImportRequest) (*dto.ImportResultDTO, error) {
+	boards, err := uc.importer.Parse(req.Format, req.Data, service.ImportOptions{Fields: req.Fields})
+	if err != nil {
+		return nil, err
+	}
+	boards = renameImportedBoards(boards, req.Project, req.Board)
+
+	result := &dto.ImportResultDTO{
+		DryRun: req.DryRun,
+		Boards: make([]dto.ImportedBoardDTO, 0, len(boards)),
+	}
+	for _, imported := range boards {
+		boardResult, err := uc.importBoard(ctx, imported, req.DryRun, result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import board %s: %w", imported.Name, err)
+		}
+		result.Boards = append(result.Boards, *boardResult)
+	}
+
+	return result, nil
+}
+
+// importBoard imports a board and its tasks, counting them in result
+func (uc *ImportBoardsUseCase) importBoard(
+	ctx context.Context,
+	imported service.ImportedBoard,
+	dryRun bool,
+	result *dto.ImportResultDTO,
+) (*dto.ImportedBoardDTO, error) {
+	projectID := slug.Generate(imported.Project)
+	boardID, err := valueobject.BuildBoardID(projectID, slug.Generate(imported.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil && err != entity.ErrBoardNotFound {
+		return nil, fmt.Errorf("failed to check for existing board: %w", err)
+	}
+	boardResult := &dto.ImportedBoardDTO{
+		ID:        boardID,
+		Name:      imported.Name,
+		ProjectID: projectID,
+		Created:   board == nil,
+		Tasks:     make([]dto.ImportedTaskDTO, 0, len(imported.Tasks)),
+	}
+
+	for _, column := range importedColumns(imported) {
+		if board != nil {
+			if _, err := board.GetColumn(column); err == nil {
+				continue
+			}
+		}
+		boardResult.ColumnsAdded = append(boardResult.ColumnsAdded, column)
+	}
+
+	if !dryRun {
+		if board == nil {
+			if err := uc.ensureProject(ctx, projectID, imported); err != nil {
+				return nil, err
+			}
+			if board, err = uc.boardService.CreateBoard(ctx, projectID, imported.Name, imported.Description); err != nil {
+				return nil, err
+			}
+		}
+		order := board.ColumnCount()
+		for _, column := range boardResult.ColumnsAdded {
+			if board, err = uc.boardService.AddColumnToBoard(ctx, boardID, column, "", order, 0, nil); err != nil {
+				return nil, err
+			}
+			order++
+		}
+	}
+
+	existing := make(map[string]*entity.Task)
+	columns := make(map[string]string)
+	if board != nil {
+		for _, column := range board.Columns() {
+			for _, task := range column.Tasks() {
+				if key, ok := task.GetMetadata(importMetadataKey); ok {
+					existing[key] = task
+					columns[key] = column.DisplayName()
+				}
+			}
+		}
+	}
+
+	for _, importedTask := range imported.Tasks {
+		key := imported.Source + ":" + importedTask.SourceID
+		taskResult := dto.ImportedTaskDTO{
+			SourceID: importedTask.SourceID,
+			Title:    importedTask.Title,
+			Column:   importedTask.Column,
+		}
+
+		task, ok := existing[key]
+		if ok {
+			taskResult.TaskID = task.ID().String()
+			taskResult.Changes = importChanges(task, columns[key], importedTask)
+			if len(taskResult.Changes) == 0 {
+				taskResult.Action = "unchanged"
+				result.TasksUnchanged++
+				boardResult.Tasks = append(boardResult.Tasks, taskResult)
+				continue
+			}
+			taskResult.Action = "update"
+		} else {
+			taskResult.Action = "create"
+		}
+
+		if !dryRun {
+			if ok {
+				err = uc.updateTask(ctx, boardID, task, importedTask)
+			} else {
+				task, err = uc.createTask(ctx, boardID, importedTask)
+				if task != nil {
+					taskResult.TaskID = task.ID().String()
+				}
+			}
+			// A task that was created is recorded even if setting it up
+			// failed, so it isn't created again on the next import
+			if task != nil {
+				if restoreErr := uc.restoreImported(ctx, boardID, task.ID(), key, importedTask, !ok); err == nil {
+					err = restoreErr
+				}
+			}
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %s: %v", imported.Name, importedTask.Title, err))
+				continue
+			}
+		}
+
+		if ok {
+			result.TasksUpdated++
+		} else {
+			result.TasksCreated++
+		}
+		boardResult.Tasks = append(boardResult.Tasks, taskResult)
+	}
+
+	return boardResult, nil
+}
+
+// ensureProject creates the project of an imported board if it doesn't
+// exist yet
+func (uc *ImportBoardsUseCase) ensureProject(ctx context.Context, projectID string, imported service.ImportedBoard) error {
+	_, err := uc.projectRepo.FindBySlug(ctx, projectID)
+	if err == nil {
+		return nil
+	}
+	if err != entity.ErrProjectNotFound {
+		return err
+	}
+
+	description := fmt.Sprintf("Imported from %s", imported.Source)
+	project, err := entity.NewProject(projectID, imported.Project, description)
+	if err != nil {
+		return err
+	}
+	if err := uc.projectRepo.Save(ctx, project); err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+	return nil
+}
+
+// createTask creates an imported task. Checklist items are added to the
+// description as checkboxes, which makes them subtasks, and the subtasks of
+// items that are done are checked off. The entry rules of a column are
+// checked on the task as imported, checked off items included, so a task
+// for a column with such rules is set up in the first column and then moved.
+func (uc *ImportBoardsUseCase) createTask(ctx context.Context, boardID string, imported service.ImportedTask) (*entity.Task, error) {
+	description := strings.TrimRight(imported.Description, "\n")
+	done := false
+	for i, item := range imported.Checklist {
+		if i == 0 && description != "" {
+			description += "\n\n"
+		} else if i > 0 {
+			description += "\n"
+		}
+		description += "- [ ] " + item.Title
+		done = done || item.Done
+	}
+
+	column, staged, err := uc.creationColumn(ctx, boardID, imported.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	_, task, err := uc.boardService.CreateTask(ctx, boardID, column, service.NewTaskFields{
+		Title:       imported.Title,
+		Description: description,
+		Priority:    importedPriority(imported.Priority),
+		Tags:        imported.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The checklist comes last, so its subtasks are the last linked ones
+	if ids := service.LinkedTaskIDs(task.Description()); done && len(ids) >= len(imported.Checklist) {
+		ids = ids[len(ids)-len(imported.Checklist):]
+		checked := task.Description()
+		for i, item := range imported.Checklist {
+			if item.Done {
+				checked = service.UpdateCheckboxState(checked, ids[i], service.CheckboxDone)
+			}
+		}
+		if _, _, err := uc.boardService.UpdateTask(ctx, boardID, task.ID(), service.TaskUpdate{Description: &checked}); err != nil {
+			return task, err
+		}
+	}
+
+	if staged {
+		if _, _, err := uc.boardService.MoveTask(ctx, boardID, task.ID(), imported.Column); err != nil {
+			return task, err
+		}
+	}
+	return task, nil
+}
+
+// creationColumn returns the column to create a task for column in, and
+// whether it's another one the task moves to column from once set up
+func (uc *ImportBoardsUseCase) creationColumn(ctx context.Context, boardID, column string) (string, bool, error) {
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return "", false, err
+	}
+	target, err := board.GetColumn(column)
+	if err != nil {
+		return "", false, err
+	}
+	first, err := board.GetColumnByIndex(0)
+	if err != nil || first == target || len(target.RulesOn(entity.RuleOnEnter)) == 0 {
+		return column, false, nil
+	}
+	return first.Name(), true, nil
+}
+
+// updateTask brings a task imported before up to date with the source. The
+// description is left alone, as it holds the links to the subtasks.
+func (uc *ImportBoardsUseCase) updateTask(ctx context.Context, boardID string, task *entity.Task, imported service.ImportedTask) error {
+	_, column, err := uc.findTask(ctx, boardID, task.ID())
+	if err != nil {
+		return err
+	}
+	if !sameColumn(column.DisplayName(), imported.Column) {
+		if _, _, err := uc.boardService.MoveTask(ctx, boardID, task.ID(), imported.Column); err != nil {
+			return err
+		}
+	}
+
+	priority := importedPriority(imported.Priority)
+	tags := imported.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	_, _, err = uc.boardService.UpdateTask(ctx, boardID, task.ID(), service.TaskUpdate{
+		Title:    &imported.Title,
+		Priority: &priority,
+		Tags:     tags,
+	})
+	return err
+}
+
+// restoreImported records what can't be set through the board service: the
+// source ID, the dates, which may be in the past, and the comments
+func (uc *ImportBoardsUseCase) restoreImported(
+	ctx context.Context,
+	boardID string,
+	taskID *valueobject.TaskID,
+	key string,
+	imported service.ImportedTask,
+	created bool,
+) error {
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	task, _, err := board.FindTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	task.SetMetadata(importMetadataKey, key)
+	createdAt := task.CreatedAt()
+	if created && !imported.CreatedAt.IsZero() {
+		createdAt = imported.CreatedAt
+	}
+	completedAt := task.CompletedDate()
+	if imported.CompletedAt != nil {
+		completedAt = imported.CompletedAt
+	}
+	task.RestoreTimestamps(createdAt, time.Now(), imported.DueDate, completedAt)
+
+	for _, comment := range imported.Comments {
+		task.AddActivity(importedComment(comment))
+	}
+
+	if err := uc.boardRepo.Save(ctx, board); err != nil {
+		return fmt.Errorf("failed to save board: %w", err)
+	}
+	return nil
+}
+
+// findTask loads a task with the column it is in
+func (uc *ImportBoardsUseCase) findTask(ctx context.Context, boardID string, taskID *valueobject.TaskID) (*entity.Task, *entity.Column, error) {
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return board.FindTask(taskID)
+}
+
+// importChanges lists the fields of a task imported before that differ
+// from the source, the task being in the column named column
+func importChanges(task *entity.Task, column string, imported service.ImportedTask) []string {
+	var changes []string
+	if task.Title() != imported.Title {
+		changes = append(changes, "title")
+	}
+	if !sameColumn(column, imported.Column) {
+		changes = append(changes, "column")
+	}
+	if task.Priority() != importedPriority(imported.Priority) {
+		changes = append(changes, "priority")
+	}
+	if strings.Join(task.Tags(), "\x00") != strings.Join(imported.Tags, "\x00") {
+		changes = append(changes, "tags")
+	}
+	due := task.DueDate()
+	if (due == nil) != (imported.DueDate == nil) || due != nil && !due.Equal(*imported.DueDate) {
+		changes = append(changes, "due date")
+	}
+
+	known := make(map[string]bool)
+	for _, entry := range task.Activity() {
+		if entry.Kind == entity.ActivityComment {
+			known[entry.Ref] = true
+		}
+	}
+	for _, comment := range imported.Comments {
+		if !known[importedComment(comment).Ref] {
+			changes = append(changes, "comments")
+			break
+		}
+	}
+	return changes
+}
+
+// sameColumn reports whether two column names name the same column
+func sameColumn(a, b string) bool {
+	return slug.Generate(a) == slug.Generate(b)
+}
+
+// importedComment returns the activity entry of a comment. Comments without
+// an ID are told apart by their time.
+func importedComment(comment service.ImportedComment) entity.Activity {
+	ref := comment.ID
+	if ref == "" {
+		ref = comment.Time.UTC().Format(time.RFC3339)
+	}
+	return entity.Activity{
+		Kind:    entity.ActivityComment,
+		Ref:     ref,
+		Summary: comment.Text,
+		Author:  comment.Author,
+		Time:    comment.Time,
+	}
+}
+
+// importedPriority parses the priority of an imported task; priorities
+// mkanban doesn't have are none
+func importedPriority(name string) valueobject.Priority {
+	priority, err := valueobject.ParsePriority(name)
+	if err != nil {
+		return valueobject.PriorityNone
+	}
+	return priority
+}
+
+// importedColumns returns the columns an imported board needs: its own and
+// those of its tasks, in order, with a Done column for checklist items that
+// are done
+func importedColumns(imported service.ImportedBoard) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(column string) {
+		if key := slug.Generate(column); !seen[key] {
+			seen[key] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, column := range imported.Columns {
+		add(column)
+	}
+	for _, task := range imported.Tasks {
+		add(task.Column)
+	}
+
+	for _, task := range imported.Tasks {
+		for _, item := range task.Checklist {
+			if item.Done {
+				add("Done")
+				return columns
+			}
+		}
+	}
+	return columns
+}
+
+// renameImportedBoards puts imported boards into the named project and
+// board, merging them when a board is named
+func renameImportedBoards(boards []service.ImportedBoard, project, board string) []service.ImportedBoard {
+	for i := range boards {
+		if project != "" {
+			boards[i].Project = project
+		}
+	}
+	if board == "" || len(boards) == 0 {
+		return boards
+	}
+
+	merged := boards[0]
+	merged.Name = board
+	for _, other := range boards[1:] {
+		merged.Columns = append(merged.Columns, other.Columns...)
+		merged.Tasks = append(merged.Tasks, other.Tasks...)
+	}
+	return []service.ImportedBoard{merged}
+}
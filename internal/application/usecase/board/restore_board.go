@@ -0,0 +1,301 @@
+package board
+
+import (
+	"context"
+	"fmt"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+)
+
+// RestoreBoardUseCase restores a board from the snapshot of a JSON board
+// export, keeping its ID, task IDs and timestamps
+type RestoreBoardUseCase struct {
+	boardRepo   repository.BoardRepository
+	projectRepo repository.ProjectRepository
+	timeLogRepo repository.TimeLogRepository
+}
+
+// NewRestoreBoardUseCase creates a new RestoreBoardUseCase
+func NewRestoreBoardUseCase(
+	boardRepo repository.BoardRepository,
+	projectRepo repository.ProjectRepository,
+	timeLogRepo repository.TimeLogRepository,
+) *RestoreBoardUseCase {
+	return &RestoreBoardUseCase{
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		timeLogRepo: timeLogRepo,
+	}
+}
+
+// Execute restores a board. A board with the same ID is only overwritten
+// when replace is set, and then loses the columns and tasks the snapshot
+// doesn't have. The project of a new board is created if it doesn't exist,
+// and time logs that aren't there yet are added to it.
+func (uc *RestoreBoardUseCase) Execute(ctx context.Context, snapshot *dto.BoardSnapshotDTO, replace bool) (*dto.RestoredBoardDTO, error) {
+	board, err := boardFromSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := uc.boardRepo.Exists(ctx, board.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing board: %w", err)
+	}
+	if exists && !replace {
+		return nil, fmt.Errorf("%w: %s", entity.ErrBoardAlreadyExists, board.ID())
+	}
+
+	if !exists {
+		if err := uc.ensureProject(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.boardRepo.Save(ctx, board); err != nil {
+		return nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	result := &dto.RestoredBoardDTO{
+		ID:        board.ID(),
+		Name:      board.Name(),
+		ProjectID: board.ProjectID(),
+		Replaced:  exists,
+		Columns:   board.ColumnCount(),
+		Tasks:     board.TotalTaskCount(),
+	}
+	for _, logDTO := range snapshot.TimeLogs {
+		added, err := uc.restoreTimeLog(ctx, logDTO)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore time log %s: %w", logDTO.ID, err)
+		}
+		if added {
+			result.TimeLogs++
+		}
+	}
+
+	return result, nil
+}
+
+// ensureProject creates the project of a restored board if it doesn't
+// exist yet
+func (uc *RestoreBoardUseCase) ensureProject(ctx context.Context, snapshot *dto.BoardSnapshotDTO) error {
+	projectSlug, _, err := valueobject.ParseBoardID(snapshot.ID)
+	if err != nil {
+		return err
+	}
+	_, err = uc.projectRepo.FindBySlug(ctx, projectSlug)
+	if err == nil {
+		return nil
+	}
+	if err != entity.ErrProjectNotFound {
+		return err
+	}
+
+	projectID := snapshot.ProjectID
+	if projectID == "" {
+		projectID = projectSlug
+	}
+	name := snapshot.ProjectName
+	if name == "" {
+		name = projectSlug
+	}
+	project, err := entity.NewProject(projectID, name, "Restored from a board export")
+	if err != nil {
+		return err
+	}
+	if err := uc.projectRepo.Save(ctx, project); err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+	return nil
+}
+
+// restoreTimeLog saves a time log of a snapshot unless it already exists,
+// reporting whether it was added
+func (uc *RestoreBoardUseCase) restoreTimeLog(ctx context.Context, logDTO dto.TimeLogDTO) (bool, error) {
+	if _, err := uc.timeLogRepo.FindByID(ctx, logDTO.ID); err == nil {
+		return false, nil
+	} else if err != entity.ErrTimeLogNotFound {
+		return false, err
+	}
+
+	// Exports leave running timers out, so there is nothing to stop
+	if logDTO.EndTime == nil {
+		return false, nil
+	}
+	source := entity.TimeLogSource(logDTO.Source)
+	if !source.IsValid() {
+		return false, entity.ErrInvalidTimeLogSource
+	}
+
+	var taskID *string
+	if logDTO.TaskID != "" {
+		taskID = &logDTO.TaskID
+	}
+	log := entity.NewTimeLogWithDuration(logDTO.ID, logDTO.ProjectID, taskID, source, logDTO.StartTime, *logDTO.EndTime, logDTO.Description)
+	for key, value := range logDTO.Metadata {
+		log.SetMetadata(key, value)
+	}
+	if err := uc.timeLogRepo.Save(ctx, log); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// boardFromSnapshot rebuilds a board entity from a snapshot
+func boardFromSnapshot(snapshot *dto.BoardSnapshotDTO) (*entity.Board, error) {
+	board, err := entity.NewBoard(snapshot.ID, snapshot.Name, snapshot.Description)
+	if err != nil {
+		return nil, err
+	}
+	board.SetProjectID(snapshot.ProjectID)
+	board.SetNextTaskNum(snapshot.NextTaskNum)
+
+	swimlanes, err := entity.ParseSwimlanes(snapshot.Swimlanes)
+	if err != nil {
+		return nil, err
+	}
+	board.SetSwimlanes(swimlanes)
+
+	for _, columnSnapshot := range snapshot.Columns {
+		column, err := columnFromSnapshot(columnSnapshot)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", columnSnapshot.Name, err)
+		}
+		if err := board.AddColumn(column); err != nil {
+			return nil, fmt.Errorf("column %s: %w", columnSnapshot.Name, err)
+		}
+	}
+	board.ReorderColumns()
+
+	return board, nil
+}
+
+// columnFromSnapshot rebuilds a column and its tasks from a snapshot. The
+// WIP limit is set once the tasks are in, which a snapshot may exceed.
+func columnFromSnapshot(snapshot dto.ColumnSnapshotDTO) (*entity.Column, error) {
+	var color *valueobject.Color
+	if snapshot.Color != nil {
+		var err error
+		if color, err = valueobject.NewColor(*snapshot.Color); err != nil {
+			return nil, err
+		}
+	}
+
+	key := snapshot.Key
+	if key == "" {
+		key = snapshot.Name
+	}
+	column, err := entity.NewColumnWithDisplayName(key, snapshot.Name, snapshot.Description, snapshot.Order, 0, color)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.StaleAfter > 0 || snapshot.EscalateAfter > 0 {
+		policy, err := entity.NewAgingPolicy(snapshot.StaleAfter, snapshot.EscalateAfter)
+		if err != nil {
+			return nil, err
+		}
+		column.UpdateAgingPolicy(policy)
+	}
+	for _, ruleDTO := range snapshot.Rules {
+		rule, err := dto.ColumnRuleFromDTO(ruleDTO)
+		if err != nil {
+			return nil, err
+		}
+		if err := column.AddRule(rule); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, taskSnapshot := range snapshot.Tasks {
+		task, err := taskFromSnapshot(taskSnapshot)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", taskSnapshot.ID, err)
+		}
+		if err := column.AddTask(task); err != nil {
+			return nil, fmt.Errorf("task %s: %w", taskSnapshot.ID, err)
+		}
+	}
+
+	if err := column.UpdateWIPLimit(snapshot.WIPLimit); err != nil {
+		return nil, err
+	}
+	return column, nil
+}
+
+// taskFromSnapshot rebuilds a task from a snapshot
+func taskFromSnapshot(snapshot dto.TaskSnapshotDTO) (*entity.Task, error) {
+	taskID, err := valueobject.ParseTaskID(snapshot.ID)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := valueobject.ParsePriority(snapshot.Priority)
+	if err != nil {
+		return nil, err
+	}
+	status, err := valueobject.ParseStatus(snapshot.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := entity.NewTask(taskID, snapshot.Title, snapshot.Description, priority, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.ScheduledDate != nil {
+		task.SetScheduledDate(*snapshot.ScheduledDate)
+	}
+	if snapshot.ScheduledTime != nil {
+		task.SetScheduledTime(*snapshot.ScheduledTime)
+	}
+	if snapshot.TimeBlock != nil {
+		task.SetTimeBlock(*snapshot.TimeBlock)
+	}
+	if snapshot.EstimatedTime != nil {
+		task.SetEstimatedTime(*snapshot.EstimatedTime)
+	}
+	if snapshot.TaskType != "" {
+		task.SetTaskType(entity.TaskType(snapshot.TaskType))
+	}
+	for _, tag := range snapshot.Tags {
+		task.AddTag(tag)
+	}
+	if snapshot.ParentID != "" {
+		parentID, err := valueobject.ParseTaskID(snapshot.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent: %w", err)
+		}
+		task.SetParentID(parentID)
+		task.SetParentBoardID(snapshot.ParentBoardID)
+	}
+	for key, value := range snapshot.Metadata {
+		task.SetMetadata(key, value)
+	}
+
+	// Tasks that never moved entered their column when they were created
+	if !snapshot.ColumnEnteredAt.IsZero() && !snapshot.ColumnEnteredAt.Equal(snapshot.CreatedAt) {
+		task.EnterColumn(snapshot.ColumnEnteredAt)
+	}
+	if level := entity.AgingLevel(snapshot.Aging); level.IsValid() {
+		task.SetAgingLevel(level)
+	}
+
+	for _, entry := range snapshot.Activity {
+		task.AddActivity(entity.Activity{
+			Kind:    entity.ActivityKind(entry.Kind),
+			Ref:     entry.Ref,
+			Summary: entry.Summary,
+			Author:  entry.Author,
+			Time:    entry.Time,
+		})
+	}
+
+	// Restore timestamps last, the setters above touch the modified time
+	task.RestoreTimestamps(snapshot.CreatedAt, snapshot.ModifiedAt, snapshot.DueDate, snapshot.CompletedDate)
+
+	return task, nil
+}
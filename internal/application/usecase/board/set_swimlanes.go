@@ -0,0 +1,37 @@
+package board
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
+)
+
+// SetSwimlanesUseCase handles setting how a board is split into swimlanes
+type SetSwimlanesUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewSetSwimlanesUseCase creates a new SetSwimlanesUseCase
+func NewSetSwimlanesUseCase(boardService *service.BoardService) *SetSwimlanesUseCase {
+	return &SetSwimlanesUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute sets the swimlanes of a board from a spec such as "tag:team:" or
+// "priority". An empty spec removes the swimlanes.
+func (uc *SetSwimlanesUseCase) Execute(ctx context.Context, boardID string, spec string) (*dto.BoardDTO, error) {
+	swimlanes, err := entity.ParseSwimlanes(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := uc.boardService.SetSwimlanes(ctx, boardID, swimlanes)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDTO := dto.BoardToDTO(board)
+	return &boardDTO, nil
+}
@@ -0,0 +1,35 @@
+package column
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/service"
+)
+
+// AddColumnRuleUseCase handles adding entry and exit rules to a column
+type AddColumnRuleUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewAddColumnRuleUseCase creates a new AddColumnRuleUseCase
+func NewAddColumnRuleUseCase(boardService *service.BoardService) *AddColumnRuleUseCase {
+	return &AddColumnRuleUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute adds a rule to a column
+func (uc *AddColumnRuleUseCase) Execute(ctx context.Context, boardID string, req dto.AddColumnRuleRequest) (*dto.BoardDTO, error) {
+	rule, err := dto.ColumnRuleFromDTO(req.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := uc.boardService.AddColumnRule(ctx, boardID, req.ColumnName, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDTO := dto.BoardToDTO(board)
+	return &boardDTO, nil
+}
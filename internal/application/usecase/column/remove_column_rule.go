@@ -0,0 +1,31 @@
+package column
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/service"
+)
+
+// RemoveColumnRuleUseCase handles removing entry and exit rules from a column
+type RemoveColumnRuleUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewRemoveColumnRuleUseCase creates a new RemoveColumnRuleUseCase
+func NewRemoveColumnRuleUseCase(boardService *service.BoardService) *RemoveColumnRuleUseCase {
+	return &RemoveColumnRuleUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute removes the rule at index (counting from 0, in the order of
+// ColumnDTO.Rules) from a column
+func (uc *RemoveColumnRuleUseCase) Execute(ctx context.Context, boardID string, columnName string, index int) (*dto.BoardDTO, error) {
+	board, err := uc.boardService.RemoveColumnRule(ctx, boardID, columnName, index)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDTO := dto.BoardToDTO(board)
+	return &boardDTO, nil
+}
@@ -0,0 +1,41 @@
+package column
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
+)
+
+// SetAgingPolicyUseCase handles setting the aging policy of a column
+type SetAgingPolicyUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewSetAgingPolicyUseCase creates a new SetAgingPolicyUseCase
+func NewSetAgingPolicyUseCase(boardService *service.BoardService) *SetAgingPolicyUseCase {
+	return &SetAgingPolicyUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute sets the aging policy of a column. Zero thresholds on both sides
+// remove the policy.
+func (uc *SetAgingPolicyUseCase) Execute(ctx context.Context, boardID string, req dto.SetAgingPolicyRequest) (*dto.BoardDTO, error) {
+	var policy *entity.AgingPolicy
+	if req.StaleAfter != 0 || req.EscalateAfter != 0 {
+		var err error
+		policy, err = entity.NewAgingPolicy(req.StaleAfter, req.EscalateAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	board, err := uc.boardService.SetColumnAgingPolicy(ctx, boardID, req.ColumnName, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDTO := dto.BoardToDTO(board)
+	return &boardDTO, nil
+}
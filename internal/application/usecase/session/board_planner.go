@@ -6,25 +6,28 @@ import (
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/service"
 	"mkanban/internal/domain/valueobject"
-	"mkanban/pkg/filesystem"
 )
 
 type SessionBoardPlan struct {
-	ProjectID    string
-	ProjectName  string
-	WorkingDir   string
-	BoardNames   []string
+	ProjectID     string
+	ProjectName   string
+	WorkingDir    string
+	BoardNames    []string
 	SyncBoardName string
-	IsRepo       bool
+	// ActiveBoardName is the board of the directory the session is in
+	ActiveBoardName string
+	IsRepo          bool
 }
 
 type SessionBoardPlanner struct {
-	vcsProvider service.VCSProvider
+	vcsProvider    service.VCSProvider
+	layoutProvider service.BoardLayoutProvider
 }
 
-func NewSessionBoardPlanner(vcsProvider service.VCSProvider) *SessionBoardPlanner {
+func NewSessionBoardPlanner(vcsProvider service.VCSProvider, layoutProvider service.BoardLayoutProvider) *SessionBoardPlanner {
 	return &SessionBoardPlanner{
-		vcsProvider: vcsProvider,
+		vcsProvider:    vcsProvider,
+		layoutProvider: layoutProvider,
 	}
 }
 
@@ -35,7 +38,8 @@ func (p *SessionBoardPlanner) Plan(session *entity.Session) (*SessionBoardPlan,
 
 	isRepo := p.vcsProvider.IsRepository(workingDir)
 	if isRepo {
-		repoRoot, err := p.vcsProvider.GetRepositoryRoot(workingDir)
+		// Worktrees of a repository share the board of its main checkout
+		repoRoot, err := p.vcsProvider.GetMainRepositoryRoot(workingDir)
 		if err != nil {
 			return nil, err
 		}
@@ -44,46 +48,42 @@ func (p *SessionBoardPlanner) Plan(session *entity.Session) (*SessionBoardPlan,
 	}
 
 	projectID := valueobject.GenerateSlug(projectName)
-	boardNames := []string{"default"}
-
-	if isRepo {
-		monorepo, err := p.isMonorepo(projectWorkingDir)
-		if err != nil {
-			return nil, err
-		}
-		if monorepo {
-			boardNames = []string{"default", "backend", "android"}
-		}
+	plan := &SessionBoardPlan{
+		ProjectID:       projectID,
+		ProjectName:     projectName,
+		WorkingDir:      projectWorkingDir,
+		BoardNames:      []string{service.DefaultBoardName},
+		SyncBoardName:   service.DefaultBoardName,
+		ActiveBoardName: service.DefaultBoardName,
+		IsRepo:          isRepo,
+	}
+	if !isRepo {
+		return plan, nil
 	}
 
-	return &SessionBoardPlan{
-		ProjectID:     projectID,
-		ProjectName:   projectName,
-		WorkingDir:    projectWorkingDir,
-		BoardNames:    boardNames,
-		SyncBoardName: "default",
-		IsRepo:        isRepo,
-	}, nil
-}
-
-func (p *SessionBoardPlanner) isMonorepo(repoRoot string) (bool, error) {
-	backendPath := filepath.Join(repoRoot, "backend")
-	backendExists, err := filesystem.Exists(backendPath)
+	layout, err := p.layoutProvider.LayoutFor(projectWorkingDir)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	plan.BoardNames = layout.BoardNames()
+	plan.SyncBoardName = layout.Default()
 
-	mobilePath := filepath.Join(repoRoot, "mobile")
-	mobileExists, err := filesystem.Exists(mobilePath)
+	// The pane's directory within its checkout, which is a worktree or the
+	// main one
+	checkoutRoot, err := p.vcsProvider.GetRepositoryRoot(workingDir)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-
-	androidPath := filepath.Join(repoRoot, "android")
-	androidExists, err := filesystem.Exists(androidPath)
+	paneDir := workingDir
+	if resolved, err := filepath.EvalSymlinks(workingDir); err == nil {
+		// git resolves symlinks in the root
+		paneDir = resolved
+	}
+	relDir, err := filepath.Rel(checkoutRoot, paneDir)
 	if err != nil {
-		return false, err
+		relDir = "."
 	}
+	plan.ActiveBoardName = layout.BoardFor(relDir)
 
-	return backendExists && (mobileExists || androidExists), nil
+	return plan, nil
 }
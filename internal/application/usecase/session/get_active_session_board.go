@@ -64,7 +64,7 @@ func (uc *GetActiveSessionBoardUseCase) Execute(ctx context.Context, sessionName
 		return "", err
 	}
 
-	boardID, err := valueobject.BuildBoardID(plan.ProjectID, slug.Generate(plan.SyncBoardName))
+	boardID, err := valueobject.BuildBoardID(plan.ProjectID, slug.Generate(plan.ActiveBoardName))
 	if err != nil {
 		return "", err
 	}
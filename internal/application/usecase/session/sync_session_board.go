@@ -71,7 +71,7 @@ func (uc *SyncSessionBoardUseCase) Execute(ctx context.Context, session *entity.
 		}
 
 		if boardName == plan.SyncBoardName {
-			if err := selectedStrategy.Sync(session, board); err != nil {
+			if err := selectedStrategy.Sync(ctx, session, board); err != nil {
 				return fmt.Errorf("failed to sync board: %w", err)
 			}
 		}
@@ -0,0 +1,82 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// CheckAgingUseCase checks tasks against the aging policies of their columns
+// and publishes task.stale and task.escalated events for tasks that aged into
+// a higher level
+type CheckAgingUseCase struct {
+	boardRepo    repository.BoardRepository
+	workSchedule *entity.WorkSchedule
+	eventBus     entity.EventBus
+}
+
+// NewCheckAgingUseCase creates a new CheckAgingUseCase
+func NewCheckAgingUseCase(
+	boardRepo repository.BoardRepository,
+	workSchedule *entity.WorkSchedule,
+	eventBus entity.EventBus,
+) *CheckAgingUseCase {
+	return &CheckAgingUseCase{
+		boardRepo:    boardRepo,
+		workSchedule: workSchedule,
+		eventBus:     eventBus,
+	}
+}
+
+// Execute checks every board at now and returns the events it published.
+// Each level is announced once per stay in a column: the level reached is
+// saved on the task before its events are published, so actions reacting to
+// them see, and may change, the up-to-date task.
+func (uc *CheckAgingUseCase) Execute(ctx context.Context, now time.Time) ([]*entity.DomainEvent, error) {
+	boards, err := uc.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*entity.DomainEvent, 0)
+	for _, board := range boards {
+		transitions, changed := service.UpdateAging(board, now, uc.workSchedule)
+		if !changed {
+			continue
+		}
+		if err := uc.boardRepo.Save(ctx, board); err != nil {
+			return events, fmt.Errorf("failed to save aging of board %s: %w", board.ID(), err)
+		}
+
+		for _, transition := range transitions {
+			for _, level := range transition.Levels {
+				event := agingEvent(transition, level)
+				if uc.eventBus != nil {
+					uc.eventBus.Publish(event)
+				}
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// agingEvent builds the event announcing that a task reached an aging level
+func agingEvent(transition service.AgingTransition, level entity.AgingLevel) *entity.DomainEvent {
+	eventType := valueobject.EventTaskStale
+	if level == entity.AgingEscalated {
+		eventType = valueobject.EventTaskEscalated
+	}
+
+	return entity.NewDomainEvent(eventType, transition.Board.ID(), transition.Column.Name(), transition.Task.ID(), map[string]interface{}{
+		"level":        string(level),
+		"working_days": transition.Age.WorkingDays,
+		"entered_at":   transition.Age.EnteredAt,
+	})
+}
@@ -15,20 +15,26 @@ import (
 // CheckoutTaskUseCase handles checking out a task (moves to In Progress and checks out git branch)
 type CheckoutTaskUseCase struct {
 	boardRepo        repository.BoardRepository
+	boardService     *service.BoardService
 	vcsProvider      service.VCSProvider
 	repoPathResolver service.RepoPathResolver
+	sessionLauncher  service.SessionLauncher
 }
 
 // NewCheckoutTaskUseCase creates a new CheckoutTaskUseCase
 func NewCheckoutTaskUseCase(
 	boardRepo repository.BoardRepository,
+	boardService *service.BoardService,
 	vcsProvider service.VCSProvider,
 	repoPathResolver service.RepoPathResolver,
+	sessionLauncher service.SessionLauncher,
 ) *CheckoutTaskUseCase {
 	return &CheckoutTaskUseCase{
 		boardRepo:        boardRepo,
+		boardService:     boardService,
 		vcsProvider:      vcsProvider,
 		repoPathResolver: repoPathResolver,
+		sessionLauncher:  sessionLauncher,
 	}
 }
 
@@ -46,7 +52,7 @@ func (uc *CheckoutTaskUseCase) Execute(
 	}
 
 	// Find task by ID (supports short ID like "REC-007" or full ID)
-	task, currentColumn, err := uc.findTaskByID(board, taskIDStr)
+	task, _, err := uc.findTaskByID(board, taskIDStr)
 	if err != nil {
 		return fmt.Errorf("failed to find task: %w", err)
 	}
@@ -57,18 +63,7 @@ func (uc *CheckoutTaskUseCase) Execute(
 		return fmt.Errorf("failed to get repository path: %w (ensure tmux session is active)", err)
 	}
 
-	// Determine branch name
-	var branchName string
-	if gitBranch, hasBranch := task.GetMetadata("git_branch"); hasBranch {
-		// Task already has a branch, use it
-		branchName = gitBranch
-	} else {
-		// Create new branch name using format
-		branchName = FormatBranchName(branchFormat, task.ID(), task.Title())
-
-		// Set metadata for future reference
-		task.SetMetadata("git_branch", branchName)
-	}
+	branchName := taskBranchName(task, branchFormat)
 
 	// Check if branch exists
 	branchExists, err := uc.vcsProvider.BranchExists(repoRoot, branchName)
@@ -89,12 +84,58 @@ func (uc *CheckoutTaskUseCase) Execute(
 		fmt.Printf("Created and checked out new branch: %s\n", branchName)
 	}
 
+	if err := uc.startTask(ctx, board, task, true); err != nil {
+		return err
+	}
+
+	// Update task metadata
+	task.SetMetadata("is_current_branch", "true")
+
+	// Save board
+	if err := uc.boardRepo.Save(ctx, board); err != nil {
+		return fmt.Errorf("failed to save board: %w", err)
+	}
+
+	fmt.Printf("Task %s (%s) is now in progress\n", task.ID().ShortID(), task.Title())
+
+	return nil
+}
+
+// startTask moves a task to "In Progress". With onlyTask the tasks already
+// in progress go back to "To Do", as a repository has one checked out branch.
+// Column rules apply to these moves as to moves made by hand; tasks they
+// keep in progress stay there.
+func (uc *CheckoutTaskUseCase) startTask(ctx context.Context, board *entity.Board, task *entity.Task, onlyTask bool) error {
 	// Get columns
 	inProgressColumn, err := board.GetColumn("In Progress")
 	if err != nil {
 		return fmt.Errorf("failed to get In Progress column: %w", err)
 	}
 
+	if onlyTask {
+		if err := uc.demoteInProgress(ctx, board, inProgressColumn, task); err != nil {
+			return err
+		}
+	}
+
+	// Move target task to "In Progress" if not already there
+	// Re-fetch the task's current column after moving other tasks
+	_, currentColumn, err := board.FindTask(task.ID())
+	if err != nil {
+		return fmt.Errorf("failed to find task after moving others: %w", err)
+	}
+
+	if currentColumn.Name() != "In Progress" {
+		if _, err := uc.boardService.MoveTaskOnBoard(ctx, board, task.ID(), "In Progress"); err != nil {
+			return fmt.Errorf("failed to move task to In Progress: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// demoteInProgress moves the in-progress tasks other than task to "To Do"
+func (uc *CheckoutTaskUseCase) demoteInProgress(ctx context.Context, board *entity.Board, inProgressColumn *entity.Column, task *entity.Task) error {
 	todoColumn, err := board.GetColumn("To Do")
 	if err != nil {
 		// Try alternative names
@@ -104,7 +145,7 @@ func (uc *CheckoutTaskUseCase) Execute(
 		}
 	}
 
-	// Step 1: Move all in-progress tasks to todo (except the target task if it's already in progress)
+	// Move all in-progress tasks to todo (except the target task if it's already in progress)
 	tasksToMove := make([]*entity.Task, 0)
 	for _, inProgressTask := range inProgressColumn.Tasks() {
 		if !inProgressTask.ID().Equal(task.ID()) {
@@ -114,39 +155,31 @@ func (uc *CheckoutTaskUseCase) Execute(
 
 	// Actually move the tasks
 	for _, taskToMove := range tasksToMove {
-		// Use board.MoveTask for proper handling
 		if todoColumn.CanAddTask() {
-			if err := board.MoveTask(taskToMove.ID(), todoColumn.Name()); err != nil {
+			if _, err := uc.boardService.MoveTaskOnBoard(ctx, board, taskToMove.ID(), todoColumn.Name()); err != nil {
 				// If can't move, just skip
 				continue
 			}
 		}
 	}
 
-	// Step 2: Move target task to "In Progress" if not already there
-	// Re-fetch the task's current column after moving other tasks
-	_, currentColumn, err = board.FindTask(task.ID())
-	if err != nil {
-		return fmt.Errorf("failed to find task after moving others: %w", err)
-	}
-
-	if currentColumn.Name() != "In Progress" {
-		if err := board.MoveTask(task.ID(), "In Progress"); err != nil {
-			return fmt.Errorf("failed to move task to In Progress: %w", err)
-		}
-	}
-
-	// Update task metadata
-	task.SetMetadata("is_current_branch", "true")
+	return nil
+}
 
-	// Save board
-	if err := uc.boardRepo.Save(ctx, board); err != nil {
-		return fmt.Errorf("failed to save board: %w", err)
+// taskBranchName returns the branch of a task, naming it with branchFormat
+// and recording it on the task if it has none yet
+func taskBranchName(task *entity.Task, branchFormat string) string {
+	if gitBranch, hasBranch := task.GetMetadata("git_branch"); hasBranch {
+		// Task already has a branch, use it
+		return gitBranch
 	}
 
-	fmt.Printf("Task %s (%s) is now in progress\n", task.ID().ShortID(), task.Title())
+	// Create new branch name using format
+	branchName := FormatBranchName(branchFormat, task.ID(), task.Title())
 
-	return nil
+	// Set metadata for future reference
+	task.SetMetadata("git_branch", branchName)
+	return branchName
 }
 
 // findTaskByID finds a task by full ID or short ID (e.g., "REC-007")
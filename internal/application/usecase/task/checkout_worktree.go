@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/service"
+)
+
+// Ways to open a task worktree in tmux
+const (
+	WorktreeTmuxNone    = ""
+	WorktreeTmuxSession = "session"
+	WorktreeTmuxWindow  = "window"
+)
+
+// WorktreeOptions configures checking out a task in a worktree
+type WorktreeOptions struct {
+	Dir  string // directory for worktrees, see service.WorktreePath
+	Tmux string // one of the WorktreeTmux values
+}
+
+// ExecuteWorktree checks out a task's branch in a worktree of its own,
+// reusing the worktree the branch is checked out in if there is one, and
+// moves the task to In Progress. Other tasks stay in progress, since each
+// has its own checkout. The worktree then opens in a tmux session or window
+// as opts says.
+func (uc *CheckoutTaskUseCase) ExecuteWorktree(
+	ctx context.Context,
+	boardID string,
+	taskIDStr string,
+	branchFormat string,
+	opts WorktreeOptions,
+) (*dto.WorktreeCheckoutDTO, error) {
+	switch opts.Tmux {
+	case WorktreeTmuxNone, WorktreeTmuxSession, WorktreeTmuxWindow:
+	default:
+		return nil, fmt.Errorf("unknown tmux mode %q, expected %q or %q", opts.Tmux, WorktreeTmuxSession, WorktreeTmuxWindow)
+	}
+
+	board, err := uc.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load board: %w", err)
+	}
+
+	task, _, err := uc.findTaskByID(board, taskIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	repoPath, err := uc.repoPathResolver.GetRepoPathForBoard(board)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository path: %w (ensure tmux session is active)", err)
+	}
+
+	// Worktrees belong to the main repository, wherever we are now
+	repoRoot, err := uc.vcsProvider.GetMainRepositoryRoot(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	branchName := taskBranchName(task, branchFormat)
+	result := &dto.WorktreeCheckoutDTO{
+		TaskID: task.ID().String(),
+		Branch: branchName,
+	}
+
+	worktrees, err := uc.vcsProvider.ListWorktrees(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if path, ok := worktrees[branchName]; ok {
+		result.Path = path
+	} else {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		result.Path = service.WorktreePath(opts.Dir, repoRoot, branchName, home)
+
+		branchExists, err := uc.vcsProvider.BranchExists(repoRoot, branchName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if branch exists: %w", err)
+		}
+		if err := uc.vcsProvider.AddWorktree(repoRoot, result.Path, branchName, !branchExists); err != nil {
+			return nil, err
+		}
+		result.Created = true
+	}
+
+	if err := uc.startTask(ctx, board, task, false); err != nil {
+		return nil, err
+	}
+	task.SetMetadata("git_worktree", result.Path)
+	task.SetMetadata("is_current_branch", "true")
+
+	if err := uc.boardRepo.Save(ctx, board); err != nil {
+		return nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	// The worktree is ready, so a failure to open it is returned along with it
+	switch opts.Tmux {
+	case WorktreeTmuxSession:
+		result.Session = service.WorktreeSessionName(repoRoot, task.ID().ShortID())
+		result.Switched, err = uc.sessionLauncher.OpenSession(result.Session, result.Path)
+	case WorktreeTmuxWindow:
+		result.Session = task.ID().ShortID()
+		err = uc.sessionLauncher.OpenWindow(result.Session, result.Path)
+		result.Switched = err == nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("worktree is at %s but tmux failed: %w", result.Path, err)
+	}
+
+	return result, nil
+}
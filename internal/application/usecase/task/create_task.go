@@ -28,27 +28,17 @@ func (uc *CreateTaskUseCase) Execute(ctx context.Context, boardID string, req dt
 	}
 
 	// Create task
-	_, task, err := uc.boardService.CreateTask(
-		ctx,
-		boardID,
-		req.ColumnName,
-		req.Title,
-		req.Description,
-		priority,
-	)
+	_, task, err := uc.boardService.CreateTask(ctx, boardID, req.ColumnName, service.NewTaskFields{
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    priority,
+		DueDate:     req.DueDate,
+		Tags:        req.Tags,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Set optional fields
-	if req.DueDate != nil {
-		_ = task.SetDueDate(*req.DueDate)
-	}
-
-	for _, tag := range req.Tags {
-		task.AddTag(tag)
-	}
-
 	taskDTO := dto.TaskToDTO(task)
 	return &taskDTO, nil
 }
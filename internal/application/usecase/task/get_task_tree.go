@@ -0,0 +1,51 @@
+package task
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// GetTaskTreeUseCase handles showing a task with its subtasks from all boards
+type GetTaskTreeUseCase struct {
+	boardService *service.BoardService
+	timeLogRepo  repository.TimeLogRepository
+}
+
+// NewGetTaskTreeUseCase creates a new GetTaskTreeUseCase
+func NewGetTaskTreeUseCase(boardService *service.BoardService, timeLogRepo repository.TimeLogRepository) *GetTaskTreeUseCase {
+	return &GetTaskTreeUseCase{
+		boardService: boardService,
+		timeLogRepo:  timeLogRepo,
+	}
+}
+
+// Execute returns the tree of subtasks under a task, with the progress of
+// each task and its estimated and tracked time rolled up from its subtasks
+func (uc *GetTaskTreeUseCase) Execute(ctx context.Context, boardID string, taskIDStr string) (*dto.TaskTreeDTO, error) {
+	taskID, err := valueobject.ParseTaskID(taskIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := uc.boardService.GetTaskTree(ctx, boardID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Time is tracked in time logs as well as on the tasks
+	tree.Walk(func(node *service.TaskTree) {
+		logs, err := uc.timeLogRepo.FindByTask(ctx, node.Task.ID())
+		if err != nil {
+			return
+		}
+		for _, log := range logs {
+			node.Tracked += log.Duration()
+		}
+	})
+
+	treeDTO := dto.TaskTreeToDTO(tree)
+	return &treeDTO, nil
+}
@@ -0,0 +1,238 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// commitScanLimit bounds the commits read per scan, which matters on the
+// first scan of a repository with a long history
+const commitScanLimit = 200
+
+// LinkCommitsUseCase attaches the new commits of a repository to the tasks
+// of its project: commits that mention a task ID in their message and
+// commits made on a task's branch. A commit that closes a task, as in
+// "fixes PRO-12", advances it to the next column.
+type LinkCommitsUseCase struct {
+	boardRepo    repository.BoardRepository
+	boardService *service.BoardService
+	vcsProvider  service.VCSProvider
+}
+
+// NewLinkCommitsUseCase creates a new LinkCommitsUseCase
+func NewLinkCommitsUseCase(
+	boardRepo repository.BoardRepository,
+	boardService *service.BoardService,
+	vcsProvider service.VCSProvider,
+) *LinkCommitsUseCase {
+	return &LinkCommitsUseCase{
+		boardRepo:    boardRepo,
+		boardService: boardService,
+		vcsProvider:  vcsProvider,
+	}
+}
+
+// commitLink is a task a scanned commit belongs to
+type commitLink struct {
+	commit service.Commit
+	board  *entity.Board
+	task   *entity.Task
+	closes bool
+}
+
+// Execute scans the branches of the repository at repoPath that moved since
+// previousHeads, the branch heads returned by the previous scan, and links
+// their new commits to the tasks on the boards of projectID. Without
+// previous heads it scans the latest commits of every branch. Commits
+// already in a task's activity are skipped, so rescanning is harmless.
+func (uc *LinkCommitsUseCase) Execute(
+	ctx context.Context,
+	repoPath string,
+	projectID string,
+	previousHeads map[string]string,
+	closeKeywords []string,
+) (map[string]string, []dto.CommitLinkDTO, error) {
+	heads, err := uc.vcsProvider.ListBranchHeads(repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var moved []string
+	for branch, head := range heads {
+		if previousHeads[branch] != head {
+			moved = append(moved, branch)
+		}
+	}
+	if len(moved) == 0 {
+		return heads, nil, nil
+	}
+	sort.Strings(moved)
+
+	boards, err := uc.projectBoards(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var seen []string
+	for _, head := range previousHeads {
+		seen = append(seen, head)
+	}
+
+	var links []*commitLink
+	index := make(map[string]*commitLink)
+	addLink := func(commit service.Commit, board *entity.Board, task *entity.Task, closes bool) {
+		// A commit on a task's branch may mention the task as well
+		key := commit.Hash + " " + board.ID() + " " + task.ID().String()
+		if link, ok := index[key]; ok {
+			link.closes = link.closes || closes
+			return
+		}
+		index[key] = &commitLink{commit: commit, board: board, task: task, closes: closes}
+		links = append(links, index[key])
+	}
+
+	// Commits on task branches, leaving out those other branches have too
+	for _, branch := range moved {
+		board, task := service.FindBranchTask(boards, branch)
+		if task == nil {
+			continue
+		}
+		excluded := append([]string(nil), seen...)
+		for other, head := range heads {
+			if other != branch {
+				excluded = append(excluded, head)
+			}
+		}
+		commits, err := uc.vcsProvider.ListCommits(repoPath, []string{heads[branch]}, excluded, commitScanLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, commit := range commits {
+			addLink(commit, board, task, false)
+		}
+	}
+
+	// Commits mentioning tasks, on any branch
+	included := make([]string, 0, len(moved))
+	for _, branch := range moved {
+		included = append(included, heads[branch])
+	}
+	commits, err := uc.vcsProvider.ListCommits(repoPath, included, seen, commitScanLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, commit := range commits {
+		for _, ref := range service.ParseTaskReferences(commit.Subject+"\n"+commit.Body, closeKeywords) {
+			if board, task := service.FindReferencedTask(boards, ref); task != nil {
+				addLink(commit, board, task, ref.Closes)
+			}
+		}
+	}
+
+	// Oldest first, so that closing commits advance tasks in order
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].commit.Time.Before(links[j].commit.Time)
+	})
+
+	var result []dto.CommitLinkDTO
+	var changed []*entity.Board
+	var closing []int
+	for _, link := range links {
+		if !link.task.AddActivity(service.CommitActivity(link.commit)) {
+			continue
+		}
+		if findBoard(changed, link.board.ID()) == nil {
+			changed = append(changed, link.board)
+		}
+		result = append(result, dto.CommitLinkDTO{
+			BoardID: link.board.ID(),
+			TaskID:  link.task.ID().String(),
+			Hash:    link.commit.Hash,
+			Subject: link.commit.Subject,
+		})
+		if link.closes {
+			closing = append(closing, len(result)-1)
+		}
+	}
+
+	for _, board := range changed {
+		if err := uc.boardRepo.Save(ctx, board); err != nil {
+			return nil, nil, fmt.Errorf("failed to save board: %w", err)
+		}
+	}
+
+	for _, i := range closing {
+		result[i].AdvancedTo, err = uc.advance(ctx, result[i])
+		if err != nil {
+			result[i].Error = err.Error()
+		}
+	}
+
+	return heads, result, nil
+}
+
+// advance moves the task of a closing commit to the column after its own,
+// returning the column's name, or "" if the task is in the last column
+func (uc *LinkCommitsUseCase) advance(ctx context.Context, link dto.CommitLinkDTO) (string, error) {
+	board, err := uc.boardRepo.FindByID(ctx, link.BoardID)
+	if err != nil {
+		return "", err
+	}
+	taskID, err := valueobject.ParseTaskID(link.TaskID)
+	if err != nil {
+		return "", err
+	}
+	task, column, err := board.FindTask(taskID)
+	if err != nil {
+		return "", err
+	}
+
+	columns := board.Columns()
+	for i, c := range columns {
+		if c.Name() != column.Name() {
+			continue
+		}
+		if i+1 == len(columns) {
+			return "", nil
+		}
+		next := columns[i+1].Name()
+		if _, _, err := uc.boardService.MoveTask(ctx, board.ID(), task.ID(), next); err != nil {
+			return "", err
+		}
+		return next, nil
+	}
+	return "", nil
+}
+
+// projectBoards loads the boards of a project
+func (uc *LinkCommitsUseCase) projectBoards(ctx context.Context, projectID string) ([]*entity.Board, error) {
+	all, err := uc.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load boards: %w", err)
+	}
+
+	var boards []*entity.Board
+	for _, board := range all {
+		if board.ProjectID() == projectID {
+			boards = append(boards, board)
+		}
+	}
+	return boards, nil
+}
+
+// findBoard returns the board with the given ID, or nil
+func findBoard(boards []*entity.Board, boardID string) *entity.Board {
+	for _, board := range boards {
+		if board.ID() == boardID {
+			return board
+		}
+	}
+	return nil
+}
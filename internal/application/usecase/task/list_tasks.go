@@ -38,10 +38,14 @@ func (uc *ListTasksUseCase) Execute(ctx context.Context, boardID string) ([]dto.
 	// Iterate through all columns and their tasks
 	for _, column := range board.Columns() {
 		for _, task := range column.Tasks() {
-			taskFolderName := task.ID().String()
-			filePath, err := buildTaskFilePath(dataPath, boardID, column.Name(), taskFolderName)
-			if err != nil {
-				return nil, err
+			// Tasks only have files in the filesystem layout
+			filePath := ""
+			if uc.config.Storage.Backend != config.StorageBackendSQLite {
+				taskFolderName := task.ID().String()
+				filePath, err = buildTaskFilePath(dataPath, boardID, column.Name(), taskFolderName)
+				if err != nil {
+					return nil, err
+				}
 			}
 
 			// Convert to DTO with path and column name
@@ -19,20 +19,22 @@ func NewMoveTaskUseCase(boardService *service.BoardService) *MoveTaskUseCase {
 	}
 }
 
-// Execute moves a task to a different column
-func (uc *MoveTaskUseCase) Execute(ctx context.Context, boardID string, req dto.MoveTaskRequest) (*dto.BoardDTO, error) {
+// Execute moves a task to a different column. It returns the violated
+// warning rules of the columns involved; violated blocking rules refuse the
+// move with an *entity.ColumnRuleError.
+func (uc *MoveTaskUseCase) Execute(ctx context.Context, boardID string, req dto.MoveTaskRequest) (*dto.BoardDTO, []dto.RuleViolationDTO, error) {
 	// Parse task ID
 	taskID, err := valueobject.ParseTaskID(req.TaskID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Move task
-	board, err := uc.boardService.MoveTask(ctx, boardID, taskID, req.TargetColumnName)
+	board, warnings, err := uc.boardService.MoveTask(ctx, boardID, taskID, req.TargetColumnName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	boardDTO := dto.BoardToDTO(board)
-	return &boardDTO, nil
+	return &boardDTO, dto.RuleViolationsToDTO(warnings), nil
 }
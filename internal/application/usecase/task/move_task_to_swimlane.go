@@ -0,0 +1,43 @@
+package task
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// MoveTaskToSwimlaneUseCase handles moving tasks between swimlanes
+type MoveTaskToSwimlaneUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewMoveTaskToSwimlaneUseCase creates a new MoveTaskToSwimlaneUseCase
+func NewMoveTaskToSwimlaneUseCase(boardService *service.BoardService) *MoveTaskToSwimlaneUseCase {
+	return &MoveTaskToSwimlaneUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute moves a task into another lane by updating the field the lanes
+// group by, and returns the updated board
+func (uc *MoveTaskToSwimlaneUseCase) Execute(ctx context.Context, boardID string, req dto.MoveTaskToSwimlaneRequest) (*dto.BoardDTO, error) {
+	taskID, err := valueobject.ParseTaskID(req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	swimlanes, err := entity.ParseSwimlanes(req.Swimlanes)
+	if err != nil {
+		return nil, err
+	}
+
+	board, _, err := uc.boardService.MoveTaskToSwimlane(ctx, boardID, taskID, swimlanes, req.Lane)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDTO := dto.BoardToDTO(board)
+	return &boardDTO, nil
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"context"
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// SetTaskParentUseCase handles making tasks subtasks of an epic
+type SetTaskParentUseCase struct {
+	boardService *service.BoardService
+}
+
+// NewSetTaskParentUseCase creates a new SetTaskParentUseCase
+func NewSetTaskParentUseCase(boardService *service.BoardService) *SetTaskParentUseCase {
+	return &SetTaskParentUseCase{
+		boardService: boardService,
+	}
+}
+
+// Execute makes a task a subtask of the parent in the request, possibly on
+// another board, or detaches it from its parent for an empty parent ID
+func (uc *SetTaskParentUseCase) Execute(ctx context.Context, boardID string, req dto.SetTaskParentRequest) (*dto.TaskDTO, error) {
+	taskID, err := valueobject.ParseTaskID(req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *valueobject.TaskID
+	if req.ParentID != "" {
+		parentID, err = valueobject.ParseTaskID(req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, task, err := uc.boardService.SetTaskParent(ctx, boardID, taskID, req.ParentBoardID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskDTO := dto.TaskToDTO(task)
+	return &taskDTO, nil
+}
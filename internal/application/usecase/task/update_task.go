@@ -46,24 +46,19 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, boardID string, taskID
 	}
 
 	// Update task
-	_, task, err := uc.boardService.UpdateTask(
-		ctx,
-		boardID,
-		taskID,
-		req.Title,
-		req.Description,
-		priority,
-		status,
-	)
+	_, task, err := uc.boardService.UpdateTask(ctx, boardID, taskID, service.TaskUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    priority,
+		Status:      status,
+		DueDate:     req.DueDate,
+		Estimate:    req.Estimate,
+		Tags:        req.Tags,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update optional fields
-	if req.DueDate != nil {
-		_ = task.SetDueDate(*req.DueDate)
-	}
-
 	taskDTO := dto.TaskToDTO(task)
 	return &taskDTO, nil
 }
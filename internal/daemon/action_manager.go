@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"mkanban/internal/application/usecase/action"
+	"mkanban/internal/application/usecase/task"
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/repository"
 	"mkanban/internal/domain/valueobject"
@@ -19,6 +20,7 @@ type ActionManager struct {
 	evaluateUseCase     *action.EvaluateActionsUseCase
 	executeUseCase      *action.ExecuteActionUseCase
 	processEventUseCase *action.ProcessEventUseCase
+	checkAgingUseCase   *task.CheckAgingUseCase
 	actionRepo          repository.ActionRepository
 	eventBus            entity.EventBus
 
@@ -33,6 +35,7 @@ func NewActionManager(
 	evaluateUseCase *action.EvaluateActionsUseCase,
 	executeUseCase *action.ExecuteActionUseCase,
 	processEventUseCase *action.ProcessEventUseCase,
+	checkAgingUseCase *task.CheckAgingUseCase,
 	actionRepo repository.ActionRepository,
 	eventBus entity.EventBus,
 ) *ActionManager {
@@ -43,6 +46,7 @@ func NewActionManager(
 		evaluateUseCase:     evaluateUseCase,
 		executeUseCase:      executeUseCase,
 		processEventUseCase: processEventUseCase,
+		checkAgingUseCase:   checkAgingUseCase,
 		actionRepo:          actionRepo,
 		eventBus:            eventBus,
 		ctx:                 ctx,
@@ -91,67 +95,58 @@ func (m *ActionManager) runScheduler() {
 
 	fmt.Printf("Time-based scheduler started (checking every %v)\n", checkInterval)
 
+	// Catch up on schedules that came due while the daemon wasn't running
+	m.checkAging()
+	m.checkTimeBasedActions()
+
 	for {
 		select {
 		case <-m.ctx.Done():
 			return
 		case <-ticker.C:
+			m.checkAging()
 			m.checkTimeBasedActions()
 		}
 	}
 }
 
+// checkAging publishes aging events for tasks that have been in their column
+// longer than its aging policy allows
+func (m *ActionManager) checkAging() {
+	if m.checkAgingUseCase == nil {
+		return
+	}
+
+	events, err := m.checkAgingUseCase.Execute(context.Background(), time.Now())
+	if err != nil {
+		fmt.Printf("Failed to check task aging: %v\n", err)
+	}
+	for _, event := range events {
+		fmt.Printf("Task %s is %s in column %s\n", event.TaskID, event.Metadata["level"], event.ColumnID)
+	}
+}
+
 // checkTimeBasedActions checks and executes time-based actions
 func (m *ActionManager) checkTimeBasedActions() {
 	ctx := context.Background()
 
-	// Get all time-based actions
-	timeTriggerType := entity.TriggerTypeTime
-	actions, err := m.actionRepo.ListByTriggerType(ctx, timeTriggerType)
+	results, err := m.evaluateUseCase.EvaluateTimeTriggers(ctx, time.Now())
 	if err != nil {
-		fmt.Printf("Failed to list time-based actions: %v\n", err)
+		fmt.Printf("Failed to evaluate time-based actions: %v\n", err)
 		return
 	}
 
-	if len(actions) == 0 {
-		return
-	}
-
-	fmt.Printf("Checking %d time-based actions...\n", len(actions))
-
-	// Evaluate each action
-	for _, act := range actions {
-		if !act.Enabled() {
-			continue
-		}
-
-		// Build evaluation context
-		evalCtx := action.EvaluationContext{
-			CurrentTime: time.Now(),
-			// TODO: For time-based actions, we might need to iterate over all tasks
-			// or have a way to identify which tasks to check
-		}
-
-		// For now, we'll evaluate without specific task context
-		// This works for global time-based actions
-		results, err := m.evaluateUseCase.Execute(ctx, evalCtx)
-		if err != nil {
-			fmt.Printf("Failed to evaluate action %s: %v\n", act.Name(), err)
-			continue
+	// Execute triggered actions
+	for _, result := range results {
+		execReq := action.ExecutionRequest{
+			Action:         result.Action,
+			TriggerContext: result.Context,
 		}
 
-		// Execute triggered actions
-		for _, result := range results {
-			execReq := action.ExecutionRequest{
-				Action:         result.Action,
-				TriggerContext: result.Context,
-			}
-
-			if err := m.executeUseCase.Execute(ctx, execReq); err != nil {
-				fmt.Printf("Failed to execute action %s: %v\n", result.Action.Name(), err)
-			} else {
-				fmt.Printf("Executed action: %s\n", result.Action.Name())
-			}
+		if err := m.executeUseCase.Execute(ctx, execReq); err != nil {
+			fmt.Printf("Failed to execute action %s: %v\n", result.Action.Name(), err)
+		} else {
+			fmt.Printf("Executed action: %s\n", result.Action.Name())
 		}
 	}
 }
@@ -193,6 +188,8 @@ func (m *ActionManager) subscribeToEvents() {
 	m.eventBus.Subscribe("task.due_date_set", handler)
 	m.eventBus.Subscribe("task.due_date_changed", handler)
 	m.eventBus.Subscribe("task.completed", handler)
+	m.eventBus.Subscribe("task.stale", handler)
+	m.eventBus.Subscribe("task.escalated", handler)
 	m.eventBus.Subscribe("column.created", handler)
 	m.eventBus.Subscribe("column.deleted", handler)
 	m.eventBus.Subscribe("column.wip_reached", handler)
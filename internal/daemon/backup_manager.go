@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+)
+
+// BackupManager takes a snapshot of the data directory on an interval and
+// prunes the ones the retention rules no longer keep. Mutations are held
+// off while a snapshot is taken, so none is caught half written.
+type BackupManager struct {
+	config      *config.Config
+	snapshotter service.DataSnapshotter
+	mutations   sync.Locker
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewBackupManager creates a new BackupManager. mutations is the lock the
+// daemon holds while changing data.
+func NewBackupManager(
+	config *config.Config,
+	snapshotter service.DataSnapshotter,
+	mutations sync.Locker,
+) *BackupManager {
+	return &BackupManager{
+		config:      config,
+		snapshotter: snapshotter,
+		mutations:   mutations,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the snapshot loop
+func (bm *BackupManager) Start(ctx context.Context) error {
+	if _, err := bm.snapshotter.List(ctx); err != nil {
+		return fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	fmt.Println("[BackupManager] Starting snapshots")
+	go bm.snapshotLoop(ctx)
+
+	return nil
+}
+
+// Stop stops the snapshot loop
+func (bm *BackupManager) Stop() error {
+	bm.stopOnce.Do(func() {
+		close(bm.stopChan)
+	})
+	return nil
+}
+
+// snapshot takes a snapshot of the kind given while holding off mutations
+func (bm *BackupManager) snapshot(ctx context.Context, kind string) (*service.DataSnapshot, error) {
+	bm.mutations.Lock()
+	defer bm.mutations.Unlock()
+
+	return bm.snapshotter.Create(ctx, kind)
+}
+
+func (bm *BackupManager) interval() time.Duration {
+	interval := time.Duration(bm.config.Backup.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return interval
+}
+
+func (bm *BackupManager) snapshotLoop(ctx context.Context) {
+	interval := bm.interval()
+
+	// The first snapshot is due an interval after the newest one kept, which
+	// is right away after a long enough downtime
+	wait := time.Duration(0)
+	if snapshots, err := bm.snapshotter.List(ctx); err == nil && len(snapshots) > 0 {
+		wait = time.Until(snapshots[0].CreatedAt.Add(interval))
+		if wait < 0 {
+			wait = 0
+		}
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			bm.scheduledSnapshot(ctx)
+			timer.Reset(interval)
+		case <-bm.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (bm *BackupManager) scheduledSnapshot(ctx context.Context) {
+	if _, err := bm.snapshot(ctx, service.SnapshotScheduled); err != nil {
+		fmt.Printf("[BackupManager] Snapshot failed: %v\n", err)
+		return
+	}
+
+	pruned, err := bm.snapshotter.Prune(ctx, time.Now())
+	if err != nil {
+		fmt.Printf("[BackupManager] Pruning snapshots failed: %v\n", err)
+	}
+	if len(pruned) > 0 {
+		fmt.Printf("[BackupManager] Pruned %d snapshots\n", len(pruned))
+	}
+}
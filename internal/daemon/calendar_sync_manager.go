@@ -0,0 +1,149 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/external"
+	"mkanban/internal/infrastructure/service"
+)
+
+// CalendarSyncListener receives the outcome of every automatic calendar sync
+type CalendarSyncListener func(result dto.CalendarSyncDTO, err error)
+
+// CalendarSyncManager runs Google Calendar synchronisation on an interval.
+// Mutations are held off while it syncs, as pulling events writes tasks.
+type CalendarSyncManager struct {
+	config      *config.Config
+	boardRepo   repository.BoardRepository
+	projectRepo repository.ProjectRepository
+	mutations   sync.Locker
+	listener    CalendarSyncListener
+	syncService *service.CalendarSyncService
+	mu          sync.Mutex
+	stopChan    chan struct{}
+	stopped     bool
+}
+
+// NewCalendarSyncManager creates a new CalendarSyncManager. mutations is
+// the lock the daemon holds while changing data.
+func NewCalendarSyncManager(
+	config *config.Config,
+	boardRepo repository.BoardRepository,
+	projectRepo repository.ProjectRepository,
+	mutations sync.Locker,
+	listener CalendarSyncListener,
+) *CalendarSyncManager {
+	return &CalendarSyncManager{
+		config:      config,
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		mutations:   mutations,
+		listener:    listener,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start connects to the calendar and begins the sync loop
+func (cm *CalendarSyncManager) Start(ctx context.Context) error {
+	cfg := cm.config.Calendar
+
+	client, err := external.NewGoogleCalendarClient(cfg.CredentialsPath, cfg.TokenPath, cfg.CalendarID)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar client: %w", err)
+	}
+
+	if !client.IsAuthenticated() {
+		fmt.Println("[CalendarSyncManager] Calendar not authenticated, run 'magenda calendar auth'")
+		return nil
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to calendar: %w", err)
+	}
+
+	cm.syncService = service.NewCalendarSyncService(client, cm.boardRepo, cm.projectRepo)
+	if cfg.ConflictPolicy != "" {
+		cm.syncService.SetConflictPolicy(service.ConflictPolicy(cfg.ConflictPolicy))
+	}
+
+	fmt.Println("[CalendarSyncManager] Starting calendar auto-sync")
+	go cm.syncLoop(ctx)
+
+	return nil
+}
+
+// Stop stops the sync loop
+func (cm *CalendarSyncManager) Stop() error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.stopped {
+		return nil
+	}
+
+	cm.stopped = true
+	close(cm.stopChan)
+	return nil
+}
+
+func (cm *CalendarSyncManager) syncLoop(ctx context.Context) {
+	interval := time.Duration(cm.config.Calendar.SyncInterval) * time.Second
+	if interval == 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	cm.sync(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			cm.sync(ctx)
+		case <-cm.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (cm *CalendarSyncManager) sync(ctx context.Context) {
+	cm.mutations.Lock()
+	result, err := cm.syncService.SyncAll(ctx)
+	cm.mutations.Unlock()
+
+	if err != nil {
+		fmt.Printf("[CalendarSyncManager] Sync failed: %v\n", err)
+		cm.listener(dto.CalendarSyncDTO{SyncedAt: time.Now(), Errors: []string{err.Error()}}, err)
+		return
+	}
+
+	cm.listener(syncResultToDTO(result), nil)
+}
+
+// syncResultToDTO converts a SyncResult into its notification payload
+func syncResultToDTO(result *service.SyncResult) dto.CalendarSyncDTO {
+	syncDTO := dto.CalendarSyncDTO{
+		EventsCreated: result.EventsCreated,
+		EventsUpdated: result.EventsUpdated,
+		EventsDeleted: result.EventsDeleted,
+		TasksCreated:  result.TasksCreated,
+		TasksUpdated:  result.TasksUpdated,
+		Conflicts:     len(result.Conflicts),
+		SyncedAt:      result.LastSyncTime,
+	}
+	for _, err := range result.Errors {
+		syncDTO.Errors = append(syncDTO.Errors, err.Error())
+	}
+	if syncDTO.SyncedAt.IsZero() {
+		syncDTO.SyncedAt = time.Now()
+	}
+	return syncDTO
+}
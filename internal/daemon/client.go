@@ -126,6 +126,12 @@ func (c *Client) Close() error {
 
 // sendRequest sends a request to the daemon and returns the response
 func (c *Client) sendRequest(req *Request) (*Response, error) {
+	return c.sendRequestWithTimeout(req, 5*time.Second)
+}
+
+// sendRequestWithTimeout sends a request, waiting up to timeout for the
+// response
+func (c *Client) sendRequestWithTimeout(req *Request, timeout time.Duration) (*Response, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -145,7 +151,7 @@ func (c *Client) sendRequest(req *Request) (*Response, error) {
 	}
 
 	// Set read deadline
-	if err := c.conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+	if err := c.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, fmt.Errorf("failed to set read deadline: %w", err)
 	}
 
@@ -157,7 +163,7 @@ func (c *Client) sendRequest(req *Request) (*Response, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("daemon error: %s", resp.Error)
+		return nil, &ResponseError{Message: resp.Error, Violations: resp.Violations}
 	}
 
 	return &resp, nil
@@ -323,8 +329,10 @@ func (c *Client) CreateTask(ctx context.Context, boardID string, taskReq dto.Cre
 	return &task, nil
 }
 
-// MoveTask moves a task to a different column
-func (c *Client) MoveTask(ctx context.Context, boardID, taskID, targetColumn string) (*dto.BoardDTO, error) {
+// MoveTask moves a task to a different column. It returns the column rules
+// the move broke as warnings; a move refused by column rules fails with a
+// *ResponseError listing them.
+func (c *Client) MoveTask(ctx context.Context, boardID, taskID, targetColumn string) (*dto.BoardDTO, []dto.RuleViolationDTO, error) {
 	req := &Request{
 		Type: RequestMoveTask,
 		Payload: MoveTaskPayload{
@@ -334,6 +342,40 @@ func (c *Client) MoveTask(ctx context.Context, boardID, taskID, targetColumn str
 		},
 	}
 
+	resp, err := c.sendRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Decode board from response data
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal board data: %w", err)
+	}
+
+	var board dto.BoardDTO
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal board: %w", err)
+	}
+
+	return &board, resp.Violations, nil
+}
+
+// MoveTaskToLane moves a task into another swimlane of the board, or of
+// swimlanes if given
+func (c *Client) MoveTaskToLane(ctx context.Context, boardID, taskID, swimlanes, lane string) (*dto.BoardDTO, error) {
+	req := &Request{
+		Type: RequestMoveTaskToLane,
+		Payload: MoveTaskToLanePayload{
+			BoardID: boardID,
+			LaneRequest: dto.MoveTaskToSwimlaneRequest{
+				TaskID:    taskID,
+				Swimlanes: swimlanes,
+				Lane:      lane,
+			},
+		},
+	}
+
 	resp, err := c.sendRequest(req)
 	if err != nil {
 		return nil, err
@@ -440,6 +482,103 @@ func (c *Client) DeleteColumn(ctx context.Context, boardID, columnName string) e
 	return err
 }
 
+// ReportShell tells the daemon a shell with the given pid is at its prompt
+// in dir
+func (c *Client) ReportShell(pid int, dir string) error {
+	req := &Request{
+		Type: RequestReportShell,
+		Payload: ReportShellPayload{
+			PID: pid,
+			Dir: dir,
+		},
+	}
+
+	_, err := c.sendRequest(req)
+	return err
+}
+
+// SyncData has the daemon sync the data directory with its remote, holding
+// off changes while it runs
+func (c *Client) SyncData(ctx context.Context) (*dto.DataSyncDTO, error) {
+	req := &Request{
+		Type: RequestSyncData,
+	}
+
+	// Pulling and pushing take as long as the network does
+	resp, err := c.sendRequestWithTimeout(req, 2*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync data: %w", err)
+	}
+
+	var result dto.DataSyncDTO
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CreateSnapshot has the daemon take a manual snapshot of the data
+// directory, holding off changes while it is taken
+func (c *Client) CreateSnapshot(ctx context.Context) (*dto.DataSnapshotDTO, error) {
+	req := &Request{
+		Type: RequestCreateSnapshot,
+	}
+
+	// Archiving takes as long as the data directory is large
+	resp, err := c.sendRequestWithTimeout(req, 2*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot data: %w", err)
+	}
+
+	var snapshot dto.DataSnapshotDTO
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// RestoreSnapshot has the daemon restore the data directory, or the board
+// or project given, from a snapshot, holding off changes while it runs
+func (c *Client) RestoreSnapshot(ctx context.Context, snapshotID, projectSlug, boardID string) (*dto.SnapshotRestoreDTO, error) {
+	req := &Request{
+		Type: RequestRestoreSnapshot,
+		Payload: RestoreSnapshotPayload{
+			SnapshotID:  snapshotID,
+			ProjectSlug: projectSlug,
+			BoardID:     boardID,
+		},
+	}
+
+	resp, err := c.sendRequestWithTimeout(req, 2*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal restore data: %w", err)
+	}
+
+	var result dto.SnapshotRestoreDTO
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal restore result: %w", err)
+	}
+
+	return &result, nil
+}
+
 // IsHealthy checks if the daemon is healthy
 func (c *Client) IsHealthy() bool {
 	socketPath := GetSocketPath(c.config)
@@ -461,6 +600,12 @@ func (c *Client) IsHealthy() bool {
 
 // Subscribe subscribes to real-time updates for a board
 func (c *Client) Subscribe(boardID string) error {
+	return c.SubscribeWithFilter(SubscribePayload{BoardID: boardID})
+}
+
+// SubscribeWithFilter subscribes to real-time updates matching the filter,
+// e.g. all events of a project or only timer events across every project
+func (c *Client) SubscribeWithFilter(filter SubscribePayload) error {
 	c.subMu.Lock()
 	defer c.subMu.Unlock()
 
@@ -493,10 +638,8 @@ func (c *Client) Subscribe(boardID string) error {
 	decoder := json.NewDecoder(conn)
 
 	req := &Request{
-		Type: RequestSubscribe,
-		Payload: SubscribePayload{
-			BoardID: boardID,
-		},
+		Type:    RequestSubscribe,
+		Payload: filter,
 	}
 
 	if err := encoder.Encode(req); err != nil {
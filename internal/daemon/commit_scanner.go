@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/application/usecase/task"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+)
+
+// CommitLinkListener receives the commits a scan attached to tasks
+type CommitLinkListener func(links []dto.CommitLinkDTO)
+
+// CommitScanner polls the repositories of projects with a working directory
+// for new commits and attaches them to the tasks they belong to
+type CommitScanner struct {
+	config        *config.Config
+	projectRepo   repository.ProjectRepository
+	vcsProvider   service.VCSProvider
+	linkCommitsUC *task.LinkCommitsUseCase
+	mutations     sync.Locker
+	listener      CommitLinkListener
+	heads         map[string]map[string]string // branch heads by project, as of the last scan
+	mu            sync.Mutex
+	stopChan      chan struct{}
+	stopped       bool
+}
+
+// NewCommitScanner creates a new CommitScanner. mutations is the lock the
+// daemon holds while changing data.
+func NewCommitScanner(
+	config *config.Config,
+	projectRepo repository.ProjectRepository,
+	vcsProvider service.VCSProvider,
+	linkCommitsUC *task.LinkCommitsUseCase,
+	mutations sync.Locker,
+	listener CommitLinkListener,
+) *CommitScanner {
+	return &CommitScanner{
+		config:        config,
+		projectRepo:   projectRepo,
+		vcsProvider:   vcsProvider,
+		linkCommitsUC: linkCommitsUC,
+		mutations:     mutations,
+		listener:      listener,
+		heads:         make(map[string]map[string]string),
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start scans once and then keeps polling in the background
+func (cs *CommitScanner) Start(ctx context.Context) error {
+	fmt.Println("[CommitScanner] Scanning project repositories for commits")
+	cs.scan(ctx)
+
+	go cs.pollLoop(ctx)
+	return nil
+}
+
+// Stop stops the polling loop
+func (cs *CommitScanner) Stop() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.stopped {
+		return nil
+	}
+
+	cs.stopped = true
+	close(cs.stopChan)
+	return nil
+}
+
+func (cs *CommitScanner) pollLoop(ctx context.Context) {
+	pollInterval := time.Duration(cs.config.SessionTracking.PollInterval) * time.Second
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cs.scan(ctx)
+		case <-cs.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scan links the new commits of every project repository
+func (cs *CommitScanner) scan(ctx context.Context) {
+	projects, err := cs.projectRepo.FindAll(ctx)
+	if err != nil {
+		fmt.Printf("[CommitScanner] Failed to load projects: %v\n", err)
+		return
+	}
+
+	closeKeywords := cs.config.SessionTracking.GitSync.CloseKeywords
+	if len(closeKeywords) == 0 {
+		closeKeywords = service.DefaultCloseKeywords
+	}
+
+	var links []dto.CommitLinkDTO
+	for _, project := range projects {
+		repoPath := project.WorkingDir()
+		if repoPath == "" || !cs.vcsProvider.IsRepository(repoPath) {
+			continue
+		}
+
+		cs.mutations.Lock()
+		heads, linked, err := cs.linkCommitsUC.Execute(ctx, repoPath, project.ID(), cs.heads[project.ID()], closeKeywords)
+		cs.mutations.Unlock()
+		if err != nil {
+			fmt.Printf("[CommitScanner] Failed to scan %s: %v\n", repoPath, err)
+			continue
+		}
+		cs.heads[project.ID()] = heads
+
+		for _, link := range linked {
+			fmt.Printf("[CommitScanner] Linked commit %.7s to %s\n", link.Hash, link.TaskID)
+			if link.AdvancedTo != "" {
+				fmt.Printf("[CommitScanner] Commit %.7s closes %s, moved it to %s\n", link.Hash, link.TaskID, link.AdvancedTo)
+			}
+			if link.Error != "" {
+				fmt.Printf("[CommitScanner] Commit %.7s closes %s but it can't advance: %s\n", link.Hash, link.TaskID, link.Error)
+			}
+		}
+		links = append(links, linked...)
+	}
+
+	if len(links) > 0 {
+		cs.listener(links)
+	}
+}
@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+)
+
+// DataSyncListener receives the outcome of every sync of the data directory
+type DataSyncListener func(result dto.DataSyncDTO, err error)
+
+// DataSyncManager commits the changes the daemon makes to the data
+// directory shortly after they happen, and syncs it with its remote on an
+// interval or on demand. Mutations are held off while a commit or sync
+// runs, so neither sees a change half written.
+type DataSyncManager struct {
+	config      *config.Config
+	syncer      service.DataSyncer
+	mutations   sync.Locker
+	listener    DataSyncListener
+	mu          sync.Mutex
+	commitTimer *time.Timer
+	stopChan    chan struct{}
+	stopped     bool
+}
+
+// NewDataSyncManager creates a new DataSyncManager. mutations is the lock
+// the daemon holds while changing data.
+func NewDataSyncManager(
+	config *config.Config,
+	syncer service.DataSyncer,
+	mutations sync.Locker,
+	listener DataSyncListener,
+) *DataSyncManager {
+	return &DataSyncManager{
+		config:    config,
+		syncer:    syncer,
+		mutations: mutations,
+		listener:  listener,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start sets the data directory up for syncing and begins the sync loop
+func (dm *DataSyncManager) Start(ctx context.Context) error {
+	if err := dm.syncer.Init(ctx, dm.config.Sync.Remote); err != nil {
+		return fmt.Errorf("failed to set up data sync: %w", err)
+	}
+
+	fmt.Println("[DataSyncManager] Starting data sync")
+	go dm.syncLoop(ctx)
+
+	return nil
+}
+
+// Stop stops the sync loop, committing changes still waiting for their
+// commit
+func (dm *DataSyncManager) Stop() error {
+	dm.mu.Lock()
+	if dm.stopped {
+		dm.mu.Unlock()
+		return nil
+	}
+	dm.stopped = true
+	close(dm.stopChan)
+	pending := dm.commitTimer != nil && dm.commitTimer.Stop()
+	dm.mu.Unlock()
+
+	if pending {
+		dm.commit()
+	}
+	return nil
+}
+
+// Changed schedules a commit of the data directory, pushed back by every
+// further change until the commit delay passes without one
+func (dm *DataSyncManager) Changed() {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	if dm.stopped {
+		return
+	}
+
+	delay := time.Duration(dm.config.Sync.CommitDelay) * time.Second
+	if delay <= 0 {
+		delay = 10 * time.Second
+	}
+	if dm.commitTimer == nil {
+		dm.commitTimer = time.AfterFunc(delay, dm.commit)
+	} else {
+		dm.commitTimer.Reset(delay)
+	}
+}
+
+// SyncNow syncs the data directory with its remote and reports the outcome
+// to the listener
+func (dm *DataSyncManager) SyncNow(ctx context.Context) (dto.DataSyncDTO, error) {
+	dm.mutations.Lock()
+	result, err := dm.syncer.Sync(ctx)
+	dm.mutations.Unlock()
+
+	if err != nil {
+		fmt.Printf("[DataSyncManager] Sync failed: %v\n", err)
+		syncDTO := dto.DataSyncDTO{Error: err.Error(), SyncedAt: time.Now()}
+		dm.listener(syncDTO, err)
+		return syncDTO, err
+	}
+
+	for _, task := range result.Renumbered {
+		fmt.Printf("[DataSyncManager] Renumbered %s to %s on %s, its number was taken elsewhere\n",
+			task.OldID, task.NewID, task.BoardID)
+	}
+
+	syncDTO := dto.DataSyncToDTO(result)
+	dm.listener(syncDTO, nil)
+	return syncDTO, nil
+}
+
+func (dm *DataSyncManager) commit() {
+	dm.mutations.Lock()
+	defer dm.mutations.Unlock()
+
+	if _, err := dm.syncer.Commit(context.Background(), "Save changes"); err != nil {
+		fmt.Printf("[DataSyncManager] Commit failed: %v\n", err)
+	}
+}
+
+func (dm *DataSyncManager) syncLoop(ctx context.Context) {
+	// Without a remote there is nothing to pull or push, only commits
+	if dm.config.Sync.Remote == "" {
+		return
+	}
+
+	interval := time.Duration(dm.config.Sync.Interval) * time.Second
+	if interval == 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	dm.SyncNow(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			dm.SyncNow(ctx)
+		case <-dm.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package daemon
+
+import (
+	"context"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// notifyTimer broadcasts a timer start or stop from the time tracking manager
+func (s *Server) notifyTimer(log *entity.TimeLog, started bool) {
+	logDTO := dto.TimeLogToDTO(log)
+
+	notification := &Notification{
+		Type:      NotificationTimerStarted,
+		ProjectID: logDTO.ProjectID,
+		Data:      logDTO,
+		Diff:      dto.Diff(nil, logDTO),
+	}
+
+	if !started {
+		// The timer was running until now, so only the end of it changed
+		running := logDTO
+		running.EndTime = nil
+		running.Duration = 0
+		running.Running = true
+
+		notification.Type = NotificationTimerStopped
+		notification.Diff = dto.Diff(running, logDTO)
+	}
+
+	s.notifySubscribers(notification)
+}
+
+// notifyActionEvent forwards action.executed, action.failed and
+// action.disabled domain events
+func (s *Server) notifyActionEvent(event *entity.DomainEvent) {
+	action, ok := event.Metadata["action"].(*entity.Action)
+	if !ok {
+		return
+	}
+	actionDTO := dto.ActionToDTO(action)
+
+	notification := &Notification{
+		BoardID:   event.BoardID,
+		Timestamp: event.Timestamp,
+	}
+
+	switch event.Type {
+	case valueobject.EventActionDisabled:
+		notification.Type = NotificationActionDisabled
+		notification.Data = actionDTO
+	default:
+		execution, ok := event.Metadata["execution"].(*entity.ActionExecution)
+		if !ok {
+			return
+		}
+		executionDTO := dto.ActionExecutionToDTO(execution)
+		executionDTO.Action = &actionDTO
+
+		notification.Type = NotificationActionExecuted
+		if !executionDTO.Success {
+			notification.Type = NotificationActionFailed
+		}
+		notification.Data = executionDTO
+	}
+
+	if notification.BoardID == "" && action.Scope() == valueobject.ActionScopeBoard {
+		notification.BoardID = action.ScopeID()
+	}
+
+	s.notifySubscribers(notification)
+}
+
+// notifyNoteChange broadcasts a note change picked up by the note monitor
+func (s *Server) notifyNoteChange(eventType string, before, after *dto.NoteDTO) {
+	notification := &Notification{
+		Type: eventType,
+		Diff: dto.Diff(before, after),
+	}
+
+	if after != nil {
+		notification.Data = after
+		notification.ProjectID = after.ProjectID
+	} else {
+		notification.Data = before
+		notification.ProjectID = before.ProjectID
+	}
+
+	s.notifySubscribers(notification)
+}
+
+// notifyCalendarSync broadcasts the result of an automatic calendar sync
+func (s *Server) notifyCalendarSync(result dto.CalendarSyncDTO, err error) {
+	notificationType := NotificationCalendarSynced
+	if err != nil {
+		notificationType = NotificationCalendarSyncFailed
+	}
+
+	s.notifySubscribers(&Notification{
+		Type:      notificationType,
+		Data:      result,
+		Timestamp: result.SyncedAt,
+	})
+}
+
+// notifyDataSync broadcasts the result of a sync of the data directory.
+// Boards are reloaded by subscribers when changes were pulled, as any of
+// them may have changed.
+func (s *Server) notifyDataSync(result dto.DataSyncDTO, err error) {
+	notificationType := NotificationDataSynced
+	if err != nil {
+		notificationType = NotificationDataSyncFailed
+	}
+
+	s.notifySubscribers(&Notification{
+		Type:      notificationType,
+		Data:      result,
+		Timestamp: result.SyncedAt,
+	})
+	if err != nil || result.Pulled == 0 {
+		return
+	}
+	s.notifyBoardsReloaded(context.Background())
+}
+
+// notifyBoardsReloaded broadcasts every board, for changes to the data
+// directory that may have touched any of them
+func (s *Server) notifyBoardsReloaded(ctx context.Context) {
+	boards, err := s.container.ListBoardsUseCase.Execute(ctx)
+	if err != nil {
+		return
+	}
+	for _, board := range boards {
+		boardDTO, err := s.container.GetBoardUseCase.Execute(ctx, board.ID)
+		if err != nil {
+			continue
+		}
+		s.notifySubscribers(&Notification{
+			Type:    NotificationBoardUpdated,
+			BoardID: board.ID,
+			Data:    boardDTO,
+		})
+	}
+}
+
+// notifyCommitsLinked broadcasts the boards whose tasks the commit scanner
+// attached commits to
+func (s *Server) notifyCommitsLinked(links []dto.CommitLinkDTO) {
+	ctx := context.Background()
+	notified := make(map[string]bool)
+	for _, link := range links {
+		if notified[link.BoardID] {
+			continue
+		}
+		notified[link.BoardID] = true
+
+		boardDTO, err := s.container.GetBoardUseCase.Execute(ctx, link.BoardID)
+		if err != nil {
+			continue
+		}
+		s.notifySubscribers(&Notification{
+			Type:    NotificationBoardUpdated,
+			BoardID: link.BoardID,
+			Data:    boardDTO,
+		})
+	}
+}
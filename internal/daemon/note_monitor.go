@@ -0,0 +1,216 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+)
+
+// NoteChangeListener is called for every note that appeared, changed or
+// disappeared since the previous poll. before is nil for new notes and after
+// is nil for deleted ones.
+type NoteChangeListener func(eventType string, before, after *dto.NoteDTO)
+
+// NoteMonitor polls the note repository and reports changes. Notes are
+// written by the mnotes CLI and editors directly, so polling is the only
+// way the daemon sees them. Notes are only reloaded when a file of the data
+// directory changed since the previous poll.
+type NoteMonitor struct {
+	config      *config.Config
+	noteRepo    repository.NoteRepository
+	projectRepo repository.ProjectRepository
+	listener    NoteChangeListener
+	snapshot    map[string]dto.NoteDTO
+	fingerprint dataFingerprint
+	mu          sync.Mutex
+	stopChan    chan struct{}
+	stopped     bool
+}
+
+// NewNoteMonitor creates a new NoteMonitor
+func NewNoteMonitor(
+	config *config.Config,
+	noteRepo repository.NoteRepository,
+	projectRepo repository.ProjectRepository,
+	listener NoteChangeListener,
+) *NoteMonitor {
+	return &NoteMonitor{
+		config:      config,
+		noteRepo:    noteRepo,
+		projectRepo: projectRepo,
+		listener:    listener,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start takes an initial snapshot and begins polling in the background
+func (nm *NoteMonitor) Start(ctx context.Context) error {
+	nm.fingerprint = nm.fingerprintData()
+	snapshot, err := nm.collect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load notes: %w", err)
+	}
+	nm.snapshot = snapshot
+
+	go nm.pollLoop(ctx)
+	return nil
+}
+
+// Stop stops the polling loop
+func (nm *NoteMonitor) Stop() error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if nm.stopped {
+		return nil
+	}
+
+	nm.stopped = true
+	close(nm.stopChan)
+	return nil
+}
+
+func (nm *NoteMonitor) pollLoop(ctx context.Context) {
+	pollInterval := time.Duration(nm.config.SessionTracking.PollInterval) * time.Second
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			nm.poll(ctx)
+		case <-nm.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll compares the current notes against the last snapshot
+func (nm *NoteMonitor) poll(ctx context.Context) {
+	fingerprint := nm.fingerprintData()
+	if fingerprint == nm.fingerprint {
+		return
+	}
+	nm.fingerprint = fingerprint
+
+	current, err := nm.collect(ctx)
+	if err != nil {
+		fmt.Printf("[NoteMonitor] Failed to load notes: %v\n", err)
+		return
+	}
+
+	for key, after := range current {
+		after := after
+		before, existed := nm.snapshot[key]
+		switch {
+		case !existed:
+			nm.listener(NotificationNoteCreated, nil, &after)
+		case !reflect.DeepEqual(before, after):
+			nm.listener(NotificationNoteUpdated, &before, &after)
+		}
+	}
+	for key, before := range nm.snapshot {
+		before := before
+		if _, exists := current[key]; !exists {
+			nm.listener(NotificationNoteDeleted, &before, nil)
+		}
+	}
+
+	nm.snapshot = current
+}
+
+// collect loads global notes and the notes of every project
+func (nm *NoteMonitor) collect(ctx context.Context) (map[string]dto.NoteDTO, error) {
+	notes := make(map[string]dto.NoteDTO)
+
+	global, err := nm.noteRepo.FindGlobal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range global {
+		notes["/"+note.ID()] = dto.NoteToDTO(note)
+	}
+
+	projects, err := nm.projectRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		projectNotes, err := nm.noteRepo.FindByProject(ctx, project.ID())
+		if err != nil {
+			continue
+		}
+		for _, note := range projectNotes {
+			notes[project.ID()+"/"+note.ID()] = dto.NoteToDTO(note)
+		}
+	}
+
+	return notes, nil
+}
+
+// dataFingerprint sums up the files of the data directory cheaply enough to
+// tell whether any of them changed
+type dataFingerprint struct {
+	files   int
+	size    int64
+	modTime time.Time
+}
+
+func (f *dataFingerprint) add(info fs.FileInfo) {
+	f.files++
+	f.size += info.Size()
+	if info.ModTime().After(f.modTime) {
+		f.modTime = info.ModTime()
+	}
+}
+
+// fingerprintData stats the files of the data directory and the SQLite
+// database without reading them
+func (nm *NoteMonitor) fingerprintData() dataFingerprint {
+	var fingerprint dataFingerprint
+
+	root := nm.config.Storage.DataPath
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || filesystem.SkipArchived(filepath.ToSlash(rel), entry) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			fingerprint.add(info)
+		}
+		return nil
+	})
+
+	if persistence.Backend(nm.config) == config.StorageBackendSQLite {
+		dbPath := persistence.DatabasePath(nm.config)
+		for _, path := range []string{dbPath, dbPath + "-wal"} {
+			if info, err := os.Stat(path); err == nil {
+				fingerprint.add(info)
+			}
+		}
+	}
+
+	return fingerprint
+}
@@ -1,6 +1,10 @@
 package daemon
 
-import "mkanban/internal/application/dto"
+import (
+	"time"
+
+	"mkanban/internal/application/dto"
+)
 
 // Request types
 const (
@@ -9,11 +13,13 @@ const (
 	RequestCreateBoard     = "create_board"
 	RequestAddTask         = "add_task"
 	RequestMoveTask        = "move_task"
+	RequestMoveTaskToLane  = "move_task_to_lane"
 	RequestUpdateTask      = "update_task"
 	RequestDeleteTask      = "delete_task"
 	RequestAddColumn       = "add_column"
 	RequestDeleteColumn    = "delete_column"
 	RequestGetActiveBoard  = "get_active_board"
+	RequestReportShell     = "report_shell"
 
 	// Action request types
 	RequestCreateAction    = "create_action"
@@ -23,6 +29,7 @@ const (
 	RequestListActions     = "list_actions"
 	RequestEnableAction    = "enable_action"
 	RequestDisableAction   = "disable_action"
+	RequestActionHistory   = "action_history"
 
 	// Real-time update request types
 	RequestSubscribe   = "subscribe"
@@ -46,6 +53,13 @@ const (
 	// Agenda request types
 	RequestScheduleTask  = "schedule_task"
 	RequestCreateMeeting = "create_meeting"
+
+	// Sync request types
+	RequestSyncData = "sync_data"
+
+	// Snapshot request types
+	RequestCreateSnapshot  = "create_snapshot"
+	RequestRestoreSnapshot = "restore_snapshot"
 )
 
 // Request represents a client request to the daemon
@@ -59,6 +73,20 @@ type Response struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	// Violations are the column rules a move broke: the warnings of a move
+	// that went ahead, or everything that refused it
+	Violations []dto.RuleViolationDTO `json:"violations,omitempty"`
+}
+
+// ResponseError is a failed daemon response
+type ResponseError struct {
+	Message    string
+	Violations []dto.RuleViolationDTO
+}
+
+// Error implements the error interface
+func (e *ResponseError) Error() string {
+	return "daemon error: " + e.Message
 }
 
 // GetBoardPayload contains data for getting a specific board
@@ -86,6 +114,12 @@ type MoveTaskPayload struct {
 	TargetColumnName string `json:"target_column_name"`
 }
 
+// MoveTaskToLanePayload contains data for moving a task into another swimlane
+type MoveTaskToLanePayload struct {
+	BoardID     string                        `json:"board_id"`
+	LaneRequest dto.MoveTaskToSwimlaneRequest `json:"lane"`
+}
+
 // UpdateTaskPayload contains data for updating a task
 type UpdateTaskPayload struct {
 	BoardID     string                `json:"board_id"`
@@ -161,9 +195,21 @@ type DisableActionPayload struct {
 	ActionID string `json:"action_id"`
 }
 
-// SubscribePayload contains data for subscribing to board updates
+// ActionHistoryPayload contains data for listing action executions.
+// Without an action ID the most recent executions of all actions are listed.
+type ActionHistoryPayload struct {
+	ActionID string `json:"action_id,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// SubscribePayload contains data for subscribing to updates. A subscription
+// matches a notification when it is global or the board/project matches, and
+// EventTypes (if set) contains the notification type.
 type SubscribePayload struct {
-	BoardID string `json:"board_id"`
+	BoardID    string   `json:"board_id,omitempty"`
+	ProjectID  string   `json:"project_id,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Global     bool     `json:"global,omitempty"`
 }
 
 // UnsubscribePayload contains data for unsubscribing from board updates
@@ -176,11 +222,20 @@ type GetActiveBoardPayload struct {
 	SessionName string `json:"session_name,omitempty"`
 }
 
+// ReportShellPayload contains the prompt of a shell set up with shell-init
+type ReportShellPayload struct {
+	PID int    `json:"pid"`
+	Dir string `json:"dir"`
+}
+
 // Notification represents a push notification from daemon to client
 type Notification struct {
-	Type    string      `json:"type"`
-	BoardID string      `json:"board_id,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
+	Type      string                     `json:"type"`
+	BoardID   string                     `json:"board_id,omitempty"`
+	ProjectID string                     `json:"project_id,omitempty"`
+	Data      interface{}                `json:"data,omitempty"`
+	Diff      map[string]dto.FieldChange `json:"diff,omitempty"`
+	Timestamp time.Time                  `json:"timestamp"`
 }
 
 // Time tracking payloads
@@ -256,6 +311,14 @@ type CreateMeetingPayload struct {
 	Location  *string  `json:"location,omitempty"`
 }
 
+// Snapshot payloads
+
+type RestoreSnapshotPayload struct {
+	SnapshotID  string `json:"snapshot_id"`
+	ProjectSlug string `json:"project_slug,omitempty"`
+	BoardID     string `json:"board_id,omitempty"`
+}
+
 // Notification types
 const (
 	NotificationBoardUpdated = "board_updated"
@@ -264,4 +327,27 @@ const (
 	NotificationTaskMoved    = "task_moved"
 	NotificationTaskDeleted  = "task_deleted"
 	NotificationPong         = "pong"
+
+	NotificationTimerStarted = "timer_started"
+	NotificationTimerStopped = "timer_stopped"
+
+	NotificationActionExecuted = "action_executed"
+	NotificationActionFailed   = "action_failed"
+	NotificationActionDisabled = "action_disabled"
+
+	NotificationProjectCreated = "project_created"
+	NotificationProjectUpdated = "project_updated"
+	NotificationProjectDeleted = "project_deleted"
+
+	NotificationNoteCreated = "note_created"
+	NotificationNoteUpdated = "note_updated"
+	NotificationNoteDeleted = "note_deleted"
+
+	NotificationCalendarSynced     = "calendar_synced"
+	NotificationCalendarSyncFailed = "calendar_sync_failed"
+
+	NotificationDataSynced     = "data_synced"
+	NotificationDataSyncFailed = "data_sync_failed"
+
+	NotificationDataRestored = "data_restored"
 )
@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -18,8 +19,11 @@ import (
 	"mkanban/internal/application/dto"
 	"mkanban/internal/di"
 	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
 	"mkanban/internal/domain/valueobject"
 	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/external"
+	"mkanban/internal/infrastructure/persistence"
 	"mkanban/pkg/slug"
 )
 
@@ -31,8 +35,15 @@ type Server struct {
 	sessionManager      *SessionManager
 	actionManager       *ActionManager
 	timeTrackingManager *TimeTrackingManager
+	noteMonitor         *NoteMonitor
+	calendarSyncManager *CalendarSyncManager
+	commitScanner       *CommitScanner
+	dataSyncManager     *DataSyncManager
+	backupManager       *BackupManager
+	webServer           *WebServer
+	shellSessions       *external.ShellSessionTracker
 	mu                  sync.RWMutex
-	subscribers         map[string]map[net.Conn]chan *Notification // boardID -> conn -> channel
+	subscribers         map[interface{}]*subscription // subscriber (conn or web stream) -> subscription
 	subMu               sync.RWMutex
 }
 
@@ -45,9 +56,10 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	}
 
 	return &Server{
-		container:   container,
-		config:      cfg,
-		subscribers: make(map[string]map[net.Conn]chan *Notification),
+		container:     container,
+		config:        cfg,
+		shellSessions: external.NewShellSessionTracker(cfg.Storage.DataPath),
+		subscribers:   make(map[interface{}]*subscription),
 	}, nil
 }
 
@@ -59,6 +71,11 @@ func (s *Server) Start() error {
 
 	ctx := context.Background()
 
+	if err := s.checkDataSchema(ctx); err != nil {
+		s.releaseLock()
+		return err
+	}
+
 	// Initialize session manager if session tracking use cases are available
 	if s.container.TrackSessionsUseCase != nil &&
 		s.container.SessionTracker != nil &&
@@ -91,6 +108,7 @@ func (s *Server) Start() error {
 			s.container.EvaluateActionsUseCase,
 			s.container.ExecuteActionUseCase,
 			s.container.ProcessEventUseCase,
+			s.container.CheckAgingUseCase,
 			s.container.ActionRepo,
 			s.container.EventBus,
 		)
@@ -101,6 +119,63 @@ func (s *Server) Start() error {
 		fmt.Println("Action manager started")
 	}
 
+	// Forward action outcomes to subscribers
+	if s.container.EventBus != nil {
+		s.container.EventBus.Subscribe(valueobject.EventActionExecuted, s.notifyActionEvent)
+		s.container.EventBus.Subscribe(valueobject.EventActionFailed, s.notifyActionEvent)
+		s.container.EventBus.Subscribe(valueobject.EventActionDisabled, s.notifyActionEvent)
+	}
+
+	// Attach commits in project repositories to their tasks
+	if s.config.SessionTracking.GitSync.ScanCommits &&
+		s.container.ProjectRepo != nil &&
+		s.container.VCSProvider != nil &&
+		s.container.LinkCommitsUseCase != nil {
+
+		s.commitScanner = NewCommitScanner(
+			s.container.Config,
+			s.container.ProjectRepo,
+			s.container.VCSProvider,
+			s.container.LinkCommitsUseCase,
+			&s.mu,
+			s.notifyCommitsLinked,
+		)
+
+		if err := s.commitScanner.Start(ctx); err != nil {
+			fmt.Printf("Commit scanner not started: %v\n", err)
+			s.commitScanner = nil
+		}
+	}
+
+	// Commit data changes and sync them with other machines
+	if s.config.Sync.Enabled && s.container.DataSyncer != nil {
+		s.dataSyncManager = NewDataSyncManager(
+			s.container.Config,
+			s.container.DataSyncer,
+			&s.mu,
+			s.notifyDataSync,
+		)
+
+		if err := s.dataSyncManager.Start(ctx); err != nil {
+			fmt.Printf("Data sync not started: %v\n", err)
+			s.dataSyncManager = nil
+		}
+	}
+
+	// Snapshot the data directory so a bad change can be rolled back
+	if s.config.Backup.Enabled && s.container.DataSnapshotter != nil {
+		s.backupManager = NewBackupManager(
+			s.container.Config,
+			s.container.DataSnapshotter,
+			&s.mu,
+		)
+
+		if err := s.backupManager.Start(ctx); err != nil {
+			fmt.Printf("Snapshots not started: %v\n", err)
+			s.backupManager = nil
+		}
+	}
+
 	socketDir := s.config.Daemon.SocketDir
 	if err := os.MkdirAll(socketDir, 0755); err != nil {
 		return fmt.Errorf("failed to create socket directory: %w", err)
@@ -121,6 +196,15 @@ func (s *Server) Start() error {
 	s.listener = listener
 	fmt.Printf("Daemon listening on %s\n", socketPath)
 
+	// Serve the browser UI alongside the socket if enabled
+	if s.config.Daemon.Web.Enabled {
+		s.webServer = NewWebServer(s, s.config.Daemon.Web.ListenAddr, s.config.Daemon.Web.AllowedHosts)
+		if err := s.webServer.Start(); err != nil {
+			return fmt.Errorf("failed to start web server: %w", err)
+		}
+		fmt.Printf("Web UI listening on http://%s\n", s.webServer.Addr())
+	}
+
 	return s.acceptConnections()
 }
 
@@ -190,6 +274,8 @@ func (s *Server) handleRequest(req *Request) *Response {
 		return s.handleAddTask(ctx, req)
 	case RequestMoveTask:
 		return s.handleMoveTask(ctx, req)
+	case RequestMoveTaskToLane:
+		return s.handleMoveTaskToLane(ctx, req)
 	case RequestUpdateTask:
 		return s.handleUpdateTask(ctx, req)
 	case RequestDeleteTask:
@@ -200,6 +286,8 @@ func (s *Server) handleRequest(req *Request) *Response {
 		return s.handleDeleteColumn(ctx, req)
 	case RequestGetActiveBoard:
 		return s.handleGetActiveBoard(ctx, req)
+	case RequestReportShell:
+		return s.handleReportShell(req)
 	case RequestPing:
 		return &Response{Success: true, Data: "pong"}
 
@@ -225,11 +313,22 @@ func (s *Server) handleRequest(req *Request) *Response {
 	case RequestDeleteProject:
 		return s.handleDeleteProject(ctx, req)
 
+	case RequestActionHistory:
+		return s.handleActionHistory(ctx, req)
+
 	case RequestScheduleTask:
 		return s.handleScheduleTask(ctx, req)
 	case RequestCreateMeeting:
 		return s.handleCreateMeeting(ctx, req)
 
+	case RequestSyncData:
+		return s.handleSyncData(ctx)
+
+	case RequestCreateSnapshot:
+		return s.handleCreateSnapshot(ctx)
+	case RequestRestoreSnapshot:
+		return s.handleRestoreSnapshot(ctx, req)
+
 	default:
 		return &Response{
 			Success: false,
@@ -309,10 +408,11 @@ func (s *Server) handleAddTask(ctx context.Context, req *Request) *Response {
 	}
 
 	// Notify subscribers
-	s.notifySubscribers(payload.BoardID, &Notification{
+	s.notifySubscribers(&Notification{
 		Type:    NotificationTaskCreated,
 		BoardID: payload.BoardID,
 		Data:    taskDTO,
+		Diff:    dto.Diff(nil, taskDTO),
 	})
 
 	return &Response{Success: true, Data: taskDTO}
@@ -333,16 +433,53 @@ func (s *Server) handleMoveTask(ctx context.Context, req *Request) *Response {
 		TargetColumnName: payload.TargetColumnName,
 	}
 
-	boardDTO, err := s.container.MoveTaskUseCase.Execute(ctx, payload.BoardID, moveReq)
+	before := s.lookupTaskDTO(ctx, payload.BoardID, payload.TaskID)
+
+	boardDTO, warnings, err := s.container.MoveTaskUseCase.Execute(ctx, payload.BoardID, moveReq)
 	if err != nil {
-		return &Response{Success: false, Error: err.Error()}
+		resp := &Response{Success: false, Error: err.Error()}
+		var ruleErr *entity.ColumnRuleError
+		if errors.As(err, &ruleErr) {
+			resp.Violations = dto.RuleViolationsToDTO(ruleErr.Violations)
+		}
+		return resp
 	}
 
 	// Notify subscribers
-	s.notifySubscribers(payload.BoardID, &Notification{
+	s.notifySubscribers(&Notification{
 		Type:    NotificationTaskMoved,
 		BoardID: payload.BoardID,
 		Data:    boardDTO,
+		Diff:    dto.Diff(before, s.lookupTaskDTO(ctx, payload.BoardID, payload.TaskID)),
+	})
+
+	return &Response{Success: true, Data: boardDTO, Violations: warnings}
+}
+
+// handleMoveTaskToLane moves a task into another swimlane
+func (s *Server) handleMoveTaskToLane(ctx context.Context, req *Request) *Response {
+	var payload MoveTaskToLanePayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	taskID := payload.LaneRequest.TaskID
+	before := s.lookupTaskDTO(ctx, payload.BoardID, taskID)
+
+	boardDTO, err := s.container.MoveTaskToSwimlaneUseCase.Execute(ctx, payload.BoardID, payload.LaneRequest)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	// Notify subscribers
+	s.notifySubscribers(&Notification{
+		Type:    NotificationBoardUpdated,
+		BoardID: payload.BoardID,
+		Data:    boardDTO,
+		Diff:    dto.Diff(before, s.lookupTaskDTO(ctx, payload.BoardID, taskID)),
 	})
 
 	return &Response{Success: true, Data: boardDTO}
@@ -358,21 +495,46 @@ func (s *Server) handleUpdateTask(ctx context.Context, req *Request) *Response {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	before := s.lookupTaskDTO(ctx, payload.BoardID, payload.TaskID)
+
 	taskDTO, err := s.container.UpdateTaskUseCase.Execute(ctx, payload.BoardID, payload.TaskID, payload.TaskRequest)
 	if err != nil {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
 	// Notify subscribers
-	s.notifySubscribers(payload.BoardID, &Notification{
+	s.notifySubscribers(&Notification{
 		Type:    NotificationTaskUpdated,
 		BoardID: payload.BoardID,
 		Data:    taskDTO,
+		Diff:    dto.Diff(before, s.lookupTaskDTO(ctx, payload.BoardID, payload.TaskID)),
 	})
 
 	return &Response{Success: true, Data: taskDTO}
 }
 
+// lookupTaskDTO loads the current state of a task for diffing. It returns
+// nil when the task can't be found so callers can diff against nothing.
+func (s *Server) lookupTaskDTO(ctx context.Context, boardID, taskID string) *dto.TaskDTO {
+	parsedID, err := valueobject.ParseTaskID(taskID)
+	if err != nil {
+		return nil
+	}
+
+	board, err := s.container.BoardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil
+	}
+
+	task, column, err := board.FindTask(parsedID)
+	if err != nil {
+		return nil
+	}
+
+	taskDTO := dto.TaskToDTOWithPath(task, "", column.Name())
+	return &taskDTO
+}
+
 // handleDeleteTask deletes a task
 func (s *Server) handleDeleteTask(ctx context.Context, req *Request) *Response {
 	var payload DeleteTaskPayload
@@ -448,6 +610,21 @@ func (s *Server) handleGetActiveBoard(ctx context.Context, req *Request) *Respon
 	return &Response{Success: true, Data: map[string]string{"board_id": boardID}}
 }
 
+// handleReportShell records the prompt of a shell for the shell session
+// tracker
+func (s *Server) handleReportShell(req *Request) *Response {
+	var payload ReportShellPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	if err := s.shellSessions.Report(payload.PID, payload.Dir); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true}
+}
+
 // decodePayload decodes request payload into target struct
 func (s *Server) decodePayload(payload interface{}, target interface{}) error {
 	data, err := json.Marshal(payload)
@@ -473,6 +650,48 @@ func (s *Server) sendError(encoder *json.Encoder, message string) {
 
 // Stop stops the daemon server
 func (s *Server) Stop() error {
+	// Stop web server if it exists
+	if s.webServer != nil {
+		if err := s.webServer.Stop(); err != nil {
+			fmt.Printf("Error stopping web server: %v\n", err)
+		}
+	}
+
+	// Stop backup manager if it exists
+	if s.backupManager != nil {
+		if err := s.backupManager.Stop(); err != nil {
+			fmt.Printf("Error stopping backup manager: %v\n", err)
+		}
+	}
+
+	// Stop data sync manager if it exists
+	if s.dataSyncManager != nil {
+		if err := s.dataSyncManager.Stop(); err != nil {
+			fmt.Printf("Error stopping data sync manager: %v\n", err)
+		}
+	}
+
+	// Stop commit scanner if it exists
+	if s.commitScanner != nil {
+		if err := s.commitScanner.Stop(); err != nil {
+			fmt.Printf("Error stopping commit scanner: %v\n", err)
+		}
+	}
+
+	// Stop calendar sync manager if it exists
+	if s.calendarSyncManager != nil {
+		if err := s.calendarSyncManager.Stop(); err != nil {
+			fmt.Printf("Error stopping calendar sync manager: %v\n", err)
+		}
+	}
+
+	// Stop note monitor if it exists
+	if s.noteMonitor != nil {
+		if err := s.noteMonitor.Stop(); err != nil {
+			fmt.Printf("Error stopping note monitor: %v\n", err)
+		}
+	}
+
 	// Stop time tracking manager if it exists
 	if s.timeTrackingManager != nil {
 		if err := s.timeTrackingManager.Stop(); err != nil {
@@ -507,6 +726,36 @@ func (s *Server) lockFilePath() string {
 	return filepath.Join(s.config.Daemon.SocketDir, "mkanban.pid")
 }
 
+// checkDataSchema makes sure the data directory is in the layout this
+// daemon reads, migrating it after a backup when storage.auto_migrate is on
+func (s *Server) checkDataSchema(ctx context.Context) error {
+	migrator := s.container.DataMigrator
+	if migrator == nil {
+		return nil
+	}
+	if err := migrator.Init(ctx); err != nil {
+		return fmt.Errorf("failed to initialize data schema: %w", err)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.UpToDate() {
+		return nil
+	}
+	if !s.config.Storage.AutoMigrate {
+		return fmt.Errorf("data directory is at schema version %d, version %d is needed: run 'mkanban migrate' or set storage.auto_migrate", status.Version, status.Latest)
+	}
+
+	result, err := migrator.Migrate(ctx, service.DataMigrateOptions{Backup: true})
+	if err != nil {
+		return fmt.Errorf("failed to migrate data directory: %w", err)
+	}
+	fmt.Printf("Migrated data directory from schema version %d to %d, backup in %s\n", result.From, result.To, result.Backup)
+	return nil
+}
+
 func (s *Server) acquireLock() error {
 	lockPath := s.lockFilePath()
 
@@ -559,16 +808,12 @@ func (s *Server) handleSubscribe(conn net.Conn, encoder *json.Encoder, req *Requ
 		return
 	}
 
-	// Create notification channel for this connection
-	notifChan := make(chan *Notification, 10)
-
-	// Register subscriber
-	s.subMu.Lock()
-	if _, exists := s.subscribers[payload.BoardID]; !exists {
-		s.subscribers[payload.BoardID] = make(map[net.Conn]chan *Notification)
+	if payload.BoardID == "" && payload.ProjectID == "" && !payload.Global {
+		s.sendError(encoder, "subscription requires board_id, project_id or global")
+		return
 	}
-	s.subscribers[payload.BoardID][conn] = notifChan
-	s.subMu.Unlock()
+
+	notifChan := s.addSubscriber(payload, conn)
 
 	// Send success response
 	resp := &Response{Success: true, Data: "subscribed"}
@@ -587,20 +832,75 @@ func (s *Server) handleSubscribe(conn net.Conn, encoder *json.Encoder, req *Requ
 	}
 }
 
-// notifySubscribers sends a notification to all subscribers of a board
-func (s *Server) notifySubscribers(boardID string, notification *Notification) {
-	s.subMu.RLock()
-	defer s.subMu.RUnlock()
+// subscription holds a subscriber's filter and notification channel
+type subscription struct {
+	filter SubscribePayload
+	ch     chan *Notification
+}
 
-	subscribers, exists := s.subscribers[boardID]
-	if !exists {
-		return
+// matches reports whether the notification passes the subscription filter
+func (sub *subscription) matches(notification *Notification) bool {
+	f := sub.filter
+
+	inScope := f.Global ||
+		(f.BoardID != "" && f.BoardID == notification.BoardID) ||
+		(f.ProjectID != "" && f.ProjectID == notification.ProjectID)
+	if !inScope {
+		return false
+	}
+
+	if len(f.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range f.EventTypes {
+		if eventType == notification.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// addSubscriber registers a notification channel for a subscriber
+func (s *Server) addSubscriber(filter SubscribePayload, key interface{}) chan *Notification {
+	notifChan := make(chan *Notification, 10)
+
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	if existing, exists := s.subscribers[key]; exists {
+		close(existing.ch)
 	}
+	s.subscribers[key] = &subscription{filter: filter, ch: notifChan}
 
-	// Send notification to all subscribers
-	for _, ch := range subscribers {
+	return notifChan
+}
+
+// notifySubscribers sends a notification to every subscriber whose filter matches it
+func (s *Server) notifySubscribers(notification *Notification) {
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now()
+	}
+	if notification.ProjectID == "" && notification.BoardID != "" {
+		if projectSlug, _, err := valueobject.ParseBoardID(notification.BoardID); err == nil {
+			notification.ProjectID = projectSlug
+		}
+	}
+
+	// Whatever changed data gets committed for syncing
+	if s.dataSyncManager != nil && notification.Type != NotificationPong &&
+		notification.Type != NotificationDataSynced && notification.Type != NotificationDataSyncFailed {
+		s.dataSyncManager.Changed()
+	}
+
+	s.subMu.RLock()
+	defer s.subMu.RUnlock()
+
+	for _, sub := range s.subscribers {
+		if !sub.matches(notification) {
+			continue
+		}
 		select {
-		case ch <- notification:
+		case sub.ch <- notification:
 			// Notification sent
 		default:
 			// Channel full, skip this subscriber
@@ -608,22 +908,14 @@ func (s *Server) notifySubscribers(boardID string, notification *Notification) {
 	}
 }
 
-// cleanupSubscriber removes a connection from all subscriptions
-func (s *Server) cleanupSubscriber(conn net.Conn) {
+// cleanupSubscriber removes a subscriber and closes its channel
+func (s *Server) cleanupSubscriber(key interface{}) {
 	s.subMu.Lock()
 	defer s.subMu.Unlock()
 
-	// Find and remove this connection from all boards
-	for boardID, subscribers := range s.subscribers {
-		if ch, exists := subscribers[conn]; exists {
-			close(ch)
-			delete(subscribers, conn)
-
-			// Clean up empty board subscriptions
-			if len(subscribers) == 0 {
-				delete(s.subscribers, boardID)
-			}
-		}
+	if sub, exists := s.subscribers[key]; exists {
+		close(sub.ch)
+		delete(s.subscribers, key)
 	}
 }
 
@@ -825,6 +1117,14 @@ func (s *Server) handleCreateProject(ctx context.Context, req *Request) *Respons
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	projectDTO := dto.ProjectToDTO(project)
+	s.notifySubscribers(&Notification{
+		Type:      NotificationProjectCreated,
+		ProjectID: project.ID(),
+		Data:      projectDTO,
+		Diff:      dto.Diff(nil, projectDTO),
+	})
+
 	return &Response{Success: true, Data: map[string]interface{}{
 		"id":   project.ID(),
 		"name": project.Name(),
@@ -890,6 +1190,7 @@ func (s *Server) handleUpdateProject(ctx context.Context, req *Request) *Respons
 	if err != nil {
 		return &Response{Success: false, Error: "project not found"}
 	}
+	before := dto.ProjectToDTO(project)
 
 	if payload.Name != nil {
 		if err := project.UpdateName(*payload.Name); err != nil {
@@ -914,6 +1215,14 @@ func (s *Server) handleUpdateProject(ctx context.Context, req *Request) *Respons
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	projectDTO := dto.ProjectToDTO(project)
+	s.notifySubscribers(&Notification{
+		Type:      NotificationProjectUpdated,
+		ProjectID: project.ID(),
+		Data:      projectDTO,
+		Diff:      dto.Diff(before, projectDTO),
+	})
+
 	return &Response{Success: true, Data: map[string]interface{}{
 		"id":   project.ID(),
 		"name": project.Name(),
@@ -927,13 +1236,46 @@ func (s *Server) handleDeleteProject(ctx context.Context, req *Request) *Respons
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	var before *dto.ProjectDTO
+	if project, err := s.container.ProjectRepo.FindByID(ctx, payload.ProjectID); err == nil {
+		projectDTO := dto.ProjectToDTO(project)
+		before = &projectDTO
+	}
+
 	if err := s.container.ProjectRepo.Delete(ctx, payload.ProjectID); err != nil {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	s.notifySubscribers(&Notification{
+		Type:      NotificationProjectDeleted,
+		ProjectID: payload.ProjectID,
+		Data:      before,
+		Diff:      dto.Diff(before, nil),
+	})
+
 	return &Response{Success: true, Data: "project deleted"}
 }
 
+// handleActionHistory returns recorded action executions, newest first
+func (s *Server) handleActionHistory(ctx context.Context, req *Request) *Response {
+	var payload ActionHistoryPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	executions, err := s.container.GetActionHistoryUseCase.Execute(ctx, payload.ActionID, payload.Limit)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	result := make([]dto.ActionExecutionDTO, 0, len(executions))
+	for _, execution := range executions {
+		result = append(result, dto.ActionExecutionToDTO(execution))
+	}
+
+	return &Response{Success: true, Data: result}
+}
+
 func (s *Server) handleScheduleTask(ctx context.Context, req *Request) *Response {
 	fmt.Println("[Schedule] Decoding payload...")
 	var payload ScheduleTaskPayload
@@ -965,6 +1307,8 @@ func (s *Server) handleScheduleTask(ctx context.Context, req *Request) *Response
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	before := dto.TaskToDTOWithPath(task, "", columnName)
+
 	fmt.Println("[Schedule] Setting scheduled date...")
 	task.SetScheduledDate(scheduledDate)
 
@@ -1000,10 +1344,12 @@ func (s *Server) handleScheduleTask(ctx context.Context, req *Request) *Response
 	fmt.Println("[Schedule] Task saved!")
 
 	fmt.Println("[Schedule] Notifying subscribers...")
-	s.notifySubscribers(board.ID(), &Notification{
+	taskDTO := dto.TaskToDTOWithPath(task, "", columnName)
+	s.notifySubscribers(&Notification{
 		Type:    NotificationTaskUpdated,
 		BoardID: board.ID(),
-		Data:    task,
+		Data:    taskDTO,
+		Diff:    dto.Diff(before, taskDTO),
 	})
 	fmt.Println("[Schedule] Returning response...")
 
@@ -1104,10 +1450,12 @@ func (s *Server) handleCreateMeeting(ctx context.Context, req *Request) *Respons
 		return &Response{Success: false, Error: err.Error()}
 	}
 
-	s.notifySubscribers(board.ID(), &Notification{
+	taskDTO := dto.TaskToDTOWithPath(task, "", targetColumn.Name())
+	s.notifySubscribers(&Notification{
 		Type:    NotificationTaskCreated,
 		BoardID: board.ID(),
-		Data:    task,
+		Data:    taskDTO,
+		Diff:    dto.Diff(nil, taskDTO),
 	})
 
 	return &Response{Success: true, Data: map[string]interface{}{
@@ -1120,6 +1468,67 @@ func (s *Server) handleCreateMeeting(ctx context.Context, req *Request) *Respons
 	}}
 }
 
+// handleSyncData syncs the data directory with its remote right away
+func (s *Server) handleSyncData(ctx context.Context) *Response {
+	if s.dataSyncManager == nil {
+		return &Response{Success: false, Error: "data sync not enabled"}
+	}
+
+	result, err := s.dataSyncManager.SyncNow(ctx)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: result}
+}
+
+// handleCreateSnapshot takes a manual snapshot of the data directory,
+// holding off changes while it is taken
+func (s *Server) handleCreateSnapshot(ctx context.Context) *Response {
+	s.mu.Lock()
+	snapshot, err := s.container.DataSnapshotter.Create(ctx, service.SnapshotManual)
+	s.mu.Unlock()
+
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: dto.DataSnapshotToDTO(*snapshot)}
+}
+
+// handleRestoreSnapshot restores the data directory, or a board or project
+// in it, from a snapshot, holding off changes while it is restored
+func (s *Server) handleRestoreSnapshot(ctx context.Context, req *Request) *Response {
+	var payload RestoreSnapshotPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	// The daemon keeps the database open, so it can't be swapped under it
+	scope := service.SnapshotScope{ProjectSlug: payload.ProjectSlug, BoardID: payload.BoardID}
+	if scope == (service.SnapshotScope{}) && persistence.Backend(s.config) == config.StorageBackendSQLite {
+		return &Response{Success: false, Error: "stop the daemon to restore a SQLite database"}
+	}
+
+	s.mu.Lock()
+	result, err := s.container.DataSnapshotter.Restore(ctx, payload.SnapshotID, scope)
+	s.mu.Unlock()
+
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	restoreDTO := dto.SnapshotRestoreToDTO(result)
+	if len(restoreDTO.Changes) > 0 {
+		s.notifySubscribers(&Notification{
+			Type:      NotificationDataRestored,
+			BoardID:   payload.BoardID,
+			ProjectID: payload.ProjectSlug,
+			Data:      restoreDTO,
+		})
+		s.notifyBoardsReloaded(ctx)
+	}
+	return &Response{Success: true, Data: restoreDTO}
+}
+
 func (s *Server) findTaskAcrossBoards(ctx context.Context, taskID *valueobject.TaskID) (*entity.Board, *entity.Task, string, error) {
 	boards, err := s.container.ListBoardsUseCase.Execute(ctx)
 	if err != nil {
@@ -0,0 +1,34 @@
+package daemon
+
+import "testing"
+
+func TestSubscriptionMatches(t *testing.T) {
+	timerStarted := &Notification{Type: NotificationTimerStarted, ProjectID: "shop"}
+	taskMoved := &Notification{Type: NotificationTaskMoved, BoardID: "shop/main", ProjectID: "shop"}
+	otherBoard := &Notification{Type: NotificationTaskMoved, BoardID: "ops/main", ProjectID: "ops"}
+
+	tests := []struct {
+		name   string
+		filter SubscribePayload
+		want   []bool // timerStarted, taskMoved, otherBoard
+	}{
+		{"board", SubscribePayload{BoardID: "shop/main"}, []bool{false, true, false}},
+		{"project", SubscribePayload{ProjectID: "shop"}, []bool{true, true, false}},
+		{"global", SubscribePayload{Global: true}, []bool{true, true, true}},
+		{"global by type", SubscribePayload{Global: true, EventTypes: []string{NotificationTaskMoved}}, []bool{false, true, true}},
+		{"project by type", SubscribePayload{ProjectID: "shop", EventTypes: []string{NotificationTimerStarted}}, []bool{true, false, false}},
+		{"no scope", SubscribePayload{EventTypes: []string{NotificationTaskMoved}}, []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &subscription{filter: tt.filter}
+			for i, notification := range []*Notification{timerStarted, taskMoved, otherBoard} {
+				if got := sub.matches(notification); got != tt.want[i] {
+					t.Errorf("matches(%s on %q/%q) = %v, want %v",
+						notification.Type, notification.BoardID, notification.ProjectID, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
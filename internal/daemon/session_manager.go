@@ -54,10 +54,10 @@ func (sm *SessionManager) Start(ctx context.Context) error {
 		return nil
 	}
 
-	// Check if session tracker is available
+	// Keep polling without sessions: a multiplexer may start later, and
+	// shells set up with shell-init only report once they prompt
 	if !sm.sessionTracker.IsAvailable() {
-		fmt.Println("[SessionManager] Session tracker is not available (tmux may not be running)")
-		return nil
+		fmt.Println("[SessionManager] No terminal sessions yet, waiting for some")
 	}
 
 	fmt.Printf("[SessionManager] Starting session tracking (poll interval: %ds)\n", sm.config.SessionTracking.PollInterval)
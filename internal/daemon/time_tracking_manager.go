@@ -15,6 +15,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// TimerListener is called whenever a manual or automatic timer starts or stops
+type TimerListener func(log *entity.TimeLog, started bool)
+
 type TimeTrackingManager struct {
 	config         *config.Config
 	projectRepo    repository.ProjectRepository
@@ -26,6 +29,7 @@ type TimeTrackingManager struct {
 	autoTimers     map[string]*entity.TimeLog
 	currentProject *entity.Project
 	currentTaskID  *valueobject.TaskID
+	listener       TimerListener
 
 	mu       sync.RWMutex
 	stopChan chan struct{}
@@ -52,13 +56,26 @@ func NewTimeTrackingManager(
 	}
 }
 
+// SetTimerListener registers a callback for timer starts and stops
+func (tm *TimeTrackingManager) SetTimerListener(listener TimerListener) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.listener = listener
+}
+
+func (tm *TimeTrackingManager) notifyListener(log *entity.TimeLog, started bool) {
+	if tm.listener != nil {
+		tm.listener(log, started)
+	}
+}
+
 func (tm *TimeTrackingManager) Start(ctx context.Context) error {
 	if !tm.config.TimeTracking.Enabled {
 		fmt.Println("[TimeTrackingManager] Time tracking is disabled in config")
 		return nil
 	}
 
-	if tm.sessionTracker == nil || !tm.sessionTracker.IsAvailable() {
+	if tm.sessionTracker == nil {
 		fmt.Println("[TimeTrackingManager] Session tracker not available")
 		return nil
 	}
@@ -123,6 +140,7 @@ func (tm *TimeTrackingManager) StartTimer(ctx context.Context, projectID string,
 
 	tm.activeTimers[key] = log
 	fmt.Printf("[TimeTrackingManager] Started timer for %s\n", key)
+	tm.notifyListener(log, true)
 
 	return log, nil
 }
@@ -151,6 +169,7 @@ func (tm *TimeTrackingManager) StopTimer(ctx context.Context, projectID string,
 
 	delete(tm.activeTimers, key)
 	fmt.Printf("[TimeTrackingManager] Stopped timer for %s (duration: %s)\n", key, timer.Duration())
+	tm.notifyListener(timer, false)
 
 	return timer, nil
 }
@@ -267,9 +286,17 @@ func (tm *TimeTrackingManager) detectProjectAndTask(ctx context.Context, session
 		return nil, nil
 	}
 
+	// A worktree of a project's repository belongs to the project too
+	repoRoot := workingDir
+	if tm.vcsProvider != nil && tm.vcsProvider.IsRepository(workingDir) {
+		if root, err := tm.vcsProvider.GetMainRepositoryRoot(workingDir); err == nil {
+			repoRoot = root
+		}
+	}
+
 	var matchedProject *entity.Project
 	for _, p := range projects {
-		if p.WorkingDir() == workingDir {
+		if p.WorkingDir() == workingDir || p.WorkingDir() == repoRoot {
 			matchedProject = p
 			break
 		}
@@ -322,6 +349,7 @@ func (tm *TimeTrackingManager) pauseAutoTimersLocked(ctx context.Context) {
 			_ = timer.Stop(time.Now())
 			_ = tm.timeLogRepo.Save(ctx, timer)
 			fmt.Printf("[TimeTrackingManager] Auto-paused timer for %s\n", key)
+			tm.notifyListener(timer, false)
 		}
 	}
 	tm.autoTimers = make(map[string]*entity.TimeLog)
@@ -356,6 +384,7 @@ func (tm *TimeTrackingManager) startAutoTimerLocked(ctx context.Context, project
 	}
 
 	tm.autoTimers[key] = log
+	tm.notifyListener(log, true)
 
 	if taskID != nil {
 		fmt.Printf("[TimeTrackingManager] Auto-started timer for project %s, task %s\n", project.Name(), taskID.String())
@@ -0,0 +1,245 @@
+// mkanban web UI. Talks to the daemon through POST /api/<request_type>
+// and listens for board notifications on /api/events.
+(function () {
+  "use strict";
+
+  const boardSelect = document.getElementById("board-select");
+  const boardEl = document.getElementById("board");
+  const liveStatus = document.getElementById("live-status");
+  const toast = document.getElementById("toast");
+  const dialog = document.getElementById("task-dialog");
+  const form = document.getElementById("task-form");
+  const token = document.querySelector('meta[name="mkanban-token"]').content;
+
+  let currentBoard = null;
+  let events = null;
+  let dragged = null;
+
+  async function call(type, payload) {
+    const res = await fetch("/api/" + type, {
+      method: "POST",
+      headers: { "Content-Type": "application/json", "X-Mkanban-Token": token },
+      body: payload === undefined ? "" : JSON.stringify(payload),
+    });
+    const body = await res.json();
+    if (!body.success) {
+      throw new Error(body.error || "request failed");
+    }
+    return body.data;
+  }
+
+  function showError(err) {
+    toast.textContent = err.message || String(err);
+    toast.classList.remove("hidden");
+    clearTimeout(showError.timer);
+    showError.timer = setTimeout(() => toast.classList.add("hidden"), 4000);
+  }
+
+  function el(tag, className, text) {
+    const node = document.createElement(tag);
+    if (className) node.className = className;
+    if (text !== undefined) node.textContent = text;
+    return node;
+  }
+
+  function isFull(column) {
+    return column.wip_limit > 0 && column.task_count >= column.wip_limit;
+  }
+
+  function renderCard(task, column) {
+    const card = el("div", "card priority-" + task.priority);
+    card.draggable = true;
+    card.dataset.taskId = task.id;
+    card.dataset.column = column.name;
+
+    card.appendChild(el("div", "id", task.short_id));
+    card.appendChild(el("div", "title", task.title));
+
+    const meta = el("div", "meta");
+    if (task.due_date) {
+      const due = el("span", task.is_overdue ? "overdue" : "", "due " + task.due_date.slice(0, 10));
+      meta.appendChild(due);
+      meta.appendChild(document.createTextNode(" "));
+    }
+    (task.tags || []).forEach((tag) => meta.appendChild(el("span", "tag", "#" + tag)));
+    card.appendChild(meta);
+
+    card.addEventListener("dragstart", (e) => {
+      dragged = { taskId: task.id, column: column.name };
+      card.classList.add("dragging");
+      e.dataTransfer.effectAllowed = "move";
+      e.dataTransfer.setData("text/plain", task.id);
+    });
+    card.addEventListener("dragend", () => {
+      dragged = null;
+      card.classList.remove("dragging");
+    });
+    card.addEventListener("click", () => openEditor(task, column));
+
+    return card;
+  }
+
+  function renderColumn(column) {
+    const col = el("section", "column");
+    if (column.color) {
+      col.style.borderTopColor = column.color;
+    }
+
+    const header = el("div", "column-header");
+    header.appendChild(el("h2", "", column.name));
+
+    const wipText = column.wip_limit > 0
+      ? column.task_count + "/" + column.wip_limit
+      : String(column.task_count);
+    header.appendChild(el("span", isFull(column) ? "wip full" : "wip", wipText));
+
+    const add = el("button", "", "+");
+    add.title = "Add task";
+    add.addEventListener("click", () => openEditor(null, column));
+    header.appendChild(add);
+    col.appendChild(header);
+
+    (column.tasks || []).forEach((task) => col.appendChild(renderCard(task, column)));
+
+    col.addEventListener("dragover", (e) => {
+      if (!dragged || dragged.column === column.name) return;
+      e.preventDefault();
+      const blocked = isFull(column);
+      col.classList.toggle("drop-blocked", blocked);
+      col.classList.toggle("drop-target", !blocked);
+      e.dataTransfer.dropEffect = blocked ? "none" : "move";
+    });
+    col.addEventListener("dragleave", () => {
+      col.classList.remove("drop-target", "drop-blocked");
+    });
+    col.addEventListener("drop", async (e) => {
+      e.preventDefault();
+      col.classList.remove("drop-target", "drop-blocked");
+      if (!dragged || dragged.column === column.name) return;
+      if (isFull(column)) {
+        showError(new Error("WIP limit reached for " + column.name));
+        return;
+      }
+      try {
+        const board = await call("move_task", {
+          board_id: currentBoard.id,
+          task_id: dragged.taskId,
+          target_column_name: column.name,
+        });
+        render(board);
+      } catch (err) {
+        showError(err);
+      }
+    });
+
+    return col;
+  }
+
+  function render(board) {
+    currentBoard = board;
+    boardEl.replaceChildren(...(board.columns || []).map(renderColumn));
+  }
+
+  function openEditor(task, column) {
+    form.reset();
+    document.getElementById("task-dialog-title").textContent =
+      task ? task.short_id : "New task in " + column.name;
+    form.task_id.value = task ? task.id : "";
+    form.column_name.value = column.name;
+    form.title.value = task ? task.title : "";
+    form.description.value = task ? task.description : "";
+    form.priority.value = task ? task.priority : "none";
+    form.status.value = task ? task.status : "todo";
+    form.status.disabled = !task;
+    dialog.showModal();
+  }
+
+  dialog.addEventListener("close", async () => {
+    if (dialog.returnValue !== "save" || !currentBoard) return;
+
+    const fields = {
+      title: form.title.value.trim(),
+      description: form.description.value,
+      priority: form.priority.value,
+    };
+
+    try {
+      if (form.task_id.value) {
+        fields.status = form.status.value;
+        await call("update_task", {
+          board_id: currentBoard.id,
+          task_id: form.task_id.value,
+          task: fields,
+        });
+      } else {
+        fields.column_name = form.column_name.value;
+        await call("add_task", { board_id: currentBoard.id, task: fields });
+      }
+      await loadBoard(currentBoard.id);
+    } catch (err) {
+      showError(err);
+    }
+  });
+
+  function subscribe(boardID) {
+    if (events) events.close();
+    events = new EventSource(
+      "/api/events?board_id=" + encodeURIComponent(boardID) + "&token=" + encodeURIComponent(token)
+    );
+    events.onopen = () => {
+      liveStatus.className = "status online";
+    };
+    events.onerror = () => {
+      liveStatus.className = "status offline";
+    };
+
+    const refresh = () => loadBoard(boardID).catch(showError);
+    ["board_updated", "task_created", "task_updated", "task_moved", "task_deleted"].forEach((type) =>
+      events.addEventListener(type, refresh)
+    );
+  }
+
+  async function loadBoard(boardID) {
+    const board = await call("get_board", { board_id: boardID });
+    render(board);
+  }
+
+  async function selectBoard(boardID) {
+    localStorage.setItem("mkanban.board", boardID);
+    await loadBoard(boardID);
+    subscribe(boardID);
+  }
+
+  async function init() {
+    const boards = await call("list_boards");
+    boardSelect.replaceChildren(
+      ...boards.map((b) => {
+        const opt = el("option", "", b.name + " (" + b.id + ")");
+        opt.value = b.id;
+        return opt;
+      })
+    );
+
+    if (boards.length === 0) {
+      boardEl.replaceChildren(el("p", "", "No boards yet. Create one with: mkanban board create <name>"));
+      return;
+    }
+
+    let initial = localStorage.getItem("mkanban.board");
+    try {
+      const active = await call("get_active_board", {});
+      if (active && active.board_id) initial = active.board_id;
+    } catch (_) {
+      // session tracking is optional
+    }
+    if (!boards.some((b) => b.id === initial)) {
+      initial = boards[0].id;
+    }
+
+    boardSelect.value = initial;
+    boardSelect.addEventListener("change", () => selectBoard(boardSelect.value).catch(showError));
+    await selectBoard(initial);
+  }
+
+  init().catch(showError);
+})();
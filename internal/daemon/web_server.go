@@ -0,0 +1,307 @@
+package daemon
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed web
+var webAssets embed.FS
+
+// webRequestTypes lists the daemon requests the browser UI may issue.
+// Every mutation goes through Server.handleRequest so the web UI shares
+// validation, WIP limits and subscriber notifications with the CLI.
+var webRequestTypes = map[string]bool{
+	RequestListBoards:     true,
+	RequestGetBoard:       true,
+	RequestGetActiveBoard: true,
+	RequestAddTask:        true,
+	RequestMoveTask:       true,
+	RequestUpdateTask:     true,
+}
+
+// webTokenHeader carries the per-daemon token on API requests
+const webTokenHeader = "X-Mkanban-Token"
+
+// WebServer serves the embedded browser UI and bridges it to the daemon.
+// Only requests addressed to an allowed host are served, so a page on
+// another site can't reach the API through DNS rebinding, and API calls
+// must carry the token embedded in the served page, which pages from
+// other origins can't read.
+type WebServer struct {
+	server       *Server
+	addr         string
+	allowedHosts map[string]bool
+	token        string
+	index        *template.Template
+	listener     net.Listener
+	httpServer   *http.Server
+	done         chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewWebServer creates a new WebServer. allowedHosts are host names the UI
+// may be reached by besides localhost and the host of addr.
+func NewWebServer(server *Server, addr string, allowedHosts []string) *WebServer {
+	if addr == "" {
+		addr = "127.0.0.1:8420"
+	}
+
+	hosts := map[string]bool{"localhost": true, "127.0.0.1": true, "::1": true}
+	if host, _, err := net.SplitHostPort(addr); err == nil && host != "" {
+		hosts[strings.ToLower(host)] = true
+	}
+	for _, host := range allowedHosts {
+		hosts[strings.ToLower(host)] = true
+	}
+
+	return &WebServer{
+		server:       server,
+		addr:         addr,
+		allowedHosts: hosts,
+		done:         make(chan struct{}),
+	}
+}
+
+// Start begins serving HTTP requests in the background
+func (w *WebServer) Start() error {
+	assets, err := fs.Sub(webAssets, "web")
+	if err != nil {
+		return fmt.Errorf("failed to load web assets: %w", err)
+	}
+	w.index, err = template.ParseFS(assets, "index.html")
+	if err != nil {
+		return fmt.Errorf("failed to load web assets: %w", err)
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("failed to generate web token: %w", err)
+	}
+	w.token = hex.EncodeToString(token)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServer(http.FS(assets)))
+	mux.HandleFunc("GET /{$}", w.handleIndex)
+	mux.HandleFunc("GET /index.html", w.handleIndex)
+	mux.HandleFunc("POST /api/{type}", w.handleAPI)
+	mux.HandleFunc("GET /api/events", w.handleEvents)
+
+	listener, err := net.Listen("tcp", w.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", w.addr, err)
+	}
+
+	w.listener = listener
+	w.httpServer = &http.Server{
+		Handler:           w.checkHost(mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := w.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("[WebServer] Serve error: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the web server is listening on
+func (w *WebServer) Addr() string {
+	if w.listener != nil {
+		return w.listener.Addr().String()
+	}
+	return w.addr
+}
+
+// Stop shuts down the web server
+func (w *WebServer) Stop() error {
+	if w.httpServer == nil {
+		return nil
+	}
+
+	var err error
+	w.stopOnce.Do(func() {
+		// Release open event streams so Shutdown doesn't wait on them
+		close(w.done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err = w.httpServer.Shutdown(ctx)
+	})
+	return err
+}
+
+// checkHost refuses requests addressed to a host that isn't allowed, or
+// sent from a page of another origin
+func (w *WebServer) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !w.allowedHost(r.Host) {
+			http.Error(rw, "host not allowed", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if u, err := url.Parse(origin); err != nil || !strings.EqualFold(u.Host, r.Host) {
+				http.Error(rw, "origin not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
+
+func (w *WebServer) allowedHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = strings.Trim(hostport, "[]")
+	}
+	return w.allowedHosts[strings.ToLower(host)]
+}
+
+// validToken reports whether the request carries the daemon's token
+func (w *WebServer) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(w.token)) == 1
+}
+
+// handleIndex serves the page with the token the API calls need
+func (w *WebServer) handleIndex(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	if err := w.index.Execute(rw, struct{ Token string }{w.token}); err != nil {
+		fmt.Printf("[WebServer] Render error: %v\n", err)
+	}
+}
+
+// handleAPI translates POST /api/<request_type> into a daemon request
+func (w *WebServer) handleAPI(rw http.ResponseWriter, r *http.Request) {
+	if !w.validToken(r.Header.Get(webTokenHeader)) {
+		writeJSON(rw, http.StatusForbidden, &Response{Success: false, Error: "missing or invalid token"})
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		writeJSON(rw, http.StatusUnsupportedMediaType, &Response{Success: false, Error: "payload must be application/json"})
+		return
+	}
+
+	reqType := r.PathValue("type")
+	if !webRequestTypes[reqType] {
+		writeJSON(rw, http.StatusNotFound, &Response{
+			Success: false,
+			Error:   fmt.Sprintf("unknown request type: %s", reqType),
+		})
+		return
+	}
+
+	var payload interface{}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeJSON(rw, http.StatusBadRequest, &Response{Success: false, Error: err.Error()})
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeJSON(rw, http.StatusBadRequest, &Response{Success: false, Error: "invalid JSON payload"})
+			return
+		}
+	}
+
+	resp := w.server.handleRequest(&Request{Type: reqType, Payload: payload})
+
+	status := http.StatusOK
+	if !resp.Success {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(rw, status, resp)
+}
+
+// handleEvents streams notifications as server-sent events. The query
+// accepts the same filters as a socket subscription: board_id, project_id,
+// global=true and a comma-separated types list, plus the token, as
+// EventSource can't send headers.
+func (w *WebServer) handleEvents(rw http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !w.validToken(query.Get("token")) {
+		http.Error(rw, "missing or invalid token", http.StatusForbidden)
+		return
+	}
+	filter := SubscribePayload{
+		BoardID:   query.Get("board_id"),
+		ProjectID: query.Get("project_id"),
+		Global:    query.Get("global") == "true",
+	}
+	if types := query.Get("types"); types != "" {
+		filter.EventTypes = strings.Split(types, ",")
+	}
+	if filter.BoardID == "" && filter.ProjectID == "" && !filter.Global {
+		http.Error(rw, "board_id, project_id or global is required", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// The request itself identifies this stream in the subscriber table
+	notifChan := w.server.addSubscriber(filter, r)
+	defer w.server.cleanupSubscriber(r)
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-w.done:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(rw, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case notification, ok := <-notifChan:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(notification)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", notification.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(v)
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebServerRefusesForeignRequests(t *testing.T) {
+	w := NewWebServer(nil, "127.0.0.1:0", []string{"kanban.lan"})
+	w.token = "secret"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/{type}", w.handleAPI)
+	handler := w.checkHost(mux)
+
+	tests := []struct {
+		name        string
+		host        string
+		origin      string
+		contentType string
+		token       string
+		want        int
+	}{
+		{"rebound host", "evil.example:8420", "", "application/json", "secret", http.StatusForbidden},
+		{"foreign origin", "127.0.0.1:8420", "http://evil.example", "application/json", "secret", http.StatusForbidden},
+		{"no token", "127.0.0.1:8420", "http://127.0.0.1:8420", "application/json", "", http.StatusForbidden},
+		{"wrong token", "localhost:8420", "", "application/json", "guess", http.StatusForbidden},
+		{"form post", "127.0.0.1:8420", "http://127.0.0.1:8420", "text/plain", "secret", http.StatusUnsupportedMediaType},
+		{"unknown type on allowed host", "kanban.lan:8420", "http://kanban.lan:8420", "application/json; charset=utf-8", "secret", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/delete_everything", strings.NewReader("{}"))
+			req.Host = tt.host
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.token != "" {
+				req.Header.Set(webTokenHeader, tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func TestWebServerStopTwice(t *testing.T) {
+	w := NewWebServer(nil, "127.0.0.1:0", nil)
+	if err := w.Start(); err != nil {
+		t.Skipf("can't listen: %v", err)
+	}
+	if err := w.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Stop(); err != nil {
+		t.Errorf("second Stop() = %v", err)
+	}
+}
@@ -17,7 +17,9 @@ import (
 	"mkanban/internal/domain/service"
 	"mkanban/internal/infrastructure/config"
 	"mkanban/internal/infrastructure/external"
-	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/persistence"
+	"mkanban/internal/infrastructure/persistence/migration"
+	"mkanban/internal/infrastructure/persistence/snapshot"
 	infraService "mkanban/internal/infrastructure/service"
 )
 
@@ -27,11 +29,12 @@ type Container struct {
 	Config *config.Config
 
 	// Repositories
-	BoardRepo   repository.BoardRepository
-	ActionRepo  repository.ActionRepository
-	ProjectRepo repository.ProjectRepository
-	TimeLogRepo repository.TimeLogRepository
-	NoteRepo    repository.NoteRepository
+	BoardRepo           repository.BoardRepository
+	ActionRepo          repository.ActionRepository
+	ActionExecutionRepo repository.ActionExecutionRepository
+	ProjectRepo         repository.ProjectRepository
+	TimeLogRepo         repository.TimeLogRepository
+	NoteRepo            repository.NoteRepository
 
 	// Domain Services
 	ValidationService *service.ValidationService
@@ -40,24 +43,39 @@ type Container struct {
 	VCSProvider       service.VCSProvider
 	ChangeWatcher     service.ChangeWatcher
 	RepoPathResolver  service.RepoPathResolver
+	DataSyncer        service.DataSyncer
+	DataMigrator      service.DataMigrator
+	DataSnapshotter   service.DataSnapshotter
 
 	// Strategies
 	BoardSyncStrategies []strategy.BoardSyncStrategy
 
 	// Use Cases - Board
-	CreateBoardUseCase *board.CreateBoardUseCase
-	GetBoardUseCase    *board.GetBoardUseCase
-	ListBoardsUseCase  *board.ListBoardsUseCase
+	CreateBoardUseCase  *board.CreateBoardUseCase
+	GetBoardUseCase     *board.GetBoardUseCase
+	ListBoardsUseCase   *board.ListBoardsUseCase
+	SetSwimlanesUseCase *board.SetSwimlanesUseCase
+	ImportBoardsUseCase *board.ImportBoardsUseCase
+	ExportBoardUseCase  *board.ExportBoardUseCase
+	RestoreBoardUseCase *board.RestoreBoardUseCase
 
 	// Use Cases - Column
-	CreateColumnUseCase *column.CreateColumnUseCase
+	CreateColumnUseCase     *column.CreateColumnUseCase
+	SetAgingPolicyUseCase   *column.SetAgingPolicyUseCase
+	AddColumnRuleUseCase    *column.AddColumnRuleUseCase
+	RemoveColumnRuleUseCase *column.RemoveColumnRuleUseCase
 
 	// Use Cases - Task
-	CreateTaskUseCase   *task.CreateTaskUseCase
-	MoveTaskUseCase     *task.MoveTaskUseCase
-	UpdateTaskUseCase   *task.UpdateTaskUseCase
-	ListTasksUseCase    *task.ListTasksUseCase
-	CheckoutTaskUseCase *task.CheckoutTaskUseCase
+	CreateTaskUseCase         *task.CreateTaskUseCase
+	MoveTaskUseCase           *task.MoveTaskUseCase
+	MoveTaskToSwimlaneUseCase *task.MoveTaskToSwimlaneUseCase
+	UpdateTaskUseCase         *task.UpdateTaskUseCase
+	SetTaskParentUseCase      *task.SetTaskParentUseCase
+	GetTaskTreeUseCase        *task.GetTaskTreeUseCase
+	ListTasksUseCase          *task.ListTasksUseCase
+	CheckoutTaskUseCase       *task.CheckoutTaskUseCase
+	LinkCommitsUseCase        *task.LinkCommitsUseCase
+	CheckAgingUseCase         *task.CheckAgingUseCase
 
 	// Use Cases - Session
 	TrackSessionsUseCase        *session.TrackSessionsUseCase
@@ -74,6 +92,9 @@ type Container struct {
 	DisableActionUseCase  *action.DisableActionUseCase
 	EvaluateActionsUseCase *action.EvaluateActionsUseCase
 	ExecuteActionUseCase  *action.ExecuteActionUseCase
+	GetActionHistoryUseCase *action.GetActionHistoryUseCase
+	TestActionUseCase       *action.TestActionUseCase
+	SimulateActionsUseCase  *action.SimulateActionsUseCase
 	ProcessEventUseCase   *action.ProcessEventUseCase
 
 	// Infrastructure Services
@@ -90,8 +111,10 @@ func InitializeContainer() (*Container, error) {
 		ProvideConfig,
 
 		// Repositories
+		ProvideRepositories,
 		ProvideBoardRepository,
 		ProvideActionRepository,
+		ProvideActionExecutionRepository,
 		ProvideProjectRepository,
 		ProvideTimeLogRepository,
 		ProvideNoteRepository,
@@ -100,9 +123,15 @@ func InitializeContainer() (*Container, error) {
 		ProvideValidationService,
 		ProvideBoardService,
 		ProvideSessionTracker,
+		ProvideSessionLauncher,
 		ProvideVCSProvider,
+		ProvideBoardImporter,
 		ProvideChangeWatcher,
 		ProvideRepoPathResolver,
+		ProvideBoardLayoutProvider,
+		ProvideDataSyncer,
+		ProvideDataMigrator,
+		ProvideDataSnapshotter,
 
 		// Strategies
 		ProvideBoardSyncStrategies,
@@ -111,16 +140,28 @@ func InitializeContainer() (*Container, error) {
 		board.NewCreateBoardUseCase,
 		board.NewGetBoardUseCase,
 		board.NewListBoardsUseCase,
+		board.NewSetSwimlanesUseCase,
+		board.NewImportBoardsUseCase,
+		board.NewExportBoardUseCase,
+		board.NewRestoreBoardUseCase,
 
 		// Use Cases - Column
 		column.NewCreateColumnUseCase,
+		column.NewSetAgingPolicyUseCase,
+		column.NewAddColumnRuleUseCase,
+		column.NewRemoveColumnRuleUseCase,
 
 		// Use Cases - Task
 		task.NewCreateTaskUseCase,
 		task.NewMoveTaskUseCase,
+		task.NewMoveTaskToSwimlaneUseCase,
 		task.NewUpdateTaskUseCase,
+		task.NewSetTaskParentUseCase,
+		task.NewGetTaskTreeUseCase,
 		task.NewListTasksUseCase,
 		task.NewCheckoutTaskUseCase,
+		task.NewLinkCommitsUseCase,
+		task.NewCheckAgingUseCase,
 
 		// Use Cases - Session
 		session.NewSessionBoardPlanner,
@@ -132,7 +173,10 @@ func InitializeContainer() (*Container, error) {
 		ProvideEventBus,
 		ProvideNotifier,
 		ProvideScriptRunner,
+		ProvideCommandRunner,
+		ProvideWebhookSender,
 		ProvideTaskMutator,
+		ProvideWorkSchedule,
 
 		// Use Cases - Action
 		action.NewCreateActionUseCase,
@@ -144,6 +188,9 @@ func InitializeContainer() (*Container, error) {
 		action.NewDisableActionUseCase,
 		action.NewEvaluateActionsUseCase,
 		action.NewExecuteActionUseCase,
+		action.NewGetActionHistoryUseCase,
+		action.NewTestActionUseCase,
+		action.NewSimulateActionsUseCase,
 		action.NewProcessEventUseCase,
 
 		// Wire the container
@@ -162,8 +209,12 @@ func ProvideConfig() (*config.Config, error) {
 	return loader.Load()
 }
 
-func ProvideBoardRepository(cfg *config.Config) repository.BoardRepository {
-	return filesystem.NewBoardRepository(cfg.Storage.DataPath)
+func ProvideRepositories(cfg *config.Config) (*persistence.Repositories, error) {
+	return persistence.NewRepositories(cfg, "")
+}
+
+func ProvideBoardRepository(repos *persistence.Repositories) repository.BoardRepository {
+	return repos.Boards
 }
 
 func ProvideValidationService(boardRepo repository.BoardRepository) *service.ValidationService {
@@ -173,17 +224,26 @@ func ProvideValidationService(boardRepo repository.BoardRepository) *service.Val
 func ProvideBoardService(
 	boardRepo repository.BoardRepository,
 	validationService *service.ValidationService,
+	scriptRunner entity.ScriptRunner,
 	cfg *config.Config,
 ) *service.BoardService {
-	return service.NewBoardService(boardRepo, validationService, cfg)
+	return service.NewBoardService(boardRepo, validationService, scriptRunner, cfg)
+}
+
+func ProvideSessionTracker(cfg *config.Config) (service.SessionTracker, error) {
+	return external.NewSessionTracker(cfg.SessionTracking.TrackerType, cfg.Storage.DataPath)
 }
 
-func ProvideSessionTracker() service.SessionTracker {
+func ProvideSessionLauncher() service.SessionLauncher {
 	return external.NewTmuxSessionTracker()
 }
 
 func ProvideVCSProvider() service.VCSProvider {
-	return external.NewGitVCSProvider()
+	return external.NewAutoVCSProvider()
+}
+
+func ProvideBoardImporter() service.BoardImporter {
+	return external.NewExportImporter()
 }
 
 func ProvideChangeWatcher() (service.ChangeWatcher, error) {
@@ -198,14 +258,42 @@ func ProvideRepoPathResolver(
 	return infraService.NewTmuxRepoPathResolver(sessionTracker, vcsProvider, projectRepo)
 }
 
+func ProvideDataSyncer(cfg *config.Config) service.DataSyncer {
+	database := persistence.Backend(cfg) == config.StorageBackendSQLite
+	return external.NewGitDataSyncer(cfg.Storage.DataPath, cfg.Sync.Branch, database)
+}
+
+func ProvideDataMigrator(cfg *config.Config) service.DataMigrator {
+	return migration.NewMigrator(cfg.Storage.DataPath)
+}
+
+func ProvideDataSnapshotter(cfg *config.Config) service.DataSnapshotter {
+	database := ""
+	if persistence.Backend(cfg) == config.StorageBackendSQLite {
+		database = persistence.DatabasePath(cfg)
+	}
+	return snapshot.NewSnapshotter(cfg.Storage.DataPath, cfg.Backup.Dir, database, cfg.Backup.KeepHourly, cfg.Backup.KeepDaily)
+}
+
+func ProvideBoardLayoutProvider(cfg *config.Config) service.BoardLayoutProvider {
+	return infraService.NewFileBoardLayoutProvider(cfg.SessionTracking.BoardLayouts)
+}
+
 func ProvideBoardSyncStrategies(
 	vcsProvider service.VCSProvider,
+	boardService *service.BoardService,
 	cfg *config.Config,
 ) []strategy.BoardSyncStrategy {
 	strategies := make([]strategy.BoardSyncStrategy, 0)
 
 	// Add GitRepoSyncStrategy (check first, higher priority)
-	gitStrategy := strategy.NewGitRepoSyncStrategy(vcsProvider)
+	gitSync := cfg.SessionTracking.GitSync
+	gitStrategy := strategy.NewGitRepoSyncStrategy(vcsProvider, boardService, strategy.MergeDetection{
+		Enabled:        gitSync.DetectMerges,
+		SquashMerges:   gitSync.DetectSquashMerges,
+		DeleteBranches: gitSync.DeleteMergedBranches,
+		DefaultBranch:  gitSync.DefaultBranch,
+	})
 	strategies = append(strategies, gitStrategy)
 
 	// Add GeneralSyncStrategy (fallback, lower priority)
@@ -215,8 +303,20 @@ func ProvideBoardSyncStrategies(
 	return strategies
 }
 
-func ProvideActionRepository(cfg *config.Config) repository.ActionRepository {
-	return filesystem.NewActionRepository(cfg)
+func ProvideActionRepository(repos *persistence.Repositories) repository.ActionRepository {
+	return repos.Actions
+}
+
+func ProvideActionExecutionRepository(repos *persistence.Repositories) repository.ActionExecutionRepository {
+	return repos.ActionExecutions
+}
+
+func ProvideWorkSchedule(cfg *config.Config) *entity.WorkSchedule {
+	workSchedule := entity.NewDefaultWorkSchedule("default")
+	if cfg.Actions.Timezone != "" {
+		workSchedule.SetTimezone(cfg.Actions.Timezone)
+	}
+	return workSchedule
 }
 
 func ProvideEventBus() entity.EventBus {
@@ -231,6 +331,14 @@ func ProvideScriptRunner(cfg *config.Config) entity.ScriptRunner {
 	return external.NewScriptExecutor(cfg.Actions.ScriptsEnabled, cfg.Actions.ScriptsDir)
 }
 
+func ProvideCommandRunner(cfg *config.Config) entity.CommandRunner {
+	return external.NewScriptExecutor(cfg.Actions.ScriptsEnabled, cfg.Actions.ScriptsDir)
+}
+
+func ProvideWebhookSender() entity.WebhookSender {
+	return external.NewHTTPWebhookSender()
+}
+
 func ProvideTaskMutator(
 	createTaskUseCase *task.CreateTaskUseCase,
 	updateTaskUseCase *task.UpdateTaskUseCase,
@@ -239,14 +347,14 @@ func ProvideTaskMutator(
 	return infraService.NewTaskMutatorService(createTaskUseCase, updateTaskUseCase, moveTaskUseCase)
 }
 
-func ProvideProjectRepository(cfg *config.Config) repository.ProjectRepository {
-	return filesystem.NewProjectRepository(cfg.Storage.DataPath)
+func ProvideProjectRepository(repos *persistence.Repositories) repository.ProjectRepository {
+	return repos.Projects
 }
 
-func ProvideTimeLogRepository(cfg *config.Config) repository.TimeLogRepository {
-	return filesystem.NewTimeLogRepository(cfg.Storage.DataPath)
+func ProvideTimeLogRepository(repos *persistence.Repositories) repository.TimeLogRepository {
+	return repos.TimeLogs
 }
 
-func ProvideNoteRepository(cfg *config.Config) repository.NoteRepository {
-	return filesystem.NewNoteRepository(cfg.Storage.DataPath)
+func ProvideNoteRepository(repos *persistence.Repositories) repository.NoteRepository {
+	return repos.Notes
 }
@@ -18,7 +18,9 @@ import (
 	"mkanban/internal/domain/service"
 	"mkanban/internal/infrastructure/config"
 	"mkanban/internal/infrastructure/external"
-	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/persistence"
+	"mkanban/internal/infrastructure/persistence/migration"
+	"mkanban/internal/infrastructure/persistence/snapshot"
 	service2 "mkanban/internal/infrastructure/service"
 )
 
@@ -30,52 +32,85 @@ func InitializeContainer() (*Container, error) {
 	if err != nil {
 		return nil, err
 	}
-	boardRepository := ProvideBoardRepository(config)
-	actionRepository := ProvideActionRepository(config)
-	projectRepository := ProvideProjectRepository(config)
-	timeLogRepository := ProvideTimeLogRepository(config)
-	noteRepository := ProvideNoteRepository(config)
+	repositories, err := ProvideRepositories(config)
+	if err != nil {
+		return nil, err
+	}
+	boardRepository := ProvideBoardRepository(repositories)
+	actionRepository := ProvideActionRepository(repositories)
+	actionExecutionRepository := ProvideActionExecutionRepository(repositories)
+	projectRepository := ProvideProjectRepository(repositories)
+	timeLogRepository := ProvideTimeLogRepository(repositories)
+	noteRepository := ProvideNoteRepository(repositories)
 	validationService := ProvideValidationService(boardRepository)
-	boardService := ProvideBoardService(boardRepository, validationService, config)
-	sessionTracker := ProvideSessionTracker()
+	scriptRunner := ProvideScriptRunner(config)
+	boardService := ProvideBoardService(boardRepository, validationService, scriptRunner, config)
+	sessionTracker, err := ProvideSessionTracker(config)
+	if err != nil {
+		return nil, err
+	}
+	sessionLauncher := ProvideSessionLauncher()
 	vcsProvider := ProvideVCSProvider()
 	changeWatcher, err := ProvideChangeWatcher()
 	if err != nil {
 		return nil, err
 	}
 	repoPathResolver := ProvideRepoPathResolver(sessionTracker, vcsProvider, projectRepository)
-	v := ProvideBoardSyncStrategies(vcsProvider, config)
-	sessionBoardPlanner := session.NewSessionBoardPlanner(vcsProvider)
+	v := ProvideBoardSyncStrategies(vcsProvider, boardService, config)
+	boardLayoutProvider := ProvideBoardLayoutProvider(config)
+	dataSyncer := ProvideDataSyncer(config)
+	dataMigrator := ProvideDataMigrator(config)
+	dataSnapshotter := ProvideDataSnapshotter(config)
+	sessionBoardPlanner := session.NewSessionBoardPlanner(vcsProvider, boardLayoutProvider)
 	createBoardUseCase := board.NewCreateBoardUseCase(boardService)
-	getBoardUseCase := board.NewGetBoardUseCase(boardRepository)
+	workSchedule := ProvideWorkSchedule(config)
+	getBoardUseCase := board.NewGetBoardUseCase(boardRepository, workSchedule)
 	listBoardsUseCase := board.NewListBoardsUseCase(boardRepository)
+	setSwimlanesUseCase := board.NewSetSwimlanesUseCase(boardService)
+	boardImporter := ProvideBoardImporter()
+	importBoardsUseCase := board.NewImportBoardsUseCase(boardService, boardRepository, projectRepository, boardImporter)
+	exportBoardUseCase := board.NewExportBoardUseCase(boardRepository, projectRepository, timeLogRepository)
+	restoreBoardUseCase := board.NewRestoreBoardUseCase(boardRepository, projectRepository, timeLogRepository)
 	createColumnUseCase := column.NewCreateColumnUseCase(boardService)
+	setAgingPolicyUseCase := column.NewSetAgingPolicyUseCase(boardService)
+	addColumnRuleUseCase := column.NewAddColumnRuleUseCase(boardService)
+	removeColumnRuleUseCase := column.NewRemoveColumnRuleUseCase(boardService)
 	createTaskUseCase := task.NewCreateTaskUseCase(boardService)
 	moveTaskUseCase := task.NewMoveTaskUseCase(boardService)
+	moveTaskToSwimlaneUseCase := task.NewMoveTaskToSwimlaneUseCase(boardService)
 	updateTaskUseCase := task.NewUpdateTaskUseCase(boardService)
+	setTaskParentUseCase := task.NewSetTaskParentUseCase(boardService)
+	getTaskTreeUseCase := task.NewGetTaskTreeUseCase(boardService, timeLogRepository)
 	listTasksUseCase := task.NewListTasksUseCase(boardRepository, config)
-	checkoutTaskUseCase := task.NewCheckoutTaskUseCase(boardRepository, vcsProvider, repoPathResolver)
+	checkoutTaskUseCase := task.NewCheckoutTaskUseCase(boardRepository, boardService, vcsProvider, repoPathResolver, sessionLauncher)
+	linkCommitsUseCase := task.NewLinkCommitsUseCase(boardRepository, boardService, vcsProvider)
 	syncSessionBoardUseCase := session.NewSyncSessionBoardUseCase(boardRepository, projectRepository, boardService, v, sessionBoardPlanner)
 	trackSessionsUseCase := session.NewTrackSessionsUseCase(sessionTracker, syncSessionBoardUseCase)
 	getActiveSessionBoardUseCase := session.NewGetActiveSessionBoardUseCase(sessionTracker, boardRepository, syncSessionBoardUseCase, sessionBoardPlanner)
 	createActionUseCase := action.NewCreateActionUseCase(actionRepository)
 	updateActionUseCase := action.NewUpdateActionUseCase(actionRepository)
-	deleteActionUseCase := action.NewDeleteActionUseCase(actionRepository)
+	deleteActionUseCase := action.NewDeleteActionUseCase(actionRepository, actionExecutionRepository)
 	getActionUseCase := action.NewGetActionUseCase(actionRepository)
 	listActionsUseCase := action.NewListActionsUseCase(actionRepository)
 	enableActionUseCase := action.NewEnableActionUseCase(actionRepository)
 	disableActionUseCase := action.NewDisableActionUseCase(actionRepository)
-	evaluateActionsUseCase := action.NewEvaluateActionsUseCase(actionRepository, boardRepository)
+	evaluateActionsUseCase := action.NewEvaluateActionsUseCase(actionRepository, boardRepository, workSchedule)
 	notifier := ProvideNotifier(config)
-	scriptRunner := ProvideScriptRunner(config)
+	commandRunner := ProvideCommandRunner(config)
+	webhookSender := ProvideWebhookSender()
 	taskMutator := ProvideTaskMutator(createTaskUseCase, updateTaskUseCase, moveTaskUseCase)
-	executeActionUseCase := action.NewExecuteActionUseCase(actionRepository, notifier, scriptRunner, taskMutator)
-	processEventUseCase := action.NewProcessEventUseCase(evaluateActionsUseCase, executeActionUseCase, actionRepository)
 	eventBus := ProvideEventBus()
+	checkAgingUseCase := task.NewCheckAgingUseCase(boardRepository, workSchedule, eventBus)
+	executeActionUseCase := action.NewExecuteActionUseCase(actionRepository, actionExecutionRepository, notifier, scriptRunner, commandRunner, webhookSender, taskMutator, eventBus, config)
+	getActionHistoryUseCase := action.NewGetActionHistoryUseCase(actionExecutionRepository)
+	testActionUseCase := action.NewTestActionUseCase(actionRepository, boardRepository, workSchedule)
+	simulateActionsUseCase := action.NewSimulateActionsUseCase(actionRepository, boardRepository, workSchedule, config)
+	processEventUseCase := action.NewProcessEventUseCase(evaluateActionsUseCase, executeActionUseCase, actionRepository)
 	container := &Container{
 		Config:                       config,
 		BoardRepo:                    boardRepository,
 		ActionRepo:                   actionRepository,
+		ActionExecutionRepo:          actionExecutionRepository,
 		ProjectRepo:                  projectRepository,
 		TimeLogRepo:                  timeLogRepository,
 		NoteRepo:                     noteRepository,
@@ -85,16 +120,31 @@ func InitializeContainer() (*Container, error) {
 		VCSProvider:                  vcsProvider,
 		ChangeWatcher:                changeWatcher,
 		RepoPathResolver:             repoPathResolver,
+		DataSyncer:                   dataSyncer,
+		DataMigrator:                 dataMigrator,
+		DataSnapshotter:              dataSnapshotter,
 		BoardSyncStrategies:          v,
 		CreateBoardUseCase:           createBoardUseCase,
 		GetBoardUseCase:              getBoardUseCase,
 		ListBoardsUseCase:            listBoardsUseCase,
+		SetSwimlanesUseCase:          setSwimlanesUseCase,
+		ImportBoardsUseCase:          importBoardsUseCase,
+		ExportBoardUseCase:           exportBoardUseCase,
+		RestoreBoardUseCase:          restoreBoardUseCase,
 		CreateColumnUseCase:          createColumnUseCase,
+		SetAgingPolicyUseCase:        setAgingPolicyUseCase,
+		AddColumnRuleUseCase:         addColumnRuleUseCase,
+		RemoveColumnRuleUseCase:      removeColumnRuleUseCase,
 		CreateTaskUseCase:            createTaskUseCase,
 		MoveTaskUseCase:              moveTaskUseCase,
+		MoveTaskToSwimlaneUseCase:    moveTaskToSwimlaneUseCase,
 		UpdateTaskUseCase:            updateTaskUseCase,
+		SetTaskParentUseCase:         setTaskParentUseCase,
+		GetTaskTreeUseCase:           getTaskTreeUseCase,
 		ListTasksUseCase:             listTasksUseCase,
 		CheckoutTaskUseCase:          checkoutTaskUseCase,
+		LinkCommitsUseCase:           linkCommitsUseCase,
+		CheckAgingUseCase:            checkAgingUseCase,
 		TrackSessionsUseCase:         trackSessionsUseCase,
 		GetActiveSessionBoardUseCase: getActiveSessionBoardUseCase,
 		SyncSessionBoardUseCase:      syncSessionBoardUseCase,
@@ -107,6 +157,9 @@ func InitializeContainer() (*Container, error) {
 		DisableActionUseCase:         disableActionUseCase,
 		EvaluateActionsUseCase:       evaluateActionsUseCase,
 		ExecuteActionUseCase:         executeActionUseCase,
+		GetActionHistoryUseCase:      getActionHistoryUseCase,
+		TestActionUseCase:            testActionUseCase,
+		SimulateActionsUseCase:       simulateActionsUseCase,
 		ProcessEventUseCase:          processEventUseCase,
 		EventBus:                     eventBus,
 		Notifier:                     notifier,
@@ -124,11 +177,12 @@ type Container struct {
 	Config *config.Config
 
 	// Repositories
-	BoardRepo   repository.BoardRepository
-	ActionRepo  repository.ActionRepository
-	ProjectRepo repository.ProjectRepository
-	TimeLogRepo repository.TimeLogRepository
-	NoteRepo    repository.NoteRepository
+	BoardRepo           repository.BoardRepository
+	ActionRepo          repository.ActionRepository
+	ActionExecutionRepo repository.ActionExecutionRepository
+	ProjectRepo         repository.ProjectRepository
+	TimeLogRepo         repository.TimeLogRepository
+	NoteRepo            repository.NoteRepository
 
 	// Domain Services
 	ValidationService *service.ValidationService
@@ -137,24 +191,39 @@ type Container struct {
 	VCSProvider       service.VCSProvider
 	ChangeWatcher     service.ChangeWatcher
 	RepoPathResolver  service.RepoPathResolver
+	DataSyncer        service.DataSyncer
+	DataMigrator      service.DataMigrator
+	DataSnapshotter   service.DataSnapshotter
 
 	// Strategies
 	BoardSyncStrategies []strategy.BoardSyncStrategy
 
 	// Use Cases - Board
-	CreateBoardUseCase *board.CreateBoardUseCase
-	GetBoardUseCase    *board.GetBoardUseCase
-	ListBoardsUseCase  *board.ListBoardsUseCase
+	CreateBoardUseCase  *board.CreateBoardUseCase
+	GetBoardUseCase     *board.GetBoardUseCase
+	ListBoardsUseCase   *board.ListBoardsUseCase
+	SetSwimlanesUseCase *board.SetSwimlanesUseCase
+	ImportBoardsUseCase *board.ImportBoardsUseCase
+	ExportBoardUseCase  *board.ExportBoardUseCase
+	RestoreBoardUseCase *board.RestoreBoardUseCase
 
 	// Use Cases - Column
-	CreateColumnUseCase *column.CreateColumnUseCase
+	CreateColumnUseCase     *column.CreateColumnUseCase
+	SetAgingPolicyUseCase   *column.SetAgingPolicyUseCase
+	AddColumnRuleUseCase    *column.AddColumnRuleUseCase
+	RemoveColumnRuleUseCase *column.RemoveColumnRuleUseCase
 
 	// Use Cases - Task
-	CreateTaskUseCase   *task.CreateTaskUseCase
-	MoveTaskUseCase     *task.MoveTaskUseCase
-	UpdateTaskUseCase   *task.UpdateTaskUseCase
-	ListTasksUseCase    *task.ListTasksUseCase
-	CheckoutTaskUseCase *task.CheckoutTaskUseCase
+	CreateTaskUseCase         *task.CreateTaskUseCase
+	MoveTaskUseCase           *task.MoveTaskUseCase
+	MoveTaskToSwimlaneUseCase *task.MoveTaskToSwimlaneUseCase
+	UpdateTaskUseCase         *task.UpdateTaskUseCase
+	SetTaskParentUseCase      *task.SetTaskParentUseCase
+	GetTaskTreeUseCase        *task.GetTaskTreeUseCase
+	ListTasksUseCase          *task.ListTasksUseCase
+	CheckoutTaskUseCase       *task.CheckoutTaskUseCase
+	LinkCommitsUseCase        *task.LinkCommitsUseCase
+	CheckAgingUseCase         *task.CheckAgingUseCase
 
 	// Use Cases - Session
 	TrackSessionsUseCase         *session.TrackSessionsUseCase
@@ -162,16 +231,19 @@ type Container struct {
 	SyncSessionBoardUseCase      *session.SyncSessionBoardUseCase
 
 	// Use Cases - Action
-	CreateActionUseCase    *action.CreateActionUseCase
-	UpdateActionUseCase    *action.UpdateActionUseCase
-	DeleteActionUseCase    *action.DeleteActionUseCase
-	GetActionUseCase       *action.GetActionUseCase
-	ListActionsUseCase     *action.ListActionsUseCase
-	EnableActionUseCase    *action.EnableActionUseCase
-	DisableActionUseCase   *action.DisableActionUseCase
-	EvaluateActionsUseCase *action.EvaluateActionsUseCase
-	ExecuteActionUseCase   *action.ExecuteActionUseCase
-	ProcessEventUseCase    *action.ProcessEventUseCase
+	CreateActionUseCase     *action.CreateActionUseCase
+	UpdateActionUseCase     *action.UpdateActionUseCase
+	DeleteActionUseCase     *action.DeleteActionUseCase
+	GetActionUseCase        *action.GetActionUseCase
+	ListActionsUseCase      *action.ListActionsUseCase
+	EnableActionUseCase     *action.EnableActionUseCase
+	DisableActionUseCase    *action.DisableActionUseCase
+	EvaluateActionsUseCase  *action.EvaluateActionsUseCase
+	ExecuteActionUseCase    *action.ExecuteActionUseCase
+	GetActionHistoryUseCase *action.GetActionHistoryUseCase
+	TestActionUseCase       *action.TestActionUseCase
+	SimulateActionsUseCase  *action.SimulateActionsUseCase
+	ProcessEventUseCase     *action.ProcessEventUseCase
 
 	// Infrastructure Services
 	EventBus     entity.EventBus
@@ -188,8 +260,12 @@ func ProvideConfig() (*config.Config, error) {
 	return loader.Load()
 }
 
-func ProvideBoardRepository(cfg *config.Config) repository.BoardRepository {
-	return filesystem.NewBoardRepository(cfg.Storage.DataPath)
+func ProvideRepositories(cfg *config.Config) (*persistence.Repositories, error) {
+	return persistence.NewRepositories(cfg, "")
+}
+
+func ProvideBoardRepository(repos *persistence.Repositories) repository.BoardRepository {
+	return repos.Boards
 }
 
 func ProvideValidationService(boardRepo repository.BoardRepository) *service.ValidationService {
@@ -199,17 +275,26 @@ func ProvideValidationService(boardRepo repository.BoardRepository) *service.Val
 func ProvideBoardService(
 	boardRepo repository.BoardRepository,
 	validationService *service.ValidationService,
+	scriptRunner entity.ScriptRunner,
 	cfg *config.Config,
 ) *service.BoardService {
-	return service.NewBoardService(boardRepo, validationService, cfg)
+	return service.NewBoardService(boardRepo, validationService, scriptRunner, cfg)
+}
+
+func ProvideSessionTracker(cfg *config.Config) (service.SessionTracker, error) {
+	return external.NewSessionTracker(cfg.SessionTracking.TrackerType, cfg.Storage.DataPath)
 }
 
-func ProvideSessionTracker() service.SessionTracker {
+func ProvideSessionLauncher() service.SessionLauncher {
 	return external.NewTmuxSessionTracker()
 }
 
 func ProvideVCSProvider() service.VCSProvider {
-	return external.NewGitVCSProvider()
+	return external.NewAutoVCSProvider()
+}
+
+func ProvideBoardImporter() service.BoardImporter {
+	return external.NewExportImporter()
 }
 
 func ProvideChangeWatcher() (service.ChangeWatcher, error) {
@@ -224,13 +309,41 @@ func ProvideRepoPathResolver(
 	return service2.NewTmuxRepoPathResolver(sessionTracker, vcsProvider, projectRepo)
 }
 
+func ProvideDataSyncer(cfg *config.Config) service.DataSyncer {
+	database := persistence.Backend(cfg) == config.StorageBackendSQLite
+	return external.NewGitDataSyncer(cfg.Storage.DataPath, cfg.Sync.Branch, database)
+}
+
+func ProvideDataMigrator(cfg *config.Config) service.DataMigrator {
+	return migration.NewMigrator(cfg.Storage.DataPath)
+}
+
+func ProvideDataSnapshotter(cfg *config.Config) service.DataSnapshotter {
+	database := ""
+	if persistence.Backend(cfg) == config.StorageBackendSQLite {
+		database = persistence.DatabasePath(cfg)
+	}
+	return snapshot.NewSnapshotter(cfg.Storage.DataPath, cfg.Backup.Dir, database, cfg.Backup.KeepHourly, cfg.Backup.KeepDaily)
+}
+
+func ProvideBoardLayoutProvider(cfg *config.Config) service.BoardLayoutProvider {
+	return service2.NewFileBoardLayoutProvider(cfg.SessionTracking.BoardLayouts)
+}
+
 func ProvideBoardSyncStrategies(
 	vcsProvider service.VCSProvider,
+	boardService *service.BoardService,
 	cfg *config.Config,
 ) []strategy.BoardSyncStrategy {
 	strategies := make([]strategy.BoardSyncStrategy, 0)
 
-	gitStrategy := strategy.NewGitRepoSyncStrategy(vcsProvider)
+	gitSync := cfg.SessionTracking.GitSync
+	gitStrategy := strategy.NewGitRepoSyncStrategy(vcsProvider, boardService, strategy.MergeDetection{
+		Enabled:        gitSync.DetectMerges,
+		SquashMerges:   gitSync.DetectSquashMerges,
+		DeleteBranches: gitSync.DeleteMergedBranches,
+		DefaultBranch:  gitSync.DefaultBranch,
+	})
 	strategies = append(strategies, gitStrategy)
 
 	generalStrategy := strategy.NewGeneralSyncStrategy(cfg.SessionTracking.GeneralBoardName)
@@ -239,8 +352,20 @@ func ProvideBoardSyncStrategies(
 	return strategies
 }
 
-func ProvideActionRepository(cfg *config.Config) repository.ActionRepository {
-	return filesystem.NewActionRepository(cfg)
+func ProvideActionRepository(repos *persistence.Repositories) repository.ActionRepository {
+	return repos.Actions
+}
+
+func ProvideActionExecutionRepository(repos *persistence.Repositories) repository.ActionExecutionRepository {
+	return repos.ActionExecutions
+}
+
+func ProvideWorkSchedule(cfg *config.Config) *entity.WorkSchedule {
+	workSchedule := entity.NewDefaultWorkSchedule("default")
+	if cfg.Actions.Timezone != "" {
+		workSchedule.SetTimezone(cfg.Actions.Timezone)
+	}
+	return workSchedule
 }
 
 func ProvideEventBus() entity.EventBus {
@@ -255,6 +380,14 @@ func ProvideScriptRunner(cfg *config.Config) entity.ScriptRunner {
 	return external.NewScriptExecutor(cfg.Actions.ScriptsEnabled, cfg.Actions.ScriptsDir)
 }
 
+func ProvideCommandRunner(cfg *config.Config) entity.CommandRunner {
+	return external.NewScriptExecutor(cfg.Actions.ScriptsEnabled, cfg.Actions.ScriptsDir)
+}
+
+func ProvideWebhookSender() entity.WebhookSender {
+	return external.NewHTTPWebhookSender()
+}
+
 func ProvideTaskMutator(
 	createTaskUseCase *task.CreateTaskUseCase,
 	updateTaskUseCase *task.UpdateTaskUseCase,
@@ -263,14 +396,14 @@ func ProvideTaskMutator(
 	return service2.NewTaskMutatorService(createTaskUseCase, updateTaskUseCase, moveTaskUseCase)
 }
 
-func ProvideProjectRepository(cfg *config.Config) repository.ProjectRepository {
-	return filesystem.NewProjectRepository(cfg.Storage.DataPath)
+func ProvideProjectRepository(repos *persistence.Repositories) repository.ProjectRepository {
+	return repos.Projects
 }
 
-func ProvideTimeLogRepository(cfg *config.Config) repository.TimeLogRepository {
-	return filesystem.NewTimeLogRepository(cfg.Storage.DataPath)
+func ProvideTimeLogRepository(repos *persistence.Repositories) repository.TimeLogRepository {
+	return repos.TimeLogs
 }
 
-func ProvideNoteRepository(cfg *config.Config) repository.NoteRepository {
-	return filesystem.NewNoteRepository(cfg.Storage.DataPath)
+func ProvideNoteRepository(repos *persistence.Repositories) repository.NoteRepository {
+	return repos.Notes
 }
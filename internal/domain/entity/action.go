@@ -16,9 +16,12 @@ type Action struct {
 	trigger     Trigger
 	actionType  ActionType
 	conditions  *ConditionGroup
+	aggregation *Aggregation
 	createdAt   time.Time
 	modifiedAt  time.Time
 	lastRun     *time.Time
+
+	consecutiveFailures int
 }
 
 // NewAction creates a new Action entity
@@ -114,6 +117,11 @@ func (a *Action) Conditions() *ConditionGroup {
 	return a.conditions
 }
 
+// Aggregation returns how the action groups tasks, nil for per-task actions
+func (a *Action) Aggregation() *Aggregation {
+	return a.aggregation
+}
+
 // CreatedAt returns when the action was created
 func (a *Action) CreatedAt() time.Time {
 	return a.createdAt
@@ -133,6 +141,32 @@ func (a *Action) LastRun() *time.Time {
 	return &lastRunCopy
 }
 
+// ConsecutiveFailures returns how many executions in a row have failed
+func (a *Action) ConsecutiveFailures() int {
+	return a.consecutiveFailures
+}
+
+// RecordSuccess resets the consecutive failure count
+func (a *Action) RecordSuccess() {
+	a.consecutiveFailures = 0
+}
+
+// RecordFailure increments the consecutive failure count and returns it
+func (a *Action) RecordFailure() int {
+	a.consecutiveFailures++
+	a.modifiedAt = time.Now()
+	return a.consecutiveFailures
+}
+
+// Restore reapplies persisted state when an action is loaded from storage
+func (a *Action) Restore(enabled bool, createdAt, modifiedAt time.Time, lastRun *time.Time, consecutiveFailures int) {
+	a.enabled = enabled
+	a.createdAt = createdAt
+	a.modifiedAt = modifiedAt
+	a.lastRun = lastRun
+	a.consecutiveFailures = consecutiveFailures
+}
+
 // UpdateName updates the action name
 func (a *Action) UpdateName(name string) error {
 	if name == "" {
@@ -149,9 +183,10 @@ func (a *Action) UpdateDescription(description string) {
 	a.modifiedAt = time.Now()
 }
 
-// Enable enables the action
+// Enable enables the action and gives it a clean failure record
 func (a *Action) Enable() {
 	a.enabled = true
+	a.consecutiveFailures = 0
 	a.modifiedAt = time.Now()
 }
 
@@ -190,6 +225,13 @@ func (a *Action) UpdateConditions(conditions *ConditionGroup) {
 	a.modifiedAt = time.Now()
 }
 
+// UpdateAggregation makes the action run once per group of tasks; nil makes
+// it run per task again
+func (a *Action) UpdateAggregation(aggregation *Aggregation) {
+	a.aggregation = aggregation
+	a.modifiedAt = time.Now()
+}
+
 // MarkAsRun marks the action as having been run
 func (a *Action) MarkAsRun() {
 	now := time.Now()
@@ -246,6 +288,7 @@ func (a *Action) Clone(newID string, newScopeID string, newScope valueobject.Act
 		trigger:     a.trigger,
 		actionType:  a.actionType,
 		conditions:  a.conditions,
+		aggregation: a.aggregation,
 		createdAt:   now,
 		modifiedAt:  now,
 		lastRun:     nil, // Reset last run for cloned action
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"mkanban/internal/domain/valueobject"
+	"time"
+)
+
+// ExecutionOutcome represents how an action execution ended
+type ExecutionOutcome string
+
+const (
+	ExecutionOutcomeSuccess ExecutionOutcome = "success"
+	ExecutionOutcomeFailure ExecutionOutcome = "failure"
+)
+
+// ActionExecution records a single attempt to run an action
+type ActionExecution struct {
+	ID          string
+	ActionID    string
+	ActionName  string
+	TriggerType TriggerType
+	EventType   valueobject.EventType
+	EventID     string
+	BoardID     string
+	TaskID      string
+	Attempt     int
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Outcome     ExecutionOutcome
+	Error       string
+	Output      string
+}
+
+// NewActionExecution starts recording an execution of the action in the given trigger context
+func NewActionExecution(id string, action *Action, ctx *TriggerContext, attempt int) *ActionExecution {
+	execution := &ActionExecution{
+		ID:          id,
+		ActionID:    action.ID(),
+		ActionName:  action.Name(),
+		TriggerType: action.Trigger().Type(),
+		Attempt:     attempt,
+		StartedAt:   time.Now(),
+	}
+
+	if ctx != nil {
+		if ctx.Event != nil {
+			execution.EventType = ctx.Event.Type
+			execution.EventID = ctx.Event.ID
+		}
+		if ctx.Board != nil {
+			execution.BoardID = ctx.Board.ID()
+		}
+		if ctx.Task != nil {
+			execution.TaskID = ctx.Task.ID().String()
+		}
+	}
+
+	return execution
+}
+
+// Finish records the end of the execution with its error (nil on success) and output
+func (e *ActionExecution) Finish(err error, output string) {
+	e.FinishedAt = time.Now()
+	e.Output = output
+	if err != nil {
+		e.Outcome = ExecutionOutcomeFailure
+		e.Error = err.Error()
+		return
+	}
+	e.Outcome = ExecutionOutcomeSuccess
+}
+
+// Succeeded returns whether the execution completed without error
+func (e *ActionExecution) Succeeded() bool {
+	return e.Outcome == ExecutionOutcomeSuccess
+}
+
+// Duration returns how long the execution took
+func (e *ActionExecution) Duration() time.Duration {
+	if e.FinishedAt.IsZero() {
+		return 0
+	}
+	return e.FinishedAt.Sub(e.StartedAt)
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// templateData is what action templates are rendered with. Templates use Go
+// template syntax, e.g. "{{.Count}} overdue on {{.Board.Name}}" or
+// "{{range .Tasks}}- {{.ID}} {{.Title}}\n{{end}}"; the older "{{task.title}}"
+// placeholders keep working through the task, column and board functions.
+type templateData struct {
+	Task   *Task
+	Column *Column
+	Board  *Board
+	Event  *DomainEvent
+	// Tasks are the tasks an aggregate action matched
+	Tasks []*Task
+	Count int
+}
+
+// templateFuncs returns the functions available to action templates
+func templateFuncs(ctx *ActionContext) template.FuncMap {
+	return template.FuncMap{
+		"task": func() map[string]string {
+			fields := map[string]string{"id": "", "title": "", "priority": "", "status": "", "due_date": ""}
+			if ctx != nil && ctx.Task != nil {
+				fields["id"] = ctx.Task.ID().String()
+				fields["title"] = ctx.Task.Title()
+				fields["priority"] = ctx.Task.Priority().String()
+				fields["status"] = ctx.Task.Status().String()
+				if due := ctx.Task.DueDate(); due != nil {
+					fields["due_date"] = due.Format("2006-01-02")
+				}
+			}
+			return fields
+		},
+		"column": func() map[string]string {
+			fields := map[string]string{"name": ""}
+			if ctx != nil && ctx.Column != nil {
+				fields["name"] = ctx.Column.Name()
+			}
+			return fields
+		},
+		"board": func() map[string]string {
+			fields := map[string]string{"id": "", "name": ""}
+			if ctx != nil && ctx.Board != nil {
+				fields["id"] = ctx.Board.ID()
+				fields["name"] = ctx.Board.Name()
+			}
+			return fields
+		},
+		// sum adds up a numeric field (see TaskNumber) over tasks
+		"sum": func(field string, tasks []*Task) float64 {
+			total := 0.0
+			for _, task := range tasks {
+				if v, ok := TaskNumber(task, field); ok {
+					total += v
+				}
+			}
+			return total
+		},
+	}
+}
+
+// parseTemplate parses an action template
+func parseTemplate(text string, ctx *ActionContext) (*template.Template, error) {
+	return template.New("action").Funcs(templateFuncs(ctx)).Parse(text)
+}
+
+// validateTemplate checks that an action template parses
+func validateTemplate(text string) error {
+	if _, err := parseTemplate(text, nil); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// renderTemplate renders an action template in the given context
+func renderTemplate(text string, ctx *ActionContext) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := parseTemplate(text, ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	data := templateData{}
+	if ctx != nil {
+		data = templateData{
+			Task:   ctx.Task,
+			Column: ctx.Column,
+			Board:  ctx.Board,
+			Event:  ctx.Event,
+			Tasks:  ctx.Tasks,
+			Count:  len(ctx.Tasks),
+		}
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return sb.String(), nil
+}
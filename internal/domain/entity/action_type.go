@@ -1,6 +1,12 @@
 package entity
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
 	"mkanban/internal/domain/valueobject"
 )
 
@@ -8,11 +14,13 @@ import (
 type ActionTypeEnum string
 
 const (
-	ActionTypeNotification  ActionTypeEnum = "notification"
-	ActionTypeScript        ActionTypeEnum = "script"
-	ActionTypeTaskMutation  ActionTypeEnum = "task_mutation"
-	ActionTypeTaskMovement  ActionTypeEnum = "task_movement"
-	ActionTypeTaskCreation  ActionTypeEnum = "task_creation"
+	ActionTypeNotification ActionTypeEnum = "notification"
+	ActionTypeScript       ActionTypeEnum = "script"
+	ActionTypeTaskMutation ActionTypeEnum = "task_mutation"
+	ActionTypeTaskMovement ActionTypeEnum = "task_movement"
+	ActionTypeTaskCreation ActionTypeEnum = "task_creation"
+	ActionTypeWebhook      ActionTypeEnum = "webhook"
+	ActionTypeCommand      ActionTypeEnum = "command"
 )
 
 // ActionType defines the interface for different action types
@@ -20,17 +28,28 @@ type ActionType interface {
 	Type() ActionTypeEnum
 	Execute(ctx *ActionContext) error
 	Validate() error
+	// Describe explains what Execute would do in the given context,
+	// without doing it
+	Describe(ctx *ActionContext) string
 }
 
 // ActionContext contains information needed to execute an action
 type ActionContext struct {
-	Task         *Task
-	Column       *Column
-	Board        *Board
-	Event        *DomainEvent
-	Notifier     Notifier
-	ScriptRunner ScriptRunner
-	TaskMutator  TaskMutator
+	Task          *Task
+	Column        *Column
+	Board         *Board
+	Event         *DomainEvent
+	Notifier      Notifier
+	ScriptRunner  ScriptRunner
+	TaskMutator   TaskMutator
+	WebhookSender WebhookSender
+	CommandRunner CommandRunner
+
+	// Tasks are the tasks an aggregate action matched; Task is nil then
+	Tasks []*Task
+
+	// Output collects anything the action produced, e.g. script stdout/stderr
+	Output string
 }
 
 // Notifier interface for sending notifications
@@ -38,9 +57,38 @@ type Notifier interface {
 	SendNotification(title, message string, metadata map[string]string) error
 }
 
-// ScriptRunner interface for executing scripts
+// ScriptRunner interface for executing scripts. It returns the combined
+// output of the script, even when the script fails.
 type ScriptRunner interface {
-	RunScript(scriptPath string, env map[string]string) error
+	RunScript(scriptPath string, env map[string]string) (string, error)
+}
+
+// WebhookSender interface for delivering webhooks. It returns a summary of
+// the response, even when the delivery fails.
+type WebhookSender interface {
+	SendWebhook(req *WebhookRequest) (string, error)
+}
+
+// WebhookRequest is a single webhook delivery: where to send it, how, and
+// the context the payload is built from
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Secret  string        // signs the payload with HMAC-SHA256 when set
+	Timeout time.Duration // per request; zero uses the sender's default
+	Retries int           // extra attempts after a failed request
+
+	Event  *DomainEvent
+	Board  *Board
+	Column *Column
+	Task   *Task
+	Tasks  []*Task
+}
+
+// CommandRunner interface for running inline commands. It returns the
+// combined output of the command, even when the command fails.
+type CommandRunner interface {
+	RunCommand(command string, args []string, env map[string]string, timeout time.Duration) (string, error)
 }
 
 // TaskMutator interface for mutating tasks
@@ -77,9 +125,14 @@ func (a *NotificationAction) Execute(ctx *ActionContext) error {
 		return ErrNotifierNotAvailable
 	}
 
-	// Template replacement for title and message
-	title := a.interpolateTemplate(a.Title, ctx)
-	message := a.interpolateTemplate(a.Message, ctx)
+	title, err := renderTemplate(a.Title, ctx)
+	if err != nil {
+		return err
+	}
+	message, err := renderTemplate(a.Message, ctx)
+	if err != nil {
+		return err
+	}
 
 	return ctx.Notifier.SendNotification(title, message, a.Metadata)
 }
@@ -92,18 +145,24 @@ func (a *NotificationAction) Validate() error {
 	if a.Message == "" {
 		return ErrInvalidNotificationMessage
 	}
-	return nil
+	if err := validateTemplate(a.Title); err != nil {
+		return err
+	}
+	return validateTemplate(a.Message)
 }
 
-// interpolateTemplate replaces template variables in strings
+// Describe explains the notification that would be sent
+func (a *NotificationAction) Describe(ctx *ActionContext) string {
+	return fmt.Sprintf("Send notification %q: %q",
+		a.interpolateTemplate(a.Title, ctx), a.interpolateTemplate(a.Message, ctx))
+}
+
+// interpolateTemplate renders a template, leaving it as written when it
+// can't be rendered in the context
 func (a *NotificationAction) interpolateTemplate(template string, ctx *ActionContext) string {
-	// Simple template replacement
-	// TODO: Implement proper template engine
-	result := template
-	if ctx.Task != nil {
-		// Replace common variables
-		// result = strings.ReplaceAll(result, "{{task.title}}", ctx.Task.Title())
-		// etc.
+	result, err := renderTemplate(template, ctx)
+	if err != nil {
+		return template
 	}
 	return result
 }
@@ -133,28 +192,18 @@ func (a *ScriptAction) Execute(ctx *ActionContext) error {
 		return ErrScriptRunnerNotAvailable
 	}
 
-	// Build environment variables with context
-	env := make(map[string]string)
-	for k, v := range a.EnvVars {
-		env[k] = v
-	}
+	output, err := ctx.ScriptRunner.RunScript(a.ScriptPath, contextEnv(ctx, a.EnvVars))
+	ctx.Output = output
+	return err
+}
 
-	// Add context variables
-	if ctx.Task != nil {
-		env["TASK_ID"] = ctx.Task.ID().String()
-		env["TASK_TITLE"] = ctx.Task.Title()
-		env["TASK_PRIORITY"] = ctx.Task.Priority().String()
-		env["TASK_STATUS"] = ctx.Task.Status().String()
-	}
-	if ctx.Board != nil {
-		env["BOARD_ID"] = ctx.Board.ID()
-		env["BOARD_NAME"] = ctx.Board.Name()
+// Describe explains which script would run
+func (a *ScriptAction) Describe(ctx *ActionContext) string {
+	description := fmt.Sprintf("Run script %s", a.ScriptPath)
+	if len(a.EnvVars) > 0 {
+		description += fmt.Sprintf(" with %s", formatStringMap(a.EnvVars))
 	}
-	if ctx.Column != nil {
-		env["COLUMN_NAME"] = ctx.Column.Name()
-	}
-
-	return ctx.ScriptRunner.RunScript(a.ScriptPath, env)
+	return description
 }
 
 // Validate checks if the script action is valid
@@ -168,6 +217,7 @@ func (a *ScriptAction) Validate() error {
 // TaskMutationAction updates task fields
 type TaskMutationAction struct {
 	UpdatePriority *valueobject.Priority
+	BumpPriority   bool // raise the priority one level instead of setting it
 	UpdateStatus   *valueobject.Status
 	AddTags        []string
 	RemoveTags     []string
@@ -188,42 +238,88 @@ func (a *TaskMutationAction) Type() ActionTypeEnum {
 	return ActionTypeTaskMutation
 }
 
-// Execute mutates the task
+// Execute mutates the task, or every matched task of an aggregate action
 func (a *TaskMutationAction) Execute(ctx *ActionContext) error {
-	if ctx.Task == nil {
+	tasks := ctx.targetTasks()
+	if len(tasks) == 0 {
 		return ErrTaskNotFound
 	}
 	if ctx.TaskMutator == nil {
 		return ErrTaskMutatorNotAvailable
 	}
 
-	// Apply mutations
+	for _, task := range tasks {
+		if err := a.mutate(task); err != nil {
+			return err
+		}
+		// Persist the changes
+		if err := ctx.TaskMutator.UpdateTask(ctx.Board.ID(), task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mutate applies the changes to a task
+func (a *TaskMutationAction) mutate(task *Task) error {
 	if a.UpdatePriority != nil {
-		if err := ctx.Task.UpdatePriority(*a.UpdatePriority); err != nil {
+		if err := task.UpdatePriority(*a.UpdatePriority); err != nil {
+			return err
+		}
+	}
+
+	if a.BumpPriority {
+		if err := task.UpdatePriority(task.Priority().Raised()); err != nil {
 			return err
 		}
 	}
 
 	if a.UpdateStatus != nil {
-		if err := ctx.Task.UpdateStatus(*a.UpdateStatus); err != nil {
+		if err := task.UpdateStatus(*a.UpdateStatus); err != nil {
 			return err
 		}
 	}
 
 	for _, tag := range a.AddTags {
-		ctx.Task.AddTag(tag)
+		task.AddTag(tag)
 	}
 
 	for _, tag := range a.RemoveTags {
-		ctx.Task.RemoveTag(tag)
+		task.RemoveTag(tag)
 	}
 
 	for key, value := range a.SetMetadata {
-		ctx.Task.SetMetadata(key, value)
+		task.SetMetadata(key, value)
 	}
+	return nil
+}
 
-	// Persist the changes
-	return ctx.TaskMutator.UpdateTask(ctx.Board.ID(), ctx.Task)
+// Describe lists the changes that would be made to the task
+func (a *TaskMutationAction) Describe(ctx *ActionContext) string {
+	changes := make([]string, 0)
+	if a.UpdatePriority != nil {
+		changes = append(changes, fmt.Sprintf("set priority to %s", a.UpdatePriority.String()))
+	}
+	if a.BumpPriority {
+		changes = append(changes, "raise priority one level")
+	}
+	if a.UpdateStatus != nil {
+		changes = append(changes, fmt.Sprintf("set status to %s", a.UpdateStatus.String()))
+	}
+	if len(a.AddTags) > 0 {
+		changes = append(changes, fmt.Sprintf("add tags %s", strings.Join(a.AddTags, ", ")))
+	}
+	if len(a.RemoveTags) > 0 {
+		changes = append(changes, fmt.Sprintf("remove tags %s", strings.Join(a.RemoveTags, ", ")))
+	}
+	if len(a.SetMetadata) > 0 {
+		changes = append(changes, fmt.Sprintf("set metadata %s", formatStringMap(a.SetMetadata)))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "no changes")
+	}
+
+	return fmt.Sprintf("Update %s: %s", ctx.describeTargets(), strings.Join(changes, "; "))
 }
 
 // Validate checks if the task mutation action is valid
@@ -231,6 +327,9 @@ func (a *TaskMutationAction) Validate() error {
 	if a.UpdatePriority != nil && !a.UpdatePriority.IsValid() {
 		return ErrInvalidPriority
 	}
+	if a.UpdatePriority != nil && a.BumpPriority {
+		return fmt.Errorf("%w: set the priority or bump it, not both", ErrInvalidPriority)
+	}
 	if a.UpdateStatus != nil && !a.UpdateStatus.IsValid() {
 		return ErrInvalidStatus
 	}
@@ -254,16 +353,27 @@ func (a *TaskMovementAction) Type() ActionTypeEnum {
 	return ActionTypeTaskMovement
 }
 
-// Execute moves the task
+// Execute moves the task, or every matched task of an aggregate action
 func (a *TaskMovementAction) Execute(ctx *ActionContext) error {
-	if ctx.Task == nil {
+	tasks := ctx.targetTasks()
+	if len(tasks) == 0 {
 		return ErrTaskNotFound
 	}
 	if ctx.TaskMutator == nil {
 		return ErrTaskMutatorNotAvailable
 	}
 
-	return ctx.TaskMutator.MoveTask(ctx.Board.ID(), ctx.Task.ID(), a.TargetColumn)
+	for _, task := range tasks {
+		if err := ctx.TaskMutator.MoveTask(ctx.Board.ID(), task.ID(), a.TargetColumn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe explains where the task would be moved
+func (a *TaskMovementAction) Describe(ctx *ActionContext) string {
+	return fmt.Sprintf("Move %s to column %q", ctx.describeTargets(), a.TargetColumn)
 }
 
 // Validate checks if the task movement action is valid
@@ -309,14 +419,23 @@ func (a *TaskCreationAction) Execute(ctx *ActionContext) error {
 		return ErrTaskMutatorNotAvailable
 	}
 
+	title, err := renderTemplate(a.Title, ctx)
+	if err != nil {
+		return err
+	}
+	description, err := renderTemplate(a.Description, ctx)
+	if err != nil {
+		return err
+	}
+
 	// Generate task ID
-	taskID, err := ctx.Board.GenerateNextTaskID(a.Title)
+	taskID, err := ctx.Board.GenerateNextTaskID(title)
 	if err != nil {
 		return err
 	}
 
 	// Create new task
-	task, err := NewTask(taskID, a.Title, a.Description, a.Priority, a.Status)
+	task, err := NewTask(taskID, title, description, a.Priority, a.Status)
 	if err != nil {
 		return err
 	}
@@ -332,6 +451,20 @@ func (a *TaskCreationAction) Execute(ctx *ActionContext) error {
 	return ctx.TaskMutator.CreateTask(ctx.Board.ID(), a.ColumnName, task)
 }
 
+// Describe explains which task would be created
+func (a *TaskCreationAction) Describe(ctx *ActionContext) string {
+	title, err := renderTemplate(a.Title, ctx)
+	if err != nil {
+		title = a.Title
+	}
+	description := fmt.Sprintf("Create task %q in column %q (priority %s, status %s)",
+		title, a.ColumnName, a.Priority.String(), a.Status.String())
+	if len(a.Tags) > 0 {
+		description += fmt.Sprintf(" tagged %s", strings.Join(a.Tags, ", "))
+	}
+	return description
+}
+
 // Validate checks if the task creation action is valid
 func (a *TaskCreationAction) Validate() error {
 	if a.Title == "" {
@@ -346,5 +479,228 @@ func (a *TaskCreationAction) Validate() error {
 	if !a.Status.IsValid() {
 		return ErrInvalidStatus
 	}
+	if err := validateTemplate(a.Title); err != nil {
+		return err
+	}
+	return validateTemplate(a.Description)
+}
+
+// WebhookAction posts a JSON payload describing the task and event to a URL
+type WebhookAction struct {
+	URL     string
+	Headers map[string]string
+	Secret  string
+	Timeout time.Duration
+	Retries int
+}
+
+// NewWebhookAction creates a new webhook action
+func NewWebhookAction(url string) *WebhookAction {
+	return &WebhookAction{
+		URL:     url,
+		Headers: make(map[string]string),
+	}
+}
+
+// Type returns the action type
+func (a *WebhookAction) Type() ActionTypeEnum {
+	return ActionTypeWebhook
+}
+
+// Execute delivers the webhook
+func (a *WebhookAction) Execute(ctx *ActionContext) error {
+	if ctx.WebhookSender == nil {
+		return ErrWebhookSenderNotAvailable
+	}
+
+	output, err := ctx.WebhookSender.SendWebhook(&WebhookRequest{
+		URL:     a.URL,
+		Headers: a.Headers,
+		Secret:  a.Secret,
+		Timeout: a.Timeout,
+		Retries: a.Retries,
+		Event:   ctx.Event,
+		Board:   ctx.Board,
+		Column:  ctx.Column,
+		Task:    ctx.Task,
+		Tasks:   ctx.Tasks,
+	})
+	ctx.Output = output
+	return err
+}
+
+// Describe explains where the webhook would be sent
+func (a *WebhookAction) Describe(ctx *ActionContext) string {
+	description := fmt.Sprintf("POST %s", a.URL)
+	if a.Secret != "" {
+		description += " (signed)"
+	}
+	if ctx != nil && len(ctx.Tasks) > 0 {
+		description += fmt.Sprintf(" with %d tasks", len(ctx.Tasks))
+	}
+	return description
+}
+
+// Validate checks if the webhook action is valid
+func (a *WebhookAction) Validate() error {
+	u, err := url.Parse(a.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if a.Timeout < 0 || a.Retries < 0 {
+		return fmt.Errorf("webhook timeout and retries cannot be negative")
+	}
+	return nil
+}
+
+// CommandAction runs a command with arguments, without needing a script file.
+// Arguments are templates, like notification messages.
+type CommandAction struct {
+	Command string
+	Args    []string
+	EnvVars map[string]string
+	Timeout time.Duration
+}
+
+// NewCommandAction creates a new command action
+func NewCommandAction(command string, args []string) *CommandAction {
+	return &CommandAction{
+		Command: command,
+		Args:    args,
+		EnvVars: make(map[string]string),
+	}
+}
+
+// Type returns the action type
+func (a *CommandAction) Type() ActionTypeEnum {
+	return ActionTypeCommand
+}
+
+// Execute runs the command
+func (a *CommandAction) Execute(ctx *ActionContext) error {
+	if ctx.CommandRunner == nil {
+		return ErrCommandRunnerNotAvailable
+	}
+
+	args, err := a.renderArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := ctx.CommandRunner.RunCommand(a.Command, args, contextEnv(ctx, a.EnvVars), a.Timeout)
+	ctx.Output = output
+	return err
+}
+
+// Describe shows the command line that would run
+func (a *CommandAction) Describe(ctx *ActionContext) string {
+	args, err := a.renderArgs(ctx)
+	if err != nil {
+		args = a.Args
+	}
+
+	parts := []string{a.Command}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		parts = append(parts, arg)
+	}
+	return fmt.Sprintf("Run command: %s", strings.Join(parts, " "))
+}
+
+// Validate checks if the command action is valid
+func (a *CommandAction) Validate() error {
+	if strings.TrimSpace(a.Command) == "" {
+		return ErrInvalidCommand
+	}
+	if a.Timeout < 0 {
+		return fmt.Errorf("command timeout cannot be negative")
+	}
+	for _, arg := range a.Args {
+		if err := validateTemplate(arg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// renderArgs renders the argument templates
+func (a *CommandAction) renderArgs(ctx *ActionContext) ([]string, error) {
+	args := make([]string, 0, len(a.Args))
+	for _, arg := range a.Args {
+		rendered, err := renderTemplate(arg, ctx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, rendered)
+	}
+	return args, nil
+}
+
+// contextEnv builds the environment of scripts and commands: the configured
+// variables plus TASK_*, BOARD_* and COLUMN_NAME from the context
+func contextEnv(ctx *ActionContext, vars map[string]string) map[string]string {
+	env := make(map[string]string)
+	for k, v := range vars {
+		env[k] = v
+	}
+
+	if ctx.Task != nil {
+		env["TASK_ID"] = ctx.Task.ID().String()
+		env["TASK_TITLE"] = ctx.Task.Title()
+		env["TASK_PRIORITY"] = ctx.Task.Priority().String()
+		env["TASK_STATUS"] = ctx.Task.Status().String()
+	}
+	if ctx.Board != nil {
+		env["BOARD_ID"] = ctx.Board.ID()
+		env["BOARD_NAME"] = ctx.Board.Name()
+	}
+	if ctx.Column != nil {
+		env["COLUMN_NAME"] = ctx.Column.Name()
+	}
+	if len(ctx.Tasks) > 0 {
+		ids := make([]string, 0, len(ctx.Tasks))
+		for _, task := range ctx.Tasks {
+			ids = append(ids, task.ID().String())
+		}
+		env["TASK_COUNT"] = fmt.Sprint(len(ctx.Tasks))
+		env["TASK_IDS"] = strings.Join(ids, ",")
+	}
+	return env
+}
+
+// targetTasks returns the tasks a task action applies to: the task, or the
+// matched tasks of an aggregate action
+func (ctx *ActionContext) targetTasks() []*Task {
+	if ctx.Task != nil {
+		return []*Task{ctx.Task}
+	}
+	return ctx.Tasks
+}
+
+// describeTargets names the tasks a task action applies to
+func (ctx *ActionContext) describeTargets() string {
+	if ctx == nil {
+		return "the task"
+	}
+	tasks := ctx.targetTasks()
+	switch len(tasks) {
+	case 0:
+		return "the task"
+	case 1:
+		return tasks[0].ID().String()
+	default:
+		return fmt.Sprintf("%d tasks", len(tasks))
+	}
+}
+
+// formatStringMap renders a map as sorted key=value pairs
+func formatStringMap(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// ActivityKind is what an entry of a task's activity records
+type ActivityKind string
+
+const (
+	// ActivityCommit is a commit that references the task or was made on
+	// its branch
+	ActivityCommit ActivityKind = "commit"
+
+	// ActivityMerge is the commit that merged the task's branch into the
+	// default branch
+	ActivityMerge ActivityKind = "merge"
+
+	// ActivityComment is a comment made on the task in the tool it was
+	// imported from
+	ActivityComment ActivityKind = "comment"
+)
+
+// Activity is something that happened to a task outside mkanban, such as a
+// commit made for it. Ref identifies the entry within its kind, e.g. the
+// commit hash.
+type Activity struct {
+	Kind    ActivityKind
+	Ref     string
+	Summary string
+	Author  string
+	Time    time.Time
+}
@@ -0,0 +1,248 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AggregateGroup is the set of tasks an aggregate action runs over
+type AggregateGroup string
+
+const (
+	// AggregateGroupBoard runs the action once per board
+	AggregateGroupBoard AggregateGroup = "board"
+	// AggregateGroupColumn runs the action once per column
+	AggregateGroupColumn AggregateGroup = "column"
+)
+
+// IsValid checks if the group is known
+func (g AggregateGroup) IsValid() bool {
+	return g == AggregateGroupBoard || g == AggregateGroupColumn
+}
+
+// AggregateFunction reduces the matched tasks to a number
+type AggregateFunction string
+
+const (
+	AggregateCount AggregateFunction = "count"
+	AggregateSum   AggregateFunction = "sum"
+	AggregateAvg   AggregateFunction = "avg"
+	AggregateMin   AggregateFunction = "min"
+	AggregateMax   AggregateFunction = "max"
+)
+
+// AggregateValueWIPLimit compares against the column's WIP limit
+const AggregateValueWIPLimit = "wip_limit"
+
+// AggregateCondition compares a count or sum over the matched tasks, e.g.
+// "count gt 5" or "sum estimated_hours gte 40"
+type AggregateCondition struct {
+	Function AggregateFunction
+	Field    string // numeric task field; unused for count
+	Operator ConditionOperator
+	Value    interface{} // a number, or "wip_limit"
+}
+
+// NewAggregateCondition creates an aggregate condition
+func NewAggregateCondition(function AggregateFunction, field string, operator ConditionOperator, value interface{}) (*AggregateCondition, error) {
+	switch function {
+	case AggregateCount:
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+		if field == "" {
+			return nil, fmt.Errorf("%w: %s needs a field", ErrInvalidAggregation, function)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown function %q", ErrInvalidAggregation, function)
+	}
+
+	switch operator {
+	case OperatorEquals, OperatorNotEquals, OperatorGreaterThan, OperatorLessThan,
+		OperatorGreaterOrEqual, OperatorLessOrEqual:
+	default:
+		return nil, fmt.Errorf("%w: operator %q cannot compare numbers", ErrInvalidAggregation, operator)
+	}
+
+	if s, ok := value.(string); ok && s != AggregateValueWIPLimit {
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidAggregation, s)
+		}
+	}
+
+	return &AggregateCondition{
+		Function: function,
+		Field:    field,
+		Operator: operator,
+		Value:    value,
+	}, nil
+}
+
+// Evaluate checks the condition against the matched tasks of a group. The
+// column is nil when the group is a whole board.
+func (c *AggregateCondition) Evaluate(tasks []*Task, column *Column) bool {
+	actual, ok := c.reduce(tasks)
+	if !ok {
+		return false
+	}
+	expected, ok := c.threshold(column)
+	if !ok {
+		return false
+	}
+
+	switch c.Operator {
+	case OperatorEquals:
+		return actual == expected
+	case OperatorNotEquals:
+		return actual != expected
+	case OperatorGreaterThan:
+		return actual > expected
+	case OperatorLessThan:
+		return actual < expected
+	case OperatorGreaterOrEqual:
+		return actual >= expected
+	case OperatorLessOrEqual:
+		return actual <= expected
+	default:
+		return false
+	}
+}
+
+// reduce applies the function; tasks without a value for the field are skipped
+func (c *AggregateCondition) reduce(tasks []*Task) (float64, bool) {
+	if c.Function == AggregateCount {
+		return float64(len(tasks)), true
+	}
+
+	values := make([]float64, 0, len(tasks))
+	for _, task := range tasks {
+		if v, ok := TaskNumber(task, c.Field); ok {
+			values = append(values, v)
+		}
+	}
+
+	if c.Function == AggregateSum {
+		return sumOf(values), true
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	result := values[0]
+	switch c.Function {
+	case AggregateAvg:
+		return sumOf(values) / float64(len(values)), true
+	case AggregateMin:
+		for _, v := range values[1:] {
+			if v < result {
+				result = v
+			}
+		}
+	case AggregateMax:
+		for _, v := range values[1:] {
+			if v > result {
+				result = v
+			}
+		}
+	}
+	return result, true
+}
+
+// threshold resolves the value to compare against
+func (c *AggregateCondition) threshold(column *Column) (float64, bool) {
+	switch v := c.Value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		if v == AggregateValueWIPLimit {
+			if column == nil || column.WIPLimit() <= 0 {
+				return 0, false
+			}
+			return float64(column.WIPLimit()), true
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// String formats the condition the way it is written on the command line
+func (c *AggregateCondition) String() string {
+	if c.Function == AggregateCount {
+		return fmt.Sprintf("count %s %v", c.Operator, c.Value)
+	}
+	return fmt.Sprintf("%s %s %s %v", c.Function, c.Field, c.Operator, c.Value)
+}
+
+func sumOf(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// TaskNumber returns a numeric field of a task for aggregates:
+// estimated_hours, tracked_hours, age_days, overdue_days, or a numeric
+// metadata value
+func TaskNumber(task *Task, field string) (float64, bool) {
+	switch field {
+	case "estimated_hours":
+		if task.EstimatedTime() == nil {
+			return 0, false
+		}
+		return task.EstimatedTime().Hours(), true
+	case "tracked_hours":
+		return task.TrackedTime().Hours(), true
+	case "age_days":
+		return time.Since(task.CreatedAt()).Hours() / 24, true
+	case "overdue_days":
+		if !task.IsOverdue() {
+			return 0, false
+		}
+		return time.Since(*task.DueDate()).Hours() / 24, true
+	default:
+		value, ok := task.GetMetadata(field)
+		if !ok {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		return n, err == nil
+	}
+}
+
+// Aggregation turns an action into one that runs once per group of tasks
+// instead of once per task. The action's task conditions select the tasks
+// of each group; the aggregate conditions, all of which must hold, then
+// decide whether the group fires. Without aggregate conditions a group
+// fires when at least one task matched.
+type Aggregation struct {
+	GroupBy    AggregateGroup
+	Conditions []*AggregateCondition
+}
+
+// NewAggregation creates an aggregation
+func NewAggregation(groupBy AggregateGroup, conditions ...*AggregateCondition) (*Aggregation, error) {
+	if !groupBy.IsValid() {
+		return nil, fmt.Errorf("%w: unknown group %q", ErrInvalidAggregation, groupBy)
+	}
+	return &Aggregation{
+		GroupBy:    groupBy,
+		Conditions: conditions,
+	}, nil
+}
+
+// Holds checks the aggregate conditions against the matched tasks of a group
+func (a *Aggregation) Holds(tasks []*Task, column *Column) bool {
+	if len(a.Conditions) == 0 {
+		return len(tasks) > 0
+	}
+	for _, condition := range a.Conditions {
+		if !condition.Evaluate(tasks, column) {
+			return false
+		}
+	}
+	return true
+}
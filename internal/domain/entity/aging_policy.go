@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AgingLevel is how far a task has aged past its column's aging policy
+type AgingLevel string
+
+const (
+	AgingFresh     AgingLevel = ""
+	AgingStale     AgingLevel = "stale"
+	AgingEscalated AgingLevel = "escalated"
+)
+
+// IsValid checks if the aging level is valid
+func (l AgingLevel) IsValid() bool {
+	switch l {
+	case AgingFresh, AgingStale, AgingEscalated:
+		return true
+	}
+	return false
+}
+
+// Exceeds reports whether l is a higher aging level than other
+func (l AgingLevel) Exceeds(other AgingLevel) bool {
+	return l.rank() > other.rank()
+}
+
+func (l AgingLevel) rank() int {
+	switch l {
+	case AgingStale:
+		return 1
+	case AgingEscalated:
+		return 2
+	}
+	return 0
+}
+
+// AgingPolicy defines how long tasks may stay in a column. Thresholds are in
+// working days spent in the column; a zero threshold is not used.
+type AgingPolicy struct {
+	StaleAfter    int
+	EscalateAfter int
+}
+
+// NewAgingPolicy creates an aging policy
+func NewAgingPolicy(staleAfter, escalateAfter int) (*AgingPolicy, error) {
+	if staleAfter < 0 || escalateAfter < 0 {
+		return nil, fmt.Errorf("%w: thresholds cannot be negative", ErrInvalidAgingPolicy)
+	}
+	if staleAfter == 0 && escalateAfter == 0 {
+		return nil, fmt.Errorf("%w: set stale_after, escalate_after or both", ErrInvalidAgingPolicy)
+	}
+	if staleAfter > 0 && escalateAfter > 0 && escalateAfter <= staleAfter {
+		return nil, fmt.Errorf("%w: escalate_after must be later than stale_after", ErrInvalidAgingPolicy)
+	}
+	return &AgingPolicy{StaleAfter: staleAfter, EscalateAfter: escalateAfter}, nil
+}
+
+// LevelAt returns the aging level of a task that has spent the given number
+// of working days in the column
+func (p *AgingPolicy) LevelAt(workingDays int) AgingLevel {
+	switch {
+	case p == nil:
+		return AgingFresh
+	case p.EscalateAfter > 0 && workingDays >= p.EscalateAfter:
+		return AgingEscalated
+	case p.StaleAfter > 0 && workingDays >= p.StaleAfter:
+		return AgingStale
+	}
+	return AgingFresh
+}
+
+// Levels returns the aging levels the policy has a threshold for, lowest first
+func (p *AgingPolicy) Levels() []AgingLevel {
+	levels := make([]AgingLevel, 0, 2)
+	if p == nil {
+		return levels
+	}
+	if p.StaleAfter > 0 {
+		levels = append(levels, AgingStale)
+	}
+	if p.EscalateAfter > 0 {
+		levels = append(levels, AgingEscalated)
+	}
+	return levels
+}
+
+// String describes the policy, e.g. "stale after 2, escalate after 4 working days"
+func (p *AgingPolicy) String() string {
+	parts := make([]string, 0, 2)
+	if p.StaleAfter > 0 {
+		parts = append(parts, fmt.Sprintf("stale after %d", p.StaleAfter))
+	}
+	if p.EscalateAfter > 0 {
+		parts = append(parts, fmt.Sprintf("escalate after %d", p.EscalateAfter))
+	}
+	return strings.Join(parts, ", ") + " working days"
+}
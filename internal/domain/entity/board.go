@@ -13,6 +13,7 @@ type Board struct {
 	prefix      string
 	description string
 	columns     []*Column
+	swimlanes   *Swimlanes
 	nextTaskNum int
 	createdAt   time.Time
 	modifiedAt  time.Time
@@ -58,6 +59,19 @@ func (b *Board) SetProjectID(projectID string) {
 	b.modifiedAt = time.Now()
 }
 
+// Swimlanes returns how the board's tasks are grouped into lanes, or nil
+// for a board of plain columns
+func (b *Board) Swimlanes() *Swimlanes {
+	return b.swimlanes
+}
+
+// SetSwimlanes sets how the board's tasks are grouped into lanes; nil
+// removes the lanes
+func (b *Board) SetSwimlanes(swimlanes *Swimlanes) {
+	b.swimlanes = swimlanes
+	b.modifiedAt = time.Now()
+}
+
 // Name returns the board name
 func (b *Board) Name() string {
 	return b.name
@@ -208,6 +222,7 @@ func (b *Board) MoveTask(taskID *valueobject.TaskID, targetColumnName string) er
 		_ = sourceColumn.AddTask(task)
 		return err
 	}
+	task.EnterColumn(time.Now())
 
 	b.modifiedAt = time.Now()
 	return nil
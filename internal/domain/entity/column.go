@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"mkanban/internal/domain/valueobject"
 	"time"
 )
@@ -13,6 +14,8 @@ type Column struct {
 	order       int
 	wipLimit    int
 	color       *valueobject.Color
+	agingPolicy *AgingPolicy
+	rules       []*ColumnRule
 	tasks       []*Task
 	createdAt   time.Time
 	modifiedAt  time.Time
@@ -168,6 +171,59 @@ func (c *Column) UpdateColor(color *valueobject.Color) {
 	c.modifiedAt = time.Now()
 }
 
+// AgingPolicy returns the column's aging policy, nil if it has none
+func (c *Column) AgingPolicy() *AgingPolicy {
+	return c.agingPolicy
+}
+
+// UpdateAgingPolicy sets the column's aging policy; nil removes it
+func (c *Column) UpdateAgingPolicy(policy *AgingPolicy) {
+	c.agingPolicy = policy
+	c.modifiedAt = time.Now()
+}
+
+// Rules returns a copy of the column's entry and exit rules
+func (c *Column) Rules() []*ColumnRule {
+	rulesCopy := make([]*ColumnRule, len(c.rules))
+	copy(rulesCopy, c.rules)
+	return rulesCopy
+}
+
+// RulesOn returns the column's rules checked on the given hook
+func (c *Column) RulesOn(on ColumnRuleHook) []*ColumnRule {
+	rules := make([]*ColumnRule, 0)
+	for _, rule := range c.rules {
+		if rule.On == on {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// AddRule validates and appends an entry or exit rule
+func (c *Column) AddRule(rule *ColumnRule) error {
+	if rule == nil {
+		return ErrInvalidColumnRule
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	c.rules = append(c.rules, rule)
+	c.modifiedAt = time.Now()
+	return nil
+}
+
+// RemoveRule removes the rule at index, as listed by Rules
+func (c *Column) RemoveRule(index int) (*ColumnRule, error) {
+	if index < 0 || index >= len(c.rules) {
+		return nil, fmt.Errorf("%w: no rule %d", ErrInvalidColumnRule, index+1)
+	}
+	rule := c.rules[index]
+	c.rules = append(c.rules[:index], c.rules[index+1:]...)
+	c.modifiedAt = time.Now()
+	return rule, nil
+}
+
 // AddTask adds a task to the column
 func (c *Column) AddTask(task *Task) error {
 	if task == nil {
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnRuleHook is when a column rule is checked
+type ColumnRuleHook string
+
+const (
+	RuleOnEnter ColumnRuleHook = "enter" // before a task moves into the column
+	RuleOnExit  ColumnRuleHook = "exit"  // before a task moves out of the column
+)
+
+// ColumnRuleType is what a column rule checks
+type ColumnRuleType string
+
+const (
+	// ColumnRuleRequire requires a task field to be set
+	ColumnRuleRequire ColumnRuleType = "require"
+	// ColumnRuleCondition requires a condition to hold for the task
+	ColumnRuleCondition ColumnRuleType = "condition"
+	// ColumnRuleSubtasksComplete requires every subtask checkbox in the
+	// task's description to be checked
+	ColumnRuleSubtasksComplete ColumnRuleType = "subtasks_complete"
+	// ColumnRuleScript requires a script to exit 0
+	ColumnRuleScript ColumnRuleType = "script"
+)
+
+// RuleSeverity is what happens when a column rule is violated
+type RuleSeverity string
+
+const (
+	RuleBlock RuleSeverity = "block" // the move is refused
+	RuleWarn  RuleSeverity = "warn"  // the move goes ahead with a warning
+)
+
+// RequirableFields are the task fields a require rule can name besides
+// "tag:<prefix>" and metadata keys
+var RequirableFields = []string{"description", "due_date", "estimate", "tags"}
+
+// ColumnRule is a check a task has to pass to enter or leave a column,
+// such as a definition of done on the last column
+type ColumnRule struct {
+	On       ColumnRuleHook
+	Type     ColumnRuleType
+	Severity RuleSeverity
+	// Field is the field a require rule needs: description, due_date,
+	// estimate, tags, tag:<prefix> for a tag starting with prefix, or a
+	// metadata key
+	Field     string
+	Condition *Condition // for condition rules
+	Script    string     // for script rules; gets TASK_* and COLUMN_* variables
+	Message   string     // shown when the rule is violated, instead of Describe
+}
+
+// Validate checks that the rule is complete
+func (r *ColumnRule) Validate() error {
+	switch r.On {
+	case RuleOnEnter, RuleOnExit:
+	default:
+		return fmt.Errorf("%w: on must be enter or exit, got %q", ErrInvalidColumnRule, r.On)
+	}
+
+	switch r.Severity {
+	case RuleBlock, RuleWarn:
+	default:
+		return fmt.Errorf("%w: severity must be block or warn, got %q", ErrInvalidColumnRule, r.Severity)
+	}
+
+	switch r.Type {
+	case ColumnRuleRequire:
+		if r.Field == "" || r.Field == "tag:" {
+			return fmt.Errorf("%w: require rules need a field", ErrInvalidColumnRule)
+		}
+	case ColumnRuleCondition:
+		if r.Condition == nil || r.Condition.Field == "" {
+			return fmt.Errorf("%w: condition rules need a condition", ErrInvalidColumnRule)
+		}
+	case ColumnRuleSubtasksComplete:
+	case ColumnRuleScript:
+		if r.Script == "" {
+			return fmt.Errorf("%w: %v", ErrInvalidColumnRule, ErrInvalidScriptPath)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidColumnRule, r.Type)
+	}
+
+	return nil
+}
+
+// Describe returns what the rule requires, e.g. "estimate must be set"
+func (r *ColumnRule) Describe() string {
+	switch r.Type {
+	case ColumnRuleRequire:
+		if prefix, ok := strings.CutPrefix(r.Field, "tag:"); ok {
+			return fmt.Sprintf("a tag starting with %q is required", prefix)
+		}
+		return fmt.Sprintf("%s must be set", r.Field)
+	case ColumnRuleCondition:
+		return fmt.Sprintf("%s %s %v must hold", r.Condition.Field, r.Condition.Operator, r.Condition.Value)
+	case ColumnRuleSubtasksComplete:
+		return "all subtasks must be complete"
+	case ColumnRuleScript:
+		return fmt.Sprintf("script %s must succeed", r.Script)
+	}
+	return string(r.Type)
+}
+
+// RuleViolation is a column rule a task failed when moving
+type RuleViolation struct {
+	Rule   *ColumnRule
+	Column string // display name of the column the rule belongs to
+	Reason string // why the check failed, e.g. the script's output
+}
+
+// Blocking reports whether the violation refuses the move
+func (v RuleViolation) Blocking() bool {
+	return v.Rule.Severity == RuleBlock
+}
+
+// Message describes the violation for the user
+func (v RuleViolation) Message() string {
+	message := v.Rule.Message
+	if message == "" {
+		message = v.Rule.Describe()
+	}
+	if v.Reason != "" {
+		message += ": " + v.Reason
+	}
+	return message
+}
+
+// ColumnRuleError is returned when a move is refused by column rules. It
+// carries every violation of the move, warnings included.
+type ColumnRuleError struct {
+	TaskID     string
+	Violations []RuleViolation
+}
+
+// Error lists the blocking violations
+func (e *ColumnRuleError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		if violation.Blocking() {
+			messages = append(messages, fmt.Sprintf("%s (%s %s)", violation.Message(), violation.Rule.On, violation.Column))
+		}
+	}
+	return fmt.Sprintf("%v: %s", ErrColumnRuleViolated, strings.Join(messages, "; "))
+}
+
+// Is makes errors.Is(err, ErrColumnRuleViolated) match
+func (e *ColumnRuleError) Is(target error) bool {
+	return target == ErrColumnRuleViolated
+}
@@ -8,14 +8,16 @@ import (
 type ConditionOperator string
 
 const (
-	OperatorEquals       ConditionOperator = "eq"
-	OperatorNotEquals    ConditionOperator = "ne"
-	OperatorContains     ConditionOperator = "contains"
-	OperatorNotContains  ConditionOperator = "not_contains"
-	OperatorGreaterThan  ConditionOperator = "gt"
-	OperatorLessThan     ConditionOperator = "lt"
-	OperatorIn           ConditionOperator = "in"
-	OperatorNotIn        ConditionOperator = "not_in"
+	OperatorEquals         ConditionOperator = "eq"
+	OperatorNotEquals      ConditionOperator = "ne"
+	OperatorContains       ConditionOperator = "contains"
+	OperatorNotContains    ConditionOperator = "not_contains"
+	OperatorGreaterThan    ConditionOperator = "gt"
+	OperatorLessThan       ConditionOperator = "lt"
+	OperatorGreaterOrEqual ConditionOperator = "gte"
+	OperatorLessOrEqual    ConditionOperator = "lte"
+	OperatorIn             ConditionOperator = "in"
+	OperatorNotIn          ConditionOperator = "not_in"
 )
 
 // Condition represents a filtering condition for actions
@@ -45,6 +47,8 @@ func (c *Condition) Evaluate(task *Task, column *Column) bool {
 		actualValue = task.DueDate() != nil
 	case "is_overdue":
 		actualValue = task.IsOverdue()
+	case "aging":
+		actualValue = string(task.AgingLevel())
 	default:
 		// Check metadata
 		if val, exists := task.GetMetadata(c.Field); exists {
@@ -77,7 +81,8 @@ func (c *Condition) compareValues(actualValue interface{}) bool {
 		}
 		return false
 	case OperatorNotContains:
-		return !c.compareValues(actualValue)
+		contains := Condition{Field: c.Field, Operator: OperatorContains, Value: c.Value}
+		return !contains.compareValues(actualValue)
 	case OperatorIn:
 		if values, ok := c.Value.([]string); ok {
 			if str, ok := actualValue.(string); ok {
@@ -14,6 +14,8 @@ var (
 	ErrBoardAlreadyExists = errors.New("board already exists")
 	ErrInvalidBoardName   = errors.New("invalid board name")
 	ErrEmptyBoardName     = errors.New("board name cannot be empty")
+	ErrInvalidSwimlanes   = errors.New("invalid swimlanes")
+	ErrSwimlaneReadOnly   = errors.New("tasks can't be moved between these swimlanes")
 
 	// Column errors
 	ErrColumnNotFound      = errors.New("column not found")
@@ -22,6 +24,8 @@ var (
 	ErrEmptyColumnName     = errors.New("column name cannot be empty")
 	ErrWIPLimitExceeded    = errors.New("work-in-progress limit exceeded")
 	ErrInvalidWIPLimit     = errors.New("wip limit must be positive")
+	ErrInvalidColumnRule   = errors.New("invalid column rule")
+	ErrColumnRuleViolated  = errors.New("move blocked by column rules")
 
 	// Task errors
 	ErrTaskNotFound      = errors.New("task not found")
@@ -75,4 +79,11 @@ var (
 	ErrInvalidNotificationMessage  = errors.New("notification message cannot be empty")
 	ErrInvalidScriptPath           = errors.New("script path cannot be empty")
 	ErrInvalidTargetColumn         = errors.New("target column cannot be empty")
+	ErrWebhookSenderNotAvailable   = errors.New("webhook sender not available")
+	ErrCommandRunnerNotAvailable   = errors.New("command runner not available")
+	ErrInvalidWebhookURL           = errors.New("webhook URL must be an http or https URL")
+	ErrInvalidCommand              = errors.New("command cannot be empty")
+	ErrInvalidAggregation          = errors.New("invalid aggregation")
+	ErrInvalidTemplate             = errors.New("invalid template")
+	ErrInvalidAgingPolicy          = errors.New("invalid aging policy")
 )
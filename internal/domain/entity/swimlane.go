@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SwimlaneGroup is what the tasks of a board are grouped into swimlanes by
+type SwimlaneGroup string
+
+const (
+	// SwimlaneByTag groups tasks by the value of a tag prefix, e.g. the
+	// "backend" of "team:backend"
+	SwimlaneByTag SwimlaneGroup = "tag"
+	// SwimlaneByPriority groups tasks by priority
+	SwimlaneByPriority SwimlaneGroup = "priority"
+	// SwimlaneByParent groups subtasks under their parent task
+	SwimlaneByParent SwimlaneGroup = "parent"
+	// SwimlaneByProject groups tasks by the project of their board, for views
+	// spanning several boards
+	SwimlaneByProject SwimlaneGroup = "project"
+)
+
+// priorityLaneOrder is the order of priority lanes, most urgent first
+var priorityLaneOrder = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3, "none": 4}
+
+// Swimlanes splits a board horizontally into lanes running across all its
+// columns. Lanes are identified by a key, such as the tag value or parent
+// task ID; tasks outside every lane have the empty key.
+type Swimlanes struct {
+	By        SwimlaneGroup
+	TagPrefix string // for tag lanes, e.g. "team:"
+}
+
+// ParseSwimlanes parses a swimlane spec: "tag:<prefix>", "priority",
+// "parent" or "project". An empty spec means no swimlanes and returns nil.
+func ParseSwimlanes(spec string) (*Swimlanes, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	if prefix, ok := strings.CutPrefix(spec, "tag:"); ok {
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: tag lanes need a prefix, e.g. tag:team:", ErrInvalidSwimlanes)
+		}
+		return &Swimlanes{By: SwimlaneByTag, TagPrefix: prefix}, nil
+	}
+
+	switch group := SwimlaneGroup(spec); group {
+	case SwimlaneByPriority, SwimlaneByParent, SwimlaneByProject:
+		return &Swimlanes{By: group}, nil
+	}
+	return nil, fmt.Errorf("%w: %q (use tag:<prefix>, priority, parent or project)", ErrInvalidSwimlanes, spec)
+}
+
+// String returns the spec the swimlanes parse from
+func (s *Swimlanes) String() string {
+	if s == nil {
+		return ""
+	}
+	if s.By == SwimlaneByTag {
+		return "tag:" + s.TagPrefix
+	}
+	return string(s.By)
+}
+
+// LaneOf returns the key of the lane a task with these fields belongs in
+func (s *Swimlanes) LaneOf(tags []string, priority string, parentID string, projectID string) string {
+	switch s.By {
+	case SwimlaneByTag:
+		for _, tag := range tags {
+			if value, ok := strings.CutPrefix(tag, s.TagPrefix); ok && value != "" {
+				return value
+			}
+		}
+		return ""
+	case SwimlaneByPriority:
+		return priority
+	case SwimlaneByParent:
+		return parentID
+	case SwimlaneByProject:
+		return projectID
+	}
+	return ""
+}
+
+// LaneTitle returns the heading of a lane, e.g. "team:backend" or "no team"
+func (s *Swimlanes) LaneTitle(key string) string {
+	switch s.By {
+	case SwimlaneByTag:
+		if key == "" {
+			return "no " + strings.TrimRight(s.TagPrefix, ":=/-_")
+		}
+		return s.TagPrefix + key
+	case SwimlaneByPriority:
+		if key == "" || key == "none" {
+			return "no priority"
+		}
+		return key + " priority"
+	case SwimlaneByParent:
+		if key == "" {
+			return "no parent"
+		}
+	case SwimlaneByProject:
+		if key == "" {
+			return "no project"
+		}
+	}
+	return key
+}
+
+// SortLanes orders lane keys for display: priorities from critical down,
+// everything else alphabetically, and the lane of ungrouped tasks last
+func (s *Swimlanes) SortLanes(keys []string) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if s.By == SwimlaneByPriority {
+			return priorityLaneOrder[a] < priorityLaneOrder[b]
+		}
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+}
+
+// Movable reports whether a task can be moved between lanes by changing the
+// field they group by
+func (s *Swimlanes) Movable() bool {
+	return s.By != SwimlaneByProject
+}
@@ -39,6 +39,7 @@ type Task struct {
 	tags          []string
 	metadata      map[string]string
 	parentID      *valueobject.TaskID
+	parentBoardID string // board of the parent when it isn't the task's own
 	createdAt     time.Time
 	modifiedAt    time.Time
 	dueDate       *time.Time
@@ -55,6 +56,11 @@ type Task struct {
 
 	taskType    TaskType
 	meetingData *MeetingData
+
+	columnEnteredAt *time.Time
+	agingLevel      AgingLevel
+
+	activity []Activity
 }
 
 // NewTask creates a new Task entity
@@ -165,6 +171,19 @@ func (t *Task) SetParentID(parentID *valueobject.TaskID) {
 	t.modifiedAt = time.Now()
 }
 
+// ParentBoardID returns the ID of the board the parent task is on, or empty
+// if it is on the same board as this task
+func (t *Task) ParentBoardID() string {
+	return t.parentBoardID
+}
+
+// SetParentBoardID sets the board the parent task is on; empty means the
+// task's own board
+func (t *Task) SetParentBoardID(boardID string) {
+	t.parentBoardID = boardID
+	t.modifiedAt = time.Now()
+}
+
 // IsSubtask checks if this task has a parent
 func (t *Task) IsSubtask() bool {
 	return t.parentID != nil
@@ -264,6 +283,74 @@ func (t *Task) MarkAsCompleted() error {
 	return nil
 }
 
+// RestoreTimestamps sets the stored timestamps of a task loaded from
+// storage. Unlike SetDueDate it accepts due dates in the past.
+func (t *Task) RestoreTimestamps(createdAt, modifiedAt time.Time, dueDate, completedDate *time.Time) {
+	if !createdAt.IsZero() {
+		t.createdAt = createdAt
+	}
+	if !modifiedAt.IsZero() {
+		t.modifiedAt = modifiedAt
+	}
+	t.dueDate = dueDate
+	t.completedDate = completedDate
+}
+
+// ColumnEnteredAt returns when the task entered its current column. Tasks
+// that never moved entered it when they were created.
+func (t *Task) ColumnEnteredAt() time.Time {
+	if t.columnEnteredAt == nil {
+		return t.createdAt
+	}
+	return *t.columnEnteredAt
+}
+
+// EnterColumn records that the task entered a column at the given time,
+// which starts its aging in that column over
+func (t *Task) EnterColumn(at time.Time) {
+	t.columnEnteredAt = &at
+	t.agingLevel = AgingFresh
+}
+
+// AgingLevel returns the highest aging level reached in the current column
+// that has been announced
+func (t *Task) AgingLevel() AgingLevel {
+	return t.agingLevel
+}
+
+// SetAgingLevel records the aging level reached in the current column
+func (t *Task) SetAgingLevel(level AgingLevel) {
+	t.agingLevel = level
+}
+
+// Activity returns the activity recorded for the task, oldest first
+func (t *Task) Activity() []Activity {
+	activity := make([]Activity, len(t.activity))
+	copy(activity, t.activity)
+	return activity
+}
+
+// AddActivity records an activity entry, keeping entries in time order. It
+// returns false without changes if an entry of the same kind and ref is
+// already recorded.
+func (t *Task) AddActivity(entry Activity) bool {
+	for _, existing := range t.activity {
+		if existing.Kind == entry.Kind && existing.Ref == entry.Ref {
+			return false
+		}
+	}
+
+	i := len(t.activity)
+	for i > 0 && t.activity[i-1].Time.After(entry.Time) {
+		i--
+	}
+	t.activity = append(t.activity, Activity{})
+	copy(t.activity[i+1:], t.activity[i:])
+	t.activity[i] = entry
+	t.modifiedAt = time.Now()
+	return true
+}
+
 // IsOverdue checks if the task is overdue
 func (t *Task) IsOverdue() bool {
 	if t.dueDate == nil || t.status == valueobject.StatusDone {
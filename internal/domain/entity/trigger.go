@@ -27,6 +27,14 @@ type TriggerContext struct {
 	Board       *Board
 	Event       *DomainEvent
 	LastRun     *time.Time
+	// Tasks are the tasks an aggregate action matched
+	Tasks []*Task
+	// ActionCreatedAt is where recurring schedules that never ran start
+	// counting firings from
+	ActionCreatedAt time.Time
+	// WorkSchedule provides the timezone recurring schedules are evaluated
+	// in; nil uses the location of CurrentTime
+	WorkSchedule *WorkSchedule
 }
 
 // TimeTrigger represents a time-based trigger
@@ -72,8 +80,8 @@ func (t *TimeTrigger) evaluateAbsolute(ctx *TriggerContext) bool {
 	}
 	// Trigger if current time is past scheduled time and hasn't run yet
 	if ctx.CurrentTime.After(*t.schedule.Time) || ctx.CurrentTime.Equal(*t.schedule.Time) {
-		// Check if already run (lastRun would be after scheduled time)
-		if ctx.LastRun != nil && ctx.LastRun.After(*t.schedule.Time) {
+		// Check if already run (lastRun would be at or after scheduled time)
+		if ctx.LastRun != nil && !ctx.LastRun.Before(*t.schedule.Time) {
 			return false
 		}
 		return true
@@ -92,7 +100,7 @@ func (t *TimeTrigger) evaluateRelativeDueDate(ctx *TriggerContext) bool {
 
 	if ctx.CurrentTime.After(triggerTime) || ctx.CurrentTime.Equal(triggerTime) {
 		// Check if already triggered for this due date
-		if ctx.LastRun != nil && ctx.LastRun.After(triggerTime) {
+		if ctx.LastRun != nil && !ctx.LastRun.Before(triggerTime) {
 			return false
 		}
 		return true
@@ -110,7 +118,7 @@ func (t *TimeTrigger) evaluateRelativeCreation(ctx *TriggerContext) bool {
 
 	if ctx.CurrentTime.After(triggerTime) || ctx.CurrentTime.Equal(triggerTime) {
 		// Check if already triggered
-		if ctx.LastRun != nil && ctx.LastRun.After(triggerTime) {
+		if ctx.LastRun != nil && !ctx.LastRun.Before(triggerTime) {
 			return false
 		}
 		return true
@@ -118,12 +126,33 @@ func (t *TimeTrigger) evaluateRelativeCreation(ctx *TriggerContext) bool {
 	return false
 }
 
-// evaluateRecurring checks if recurring schedule should trigger
+// evaluateRecurring checks if a cron firing is due. It fires when a firing
+// falls between the last run and now, so firings missed while nothing was
+// evaluating (e.g. the daemon was down) are caught up with a single run.
 func (t *TimeTrigger) evaluateRecurring(ctx *TriggerContext) bool {
-	// TODO: Implement cron expression evaluation
-	// For now, return false
-	// Will need to use a library like robfig/cron for proper evaluation
-	return false
+	cron, err := t.schedule.Cron()
+	if err != nil {
+		return false
+	}
+
+	loc := ctx.CurrentTime.Location()
+	if ctx.WorkSchedule != nil {
+		loc = ctx.WorkSchedule.Location()
+	}
+
+	var since time.Time
+	switch {
+	case ctx.LastRun != nil:
+		since = *ctx.LastRun
+	case !ctx.ActionCreatedAt.IsZero():
+		since = ctx.ActionCreatedAt
+	default:
+		// Nothing to catch up from; only fire on a scheduled minute
+		since = ctx.CurrentTime.Add(-time.Minute)
+	}
+
+	next := cron.Next(since.In(loc))
+	return !next.IsZero() && !next.After(ctx.CurrentTime)
 }
 
 // Schedule returns the schedule
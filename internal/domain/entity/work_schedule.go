@@ -160,6 +160,19 @@ func (w *WorkSchedule) SetTimezone(tz string) {
 	w.modifiedAt = time.Now()
 }
 
+// Location resolves the timezone, falling back to local time when it is
+// empty or unknown
+func (w *WorkSchedule) Location() *time.Location {
+	if w.timezone == "" || w.timezone == "Local" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(w.timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
 func (w *WorkSchedule) SetDefault(isDefault bool) {
 	w.isDefault = isDefault
 	w.modifiedAt = time.Now()
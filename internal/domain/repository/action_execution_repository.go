@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"mkanban/internal/domain/entity"
+)
+
+// ActionExecutionRepository defines the interface for action execution history
+type ActionExecutionRepository interface {
+	// Save appends an execution record
+	Save(ctx context.Context, execution *entity.ActionExecution) error
+
+	// ListByAction retrieves the most recent executions of an action, newest first.
+	// A limit of zero returns every stored execution.
+	ListByAction(ctx context.Context, actionID string, limit int) ([]*entity.ActionExecution, error)
+
+	// ListRecent retrieves the most recent executions across all actions, newest first
+	ListRecent(ctx context.Context, limit int) ([]*entity.ActionExecution, error)
+
+	// DeleteByAction removes the history of an action
+	DeleteByAction(ctx context.Context, actionID string) error
+}
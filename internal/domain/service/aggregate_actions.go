@@ -0,0 +1,87 @@
+package service
+
+import (
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// AggregateGroups returns the groups an aggregate action runs over on a
+// board: the board itself, or each of its columns. A nil column stands for
+// the whole board. Groups outside the action's board or column scope are left
+// out.
+func AggregateGroups(action *entity.Action, board *entity.Board) []*entity.Column {
+	if action.Aggregation() == nil || !groupInScope(action, board, nil) {
+		return nil
+	}
+	if action.Aggregation().GroupBy == entity.AggregateGroupBoard {
+		return []*entity.Column{nil}
+	}
+
+	groups := make([]*entity.Column, 0)
+	for _, column := range board.Columns() {
+		if groupInScope(action, board, column) {
+			groups = append(groups, column)
+		}
+	}
+	return groups
+}
+
+// groupInScope checks the board and column of a group against the action's
+// scope; task scopes are checked per task
+func groupInScope(action *entity.Action, board *entity.Board, column *entity.Column) bool {
+	if action.Scope() == valueobject.ActionScopeBoard && action.ScopeID() != board.ID() {
+		return false
+	}
+	if column != nil && action.Scope() == valueobject.ActionScopeColumn && action.ScopeID() != column.Name() {
+		return false
+	}
+	return true
+}
+
+// EvaluateAggregate evaluates an aggregate action for one group: the column's
+// tasks, or the whole board's when column is nil. base carries the time,
+// event and run state to evaluate with. It returns the tasks the action
+// matched and whether it fires for the group.
+//
+// A task is matched when it is in scope, the trigger fires for it and the
+// action's task conditions hold. Triggers that don't depend on a task (events,
+// absolute and recurring schedules) also fire for an empty group, so "count
+// eq 0" conditions can be met.
+func EvaluateAggregate(action *entity.Action, base entity.TriggerContext, board *entity.Board, column *entity.Column) ([]*entity.Task, bool) {
+	aggregation := action.Aggregation()
+	if aggregation == nil {
+		return nil, false
+	}
+
+	groupCtx := base
+	groupCtx.Board, groupCtx.Column, groupCtx.Task, groupCtx.Tasks = board, column, nil, nil
+	groupFires := action.Trigger().ShouldTrigger(&groupCtx)
+
+	columns := board.Columns()
+	if column != nil {
+		columns = []*entity.Column{column}
+	}
+
+	matched := make([]*entity.Task, 0)
+	for _, col := range columns {
+		for _, task := range col.Tasks() {
+			if !action.MatchesScope(board.ID(), col.Name(), task.ID().String()) {
+				continue
+			}
+			taskCtx := groupCtx
+			taskCtx.Column, taskCtx.Task = col, task
+			if !action.Trigger().ShouldTrigger(&taskCtx) {
+				continue
+			}
+			if action.Conditions() != nil && !action.Conditions().Evaluate(task, col) {
+				continue
+			}
+			matched = append(matched, task)
+		}
+	}
+
+	if !groupFires && len(matched) == 0 {
+		return matched, false
+	}
+	return matched, aggregation.Holds(matched, column)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+func newAggregateAction(t *testing.T, trigger entity.Trigger, actionType entity.ActionType, conditions *entity.ConditionGroup, aggregation *entity.Aggregation) *entity.Action {
+	t.Helper()
+
+	action, err := entity.NewAction("digest", "digest", "", valueobject.ActionScopeGlobal, "", trigger, actionType, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action.Restore(true, simulationStart, simulationStart, nil, 0)
+	action.UpdateAggregation(aggregation)
+	return action
+}
+
+func aggregateCondition(t *testing.T, function entity.AggregateFunction, field string, operator entity.ConditionOperator, value interface{}) *entity.AggregateCondition {
+	t.Helper()
+
+	condition, err := entity.NewAggregateCondition(function, field, operator, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return condition
+}
+
+func TestEvaluateAggregate(t *testing.T) {
+	overdue := entity.NewConditionGroup(entity.LogicalAnd,
+		entity.NewCondition("is_overdue", entity.OperatorEquals, true))
+
+	tests := []struct {
+		name       string
+		tasks      []simulatedTask
+		wipLimit   int
+		conditions *entity.ConditionGroup
+		aggregates []*entity.AggregateCondition
+		fires      bool
+		matched    int
+	}{
+		{
+			name: "fires once for all matching tasks",
+			tasks: []simulatedTask{
+				{title: "late", due: hours(-48)},
+				{title: "later", due: hours(-24)},
+				{title: "undated"},
+			},
+			conditions: overdue,
+			fires:      true,
+			matched:    2,
+		},
+		{
+			name:       "does not fire without matching tasks",
+			tasks:      []simulatedTask{{title: "undated"}},
+			conditions: overdue,
+			fires:      false,
+		},
+		{
+			name: "count threshold not reached",
+			tasks: []simulatedTask{
+				{title: "a"},
+				{title: "b"},
+			},
+			aggregates: []*entity.AggregateCondition{
+				aggregateCondition(t, entity.AggregateCount, "", entity.OperatorGreaterThan, 2),
+			},
+			fires:   false,
+			matched: 2,
+		},
+		{
+			name: "count over the WIP limit",
+			tasks: []simulatedTask{
+				{title: "a"},
+				{title: "b"},
+				{title: "c"},
+			},
+			wipLimit: 2,
+			aggregates: []*entity.AggregateCondition{
+				aggregateCondition(t, entity.AggregateCount, "", entity.OperatorGreaterThan, entity.AggregateValueWIPLimit),
+			},
+			fires:   true,
+			matched: 3,
+		},
+		{
+			name:  "no WIP limit never exceeds",
+			tasks: []simulatedTask{{title: "a"}},
+			aggregates: []*entity.AggregateCondition{
+				aggregateCondition(t, entity.AggregateCount, "", entity.OperatorGreaterThan, entity.AggregateValueWIPLimit),
+			},
+			fires:   false,
+			matched: 1,
+		},
+		{
+			name:       "count eq 0 fires for an empty group",
+			tasks:      []simulatedTask{{title: "undated"}},
+			conditions: overdue,
+			aggregates: []*entity.AggregateCondition{
+				aggregateCondition(t, entity.AggregateCount, "", entity.OperatorEquals, 0),
+			},
+			fires:   true,
+			matched: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := newSimulationBoard(t, tt.tasks)
+			column, err := board.GetColumn("todo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := column.UpdateWIPLimit(tt.wipLimit); err != nil {
+				t.Fatal(err)
+			}
+
+			trigger, err := entity.NewEventTrigger(valueobject.EventTaskMoved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			aggregation, err := entity.NewAggregation(entity.AggregateGroupColumn, tt.aggregates...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			action := newAggregateAction(t, trigger, entity.NewNotificationAction("title", "message", nil), tt.conditions, aggregation)
+
+			base := entity.TriggerContext{
+				CurrentTime: simulationStart,
+				Event:       entity.NewDomainEvent(valueobject.EventTaskMoved, board.ID(), "todo", nil, nil),
+			}
+			matched, fires := EvaluateAggregate(action, base, board, column)
+
+			if fires != tt.fires {
+				t.Errorf("expected fires=%t, got %t", tt.fires, fires)
+			}
+			if len(matched) != tt.matched {
+				t.Errorf("expected %d matched tasks, got %d", tt.matched, len(matched))
+			}
+		})
+	}
+}
+
+func TestAggregateConditionFunctions(t *testing.T) {
+	board := newSimulationBoard(t, []simulatedTask{{title: "a"}, {title: "b"}, {title: "c"}})
+	column, err := board.GetColumn("todo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := column.Tasks()
+	for i, points := range []string{"3", "5", "not a number"} {
+		tasks[i].SetMetadata("points", points)
+	}
+
+	tests := []struct {
+		function entity.AggregateFunction
+		value    float64
+	}{
+		{entity.AggregateSum, 8},
+		{entity.AggregateAvg, 4},
+		{entity.AggregateMin, 3},
+		{entity.AggregateMax, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.function), func(t *testing.T) {
+			condition := aggregateCondition(t, tt.function, "points", entity.OperatorEquals, tt.value)
+			if !condition.Evaluate(tasks, column) {
+				t.Errorf("expected %s of points to be %v", tt.function, tt.value)
+			}
+		})
+	}
+}
+
+func TestNewAggregateConditionValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		function entity.AggregateFunction
+		field    string
+		operator entity.ConditionOperator
+		value    interface{}
+	}{
+		{"unknown function", "median", "points", entity.OperatorEquals, 1},
+		{"sum without field", entity.AggregateSum, "", entity.OperatorEquals, 1},
+		{"non-numeric operator", entity.AggregateCount, "", entity.OperatorContains, 1},
+		{"non-numeric value", entity.AggregateCount, "", entity.OperatorEquals, "many"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := entity.NewAggregateCondition(tt.function, tt.field, tt.operator, tt.value); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSimulateAggregateDigest(t *testing.T) {
+	board := newSimulationBoard(t, []simulatedTask{
+		{title: "late", due: hours(-48)},
+		{title: "later", due: hours(-24)},
+		{title: "undated"},
+	})
+
+	schedule, err := valueobject.NewRecurringSchedule("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger, err := entity.NewTimeTrigger(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregation, err := entity.NewAggregation(entity.AggregateGroupBoard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notification := entity.NewNotificationAction("{{.Count}} overdue on {{.Board.Name}}",
+		"{{range .Tasks}}{{.Title}};{{end}}", nil)
+	action := newAggregateAction(t, trigger, notification, entity.NewConditionGroup(entity.LogicalAnd,
+		entity.NewCondition("is_overdue", entity.OperatorEquals, true)), aggregation)
+
+	runs := SimulateTimeTriggers([]*entity.Action{action}, []*entity.Board{board},
+		simulationStart, simulationStart.Add(48*time.Hour), time.Hour, nil)
+
+	if len(runs) != 2 {
+		t.Fatalf("expected one digest per day, got %d runs", len(runs))
+	}
+	run := runs[0]
+	if run.Task != nil || len(run.Tasks) != 2 {
+		t.Fatalf("expected a run over 2 tasks, got task %v and %d tasks", run.Task, len(run.Tasks))
+	}
+
+	effect := action.ActionType().Describe(&entity.ActionContext{Board: run.Board, Tasks: run.Tasks})
+	if !strings.Contains(effect, "2 overdue on demo") || !strings.Contains(effect, "late;later;") {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
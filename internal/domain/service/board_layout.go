@@ -0,0 +1,95 @@
+package service
+
+import (
+	"path"
+	"strings"
+)
+
+// DefaultBoardName is the board of a repository's session when its layout
+// doesn't name one
+const DefaultBoardName = "default"
+
+// BoardRule maps directories of a repository to a board
+type BoardRule struct {
+	Board string
+	Paths []string // globs of directories, relative to the repository root
+}
+
+// BoardLayout is the plan of a repository's boards: the default board, which
+// also gets the repository's branches, and boards for parts of it, such as
+// the packages of a monorepo
+type BoardLayout struct {
+	DefaultBoard string
+	Rules        []BoardRule
+}
+
+// BoardLayoutProvider finds the board layout of a repository, from its own
+// declaration, the config, or its workspace manifests
+type BoardLayoutProvider interface {
+	LayoutFor(repoRoot string) (*BoardLayout, error)
+}
+
+// Default returns the name of the default board
+func (l *BoardLayout) Default() string {
+	if l == nil || l.DefaultBoard == "" {
+		return DefaultBoardName
+	}
+	return l.DefaultBoard
+}
+
+// BoardNames returns the names of all the boards, the default board first
+func (l *BoardLayout) BoardNames() []string {
+	names := []string{l.Default()}
+	if l == nil {
+		return names
+	}
+	seen := map[string]bool{l.Default(): true}
+	for _, rule := range l.Rules {
+		if !seen[rule.Board] {
+			seen[rule.Board] = true
+			names = append(names, rule.Board)
+		}
+	}
+	return names
+}
+
+// BoardFor returns the board of a directory, given relative to the
+// repository root. The first rule with a glob matching the directory or one
+// of its parents wins, so "services/*" covers services/api/handlers.
+func (l *BoardLayout) BoardFor(dir string) string {
+	if l == nil {
+		return DefaultBoardName
+	}
+
+	dir = path.Clean(strings.ReplaceAll(dir, "\\", "/"))
+	if dir == "." || strings.HasPrefix(dir, "../") || dir == ".." {
+		return l.Default()
+	}
+
+	parts := strings.Split(dir, "/")
+	for _, rule := range l.Rules {
+		for _, pattern := range rule.Paths {
+			pattern = strings.Trim(path.Clean(pattern), "/")
+			for i := 1; i <= len(parts); i++ {
+				if matched, _ := path.Match(pattern, strings.Join(parts[:i], "/")); matched {
+					return rule.Board
+				}
+			}
+		}
+	}
+	return l.Default()
+}
+
+// AddRules appends the rules for boards the layout doesn't have yet
+func (l *BoardLayout) AddRules(rules []BoardRule) {
+	names := make(map[string]bool)
+	for _, name := range l.BoardNames() {
+		names[name] = true
+	}
+	for _, rule := range rules {
+		if !names[rule.Board] {
+			names[rule.Board] = true
+			l.Rules = append(l.Rules, rule)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBoardLayoutBoardFor(t *testing.T) {
+	layout := &BoardLayout{
+		Rules: []BoardRule{
+			{Board: "backend", Paths: []string{"backend", "services/*"}},
+			{Board: "android", Paths: []string{"mobile/android/"}},
+			{Board: "tools", Paths: []string{"services/tools"}},
+		},
+	}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{".", "default"},
+		{"", "default"},
+		{"backend", "backend"},
+		{"backend/internal/api", "backend"},
+		{"services/payments/handlers", "backend"},
+		{"services/tools", "backend"}, // the first matching rule wins
+		{"mobile/android", "android"},
+		{"mobile/ios", "default"},
+		{"docs", "default"},
+		{"../shop.worktrees/PRO-1", "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := layout.BoardFor(tt.dir); got != tt.want {
+				t.Errorf("BoardFor(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardLayoutBoardNames(t *testing.T) {
+	var none *BoardLayout
+	if got := none.BoardNames(); !reflect.DeepEqual(got, []string{"default"}) {
+		t.Errorf("BoardNames() without layout = %v", got)
+	}
+
+	layout := &BoardLayout{
+		DefaultBoard: "main",
+		Rules: []BoardRule{
+			{Board: "api", Paths: []string{"api"}},
+			{Board: "main", Paths: []string{"cmd"}},
+			{Board: "api", Paths: []string{"proto"}},
+		},
+	}
+	layout.AddRules([]BoardRule{
+		{Board: "api", Paths: []string{"services/api"}},
+		{Board: "web", Paths: []string{"web"}},
+	})
+
+	want := []string{"main", "api", "web"}
+	if got := layout.BoardNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("BoardNames() = %v, want %v", got, want)
+	}
+	if got := layout.BoardFor("services/api"); got != "main" {
+		t.Errorf("a detected rule for a declared board should be left out, got %q", got)
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"mkanban/internal/domain/valueobject"
 	"mkanban/internal/infrastructure/config"
 	"mkanban/pkg/slug"
+	"time"
 )
 
 // BoardService provides high-level domain operations for boards
 type BoardService struct {
 	boardRepo         repository.BoardRepository
 	validationService *ValidationService
+	scriptRunner      entity.ScriptRunner
 	config            *config.Config
 }
 
@@ -21,11 +23,13 @@ type BoardService struct {
 func NewBoardService(
 	boardRepo repository.BoardRepository,
 	validationService *ValidationService,
+	scriptRunner entity.ScriptRunner,
 	cfg *config.Config,
 ) *BoardService {
 	return &BoardService{
 		boardRepo:         boardRepo,
 		validationService: validationService,
+		scriptRunner:      scriptRunner,
 		config:            cfg,
 	}
 }
@@ -129,14 +133,116 @@ func (s *BoardService) AddColumnToBoard(
 	return board, nil
 }
 
-// CreateTask creates a new task in a specific column
+// SetColumnAgingPolicy sets the aging policy of a column; a nil policy
+// removes it
+func (s *BoardService) SetColumnAgingPolicy(
+	ctx context.Context,
+	boardID string,
+	columnName string,
+	policy *entity.AgingPolicy,
+) (*entity.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	column, err := board.GetColumn(columnName)
+	if err != nil {
+		return nil, err
+	}
+	column.UpdateAgingPolicy(policy)
+
+	if err := s.boardRepo.Save(ctx, board); err != nil {
+		return nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	return board, nil
+}
+
+// SetSwimlanes sets how the tasks of a board are grouped into lanes; nil
+// removes the lanes
+func (s *BoardService) SetSwimlanes(
+	ctx context.Context,
+	boardID string,
+	swimlanes *entity.Swimlanes,
+) (*entity.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board.SetSwimlanes(swimlanes)
+
+	if err := s.boardRepo.Save(ctx, board); err != nil {
+		return nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	return board, nil
+}
+
+// MoveTaskToSwimlane moves a task into another lane of the given swimlanes,
+// or of the board's own for nil swimlanes
+func (s *BoardService) MoveTaskToSwimlane(
+	ctx context.Context,
+	boardID string,
+	taskID *valueobject.TaskID,
+	swimlanes *entity.Swimlanes,
+	lane string,
+) (*entity.Board, *entity.Task, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, _, err := board.FindTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if swimlanes == nil {
+		swimlanes = board.Swimlanes()
+	}
+	if swimlanes == nil {
+		return nil, nil, fmt.Errorf("%w: board %s has no swimlanes", entity.ErrInvalidSwimlanes, boardID)
+	}
+	if swimlanes.By == entity.SwimlaneByParent {
+		// The old parent may be on another board
+		var parentID *valueobject.TaskID
+		if lane != "" {
+			if parentID, err = valueobject.ParseTaskID(lane); err != nil {
+				return nil, nil, err
+			}
+		}
+		return s.SetTaskParent(ctx, boardID, taskID, boardID, parentID)
+	}
+	if err := MoveTaskToSwimlane(board, task, swimlanes, lane); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.boardRepo.Save(ctx, board); err != nil {
+		return nil, nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	return board, task, nil
+}
+
+// NewTaskFields holds the fields of a task to create
+type NewTaskFields struct {
+	Title       string
+	Description string
+	Priority    valueobject.Priority
+	DueDate     *time.Time
+	Tags        []string
+}
+
+// CreateTask creates a new task in a specific column. The entry rules of the
+// column apply as they do to tasks moved into it, so creation is refused
+// with a *entity.ColumnRuleError when the task breaks a blocking one.
 func (s *BoardService) CreateTask(
 	ctx context.Context,
 	boardID string,
 	columnName string,
-	title string,
-	description string,
-	priority valueobject.Priority,
+	fields NewTaskFields,
 ) (*entity.Board, *entity.Task, error) {
 	// Load board
 	board, err := s.boardRepo.FindByID(ctx, boardID)
@@ -145,7 +251,7 @@ func (s *BoardService) CreateTask(
 	}
 
 	// Validate task title
-	if err := s.validationService.ValidateTaskTitle(title); err != nil {
+	if err := s.validationService.ValidateTaskTitle(fields.Title); err != nil {
 		return nil, nil, err
 	}
 
@@ -161,17 +267,25 @@ func (s *BoardService) CreateTask(
 	}
 
 	// Generate task ID
-	taskSlug := slug.Generate(title)
+	taskSlug := slug.Generate(fields.Title)
 	taskID, err := board.GenerateNextTaskID(taskSlug)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Create task
-	task, err := entity.NewTask(taskID, title, description, priority, valueobject.StatusTodo)
+	task, err := entity.NewTask(taskID, fields.Title, fields.Description, fields.Priority, valueobject.StatusTodo)
 	if err != nil {
 		return nil, nil, err
 	}
+	if fields.DueDate != nil {
+		if err := task.SetDueDate(*fields.DueDate); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, tag := range fields.Tags {
+		task.AddTag(tag)
+	}
 
 	// Add task to column
 	if err := column.AddTask(task); err != nil {
@@ -179,7 +293,7 @@ func (s *BoardService) CreateTask(
 	}
 
 	// Parse description for subtasks
-	subtaskTitles := ParseSubtasks(description)
+	subtaskTitles := ParseSubtasks(fields.Description)
 	if len(subtaskTitles) > 0 {
 		// Get the first column (Todo) for subtasks
 		var todoColumn *entity.Column
@@ -188,7 +302,7 @@ func (s *BoardService) CreateTask(
 		}
 
 		if todoColumn != nil {
-			updatedDescription := description
+			updatedDescription := fields.Description
 
 			// Create a subtask for each checkbox
 			for _, subtaskTitle := range subtaskTitles {
@@ -200,7 +314,7 @@ func (s *BoardService) CreateTask(
 				}
 
 				// Create subtask
-				subtask, err := entity.NewTask(subtaskID, subtaskTitle, "", priority, valueobject.StatusTodo)
+				subtask, err := entity.NewTask(subtaskID, subtaskTitle, "", fields.Priority, valueobject.StatusTodo)
 				if err != nil {
 					continue // Skip this subtask on error
 				}
@@ -222,6 +336,12 @@ func (s *BoardService) CreateTask(
 		}
 	}
 
+	// The task enters its column from nowhere
+	violations := CheckColumnRules(board, task, nil, column, s.scriptRunner)
+	if HasBlockingViolation(violations) {
+		return nil, nil, &entity.ColumnRuleError{TaskID: taskID.String(), Violations: violations}
+	}
+
 	// Save board
 	if err := s.boardRepo.Save(ctx, board); err != nil {
 		return nil, nil, fmt.Errorf("failed to save board: %w", err)
@@ -230,65 +350,228 @@ func (s *BoardService) CreateTask(
 	return board, task, nil
 }
 
-// MoveTask moves a task between columns
+// MoveTask moves a task between columns. The move is refused with a
+// *entity.ColumnRuleError if it breaks a blocking exit rule of the task's
+// column or entry rule of the target column; violated warning rules are
+// returned with the moved board.
 func (s *BoardService) MoveTask(
 	ctx context.Context,
 	boardID string,
 	taskID *valueobject.TaskID,
 	targetColumnName string,
-) (*entity.Board, error) {
+) (*entity.Board, []entity.RuleViolation, error) {
 	// Load board
 	board, err := s.boardRepo.FindByID(ctx, boardID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	violations, err := s.MoveTaskOnBoard(ctx, board, taskID, targetColumnName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Save board
+	if err := s.boardRepo.Save(ctx, board); err != nil {
+		return nil, nil, fmt.Errorf("failed to save board: %w", err)
 	}
 
+	return board, violations, nil
+}
+
+// MoveTaskOnBoard moves a task of a board the caller has loaded and saves
+// afterwards, as MoveTask does. The board of the task's parent is saved
+// here when it's another board.
+func (s *BoardService) MoveTaskOnBoard(
+	ctx context.Context,
+	board *entity.Board,
+	taskID *valueobject.TaskID,
+	targetColumnName string,
+) ([]entity.RuleViolation, error) {
 	// Find the task being moved
-	task, _, err := board.FindTask(taskID)
+	task, sourceColumn, err := board.FindTask(taskID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Check the exit and entry rules of the columns involved
+	targetColumn, err := board.GetColumn(targetColumnName)
+	if err != nil {
+		return nil, err
+	}
+	violations := CheckColumnRules(board, task, sourceColumn, targetColumn, s.scriptRunner)
+	if HasBlockingViolation(violations) {
+		return nil, &entity.ColumnRuleError{TaskID: taskID.String(), Violations: violations}
+	}
+
 	// Move task
 	if err := board.MoveTask(taskID, targetColumnName); err != nil {
 		return nil, err
 	}
 
-	// If this task has a parent, update the parent's checkbox state
+	// If this task has a parent, update the parent's checkbox state; the
+	// parent may be on another board
 	if task.IsSubtask() {
-		parentTask, _, err := board.FindTask(task.ParentID())
-		if err == nil {
-			// Determine checkbox state based on target column
-			var checkboxState CheckboxState
-			switch targetColumnName {
-			case "Done":
-				checkboxState = CheckboxDone
-			case "In Progress":
-				checkboxState = CheckboxInProgress
-			default:
-				checkboxState = CheckboxTodo
+		parentBoard := board
+		if id := task.ParentBoardID(); id != "" && id != board.ID() {
+			parentBoard, _ = s.boardRepo.FindByID(ctx, id)
+		}
+		if parentBoard != nil {
+			s.updateParentCheckbox(parentBoard, task, targetColumn)
+		}
+		if parentBoard != nil && parentBoard != board {
+			if err := s.boardRepo.Save(ctx, parentBoard); err != nil {
+				return nil, fmt.Errorf("failed to save board: %w", err)
 			}
+		}
+	}
 
-			// Update the parent's description
-			updatedDescription := UpdateCheckboxState(
-				parentTask.Description(),
-				taskID.String(),
-				checkboxState,
-			)
-			parentTask.UpdateDescription(updatedDescription)
-
-			// Check if all subtasks are complete
-			if AllCheckboxesComplete(parentTask.Description()) {
-				// Move parent to Done column
-				doneColumn, err := board.GetColumn("Done")
-				if err == nil && doneColumn.CanAddTask() {
-					_ = board.MoveTask(task.ParentID(), "Done")
-				}
+	return violations, nil
+}
+
+// updateParentCheckbox sets the checkbox of a subtask that moved to column
+// in its parent on parentBoard, and completes the parent when all its
+// subtasks are done
+func (s *BoardService) updateParentCheckbox(parentBoard *entity.Board, task *entity.Task, column *entity.Column) {
+	parentTask, parentColumn, err := parentBoard.FindTask(task.ParentID())
+	if err != nil {
+		return
+	}
+
+	// Update the parent's description
+	updatedDescription := UpdateCheckboxState(
+		parentTask.Description(),
+		task.ID().String(),
+		subtaskState(column, task),
+	)
+	parentTask.UpdateDescription(updatedDescription)
+
+	// Check if all subtasks are complete
+	if AllCheckboxesComplete(parentTask.Description()) {
+		// Move parent to Done column, unless its rules hold it back
+		doneColumn := columnForCheckbox(parentBoard, CheckboxDone)
+		if doneColumn != nil && doneColumn != parentColumn && doneColumn.CanAddTask() {
+			if !HasBlockingViolation(CheckColumnRules(parentBoard, parentTask, parentColumn, doneColumn, s.scriptRunner)) {
+				_ = parentBoard.MoveTask(parentTask.ID(), doneColumn.Name())
 			}
 		}
 	}
+}
+
+// SetTaskParent makes a task a subtask of the task with ID parentID on board
+// parentBoardID, which may be another board than the task's, or a top level
+// task for a nil parentID
+func (s *BoardService) SetTaskParent(
+	ctx context.Context,
+	boardID string,
+	taskID *valueobject.TaskID,
+	parentBoardID string,
+	parentID *valueobject.TaskID,
+) (*entity.Board, *entity.Task, error) {
+	boards, err := s.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	board := findBoard(boards, boardID)
+	if board == nil {
+		return nil, nil, fmt.Errorf("%w: %s", entity.ErrBoardNotFound, boardID)
+	}
+	task, _, err := board.FindTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var parentBoard *entity.Board
+	var parent *entity.Task
+	if parentID != nil {
+		if parentBoardID == "" {
+			parentBoardID = boardID
+		}
+		parentBoard = findBoard(boards, parentBoardID)
+		if parentBoard == nil {
+			return nil, nil, fmt.Errorf("%w: %s", entity.ErrBoardNotFound, parentBoardID)
+		}
+		parent, _, err = parentBoard.FindTask(parentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parent %s: %w", parentID, err)
+		}
+	}
+
+	changed, err := SetParent(boards, board, task, parentBoard, parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, changedBoard := range changed {
+		if err := s.boardRepo.Save(ctx, changedBoard); err != nil {
+			return nil, nil, fmt.Errorf("failed to save board: %w", err)
+		}
+	}
+
+	return board, task, nil
+}
+
+// GetTaskTree returns a task with its subtasks from all boards
+func (s *BoardService) GetTaskTree(
+	ctx context.Context,
+	boardID string,
+	taskID *valueobject.TaskID,
+) (*TaskTree, error) {
+	boards, err := s.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTaskTree(boards, boardID, taskID)
+}
+
+// AddColumnRule adds an entry or exit rule to a column
+func (s *BoardService) AddColumnRule(
+	ctx context.Context,
+	boardID string,
+	columnName string,
+	rule *entity.ColumnRule,
+) (*entity.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	column, err := board.GetColumn(columnName)
+	if err != nil {
+		return nil, err
+	}
+	if err := column.AddRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.boardRepo.Save(ctx, board); err != nil {
+		return nil, fmt.Errorf("failed to save board: %w", err)
+	}
+
+	return board, nil
+}
+
+// RemoveColumnRule removes the rule at index from a column
+func (s *BoardService) RemoveColumnRule(
+	ctx context.Context,
+	boardID string,
+	columnName string,
+	index int,
+) (*entity.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	column, err := board.GetColumn(columnName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := column.RemoveRule(index); err != nil {
+		return nil, err
+	}
 
-	// Save board
 	if err := s.boardRepo.Save(ctx, board); err != nil {
 		return nil, fmt.Errorf("failed to save board: %w", err)
 	}
@@ -334,16 +617,20 @@ func (s *BoardService) CheckAndCompleteParent(
 	board *entity.Board,
 	parentTaskID *valueobject.TaskID,
 ) error {
-	parentTask, _, err := board.FindTask(parentTaskID)
+	parentTask, parentColumn, err := board.FindTask(parentTaskID)
 	if err != nil {
 		return err
 	}
 
 	// Check if all subtasks are complete
 	if AllCheckboxesComplete(parentTask.Description()) {
-		// Move parent to Done column
+		// Move parent to Done column, unless its rules hold it back
 		doneColumn, err := board.GetColumn("Done")
 		if err == nil && doneColumn.CanAddTask() {
+			violations := CheckColumnRules(board, parentTask, parentColumn, doneColumn, s.scriptRunner)
+			if HasBlockingViolation(violations) {
+				return &entity.ColumnRuleError{TaskID: parentTaskID.String(), Violations: violations}
+			}
 			return board.MoveTask(parentTaskID, "Done")
 		}
 	}
@@ -351,15 +638,24 @@ func (s *BoardService) CheckAndCompleteParent(
 	return nil
 }
 
+// TaskUpdate holds the fields of a task to change. Nil fields are left as
+// they are; non-nil Tags replace the task's tags.
+type TaskUpdate struct {
+	Title       *string
+	Description *string
+	Priority    *valueobject.Priority
+	Status      *valueobject.Status
+	DueDate     *time.Time
+	Estimate    *time.Duration
+	Tags        []string
+}
+
 // UpdateTask updates task details
 func (s *BoardService) UpdateTask(
 	ctx context.Context,
 	boardID string,
 	taskID *valueobject.TaskID,
-	title *string,
-	description *string,
-	priority *valueobject.Priority,
-	status *valueobject.Status,
+	update TaskUpdate,
 ) (*entity.Board, *entity.Task, error) {
 	// Load board
 	board, err := s.boardRepo.FindByID(ctx, boardID)
@@ -372,37 +668,115 @@ func (s *BoardService) UpdateTask(
 	if err != nil {
 		return nil, nil, err
 	}
+	oldDescription := task.Description()
 
 	// Update fields if provided
-	if title != nil {
-		if err := s.validationService.ValidateTaskTitle(*title); err != nil {
+	if update.Title != nil {
+		if err := s.validationService.ValidateTaskTitle(*update.Title); err != nil {
+			return nil, nil, err
+		}
+		if err := task.UpdateTitle(*update.Title); err != nil {
 			return nil, nil, err
 		}
-		if err := task.UpdateTitle(*title); err != nil {
+	}
+
+	if update.Description != nil {
+		task.UpdateDescription(*update.Description)
+	}
+
+	if update.Priority != nil {
+		if err := task.UpdatePriority(*update.Priority); err != nil {
 			return nil, nil, err
 		}
 	}
 
-	if description != nil {
-		task.UpdateDescription(*description)
+	if update.Status != nil {
+		if err := task.UpdateStatus(*update.Status); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	if priority != nil {
-		if err := task.UpdatePriority(*priority); err != nil {
+	if update.DueDate != nil {
+		if err := task.SetDueDate(*update.DueDate); err != nil {
 			return nil, nil, err
 		}
 	}
 
-	if status != nil {
-		if err := task.UpdateStatus(*status); err != nil {
+	if update.Estimate != nil {
+		task.SetEstimatedTime(*update.Estimate)
+	}
+
+	if update.Tags != nil {
+		for _, tag := range task.Tags() {
+			task.RemoveTag(tag)
+		}
+		for _, tag := range update.Tags {
+			task.AddTag(tag)
+		}
+	}
+
+	// Checking or unchecking a subtask's checkbox moves the subtask
+	changed := []*entity.Board{board}
+	if update.Description != nil {
+		changedBoards, err := s.moveSubtasksToCheckboxes(ctx, board, task, ChangedCheckboxes(oldDescription, *update.Description))
+		if err != nil {
 			return nil, nil, err
 		}
+		for _, changedBoard := range changedBoards {
+			changed = appendBoard(changed, changedBoard)
+		}
 	}
 
 	// Save board
-	if err := s.boardRepo.Save(ctx, board); err != nil {
-		return nil, nil, fmt.Errorf("failed to save board: %w", err)
+	for _, changedBoard := range changed {
+		if err := s.boardRepo.Save(ctx, changedBoard); err != nil {
+			return nil, nil, fmt.Errorf("failed to save board: %w", err)
+		}
 	}
 
 	return board, task, nil
 }
+
+// moveSubtasksToCheckboxes moves the subtasks of parent whose checkboxes
+// changed to the first column of their board with the new state. A checkbox
+// is set back to its subtask's state when the subtask can't move there. It
+// returns the other boards subtasks moved on.
+func (s *BoardService) moveSubtasksToCheckboxes(
+	ctx context.Context,
+	board *entity.Board,
+	parent *entity.Task,
+	changes map[string]CheckboxState,
+) ([]*entity.Board, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	// Subtasks may be on any board
+	boards, err := s.boardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	boards = replaceBoard(boards, board)
+
+	var changed []*entity.Board
+	for taskID, state := range changes {
+		subtask := findSubtask(boards, board.ID(), parent.ID(), taskID)
+		if subtask == nil {
+			continue
+		}
+		subtaskBoard := findBoard(boards, subtask.BoardID)
+
+		target := columnForCheckbox(subtaskBoard, state)
+		if target == nil || target == subtask.Column || !target.CanAddTask() ||
+			HasBlockingViolation(CheckColumnRules(subtaskBoard, subtask.Task, subtask.Column, target, s.scriptRunner)) ||
+			subtaskBoard.MoveTask(subtask.Task.ID(), target.Name()) != nil {
+			parent.UpdateDescription(UpdateCheckboxState(parent.Description(), taskID, subtask.State()))
+			continue
+		}
+		if subtaskBoard != board {
+			changed = appendBoard(changed, subtaskBoard)
+		}
+	}
+
+	return changed, nil
+}
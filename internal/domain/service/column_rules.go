@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"mkanban/internal/domain/entity"
+)
+
+// maxRuleReason caps how much script output is quoted in a violation
+const maxRuleReason = 200
+
+// CheckColumnRules checks a move of task from one column to another against
+// the exit rules of from and the entry rules of to, and returns the rules it
+// violates. A nil from is a task entering the board. Script rules run with
+// scriptRunner; without one they are violated.
+func CheckColumnRules(
+	board *entity.Board,
+	task *entity.Task,
+	from *entity.Column,
+	to *entity.Column,
+	scriptRunner entity.ScriptRunner,
+) []entity.RuleViolation {
+	violations := make([]entity.RuleViolation, 0)
+	if from == to {
+		return violations
+	}
+
+	check := func(column *entity.Column, on entity.ColumnRuleHook) {
+		if column == nil {
+			return
+		}
+		for _, rule := range column.RulesOn(on) {
+			ok, reason := checkColumnRule(rule, board, task, column, from, to, scriptRunner)
+			if !ok {
+				violations = append(violations, entity.RuleViolation{
+					Rule:   rule,
+					Column: column.DisplayName(),
+					Reason: reason,
+				})
+			}
+		}
+	}
+	check(from, entity.RuleOnExit)
+	check(to, entity.RuleOnEnter)
+
+	return violations
+}
+
+// HasBlockingViolation reports whether any of the violations refuses a move
+func HasBlockingViolation(violations []entity.RuleViolation) bool {
+	for _, violation := range violations {
+		if violation.Blocking() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkColumnRule checks a single rule of column, returning whether it passed
+// and, if not, any detail on why
+func checkColumnRule(
+	rule *entity.ColumnRule,
+	board *entity.Board,
+	task *entity.Task,
+	column *entity.Column,
+	from *entity.Column,
+	to *entity.Column,
+	scriptRunner entity.ScriptRunner,
+) (bool, string) {
+	switch rule.Type {
+	case entity.ColumnRuleRequire:
+		return hasField(task, rule.Field), ""
+	case entity.ColumnRuleCondition:
+		return rule.Condition.Evaluate(task, column), ""
+	case entity.ColumnRuleSubtasksComplete:
+		states := GetCheckboxStates(task.Description())
+		if len(states) == 0 || AllCheckboxesComplete(task.Description()) {
+			return true, ""
+		}
+		open := 0
+		for _, state := range states {
+			if state != CheckboxDone {
+				open++
+			}
+		}
+		return false, fmt.Sprintf("%d of %d open", open, len(states))
+	case entity.ColumnRuleScript:
+		if scriptRunner == nil {
+			return false, entity.ErrScriptRunnerNotAvailable.Error()
+		}
+		output, err := scriptRunner.RunScript(rule.Script, ruleScriptEnv(rule, board, task, column, from, to))
+		if err != nil {
+			reason := strings.TrimSpace(output)
+			if reason == "" {
+				reason = err.Error()
+			}
+			if len(reason) > maxRuleReason {
+				reason = reason[:maxRuleReason] + "..."
+			}
+			return false, reason
+		}
+		return true, ""
+	}
+	return false, fmt.Sprintf("unknown rule type %q", rule.Type)
+}
+
+// hasField reports whether a task has the field a require rule names set
+func hasField(task *entity.Task, field string) bool {
+	if prefix, ok := strings.CutPrefix(field, "tag:"); ok {
+		for _, tag := range task.Tags() {
+			if strings.HasPrefix(tag, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch field {
+	case "description":
+		return strings.TrimSpace(task.Description()) != ""
+	case "due_date":
+		return task.DueDate() != nil
+	case "estimate":
+		return task.EstimatedTime() != nil && *task.EstimatedTime() > 0
+	case "tags":
+		return len(task.Tags()) > 0
+	}
+
+	value, ok := task.GetMetadata(field)
+	return ok && strings.TrimSpace(value) != ""
+}
+
+// ruleScriptEnv is the environment script rules run with
+func ruleScriptEnv(
+	rule *entity.ColumnRule,
+	board *entity.Board,
+	task *entity.Task,
+	column *entity.Column,
+	from *entity.Column,
+	to *entity.Column,
+) map[string]string {
+	env := map[string]string{
+		"TASK_ID":       task.ID().String(),
+		"TASK_TITLE":    task.Title(),
+		"TASK_PRIORITY": task.Priority().String(),
+		"TASK_STATUS":   task.Status().String(),
+		"COLUMN_NAME":   column.Name(),
+		"RULE_ON":       string(rule.On),
+	}
+	if board != nil {
+		env["BOARD_ID"] = board.ID()
+		env["BOARD_NAME"] = board.Name()
+	}
+	if from != nil {
+		env["FROM_COLUMN"] = from.Name()
+	}
+	if to != nil {
+		env["TO_COLUMN"] = to.Name()
+	}
+	return env
+}
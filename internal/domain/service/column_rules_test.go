@@ -0,0 +1,327 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// fakeScriptRunner fails scripts named in failures with their output
+type fakeScriptRunner struct {
+	failures map[string]string
+	env      map[string]string
+}
+
+func (r *fakeScriptRunner) RunScript(scriptPath string, env map[string]string) (string, error) {
+	r.env = env
+	if output, ok := r.failures[scriptPath]; ok {
+		return output, errors.New("exit status 1")
+	}
+	return "ok", nil
+}
+
+// newRulesBoard returns a board with a todo and a done column and one task in
+// todo
+func newRulesBoard(t *testing.T) (*entity.Board, *entity.Task, *entity.Column, *entity.Column) {
+	t.Helper()
+
+	board := newSimulationBoard(t, []simulatedTask{{title: "ship", priority: valueobject.PriorityHigh}})
+	done, err := entity.NewColumn("done", "", 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddColumn(done); err != nil {
+		t.Fatal(err)
+	}
+	todo, _ := board.GetColumn("todo")
+	return board, todo.Tasks()[0], todo, done
+}
+
+// subtaskList returns a description linking one subtask per checkbox state
+func subtaskList(states ...string) string {
+	lines := make([]string, 0, len(states))
+	for i, state := range states {
+		lines = append(lines, fmt.Sprintf("- [%s] [Part %d](../DEM-%d-part/task.md)", state, i+2, i+2))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestCheckColumnRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     entity.ColumnRule
+		ruleOn   string // column the rule is added to
+		setup    func(task *entity.Task)
+		failures map[string]string
+		want     bool   // whether the rule is violated
+		reason   string // expected violation reason
+	}{
+		{
+			name:   "missing estimate",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Field: "estimate"},
+			ruleOn: "done",
+			want:   true,
+		},
+		{
+			name:   "estimate set",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Field: "estimate"},
+			ruleOn: "done",
+			setup:  func(task *entity.Task) { task.SetEstimatedTime(2 * time.Hour) },
+		},
+		{
+			name:   "assignee tag present",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Field: "tag:@"},
+			ruleOn: "done",
+			setup:  func(task *entity.Task) { task.AddTag("@alice") },
+		},
+		{
+			name:   "assignee tag missing",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Field: "tag:@"},
+			ruleOn: "done",
+			setup:  func(task *entity.Task) { task.AddTag("backend") },
+			want:   true,
+		},
+		{
+			name:   "metadata key",
+			rule:   entity.ColumnRule{On: entity.RuleOnExit, Type: entity.ColumnRuleRequire, Field: "reviewer"},
+			ruleOn: "todo",
+			setup:  func(task *entity.Task) { task.SetMetadata("reviewer", "bob") },
+		},
+		{
+			name: "condition holds",
+			rule: entity.ColumnRule{
+				On:        entity.RuleOnEnter,
+				Type:      entity.ColumnRuleCondition,
+				Condition: entity.NewCondition("priority", entity.OperatorEquals, "high"),
+			},
+			ruleOn: "done",
+		},
+		{
+			name: "condition fails",
+			rule: entity.ColumnRule{
+				On:        entity.RuleOnEnter,
+				Type:      entity.ColumnRuleCondition,
+				Condition: entity.NewCondition("priority", entity.OperatorEquals, "low"),
+			},
+			ruleOn: "done",
+			want:   true,
+		},
+		{
+			name:   "no subtasks",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleSubtasksComplete},
+			ruleOn: "done",
+		},
+		{
+			name:   "open subtasks",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleSubtasksComplete},
+			ruleOn: "done",
+			setup:  func(task *entity.Task) { task.UpdateDescription(subtaskList("x", " ", "~")) },
+			want:   true,
+			reason: "2 of 3 open",
+		},
+		{
+			name:   "subtasks complete",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleSubtasksComplete},
+			ruleOn: "done",
+			setup:  func(task *entity.Task) { task.UpdateDescription(subtaskList("x", "x")) },
+		},
+		{
+			name:   "script succeeds",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleScript, Script: "ci.sh"},
+			ruleOn: "done",
+		},
+		{
+			name:     "script fails with its output",
+			rule:     entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleScript, Script: "ci.sh"},
+			ruleOn:   "done",
+			failures: map[string]string{"ci.sh": "  pipeline is red\n"},
+			want:     true,
+			reason:   "pipeline is red",
+		},
+		{
+			name:     "script fails silently",
+			rule:     entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleScript, Script: "ci.sh"},
+			ruleOn:   "done",
+			failures: map[string]string{"ci.sh": ""},
+			want:     true,
+			reason:   "exit status 1",
+		},
+		{
+			name:   "exit rule of the target is not checked",
+			rule:   entity.ColumnRule{On: entity.RuleOnExit, Type: entity.ColumnRuleRequire, Field: "estimate"},
+			ruleOn: "done",
+		},
+		{
+			name:   "entry rule of the source is not checked",
+			rule:   entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Field: "estimate"},
+			ruleOn: "todo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, task, todo, done := newRulesBoard(t)
+			rule := tt.rule
+			rule.Severity = entity.RuleBlock
+			column, _ := board.GetColumn(tt.ruleOn)
+			if err := column.AddRule(&rule); err != nil {
+				t.Fatal(err)
+			}
+			if tt.setup != nil {
+				tt.setup(task)
+			}
+			runner := &fakeScriptRunner{failures: tt.failures}
+
+			violations := CheckColumnRules(board, task, todo, done, runner)
+
+			if got := len(violations) > 0; got != tt.want {
+				t.Fatalf("violated = %v, want %v (%v)", got, tt.want, violations)
+			}
+			if !tt.want {
+				return
+			}
+			if violations[0].Reason != tt.reason {
+				t.Errorf("reason = %q, want %q", violations[0].Reason, tt.reason)
+			}
+			if violations[0].Column != column.DisplayName() {
+				t.Errorf("column = %q, want %q", violations[0].Column, column.DisplayName())
+			}
+		})
+	}
+}
+
+func TestCheckColumnRulesScriptEnv(t *testing.T) {
+	board, task, todo, done := newRulesBoard(t)
+	if err := done.AddRule(&entity.ColumnRule{
+		On:       entity.RuleOnEnter,
+		Type:     entity.ColumnRuleScript,
+		Severity: entity.RuleBlock,
+		Script:   "ci.sh",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	runner := &fakeScriptRunner{}
+
+	CheckColumnRules(board, task, todo, done, runner)
+
+	want := map[string]string{
+		"TASK_ID":     task.ID().String(),
+		"BOARD_ID":    "demo/demo",
+		"COLUMN_NAME": "done",
+		"FROM_COLUMN": "todo",
+		"TO_COLUMN":   "done",
+		"RULE_ON":     "enter",
+	}
+	for key, value := range want {
+		if runner.env[key] != value {
+			t.Errorf("%s = %q, want %q", key, runner.env[key], value)
+		}
+	}
+}
+
+func TestCheckColumnRulesSeverity(t *testing.T) {
+	board, task, todo, done := newRulesBoard(t)
+	for _, rule := range []*entity.ColumnRule{
+		{On: entity.RuleOnExit, Type: entity.ColumnRuleRequire, Severity: entity.RuleWarn, Field: "tags"},
+		{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Severity: entity.RuleBlock, Field: "estimate",
+			Message: "estimate the task first"},
+	} {
+		column := done
+		if rule.On == entity.RuleOnExit {
+			column = todo
+		}
+		if err := column.AddRule(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	violations := CheckColumnRules(board, task, todo, done, nil)
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2", len(violations))
+	}
+	if violations[0].Blocking() || !violations[1].Blocking() {
+		t.Error("exit warning should come first and only the entry rule should block")
+	}
+	if !HasBlockingViolation(violations) {
+		t.Error("HasBlockingViolation() = false, want true")
+	}
+	if HasBlockingViolation(violations[:1]) {
+		t.Error("a warning alone should not block")
+	}
+	if got := violations[1].Message(); got != "estimate the task first" {
+		t.Errorf("message = %q", got)
+	}
+
+	err := error(&entity.ColumnRuleError{TaskID: task.ID().String(), Violations: violations})
+	if !errors.Is(err, entity.ErrColumnRuleViolated) {
+		t.Error("ColumnRuleError is not ErrColumnRuleViolated")
+	}
+
+	task.SetEstimatedTime(time.Hour)
+	if violations := CheckColumnRules(board, task, todo, done, nil); HasBlockingViolation(violations) {
+		t.Error("move still blocked after setting the estimate")
+	}
+	if violations := CheckColumnRules(board, task, done, done, nil); len(violations) != 0 {
+		t.Error("staying in the same column should not check rules")
+	}
+}
+
+func TestCheckColumnRulesOnCreation(t *testing.T) {
+	board, task, todo, done := newRulesBoard(t)
+	if err := todo.AddRule(&entity.ColumnRule{
+		On: entity.RuleOnExit, Type: entity.ColumnRuleRequire, Severity: entity.RuleBlock, Field: "estimate",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := done.AddRule(&entity.ColumnRule{
+		On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Severity: entity.RuleBlock, Field: "tags",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new task has no column to leave, only the one it is created in
+	if violations := CheckColumnRules(board, task, nil, todo, nil); len(violations) != 0 {
+		t.Errorf("creating in todo: %v, want no violations", violations)
+	}
+	violations := CheckColumnRules(board, task, nil, done, nil)
+	if !HasBlockingViolation(violations) {
+		t.Fatalf("creating in done: %v, want the entry rule to block", violations)
+	}
+	if violations[0].Column != done.DisplayName() {
+		t.Errorf("column = %q, want %q", violations[0].Column, done.DisplayName())
+	}
+}
+
+func TestColumnRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    entity.ColumnRule
+		wantErr bool
+	}{
+		{name: "require", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Severity: entity.RuleBlock, Field: "estimate"}},
+		{name: "subtasks", rule: entity.ColumnRule{On: entity.RuleOnExit, Type: entity.ColumnRuleSubtasksComplete, Severity: entity.RuleWarn}},
+		{name: "bad hook", rule: entity.ColumnRule{On: "leave", Type: entity.ColumnRuleSubtasksComplete, Severity: entity.RuleBlock}, wantErr: true},
+		{name: "bad severity", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleSubtasksComplete, Severity: "fatal"}, wantErr: true},
+		{name: "require without field", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleRequire, Severity: entity.RuleBlock, Field: "tag:"}, wantErr: true},
+		{name: "condition without condition", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleCondition, Severity: entity.RuleBlock}, wantErr: true},
+		{name: "script without path", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: entity.ColumnRuleScript, Severity: entity.RuleBlock}, wantErr: true},
+		{name: "unknown type", rule: entity.ColumnRule{On: entity.RuleOnEnter, Type: "lint", Severity: entity.RuleBlock}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, entity.ErrInvalidColumnRule) {
+				t.Errorf("error %v is not ErrInvalidColumnRule", err)
+			}
+		})
+	}
+}
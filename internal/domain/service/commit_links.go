@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mkanban/internal/domain/entity"
+)
+
+// DefaultCloseKeywords are the words that, right before a task reference in
+// a commit message, mean the commit finishes the task, as in "fixes PRO-12"
+var DefaultCloseKeywords = []string{
+	"close", "closes", "closed",
+	"fix", "fixes", "fixed",
+	"resolve", "resolves", "resolved",
+}
+
+// taskReferencePattern matches short task IDs such as PRO-12 or PRO-012
+var taskReferencePattern = regexp.MustCompile(`\b([A-Z]{3})-(\d+)\b`)
+
+// TaskReference is a mention of a task by its short ID
+type TaskReference struct {
+	Prefix string
+	Number int
+	Closes bool // the mention follows a close keyword
+}
+
+// String returns the reference as written in the short ID format
+func (r TaskReference) String() string {
+	return r.Prefix + "-" + strconv.Itoa(r.Number)
+}
+
+// ParseTaskReferences returns the tasks mentioned in text, once each in
+// order of first mention. A mention closes its task when it follows one of
+// closeKeywords, or continues a list that does, as in "fixes PRO-1, PRO-2".
+func ParseTaskReferences(text string, closeKeywords []string) []TaskReference {
+	var refs []TaskReference
+	index := make(map[string]int)
+
+	closing := false
+	previousEnd := -1
+	for _, match := range taskReferencePattern.FindAllStringSubmatchIndex(text, -1) {
+		number, err := strconv.Atoi(text[match[4]:match[5]])
+		if err != nil {
+			continue
+		}
+
+		before := text[:match[0]]
+		if previousEnd >= 0 {
+			// Only a list separator may stand between two closed mentions
+			separator := strings.ToLower(strings.TrimSpace(text[previousEnd:match[0]]))
+			closing = closing && (separator == "," || separator == "and" || separator == ", and")
+		}
+		closing = closing || followsKeyword(before, closeKeywords)
+		previousEnd = match[1]
+
+		ref := TaskReference{Prefix: text[match[2]:match[3]], Number: number, Closes: closing}
+		if i, seen := index[ref.String()]; seen {
+			refs[i].Closes = refs[i].Closes || ref.Closes
+			continue
+		}
+		index[ref.String()] = len(refs)
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// followsKeyword reports whether text ends with one of keywords, allowing
+// a colon and a '#' between the keyword and what follows
+func followsKeyword(text string, keywords []string) bool {
+	text = strings.TrimRight(text, " \t#")
+	text = strings.TrimRight(text, ":")
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return false
+	}
+	last := strings.ToLower(fields[len(fields)-1])
+	for _, keyword := range keywords {
+		if last == strings.ToLower(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindReferencedTask returns the task a reference points to and its board,
+// or nils. Numbers match regardless of zero padding.
+func FindReferencedTask(boards []*entity.Board, ref TaskReference) (*entity.Board, *entity.Task) {
+	for _, board := range boards {
+		for _, column := range board.Columns() {
+			for _, task := range column.Tasks() {
+				if task.ID().Prefix() == ref.Prefix && task.ID().Number() == ref.Number {
+					return board, task
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// FindBranchTask returns the task a branch was made for and its board, or
+// nils: the task synced from the branch, else the first task the branch
+// name mentions, as in feature/PRO-12-login
+func FindBranchTask(boards []*entity.Board, branch string) (*entity.Board, *entity.Task) {
+	for _, board := range boards {
+		for _, column := range board.Columns() {
+			for _, task := range column.Tasks() {
+				if taskBranch, ok := task.GetMetadata("git_branch"); ok && taskBranch == branch {
+					return board, task
+				}
+			}
+		}
+	}
+	for _, ref := range ParseTaskReferences(branch, nil) {
+		if board, task := FindReferencedTask(boards, ref); task != nil {
+			return board, task
+		}
+	}
+	return nil, nil
+}
+
+// CommitActivity returns the activity entry recording a commit
+func CommitActivity(commit Commit) entity.Activity {
+	return entity.Activity{
+		Kind:    entity.ActivityCommit,
+		Ref:     commit.Hash,
+		Summary: commit.Subject,
+		Author:  commit.Author,
+		Time:    commit.Time,
+	}
+}
+
+// MergeActivity returns the activity entry recording that commit merged a
+// task's branch into target, squashed or not
+func MergeActivity(commit Commit, target string, squash bool) entity.Activity {
+	verb := "Merged"
+	if squash {
+		verb = "Squash-merged"
+	}
+	return entity.Activity{
+		Kind:    entity.ActivityMerge,
+		Ref:     commit.Hash,
+		Summary: fmt.Sprintf("%s into %s: %s", verb, target, commit.Subject),
+		Author:  commit.Author,
+		Time:    commit.Time,
+	}
+}
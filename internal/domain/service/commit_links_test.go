@@ -0,0 +1,116 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+)
+
+func TestParseTaskReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []TaskReference
+	}{
+		{
+			name: "mention",
+			text: "Add login form for PRO-12",
+			want: []TaskReference{{Prefix: "PRO", Number: 12}},
+		},
+		{
+			name: "close keyword",
+			text: "Fixes PRO-012: redirect after login",
+			want: []TaskReference{{Prefix: "PRO", Number: 12, Closes: true}},
+		},
+		{
+			name: "keyword with colon and hash",
+			text: "resolves: #PRO-3",
+			want: []TaskReference{{Prefix: "PRO", Number: 3, Closes: true}},
+		},
+		{
+			name: "closed list",
+			text: "closes PRO-1, PRO-2 and PRO-3, see PRO-4",
+			want: []TaskReference{
+				{Prefix: "PRO", Number: 1, Closes: true},
+				{Prefix: "PRO", Number: 2, Closes: true},
+				{Prefix: "PRO", Number: 3, Closes: true},
+				{Prefix: "PRO", Number: 4},
+			},
+		},
+		{
+			name: "repeated mention closes",
+			text: "PRO-7 part two\n\nFixes PRO-7",
+			want: []TaskReference{{Prefix: "PRO", Number: 7, Closes: true}},
+		},
+		{
+			name: "branch name",
+			text: "feature/PRO-12-login-form",
+			want: []TaskReference{{Prefix: "PRO", Number: 12}},
+		},
+		{
+			name: "not a task ID",
+			text: "lowercase pro-1, ABCD-1 or PR-1",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTaskReferences(tt.text, DefaultCloseKeywords)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTaskReferences(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindBranchTask(t *testing.T) {
+	board, tasks := newEpicBoard(t, "web/main", "WEB", "login", "signup")
+	tasks[1].SetMetadata("git_branch", "signup-flow")
+	boards := []*entity.Board{board}
+
+	tests := []struct {
+		branch string
+		want   *entity.Task
+	}{
+		{"signup-flow", tasks[1]},
+		{"feature/WEB-1-login", tasks[0]},
+		{"WEB-001", tasks[0]},
+		{"WEB-9-missing", nil},
+		{"main", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			_, got := FindBranchTask(boards, tt.branch)
+			if got != tt.want {
+				t.Errorf("FindBranchTask(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitActivity(t *testing.T) {
+	_, tasks := newEpicBoard(t, "web/main", "WEB", "login")
+	task := tasks[0]
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	newer := Commit{Hash: "bbb", Subject: "second", Author: "ann", Time: start.Add(time.Hour)}
+	older := Commit{Hash: "aaa", Subject: "first", Author: "bob", Time: start}
+	if !task.AddActivity(CommitActivity(newer)) || !task.AddActivity(CommitActivity(older)) {
+		t.Fatal("new commits should be added")
+	}
+	if task.AddActivity(CommitActivity(newer)) {
+		t.Error("a known commit should not be added again")
+	}
+
+	activity := task.Activity()
+	if len(activity) != 2 || activity[0].Ref != "aaa" || activity[1].Ref != "bbb" {
+		t.Errorf("activity = %+v, want aaa then bbb", activity)
+	}
+	if activity[0].Kind != entity.ActivityCommit || activity[0].Author != "bob" || activity[0].Summary != "first" {
+		t.Errorf("activity entry = %+v", activity[0])
+	}
+}
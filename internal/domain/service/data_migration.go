@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDataSchemaTooNew is returned when the data directory was migrated by
+// a newer mkanban than this one
+var ErrDataSchemaTooNew = errors.New("data directory was written by a newer mkanban, upgrade to use it")
+
+// DataMigration is one versioned step of the data directory's layout
+type DataMigration struct {
+	Version     int
+	ID          string
+	Description string
+	AppliedAt   time.Time // zero while pending
+}
+
+// DataSchemaStatus describes how far the data directory is migrated
+type DataSchemaStatus struct {
+	Version int // version of the data directory, 0 before versioning
+	Latest  int // version this mkanban migrates to
+	Applied []DataMigration
+	Pending []DataMigration
+}
+
+// UpToDate reports whether no migrations are pending
+func (s *DataSchemaStatus) UpToDate() bool {
+	return len(s.Pending) == 0
+}
+
+// DataFileChange is a file a migration added, removed or modified,
+// relative to the data directory
+type DataFileChange struct {
+	Change string // "added", "removed" or "modified"
+	Path   string
+}
+
+// DataMigrateOptions controls a run of the pending migrations
+type DataMigrateOptions struct {
+	// DryRun runs the migrations on a scratch copy of the data directory
+	// and reports what they would change
+	DryRun bool
+
+	// Backup archives the data directory before migrating it
+	Backup bool
+}
+
+// DataMigrateResult is the outcome of a run of the pending migrations
+type DataMigrateResult struct {
+	From    int
+	To      int
+	Applied []DataMigration
+	Changes []DataFileChange
+	Backup  string // archive of the data directory taken first, if any
+	DryRun  bool
+}
+
+// DataMigrator upgrades the data directory from older layouts, one
+// versioned migration at a time
+type DataMigrator interface {
+	// Init records a data directory without any data yet as up to date,
+	// so there is nothing to migrate later
+	Init(ctx context.Context) error
+
+	// Status reports the version of the data directory and the migrations
+	// applied to and pending for it
+	Status(ctx context.Context) (*DataSchemaStatus, error)
+
+	// Migrate applies the pending migrations in order
+	Migrate(ctx context.Context, opts DataMigrateOptions) (*DataMigrateResult, error)
+}
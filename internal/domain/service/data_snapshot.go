@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSnapshotNotFound is returned for a snapshot ID that isn't kept
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// ErrSnapshotScopeDatabase is returned for a diff or restore of a board or
+// project when the data is kept in a SQLite database, which snapshots only
+// hold whole
+var ErrSnapshotScopeDatabase = errors.New("a SQLite database can only be diffed and restored as a whole, not by board or project")
+
+// Kinds of DataSnapshot
+const (
+	SnapshotScheduled  = "scheduled"   // taken by the daemon on its interval
+	SnapshotManual     = "manual"      // taken on request, never pruned
+	SnapshotPreRestore = "pre-restore" // taken right before a restore, to undo it
+)
+
+// DataSnapshot is a compressed copy of the data directory at a point in
+// time
+type DataSnapshot struct {
+	ID        string
+	Kind      string
+	CreatedAt time.Time
+	Size      int64 // bytes of the compressed archive
+}
+
+// SnapshotScope narrows a diff or restore to part of the data directory:
+// a board, a project, or with both empty the whole directory
+type SnapshotScope struct {
+	ProjectSlug string
+	BoardID     string
+}
+
+// SnapshotRestoreResult is the outcome of restoring from a snapshot
+type SnapshotRestoreResult struct {
+	Snapshot DataSnapshot
+	Undo     *DataSnapshot // snapshot taken first, which undoes the restore
+	Changes  []DataFileChange
+}
+
+// DataSnapshotter takes snapshots of the data directory and restores it,
+// or part of it, to one of them
+type DataSnapshotter interface {
+	// Create takes a snapshot of the kind given
+	Create(ctx context.Context, kind string) (*DataSnapshot, error)
+
+	// List returns the snapshots kept, newest first
+	List(ctx context.Context) ([]DataSnapshot, error)
+
+	// Diff lists the files added, removed and modified within scope since
+	// the snapshot was taken
+	Diff(ctx context.Context, id string, scope SnapshotScope) ([]DataFileChange, error)
+
+	// Restore puts the files within scope back the way they were in the
+	// snapshot, after taking a pre-restore snapshot
+	Restore(ctx context.Context, id string, scope SnapshotScope) (*SnapshotRestoreResult, error)
+
+	// Prune removes the scheduled and pre-restore snapshots the retention
+	// rules no longer keep as of now, returning them
+	Prune(ctx context.Context, now time.Time) ([]DataSnapshot, error)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSyncNotInitialized is returned when the data directory hasn't been set
+// up for syncing yet
+var ErrSyncNotInitialized = errors.New("data directory is not set up for syncing, run 'mkanban sync init'")
+
+// ErrSyncNoRemote is returned when syncing without a remote to sync with
+var ErrSyncNoRemote = errors.New("no sync remote configured")
+
+// ErrSyncDatabaseBackend is returned when syncing data kept in a database,
+// which can't be merged like the files of the filesystem backend
+var ErrSyncDatabaseBackend = errors.New("data sync needs the filesystem storage backend, a SQLite database can't be merged; run 'mkanban storage migrate --to filesystem' first")
+
+// DataSyncResult is the outcome of a sync of the data directory
+type DataSyncResult struct {
+	Committed  bool     // local changes were committed before syncing
+	Pulled     int      // commits merged from the remote
+	Pushed     int      // commits pushed to the remote
+	Merged     []string // files changed on both sides and merged field by field
+	Renumbered []RenumberedTask
+	SyncedAt   time.Time
+}
+
+// RenumberedTask is a local task that got a new number because a task
+// created elsewhere took its number first
+type RenumberedTask struct {
+	BoardID string
+	OldID   string
+	NewID   string
+	Branch  string // git branch of the task, which keeps the old ID
+}
+
+// DataSyncStatus describes the sync state of the data directory. Ahead and
+// Behind count commits against the remote as of the last fetch.
+type DataSyncStatus struct {
+	Initialized bool
+	Remote      string
+	Branch      string
+	Pending     int // files changed since the last commit
+	Ahead       int
+	Behind      int
+	LastCommit  time.Time
+}
+
+// DataSyncer keeps the data directory under version control and in sync
+// with a remote copy shared by other machines
+type DataSyncer interface {
+	// Init sets the data directory up for syncing, with remote as the copy
+	// to sync with when given
+	Init(ctx context.Context, remote string) error
+
+	// Commit records the changes made since the last commit, reporting
+	// whether there were any
+	Commit(ctx context.Context, message string) (bool, error)
+
+	// Sync commits pending changes, merges the remote's changes and pushes
+	// the result. Edits of the same file on both sides are merged field by
+	// field, and local tasks whose numbers were taken remotely are
+	// renumbered.
+	Sync(ctx context.Context) (*DataSyncResult, error)
+
+	// Status reports the sync state without contacting the remote
+	Status(ctx context.Context) (*DataSyncStatus, error)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"mkanban/internal/domain/valueobject"
 )
 
 // CheckboxState represents the state of a checkbox
@@ -97,31 +99,74 @@ func GetCheckboxStates(description string) map[string]CheckboxState {
 			linkURL := matches[4]
 
 			// Extract task ID from URL (e.g., "../../Todo/BOARD-2-title/task.md" -> "BOARD-2-title")
-			parts := strings.Split(linkURL, "/")
-			for _, part := range parts {
-				if strings.Contains(part, "-") && len(part) > 3 {
-					// This looks like a task ID
-					var checkboxState CheckboxState
-					switch state {
-					case " ":
-						checkboxState = CheckboxTodo
-					case "x":
-						checkboxState = CheckboxDone
-					case "~":
-						checkboxState = CheckboxInProgress
-					default:
-						checkboxState = CheckboxTodo
-					}
-					states[part] = checkboxState
-					break
-				}
+			taskID := linkedTaskID(linkURL)
+			if taskID == "" {
+				continue
 			}
+			var checkboxState CheckboxState
+			switch state {
+			case " ":
+				checkboxState = CheckboxTodo
+			case "x":
+				checkboxState = CheckboxDone
+			case "~":
+				checkboxState = CheckboxInProgress
+			default:
+				checkboxState = CheckboxTodo
+			}
+			states[taskID] = checkboxState
 		}
 	}
 
 	return states
 }
 
+// LinkedTaskIDs returns the task IDs of the linked checkboxes in the
+// description, in order
+func LinkedTaskIDs(description string) []string {
+	var ids []string
+	for _, line := range strings.Split(description, "\n") {
+		matches := linkedCheckboxPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if taskID := linkedTaskID(matches[4]); taskID != "" {
+			ids = append(ids, taskID)
+		}
+	}
+	return ids
+}
+
+// linkedTaskID extracts the task ID from a subtask link: the folder holding
+// task.md, or else the first part that looks like a task ID
+func linkedTaskID(linkURL string) string {
+	parts := strings.Split(linkURL, "/")
+	if n := len(parts); n >= 2 && parts[n-1] == "task.md" {
+		return parts[n-2]
+	}
+	for _, part := range parts {
+		if strings.Contains(part, "-") && len(part) > 3 {
+			// This looks like a task ID
+			return part
+		}
+	}
+	return ""
+}
+
+// ChangedCheckboxes returns the linked checkboxes whose state differs
+// between two versions of a description, by task ID, with their new state.
+// Checkboxes that were removed or added are not changes.
+func ChangedCheckboxes(oldDescription, newDescription string) map[string]CheckboxState {
+	oldStates := GetCheckboxStates(oldDescription)
+	changed := make(map[string]CheckboxState)
+	for taskID, state := range GetCheckboxStates(newDescription) {
+		if oldState, ok := oldStates[taskID]; ok && oldState != state {
+			changed[taskID] = state
+		}
+	}
+	return changed
+}
+
 // AllCheckboxesComplete checks if all checkboxes in the description are marked as done
 func AllCheckboxesComplete(description string) bool {
 	states := GetCheckboxStates(description)
@@ -174,3 +219,45 @@ func AddSubtaskLink(description, subtaskTitle, taskID, columnName string) string
 
 	return strings.Join(updatedLines, "\n")
 }
+
+// RemoveSubtaskLink removes the checkbox linked to the given taskID
+func RemoveSubtaskLink(description, taskID string) string {
+	lines := strings.Split(description, "\n")
+	updatedLines := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		matches := linkedCheckboxPattern.FindStringSubmatch(line)
+		if matches != nil && len(matches) >= 5 && linkedTaskID(matches[4]) == taskID {
+			continue
+		}
+		updatedLines = append(updatedLines, line)
+	}
+
+	return strings.Join(updatedLines, "\n")
+}
+
+// SubtaskLinkPath returns the path of a subtask's task.md relative to its
+// parent's. Subtasks on the parent's board use the same format as
+// AddSubtaskLink; those on other boards go up to the projects directory.
+func SubtaskLinkPath(parentBoardID, subtaskBoardID, columnName, taskID string) string {
+	if subtaskBoardID == "" || subtaskBoardID == parentBoardID {
+		return fmt.Sprintf("../../%s/%s/task.md", columnName, taskID)
+	}
+	projectSlug, boardSlug, err := valueobject.ParseBoardID(subtaskBoardID)
+	if err != nil {
+		return fmt.Sprintf("../../%s/%s/task.md", columnName, taskID)
+	}
+	return fmt.Sprintf("../../../../../../../%s/boards/%s/columns/%s/tasks/%s/task.md", projectSlug, boardSlug, columnName, taskID)
+}
+
+// AppendSubtaskLink adds a checkbox linking to a subtask at taskLink, as
+// built by SubtaskLinkPath, at the end of the description
+func AppendSubtaskLink(description, subtaskTitle, taskLink string, state CheckboxState) string {
+	line := fmt.Sprintf("- %s [%s](%s)", state, subtaskTitle, taskLink)
+
+	description = strings.TrimRight(description, "\n")
+	if description == "" {
+		return line
+	}
+	return description + "\n" + line
+}
@@ -124,6 +124,17 @@ func TestGetCheckboxStates(t *testing.T) {
 	}
 }
 
+func TestLinkedTaskIDs(t *testing.T) {
+	description := `- [ ] [Third task](../../Todo/BOARD-4-third-task/task.md)
+- [ ] Unlinked task
+- [x] [First task](../../Done/BOARD-2-first-task/task.md)`
+
+	ids := LinkedTaskIDs(description)
+	if len(ids) != 2 || ids[0] != "BOARD-4-third-task" || ids[1] != "BOARD-2-first-task" {
+		t.Errorf("expected the linked tasks in order, got %v", ids)
+	}
+}
+
 func TestAllCheckboxesComplete(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -191,3 +202,74 @@ func TestAddSubtaskLink(t *testing.T) {
 		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, updated)
 	}
 }
+
+func TestRemoveSubtaskLink(t *testing.T) {
+	description := `Subtasks:
+
+- [ ] [Login](../../todo/WEB-2-login/task.md)
+- [x] [Login page](../../done/WEB-2-login-page/task.md)`
+
+	// Only the link to the task itself goes, not ones whose ID contains it
+	updated := RemoveSubtaskLink(description, "WEB-2-login")
+	expected := `Subtasks:
+
+- [x] [Login page](../../done/WEB-2-login-page/task.md)`
+
+	if updated != expected {
+		t.Errorf("expected:\n%s\n\ngot:\n%s", expected, updated)
+	}
+}
+
+func TestSubtaskLinkPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		parentBoardID  string
+		subtaskBoardID string
+		expected       string
+	}{
+		{
+			name:           "same board",
+			parentBoardID:  "web/main",
+			subtaskBoardID: "web/main",
+			expected:       "../../todo/WEB-2-login/task.md",
+		},
+		{
+			name:           "other board",
+			parentBoardID:  "web/main",
+			subtaskBoardID: "api/backend",
+			expected:       "../../../../../../../api/boards/backend/columns/todo/tasks/WEB-2-login/task.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SubtaskLinkPath(tt.parentBoardID, tt.subtaskBoardID, "todo", "WEB-2-login")
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			// The task ID must be recoverable from the link
+			states := GetCheckboxStates(AppendSubtaskLink("", "Login", got, CheckboxTodo))
+			if _, ok := states["WEB-2-login"]; !ok {
+				t.Errorf("no checkbox found for WEB-2-login in %v", states)
+			}
+		})
+	}
+}
+
+func TestChangedCheckboxes(t *testing.T) {
+	old := `- [ ] [First](../../todo/BOARD-2-first/task.md)
+- [~] [Second](../../doing/BOARD-3-second/task.md)
+- [ ] [Third](../../todo/BOARD-4-third/task.md)`
+	updated := `- [x] [First](../../todo/BOARD-2-first/task.md)
+- [~] [Second](../../doing/BOARD-3-second/task.md)
+- [ ] [Fifth](../../todo/BOARD-6-fifth/task.md)`
+
+	changed := ChangedCheckboxes(old, updated)
+
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 change, got %v", changed)
+	}
+	if changed["BOARD-2-first"] != CheckboxDone {
+		t.Errorf("expected first task to be done, got %v", changed["BOARD-2-first"])
+	}
+}
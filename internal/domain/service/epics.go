@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// TaskTree is a task with its subtasks, which may be on other boards than
+// the task itself, and their subtasks in turn
+type TaskTree struct {
+	Task     *entity.Task
+	BoardID  string
+	Column   *entity.Column
+	Tracked  time.Duration // tracked on the task itself
+	Children []*TaskTree
+}
+
+// EpicProgress counts the direct subtasks of a task by state
+type EpicProgress struct {
+	Total      int
+	Done       int
+	InProgress int
+}
+
+// Percent returns the share of done subtasks, 0 without subtasks
+func (p EpicProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// BuildTaskTree returns the tree of subtasks under a task, looking for
+// subtasks on all the boards
+func BuildTaskTree(boards []*entity.Board, boardID string, taskID *valueobject.TaskID) (*TaskTree, error) {
+	board := findBoard(boards, boardID)
+	if board == nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrBoardNotFound, boardID)
+	}
+	task, column, err := board.FindTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &TaskTree{Task: task, BoardID: boardID, Column: column, Tracked: task.TrackedTime()}
+	root.addSubtasks(boards, map[string]bool{taskKey(boardID, taskID): true})
+	return root, nil
+}
+
+// addSubtasks fills in the subtasks of the tree recursively, skipping tasks
+// already in the tree so that broken parent links can't loop
+func (t *TaskTree) addSubtasks(boards []*entity.Board, seen map[string]bool) {
+	for _, child := range FindSubtasks(boards, t.BoardID, t.Task.ID()) {
+		key := taskKey(child.BoardID, child.Task.ID())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		child.addSubtasks(boards, seen)
+		t.Children = append(t.Children, child)
+	}
+}
+
+// FindSubtasks returns the direct subtasks of the task with ID taskID on
+// board boardID, from all the boards, without their own subtasks
+func FindSubtasks(boards []*entity.Board, boardID string, taskID *valueobject.TaskID) []*TaskTree {
+	var subtasks []*TaskTree
+	for _, board := range boards {
+		for _, column := range board.Columns() {
+			for _, task := range column.Tasks() {
+				if task.ParentID() == nil || !task.ParentID().Equal(taskID) || parentBoardOf(board, task) != boardID {
+					continue
+				}
+				subtasks = append(subtasks, &TaskTree{
+					Task:    task,
+					BoardID: board.ID(),
+					Column:  column,
+					Tracked: task.TrackedTime(),
+				})
+			}
+		}
+	}
+	return subtasks
+}
+
+// Walk calls fn for the task and each of its subtasks, parents first
+func (t *TaskTree) Walk(fn func(node *TaskTree)) {
+	fn(t)
+	for _, child := range t.Children {
+		child.Walk(fn)
+	}
+}
+
+// State returns the state of the task as a subtask checkbox
+func (t *TaskTree) State() CheckboxState {
+	return subtaskState(t.Column, t.Task)
+}
+
+// Progress counts the direct subtasks by state
+func (t *TaskTree) Progress() EpicProgress {
+	progress := EpicProgress{Total: len(t.Children)}
+	for _, child := range t.Children {
+		switch child.State() {
+		case CheckboxDone:
+			progress.Done++
+		case CheckboxInProgress:
+			progress.InProgress++
+		}
+	}
+	return progress
+}
+
+// EstimatedTime returns the estimate of the task and all its subtasks
+func (t *TaskTree) EstimatedTime() time.Duration {
+	var total time.Duration
+	t.Walk(func(node *TaskTree) {
+		if estimate := node.Task.EstimatedTime(); estimate != nil {
+			total += *estimate
+		}
+	})
+	return total
+}
+
+// TrackedTime returns the time tracked on the task and all its subtasks
+func (t *TaskTree) TrackedTime() time.Duration {
+	var total time.Duration
+	t.Walk(func(node *TaskTree) {
+		total += node.Tracked
+	})
+	return total
+}
+
+// SetParent makes task, on board, a subtask of parent on parentBoard, or a
+// top level task for a nil parent. The checkbox of the task moves from its
+// old parent's description to the new one's. It returns the boards that
+// changed; boards must include those of both parents.
+func SetParent(boards []*entity.Board, board *entity.Board, task *entity.Task, parentBoard *entity.Board, parent *entity.Task) ([]*entity.Board, error) {
+	changed := []*entity.Board{board}
+
+	if parent != nil {
+		// Refuse cycles, including a task becoming its own parent
+		seen := make(map[string]bool)
+		ancestorBoard, ancestor := parentBoard, parent
+		for ancestor != nil && !seen[taskKey(ancestorBoard.ID(), ancestor.ID())] {
+			if ancestorBoard.ID() == board.ID() && ancestor.ID().Equal(task.ID()) {
+				return nil, fmt.Errorf("task %s can't become a subtask of %s, which is the task itself or one of its subtasks", task.ID(), parent.ID())
+			}
+			seen[taskKey(ancestorBoard.ID(), ancestor.ID())] = true
+			ancestorBoard, ancestor = findParentTask(boards, ancestorBoard, ancestor)
+		}
+	}
+
+	if oldParentBoard, oldParent := findParentTask(boards, board, task); oldParent != nil {
+		oldParent.UpdateDescription(RemoveSubtaskLink(oldParent.Description(), task.ID().String()))
+		changed = appendBoard(changed, oldParentBoard)
+	}
+
+	if parent == nil {
+		task.SetParentID(nil)
+		task.SetParentBoardID("")
+		return changed, nil
+	}
+
+	_, column, err := board.FindTask(task.ID())
+	if err != nil {
+		return nil, err
+	}
+	task.SetParentID(parent.ID())
+	if parentBoard.ID() == board.ID() {
+		task.SetParentBoardID("")
+	} else {
+		task.SetParentBoardID(parentBoard.ID())
+	}
+	parent.UpdateDescription(AppendSubtaskLink(
+		RemoveSubtaskLink(parent.Description(), task.ID().String()),
+		task.Title(),
+		SubtaskLinkPath(parentBoard.ID(), board.ID(), column.Name(), task.ID().String()),
+		subtaskState(column, task),
+	))
+	return appendBoard(changed, parentBoard), nil
+}
+
+// findParentTask returns the parent of a task on board and the board the
+// parent is on, or nils if it has none or the parent isn't on the boards
+func findParentTask(boards []*entity.Board, board *entity.Board, task *entity.Task) (*entity.Board, *entity.Task) {
+	if task.ParentID() == nil {
+		return nil, nil
+	}
+	parentBoard := findBoard(boards, parentBoardOf(board, task))
+	if parentBoard == nil {
+		return nil, nil
+	}
+	parent, _, err := parentBoard.FindTask(task.ParentID())
+	if err != nil {
+		return nil, nil
+	}
+	return parentBoard, parent
+}
+
+// findSubtask returns the subtask with ID taskID of the task with ID
+// parentID on board parentBoardID, or nil
+func findSubtask(boards []*entity.Board, parentBoardID string, parentID *valueobject.TaskID, taskID string) *TaskTree {
+	for _, subtask := range FindSubtasks(boards, parentBoardID, parentID) {
+		if subtask.Task.ID().String() == taskID {
+			return subtask
+		}
+	}
+	return nil
+}
+
+// columnForCheckbox returns the column a subtask moves to when its checkbox
+// is set to state: the first column with that state, or nil
+func columnForCheckbox(board *entity.Board, state CheckboxState) *entity.Column {
+	for _, column := range board.Columns() {
+		if checkboxStateForColumn(column.Name()) == state {
+			return column
+		}
+	}
+	return nil
+}
+
+// subtaskState is the state of a subtask's checkbox in its parent while the
+// subtask is in column
+func subtaskState(column *entity.Column, task *entity.Task) CheckboxState {
+	if task.Status() == valueobject.StatusDone {
+		return CheckboxDone
+	}
+	return checkboxStateForColumn(column.Name())
+}
+
+// IsTaskDone reports whether a task in column counts as done: it is marked
+// done or sits in a Done column
+func IsTaskDone(column *entity.Column, task *entity.Task) bool {
+	return subtaskState(column, task) == CheckboxDone
+}
+
+// parentBoardOf returns the ID of the board the parent of a task on board is
+// on
+func parentBoardOf(board *entity.Board, task *entity.Task) string {
+	if task.ParentBoardID() != "" {
+		return task.ParentBoardID()
+	}
+	return board.ID()
+}
+
+// findBoard returns the board with the given ID, or nil
+func findBoard(boards []*entity.Board, boardID string) *entity.Board {
+	for _, board := range boards {
+		if board.ID() == boardID {
+			return board
+		}
+	}
+	return nil
+}
+
+// replaceBoard puts board in place of the board with the same ID in boards
+func replaceBoard(boards []*entity.Board, board *entity.Board) []*entity.Board {
+	for i := range boards {
+		if boards[i].ID() == board.ID() {
+			boards[i] = board
+			return boards
+		}
+	}
+	return append(boards, board)
+}
+
+// appendBoard adds board to boards unless it's already there
+func appendBoard(boards []*entity.Board, board *entity.Board) []*entity.Board {
+	if findBoard(boards, board.ID()) != nil {
+		return boards
+	}
+	return append(boards, board)
+}
+
+// taskKey identifies a task across boards
+func taskKey(boardID string, taskID *valueobject.TaskID) string {
+	return boardID + "#" + taskID.String()
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// newEpicBoard returns a board with todo, in-progress and done columns and a
+// task with each title in todo
+func newEpicBoard(t *testing.T, boardID, prefix string, titles ...string) (*entity.Board, []*entity.Task) {
+	t.Helper()
+
+	board, err := entity.NewBoard(boardID, boardID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range []string{"todo", "in-progress", "done"} {
+		column, err := entity.NewColumn(name, "", i, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := board.AddColumn(column); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	todo, _ := board.GetColumn("todo")
+	var tasks []*entity.Task
+	for i, title := range titles {
+		id, err := valueobject.NewTaskID(prefix, i+1, title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		task, err := entity.NewTask(id, title, "", valueobject.PriorityNone, valueobject.StatusTodo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := todo.AddTask(task); err != nil {
+			t.Fatal(err)
+		}
+		tasks = append(tasks, task)
+	}
+	return board, tasks
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	web, webTasks := newEpicBoard(t, "web/main", "WEB", "epic", "login", "signup")
+	api, apiTasks := newEpicBoard(t, "api/backend", "API", "auth")
+	boards := []*entity.Board{web, api}
+	epic, login, signup, auth := webTasks[0], webTasks[1], webTasks[2], apiTasks[0]
+
+	for _, subtask := range []struct {
+		board  *entity.Board
+		task   *entity.Task
+		parent *entity.Task
+	}{
+		{web, login, epic},
+		{web, signup, epic},
+		{api, auth, epic},
+		{web, signup, login}, // moves signup under login
+	} {
+		if _, err := SetParent(boards, subtask.board, subtask.task, web, subtask.parent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := web.MoveTask(login.ID(), "done"); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.MoveTask(auth.ID(), "in-progress"); err != nil {
+		t.Fatal(err)
+	}
+
+	epic.SetEstimatedTime(4 * time.Hour)
+	signup.SetEstimatedTime(time.Hour)
+	auth.SetTrackedTime(30 * time.Minute)
+	signup.SetTrackedTime(15 * time.Minute)
+
+	tree, err := BuildTaskTree(boards, "web/main", epic.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tree.Children) != 2 {
+		t.Fatalf("expected 2 direct subtasks, got %d", len(tree.Children))
+	}
+	progress := tree.Progress()
+	if progress != (EpicProgress{Total: 2, Done: 1, InProgress: 1}) {
+		t.Errorf("progress = %+v", progress)
+	}
+	if progress.Percent() != 50 {
+		t.Errorf("percent = %d, want 50", progress.Percent())
+	}
+	if got := tree.EstimatedTime(); got != 5*time.Hour {
+		t.Errorf("estimated = %s, want 5h", got)
+	}
+	if got := tree.TrackedTime(); got != 45*time.Minute {
+		t.Errorf("tracked = %s, want 45m", got)
+	}
+
+	var visited []string
+	tree.Walk(func(node *TaskTree) {
+		visited = append(visited, node.BoardID+"#"+node.Task.Title())
+	})
+	if got := strings.Join(visited, ","); got != "web/main#epic,web/main#login,web/main#signup,api/backend#auth" {
+		t.Errorf("walk order = %s", got)
+	}
+}
+
+func TestSetParentAcrossBoards(t *testing.T) {
+	web, webTasks := newEpicBoard(t, "web/main", "WEB", "epic", "other")
+	api, apiTasks := newEpicBoard(t, "api/backend", "API", "auth")
+	boards := []*entity.Board{web, api}
+	epic, other, auth := webTasks[0], webTasks[1], apiTasks[0]
+
+	changed, err := SetParent(boards, api, auth, web, epic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 {
+		t.Errorf("expected both boards to change, got %d", len(changed))
+	}
+	if auth.ParentBoardID() != "web/main" || !auth.ParentID().Equal(epic.ID()) {
+		t.Errorf("parent = %s on %q", auth.ParentID(), auth.ParentBoardID())
+	}
+	wantLink := "../../../../../../../api/boards/backend/columns/todo/tasks/" + auth.ID().String() + "/task.md"
+	if !strings.Contains(epic.Description(), wantLink) {
+		t.Errorf("epic description %q doesn't link %s", epic.Description(), wantLink)
+	}
+
+	// A parent on another board can't become a subtask of its own subtask
+	if _, err := SetParent(boards, web, epic, api, auth); err == nil {
+		t.Error("expected a cycle across boards to be refused")
+	}
+
+	// Moving the subtask takes the checkbox along
+	if _, err := SetParent(boards, api, auth, web, other); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(epic.Description(), auth.ID().String()) {
+		t.Errorf("old parent still links the subtask: %q", epic.Description())
+	}
+	if !strings.Contains(other.Description(), auth.ID().String()) {
+		t.Errorf("new parent doesn't link the subtask: %q", other.Description())
+	}
+
+	if _, err := SetParent(boards, api, auth, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if auth.ParentID() != nil || auth.ParentBoardID() != "" || strings.Contains(other.Description(), auth.ID().String()) {
+		t.Error("detaching should clear the parent and its checkbox")
+	}
+}
+
+func TestColumnForCheckbox(t *testing.T) {
+	board, _ := newEpicBoard(t, "web/main", "WEB")
+
+	tests := []struct {
+		state CheckboxState
+		want  string
+	}{
+		{CheckboxTodo, "todo"},
+		{CheckboxInProgress, "in-progress"},
+		{CheckboxDone, "done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			column := columnForCheckbox(board, tt.state)
+			if column == nil || column.Name() != tt.want {
+				t.Errorf("columnForCheckbox(%v) = %v, want %s", tt.state, column, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import "time"
+
+// ImportedBoard is a board read from another tool's export, before it is
+// created in mkanban. Source names the tool and SourceID identifies the
+// board there; both are empty for formats without boards of their own.
+type ImportedBoard struct {
+	Source      string
+	SourceID    string
+	Project     string
+	Name        string
+	Description string
+	Columns     []string
+	Tasks       []ImportedTask
+}
+
+// ImportedTask is a task of an ImportedBoard. SourceID identifies it in the
+// source, so importing the same export again finds the tasks it created.
+type ImportedTask struct {
+	SourceID    string
+	Title       string
+	Description string
+	Column      string
+	Priority    string
+	Tags        []string
+	DueDate     *time.Time
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	Checklist   []ImportedChecklistItem
+	Comments    []ImportedComment
+}
+
+// ImportedChecklistItem is an item of a task's checklist, imported as a
+// subtask
+type ImportedChecklistItem struct {
+	Title string
+	Done  bool
+}
+
+// ImportedComment is a comment on a task. ID is unique within the task.
+type ImportedComment struct {
+	ID     string
+	Author string
+	Text   string
+	Time   time.Time
+}
+
+// ImportOptions tell a BoardImporter how to read exports without a fixed
+// layout
+type ImportOptions struct {
+	// Fields maps task fields to CSV headers, e.g. "title" to "Summary".
+	// Unmapped fields are read from the header of the same name.
+	Fields map[string]string
+}
+
+// BoardImporter reads the exports of other tools
+type BoardImporter interface {
+	// Formats returns the export formats that can be read
+	Formats() []string
+
+	// Parse reads an export in the given format
+	Parse(format string, data []byte, options ImportOptions) ([]ImportedBoard, error)
+}
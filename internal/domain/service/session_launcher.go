@@ -0,0 +1,14 @@
+package service
+
+// SessionLauncher opens terminal multiplexer sessions and windows, the
+// counterpart of SessionTracker for implementations that can create them
+type SessionLauncher interface {
+	// OpenSession creates a session named name in dir unless it exists, and
+	// switches the current client to it. It returns false when there is no
+	// client to switch, e.g. outside the multiplexer.
+	OpenSession(name, dir string) (bool, error)
+
+	// OpenWindow switches to the window named name in the current session,
+	// creating it in dir unless it exists. It fails outside a session.
+	OpenWindow(name, dir string) error
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// SwimlaneOf returns the key of the lane a task of the board belongs in
+func SwimlaneOf(board *entity.Board, task *entity.Task, swimlanes *entity.Swimlanes) string {
+	parentID := ""
+	if task.ParentID() != nil {
+		parentID = task.ParentID().String()
+	}
+	return swimlanes.LaneOf(task.Tags(), task.Priority().String(), parentID, board.ProjectID())
+}
+
+// MoveTaskToSwimlane moves a task of the board into another lane by changing
+// the field the lanes group by: the tag with the lane prefix, the priority,
+// or the parent task. The empty lane removes the tag or parent. Parent
+// checkboxes follow the subtask to its new parent.
+func MoveTaskToSwimlane(board *entity.Board, task *entity.Task, swimlanes *entity.Swimlanes, lane string) error {
+	if !swimlanes.Movable() {
+		return fmt.Errorf("%w: lanes are grouped by %s", entity.ErrSwimlaneReadOnly, swimlanes.By)
+	}
+
+	switch swimlanes.By {
+	case entity.SwimlaneByTag:
+		for _, tag := range task.Tags() {
+			if strings.HasPrefix(tag, swimlanes.TagPrefix) {
+				task.RemoveTag(tag)
+			}
+		}
+		if lane != "" {
+			task.AddTag(swimlanes.TagPrefix + lane)
+		}
+		return nil
+
+	case entity.SwimlaneByPriority:
+		if lane == "" {
+			lane = valueobject.PriorityNone.String()
+		}
+		priority, err := valueobject.ParsePriority(lane)
+		if err != nil {
+			return err
+		}
+		return task.UpdatePriority(priority)
+
+	case entity.SwimlaneByParent:
+		return reparentTask(board, task, lane)
+	}
+
+	return fmt.Errorf("%w: unknown grouping %q", entity.ErrInvalidSwimlanes, swimlanes.By)
+}
+
+// reparentTask makes task a subtask of the task with ID parentID on the same
+// board, or a top level task for an empty parentID
+func reparentTask(board *entity.Board, task *entity.Task, parentID string) error {
+	var parent *entity.Task
+	if parentID != "" {
+		id, err := valueobject.ParseTaskID(parentID)
+		if err != nil {
+			return err
+		}
+		parent, _, err = board.FindTask(id)
+		if err != nil {
+			return fmt.Errorf("parent %s: %w", parentID, err)
+		}
+	}
+
+	_, err := SetParent([]*entity.Board{board}, board, task, board, parent)
+	return err
+}
+
+// checkboxStateForColumn is the state of a subtask's checkbox in its parent
+// while the subtask is in the named column
+func checkboxStateForColumn(columnName string) CheckboxState {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(columnName))
+	switch normalized {
+	case "done":
+		return CheckboxDone
+	case "inprogress":
+		return CheckboxInProgress
+	default:
+		return CheckboxTodo
+	}
+}
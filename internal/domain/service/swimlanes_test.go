@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+func TestParseSwimlanes(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string // String() of the result
+		wantNil bool
+		wantErr bool
+	}{
+		{spec: "", wantNil: true},
+		{spec: "tag:team:", want: "tag:team:"},
+		{spec: " priority ", want: "priority"},
+		{spec: "parent", want: "parent"},
+		{spec: "project", want: "project"},
+		{spec: "tag:", wantErr: true},
+		{spec: "assignee", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			swimlanes, err := entity.ParseSwimlanes(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSwimlanes(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, entity.ErrInvalidSwimlanes) {
+					t.Errorf("error %v is not ErrInvalidSwimlanes", err)
+				}
+				return
+			}
+			if (swimlanes == nil) != tt.wantNil {
+				t.Fatalf("ParseSwimlanes(%q) = %v, want nil %v", tt.spec, swimlanes, tt.wantNil)
+			}
+			if got := swimlanes.String(); !tt.wantNil && got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwimlaneKeys(t *testing.T) {
+	team := &entity.Swimlanes{By: entity.SwimlaneByTag, TagPrefix: "team:"}
+	if got := team.LaneOf([]string{"bug", "team:backend"}, "high", "", "demo"); got != "backend" {
+		t.Errorf("LaneOf() = %q, want backend", got)
+	}
+	if got := team.LaneOf([]string{"team:"}, "high", "", "demo"); got != "" {
+		t.Errorf("LaneOf() of an empty tag value = %q, want the empty lane", got)
+	}
+	if got := team.LaneTitle(""); got != "no team" {
+		t.Errorf("LaneTitle(\"\") = %q, want %q", got, "no team")
+	}
+
+	keys := []string{"", "web", "api"}
+	team.SortLanes(keys)
+	if want := []string{"api", "web", ""}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("SortLanes() = %v, want %v", keys, want)
+	}
+
+	priority := &entity.Swimlanes{By: entity.SwimlaneByPriority}
+	keys = []string{"low", "none", "critical", "medium"}
+	priority.SortLanes(keys)
+	if want := []string{"critical", "medium", "low", "none"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("SortLanes() = %v, want %v", keys, want)
+	}
+	if got := priority.LaneTitle("none"); got != "no priority" {
+		t.Errorf("LaneTitle(none) = %q, want %q", got, "no priority")
+	}
+}
+
+func TestMoveTaskToSwimlane(t *testing.T) {
+	newBoard := func(t *testing.T) (*entity.Board, []*entity.Task) {
+		board := newSimulationBoard(t, []simulatedTask{
+			{title: "epic", priority: valueobject.PriorityHigh},
+			{title: "api", priority: valueobject.PriorityLow},
+			{title: "docs", priority: valueobject.PriorityLow},
+		})
+		todo, _ := board.GetColumn("todo")
+		return board, todo.Tasks()
+	}
+
+	t.Run("tag", func(t *testing.T) {
+		board, tasks := newBoard(t)
+		lanes := &entity.Swimlanes{By: entity.SwimlaneByTag, TagPrefix: "team:"}
+		tasks[1].AddTag("team:web")
+		tasks[1].AddTag("bug")
+
+		if err := MoveTaskToSwimlane(board, tasks[1], lanes, "backend"); err != nil {
+			t.Fatal(err)
+		}
+		if got := SwimlaneOf(board, tasks[1], lanes); got != "backend" {
+			t.Errorf("lane = %q, want backend", got)
+		}
+		if want := []string{"bug", "team:backend"}; !reflect.DeepEqual(tasks[1].Tags(), want) {
+			t.Errorf("tags = %v, want %v", tasks[1].Tags(), want)
+		}
+
+		if err := MoveTaskToSwimlane(board, tasks[1], lanes, ""); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"bug"}; !reflect.DeepEqual(tasks[1].Tags(), want) {
+			t.Errorf("tags = %v, want %v", tasks[1].Tags(), want)
+		}
+	})
+
+	t.Run("priority", func(t *testing.T) {
+		board, tasks := newBoard(t)
+		lanes := &entity.Swimlanes{By: entity.SwimlaneByPriority}
+
+		if err := MoveTaskToSwimlane(board, tasks[1], lanes, "critical"); err != nil {
+			t.Fatal(err)
+		}
+		if tasks[1].Priority() != valueobject.PriorityCritical {
+			t.Errorf("priority = %s, want critical", tasks[1].Priority())
+		}
+		if err := MoveTaskToSwimlane(board, tasks[1], lanes, "urgent"); err == nil {
+			t.Error("moving into an unknown priority should fail")
+		}
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		board, tasks := newBoard(t)
+		lanes := &entity.Swimlanes{By: entity.SwimlaneByParent}
+		epic, api, docs := tasks[0], tasks[1], tasks[2]
+
+		if err := MoveTaskToSwimlane(board, api, lanes, epic.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		if got := SwimlaneOf(board, api, lanes); got != epic.ID().String() {
+			t.Errorf("lane = %q, want %s", got, epic.ID())
+		}
+		if !strings.Contains(epic.Description(), api.ID().String()) {
+			t.Errorf("epic description %q doesn't link the subtask", epic.Description())
+		}
+
+		// Moving to another parent takes the checkbox along
+		if err := MoveTaskToSwimlane(board, api, lanes, docs.ID().String()); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(epic.Description(), api.ID().String()) {
+			t.Errorf("old parent still links the subtask: %q", epic.Description())
+		}
+		if !strings.Contains(docs.Description(), api.ID().String()) {
+			t.Errorf("new parent doesn't link the subtask: %q", docs.Description())
+		}
+
+		// docs can't become a subtask of its own subtask, or of itself
+		if err := MoveTaskToSwimlane(board, docs, lanes, api.ID().String()); err == nil {
+			t.Error("expected a cycle to be refused")
+		}
+		if err := MoveTaskToSwimlane(board, docs, lanes, docs.ID().String()); err == nil {
+			t.Error("expected a task to be refused as its own parent")
+		}
+
+		if err := MoveTaskToSwimlane(board, api, lanes, ""); err != nil {
+			t.Fatal(err)
+		}
+		if api.ParentID() != nil || strings.Contains(docs.Description(), api.ID().String()) {
+			t.Error("moving into the empty lane should detach the subtask")
+		}
+	})
+
+	t.Run("project", func(t *testing.T) {
+		board, tasks := newBoard(t)
+		lanes := &entity.Swimlanes{By: entity.SwimlaneByProject}
+		err := MoveTaskToSwimlane(board, tasks[1], lanes, "other")
+		if !errors.Is(err, entity.ErrSwimlaneReadOnly) {
+			t.Errorf("error = %v, want ErrSwimlaneReadOnly", err)
+		}
+	})
+}
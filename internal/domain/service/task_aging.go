@@ -0,0 +1,102 @@
+package service
+
+import (
+	"time"
+
+	"mkanban/internal/domain/entity"
+)
+
+// TaskAge describes how long a task has been in its column
+type TaskAge struct {
+	EnteredAt   time.Time
+	WorkingDays int
+	Level       entity.AgingLevel
+}
+
+// AgingTransition is a task that aged into a higher level since the level
+// last recorded on it. Levels lists every level of the column's policy that
+// was crossed, lowest first, so a task that skipped past stale straight to
+// escalated is announced as both.
+type AgingTransition struct {
+	Board  *entity.Board
+	Column *entity.Column
+	Task   *entity.Task
+	Age    TaskAge
+	Levels []entity.AgingLevel
+}
+
+// WorkingDaysBetween counts the working days after from's day up to and
+// including to's day, so a task that entered a column on Monday has spent one
+// working day in it on Tuesday. Days are taken in the work schedule's
+// timezone; without a work schedule every day counts.
+func WorkingDaysBetween(from, to time.Time, schedule *entity.WorkSchedule) int {
+	loc := time.Local
+	if schedule != nil {
+		loc = schedule.Location()
+	}
+
+	from, to = from.In(loc), to.In(loc)
+	start := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	if start.After(end) {
+		return 0
+	}
+
+	if schedule == nil {
+		days := 0
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			days++
+		}
+		return days
+	}
+	return len(schedule.GetWorkingDaysInRange(start, end))
+}
+
+// AgeInColumn works out how long a task has been in its column at now and
+// what aging level that puts it at under the column's policy
+func AgeInColumn(task *entity.Task, column *entity.Column, now time.Time, schedule *entity.WorkSchedule) TaskAge {
+	age := TaskAge{
+		EnteredAt:   task.ColumnEnteredAt(),
+		WorkingDays: WorkingDaysBetween(task.ColumnEnteredAt(), now, schedule),
+	}
+	if column != nil {
+		age.Level = column.AgingPolicy().LevelAt(age.WorkingDays)
+	}
+	return age
+}
+
+// UpdateAging checks the tasks of a board against the aging policies of their
+// columns and records the level each task has reached. It returns the tasks
+// that aged into a higher level, and whether any task's recorded level
+// changed and the board needs saving. Levels only drop when a policy is
+// relaxed or removed; moving a task resets its level.
+func UpdateAging(board *entity.Board, now time.Time, schedule *entity.WorkSchedule) ([]AgingTransition, bool) {
+	transitions := make([]AgingTransition, 0)
+	changed := false
+
+	for _, column := range board.Columns() {
+		for _, task := range column.Tasks() {
+			age := AgeInColumn(task, column, now, schedule)
+			recorded := task.AgingLevel()
+			if age.Level == recorded {
+				continue
+			}
+
+			changed = true
+			task.SetAgingLevel(age.Level)
+			if !age.Level.Exceeds(recorded) {
+				continue
+			}
+
+			transition := AgingTransition{Board: board, Column: column, Task: task, Age: age}
+			for _, level := range column.AgingPolicy().Levels() {
+				if level.Exceeds(recorded) && !level.Exceeds(age.Level) {
+					transition.Levels = append(transition.Levels, level)
+				}
+			}
+			transitions = append(transitions, transition)
+		}
+	}
+
+	return transitions, changed
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+)
+
+func newAgingSchedule() *entity.WorkSchedule {
+	schedule := entity.NewDefaultWorkSchedule("default")
+	schedule.SetTimezone("UTC")
+	return schedule
+}
+
+func agingPolicy(t *testing.T, staleAfter, escalateAfter int) *entity.AgingPolicy {
+	t.Helper()
+
+	policy, err := entity.NewAgingPolicy(staleAfter, escalateAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	// simulationStart is Monday 2 June 2025
+	holiday := newAgingSchedule()
+	holiday.AddException(entity.ScheduleException{
+		Date: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC),
+		Type: entity.ExceptionTypeHoliday,
+	})
+
+	tests := []struct {
+		name     string
+		to       time.Time
+		schedule *entity.WorkSchedule
+		want     int
+	}{
+		{
+			name:     "same day",
+			to:       simulationStart.Add(8 * time.Hour),
+			schedule: newAgingSchedule(),
+			want:     0,
+		},
+		{
+			name:     "next morning",
+			to:       simulationStart.Add(22 * time.Hour),
+			schedule: newAgingSchedule(),
+			want:     1,
+		},
+		{
+			name:     "weekend is skipped",
+			to:       simulationStart.AddDate(0, 0, 7),
+			schedule: newAgingSchedule(),
+			want:     5,
+		},
+		{
+			name:     "holiday is skipped",
+			to:       simulationStart.AddDate(0, 0, 3),
+			schedule: holiday,
+			want:     2,
+		},
+		{
+			name: "every day counts without a schedule",
+			to:   simulationStart.AddDate(0, 0, 7),
+			want: 7,
+		},
+		{
+			name:     "earlier end",
+			to:       simulationStart.AddDate(0, 0, -2),
+			schedule: newAgingSchedule(),
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WorkingDaysBetween(simulationStart, tt.to, tt.schedule)
+			if got != tt.want {
+				t.Errorf("WorkingDaysBetween() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateAging(t *testing.T) {
+	schedule := newAgingSchedule()
+	day := func(n int) time.Time { return simulationStart.AddDate(0, 0, n) }
+
+	tests := []struct {
+		name     string
+		policy   *entity.AgingPolicy
+		recorded entity.AgingLevel
+		entered  *time.Time
+		now      time.Time
+		levels   []entity.AgingLevel // levels announced, nil for no transition
+		want     entity.AgingLevel
+		changed  bool
+	}{
+		{
+			name:   "fresh within the policy",
+			policy: agingPolicy(t, 2, 4),
+			now:    day(1),
+			want:   entity.AgingFresh,
+		},
+		{
+			name:    "becomes stale",
+			policy:  agingPolicy(t, 2, 4),
+			now:     day(2),
+			levels:  []entity.AgingLevel{entity.AgingStale},
+			want:    entity.AgingStale,
+			changed: true,
+		},
+		{
+			name:     "stale is not announced twice",
+			policy:   agingPolicy(t, 2, 4),
+			recorded: entity.AgingStale,
+			now:      day(3),
+			want:     entity.AgingStale,
+		},
+		{
+			name:     "weekend does not count",
+			policy:   agingPolicy(t, 2, 5),
+			recorded: entity.AgingStale,
+			now:      day(6),
+			want:     entity.AgingStale,
+		},
+		{
+			name:     "escalates on the fifth working day",
+			policy:   agingPolicy(t, 2, 5),
+			recorded: entity.AgingStale,
+			now:      day(7),
+			levels:   []entity.AgingLevel{entity.AgingEscalated},
+			want:     entity.AgingEscalated,
+			changed:  true,
+		},
+		{
+			name:    "jumping to escalated announces both levels",
+			policy:  agingPolicy(t, 2, 4),
+			now:     day(14),
+			levels:  []entity.AgingLevel{entity.AgingStale, entity.AgingEscalated},
+			want:    entity.AgingEscalated,
+			changed: true,
+		},
+		{
+			name:    "escalation only policy",
+			policy:  agingPolicy(t, 0, 3),
+			now:     day(3),
+			levels:  []entity.AgingLevel{entity.AgingEscalated},
+			want:    entity.AgingEscalated,
+			changed: true,
+		},
+		{
+			name:     "entering a column starts over",
+			policy:   agingPolicy(t, 2, 4),
+			recorded: entity.AgingEscalated,
+			entered:  func() *time.Time { t := day(14); return &t }(),
+			now:      day(15),
+			want:     entity.AgingFresh,
+		},
+		{
+			name:     "removed policy lowers the level silently",
+			recorded: entity.AgingStale,
+			now:      day(14),
+			want:     entity.AgingFresh,
+			changed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := newSimulationBoard(t, []simulatedTask{{title: "review"}})
+			column, _ := board.GetColumn("todo")
+			column.UpdateAgingPolicy(tt.policy)
+			task := column.Tasks()[0]
+			task.SetAgingLevel(tt.recorded)
+			if tt.entered != nil {
+				task.EnterColumn(*tt.entered)
+			}
+
+			transitions, changed := UpdateAging(board, tt.now, schedule)
+
+			if changed != tt.changed {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+			if got := task.AgingLevel(); got != tt.want {
+				t.Errorf("task aging level = %q, want %q", got, tt.want)
+			}
+			if tt.levels == nil {
+				if len(transitions) != 0 {
+					t.Fatalf("got %d transitions, want none", len(transitions))
+				}
+				return
+			}
+			if len(transitions) != 1 {
+				t.Fatalf("got %d transitions, want 1", len(transitions))
+			}
+			if !reflect.DeepEqual(transitions[0].Levels, tt.levels) {
+				t.Errorf("levels = %v, want %v", transitions[0].Levels, tt.levels)
+			}
+			if transitions[0].Task != task || transitions[0].Column != column {
+				t.Error("transition does not point at the aging task and its column")
+			}
+		})
+	}
+}
+
+func TestNewAgingPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		staleAfter    int
+		escalateAfter int
+		wantErr       bool
+	}{
+		{name: "both thresholds", staleAfter: 2, escalateAfter: 4},
+		{name: "stale only", staleAfter: 2},
+		{name: "escalate only", escalateAfter: 4},
+		{name: "no thresholds", wantErr: true},
+		{name: "negative", staleAfter: -1, escalateAfter: 4, wantErr: true},
+		{name: "escalates before stale", staleAfter: 4, escalateAfter: 2, wantErr: true},
+		{name: "escalates with stale", staleAfter: 3, escalateAfter: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewAgingPolicy(tt.staleAfter, tt.escalateAfter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAgingPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, entity.ErrInvalidAgingPolicy) {
+				t.Errorf("error %v is not ErrInvalidAgingPolicy", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// ScheduledRun is a moment at which a time-triggered action runs. Task is nil
+// for actions that don't act on a task, and Board is nil for global actions
+// that don't need one. Runs of aggregate actions carry the matched Tasks
+// instead of a Task.
+type ScheduledRun struct {
+	At     time.Time
+	Action *entity.Action
+	Board  *entity.Board
+	Column *entity.Column
+	Task   *entity.Task
+	Tasks  []*entity.Task
+}
+
+// TriggerContext returns the context the run was evaluated in
+func (r ScheduledRun) TriggerContext(lastRun *time.Time, workSchedule *entity.WorkSchedule) *entity.TriggerContext {
+	return &entity.TriggerContext{
+		CurrentTime:     r.At,
+		Task:            r.Task,
+		Column:          r.Column,
+		Board:           r.Board,
+		Tasks:           r.Tasks,
+		LastRun:         lastRun,
+		ActionCreatedAt: r.Action.CreatedAt(),
+		WorkSchedule:    workSchedule,
+	}
+}
+
+// runTarget describes what a time-triggered action is evaluated against
+type runTarget int
+
+const (
+	// runOnce evaluates the action once, without board or task
+	runOnce runTarget = iota
+	// runPerBoard evaluates the action once for every board in scope
+	runPerBoard
+	// runPerTask evaluates the action for every task in scope
+	runPerTask
+	// runPerGroup evaluates an aggregate action once per board or column
+	runPerGroup
+)
+
+// targetOf works out what an action needs to be evaluated against. Schedules
+// relative to a task, task conditions, column/task scopes and task actions
+// need a task; task creation and board-scoped actions need a board. Aggregate
+// actions run per group, whatever their schedule.
+func targetOf(action *entity.Action) runTarget {
+	if action.Aggregation() != nil {
+		return runPerGroup
+	}
+	if trigger, ok := action.Trigger().(*entity.TimeTrigger); ok {
+		switch trigger.Schedule().Type {
+		case valueobject.ScheduleTypeRelativeDueDate, valueobject.ScheduleTypeRelativeCreation:
+			return runPerTask
+		}
+	}
+	if action.Conditions() != nil {
+		return runPerTask
+	}
+	switch action.Scope() {
+	case valueobject.ActionScopeColumn, valueobject.ActionScopeTask:
+		return runPerTask
+	case valueobject.ActionScopeBoard:
+		return runPerBoard
+	}
+	switch action.ActionType().Type() {
+	case entity.ActionTypeTaskMutation, entity.ActionTypeTaskMovement:
+		return runPerTask
+	case entity.ActionTypeTaskCreation:
+		return runPerBoard
+	}
+	return runOnce
+}
+
+// DueTimeTriggerRuns evaluates a time-triggered action at now against the
+// boards and returns every run that is due. All runs of one evaluation share
+// the action's lastRun, so once the action has run its relative schedules are
+// only due again for tasks whose trigger time comes after that run. Recurring
+// schedules are evaluated in the work schedule's timezone.
+func DueTimeTriggerRuns(action *entity.Action, boards []*entity.Board, now time.Time, lastRun *time.Time, workSchedule *entity.WorkSchedule) []ScheduledRun {
+	if action.Trigger() == nil || action.Trigger().Type() != entity.TriggerTypeTime {
+		return nil
+	}
+
+	runs := make([]ScheduledRun, 0)
+	candidate := ScheduledRun{At: now, Action: action}
+
+	switch targetOf(action) {
+	case runOnce:
+		if action.Trigger().ShouldTrigger(candidate.TriggerContext(lastRun, workSchedule)) {
+			runs = append(runs, candidate)
+		}
+	case runPerBoard:
+		for _, board := range boards {
+			if !action.MatchesScope(board.ID(), "", "") {
+				continue
+			}
+			candidate.Board = board
+			if action.Trigger().ShouldTrigger(candidate.TriggerContext(lastRun, workSchedule)) {
+				runs = append(runs, candidate)
+			}
+		}
+	case runPerTask:
+		for _, board := range boards {
+			for _, column := range board.Columns() {
+				for _, task := range column.Tasks() {
+					if !action.MatchesScope(board.ID(), column.Name(), task.ID().String()) {
+						continue
+					}
+					candidate.Board, candidate.Column, candidate.Task = board, column, task
+					if !action.Trigger().ShouldTrigger(candidate.TriggerContext(lastRun, workSchedule)) {
+						continue
+					}
+					if action.Conditions() != nil && !action.Conditions().Evaluate(task, column) {
+						continue
+					}
+					runs = append(runs, candidate)
+				}
+			}
+		}
+	case runPerGroup:
+		base := candidate.TriggerContext(lastRun, workSchedule)
+		for _, board := range boards {
+			for _, column := range AggregateGroups(action, board) {
+				tasks, fires := EvaluateAggregate(action, *base, board, column)
+				if !fires {
+					continue
+				}
+				runs = append(runs, ScheduledRun{At: now, Action: action, Board: board, Column: column, Tasks: tasks})
+			}
+		}
+	}
+
+	return runs
+}
+
+// SimulateTimeTriggers replays the time triggers of the actions from `from`
+// through `to`, evaluating them every step the way the daemon's scheduler
+// does, and returns the timeline of runs in chronological order. Nothing is
+// executed; each action's last run is tracked as if its runs had happened.
+// Actions are simulated whether or not they are enabled.
+func SimulateTimeTriggers(actions []*entity.Action, boards []*entity.Board, from, to time.Time, step time.Duration, workSchedule *entity.WorkSchedule) []ScheduledRun {
+	if step <= 0 {
+		step = time.Minute
+	}
+
+	lastRuns := make(map[string]*time.Time, len(actions))
+	for _, action := range actions {
+		lastRuns[action.ID()] = action.LastRun()
+	}
+
+	timeline := make([]ScheduledRun, 0)
+	for now := from; !now.After(to); now = now.Add(step) {
+		for _, action := range actions {
+			runs := DueTimeTriggerRuns(action, boards, now, lastRuns[action.ID()], workSchedule)
+			if len(runs) == 0 {
+				continue
+			}
+			ranAt := now
+			lastRuns[action.ID()] = &ranAt
+			timeline = append(timeline, runs...)
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.Before(timeline[j].At)
+	})
+	return timeline
+}
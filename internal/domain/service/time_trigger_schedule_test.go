@@ -0,0 +1,276 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+var simulationStart = time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+type simulatedTask struct {
+	title    string
+	created  time.Duration // after simulationStart
+	due      *time.Duration
+	priority valueobject.Priority
+}
+
+func hours(n int) *time.Duration {
+	d := time.Duration(n) * time.Hour
+	return &d
+}
+
+func newSimulationBoard(t *testing.T, tasks []simulatedTask) *entity.Board {
+	t.Helper()
+
+	board, err := entity.NewBoard("demo/demo", "demo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	column, err := entity.NewColumn("todo", "", 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddColumn(column); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, st := range tasks {
+		id, err := valueobject.NewTaskID("DEM", i+1, st.title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		task, err := entity.NewTask(id, st.title, "", st.priority, valueobject.StatusTodo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var due *time.Time
+		if st.due != nil {
+			d := simulationStart.Add(*st.due)
+			due = &d
+		}
+		created := simulationStart.Add(st.created)
+		task.RestoreTimestamps(created, created, due, nil)
+		if err := column.AddTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return board
+}
+
+func newTimeAction(t *testing.T, id string, schedule *valueobject.Schedule, conditions *entity.ConditionGroup, lastRun *time.Time) *entity.Action {
+	t.Helper()
+
+	trigger, err := entity.NewTimeTrigger(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := entity.NewAction(id, id, "", valueobject.ActionScopeGlobal, "", trigger,
+		entity.NewNotificationAction("title", "message", nil), conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action.Restore(true, simulationStart, simulationStart, lastRun, 0)
+	return action
+}
+
+func recurringSchedule(t *testing.T, cronExpr string) *valueobject.Schedule {
+	t.Helper()
+
+	schedule, err := valueobject.NewRecurringSchedule(cronExpr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func TestSimulateTimeTriggers(t *testing.T) {
+	ranLate := simulationStart.Add(10 * time.Hour)
+	ranDaysAgo := simulationStart.Add(-72 * time.Hour)
+
+	tests := []struct {
+		name     string
+		tasks    []simulatedTask
+		schedule *valueobject.Schedule
+		cond     *entity.ConditionGroup
+		lastRun  *time.Time
+		window   time.Duration
+		expected []string // "<offset from start> <task title>"
+	}{
+		{
+			name: "relative to creation fires once per task",
+			tasks: []simulatedTask{
+				{title: "first", created: 0},
+				{title: "second", created: 2 * time.Hour},
+			},
+			schedule: valueobject.NewRelativeCreationSchedule(time.Hour),
+			window:   24 * time.Hour,
+			expected: []string{"1h0m0s first", "3h0m0s second"},
+		},
+		{
+			name: "relative to due date skips tasks without due date",
+			tasks: []simulatedTask{
+				{title: "due", due: hours(48)},
+				{title: "undated"},
+			},
+			schedule: valueobject.NewRelativeDueDateSchedule(24 * time.Hour),
+			window:   72 * time.Hour,
+			expected: []string{"24h0m0s due"},
+		},
+		{
+			name: "past trigger times fire at the start of the window",
+			tasks: []simulatedTask{
+				{title: "overdue", due: hours(-48)},
+			},
+			schedule: valueobject.NewRelativeDueDateSchedule(time.Hour),
+			window:   24 * time.Hour,
+			expected: []string{"0s overdue"},
+		},
+		{
+			name: "last run after trigger time suppresses the run",
+			tasks: []simulatedTask{
+				{title: "early", created: 0},
+				{title: "late", created: 12 * time.Hour},
+			},
+			schedule: valueobject.NewRelativeCreationSchedule(time.Hour),
+			lastRun:  &ranLate,
+			window:   24 * time.Hour,
+			expected: []string{"13h0m0s late"},
+		},
+		{
+			name: "conditions filter tasks",
+			tasks: []simulatedTask{
+				{title: "urgent", priority: valueobject.PriorityHigh},
+				{title: "later", priority: valueobject.PriorityLow},
+			},
+			schedule: valueobject.NewRelativeCreationSchedule(time.Hour),
+			cond: entity.NewConditionGroup(entity.LogicalAnd,
+				entity.NewCondition("priority", entity.OperatorEquals, valueobject.PriorityHigh.String())),
+			window:   24 * time.Hour,
+			expected: []string{"1h0m0s urgent"},
+		},
+		{
+			name: "absolute schedule without task fires once",
+			tasks: []simulatedTask{
+				{title: "first"},
+				{title: "second"},
+			},
+			schedule: valueobject.NewAbsoluteSchedule(simulationStart.Add(5 * time.Hour)),
+			window:   24 * time.Hour,
+			expected: []string{"5h0m0s -"},
+		},
+		{
+			name:     "recurring schedule fires on every match after creation",
+			schedule: recurringSchedule(t, "0 9 * * *"),
+			window:   72 * time.Hour,
+			expected: []string{"24h0m0s -", "48h0m0s -", "72h0m0s -"},
+		},
+		{
+			name:     "missed recurring firings catch up once",
+			schedule: recurringSchedule(t, "0 9 * * *"),
+			lastRun:  &ranDaysAgo,
+			window:   48 * time.Hour,
+			expected: []string{"0s -", "24h0m0s -", "48h0m0s -"},
+		},
+		{
+			name: "recurring schedule with conditions runs per task",
+			tasks: []simulatedTask{
+				{title: "overdue", due: hours(-1)},
+				{title: "undated"},
+			},
+			schedule: recurringSchedule(t, "@weekly"),
+			cond: entity.NewConditionGroup(entity.LogicalAnd,
+				entity.NewCondition("is_overdue", entity.OperatorEquals, true)),
+			window:   7 * 24 * time.Hour,
+			expected: []string{"135h0m0s overdue"},
+		},
+		{
+			name: "nothing due outside the window",
+			tasks: []simulatedTask{
+				{title: "future", created: 48 * time.Hour},
+			},
+			schedule: valueobject.NewRelativeCreationSchedule(time.Hour),
+			window:   24 * time.Hour,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := newSimulationBoard(t, tt.tasks)
+			action := newTimeAction(t, "rule", tt.schedule, tt.cond, tt.lastRun)
+
+			runs := SimulateTimeTriggers([]*entity.Action{action}, []*entity.Board{board},
+				simulationStart, simulationStart.Add(tt.window), time.Hour, nil)
+
+			if len(runs) != len(tt.expected) {
+				t.Fatalf("expected %d runs, got %d", len(tt.expected), len(runs))
+			}
+			for i, run := range runs {
+				title := "-"
+				if run.Task != nil {
+					title = run.Task.Title()
+				}
+				got := run.At.Sub(simulationStart).String() + " " + title
+				if got != tt.expected[i] {
+					t.Errorf("run %d: expected %q, got %q", i, tt.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateTimeTriggersDoesNotMutateActions(t *testing.T) {
+	board := newSimulationBoard(t, []simulatedTask{{title: "task"}})
+	action := newTimeAction(t, "rule", valueobject.NewRelativeCreationSchedule(time.Hour), nil, nil)
+	action.Disable()
+
+	runs := SimulateTimeTriggers([]*entity.Action{action}, []*entity.Board{board},
+		simulationStart, simulationStart.Add(24*time.Hour), time.Hour, nil)
+
+	if len(runs) != 1 {
+		t.Fatalf("expected disabled action to be simulated once, got %d runs", len(runs))
+	}
+	if action.LastRun() != nil {
+		t.Errorf("expected last run to stay unset, got %v", action.LastRun())
+	}
+}
+
+func TestSimulateRecurringTriggersInWorkScheduleTimezone(t *testing.T) {
+	workSchedule := entity.NewDefaultWorkSchedule("default")
+	workSchedule.SetTimezone("America/New_York")
+	if workSchedule.Location().String() != "America/New_York" {
+		t.Skip("timezone data not available")
+	}
+
+	board := newSimulationBoard(t, nil)
+	action := newTimeAction(t, "rule", recurringSchedule(t, "0 9 * * *"), nil, nil)
+
+	runs := SimulateTimeTriggers([]*entity.Action{action}, []*entity.Board{board},
+		simulationStart, simulationStart.Add(24*time.Hour), time.Hour, workSchedule)
+
+	// 09:00 in New York is 13:00 UTC during daylight saving time
+	if len(runs) != 1 || !runs[0].At.Equal(simulationStart.Add(4*time.Hour)) {
+		t.Fatalf("expected one run at 13:00 UTC, got %v", runs)
+	}
+}
+
+func TestDueTimeTriggerRunsIgnoresEventTriggers(t *testing.T) {
+	board := newSimulationBoard(t, []simulatedTask{{title: "task"}})
+	trigger, err := entity.NewEventTrigger(valueobject.EventTaskCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := entity.NewAction("event", "event", "", valueobject.ActionScopeGlobal, "", trigger,
+		entity.NewNotificationAction("title", "message", nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runs := DueTimeTriggerRuns(action, []*entity.Board{board}, simulationStart, nil, nil); len(runs) != 0 {
+		t.Errorf("expected no runs for an event trigger, got %d", len(runs))
+	}
+}
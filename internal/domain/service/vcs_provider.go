@@ -1,5 +1,16 @@
 package service
 
+import "time"
+
+// Commit is a commit read from a repository
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
+	Author  string
+	Time    time.Time
+}
+
 // VCSProvider defines the interface for interacting with version control systems
 // This abstraction allows for different VCS implementations (git, svn, hg, etc.)
 type VCSProvider interface {
@@ -10,6 +21,10 @@ type VCSProvider interface {
 	// Returns empty string if path is not in a repository
 	GetRepositoryRoot(path string) (string, error)
 
+	// GetMainRepositoryRoot returns the root directory of the main working
+	// tree, which differs from GetRepositoryRoot inside linked worktrees
+	GetMainRepositoryRoot(path string) (string, error)
+
 	// GetCurrentBranch returns the name of the currently checked out branch
 	GetCurrentBranch(repoPath string) (string, error)
 
@@ -28,4 +43,39 @@ type VCSProvider interface {
 
 	// CreateAndCheckoutBranch creates a new branch and checks it out
 	CreateAndCheckoutBranch(repoPath, branchName string) error
+
+	// ListBranchHeads returns the commit each local branch points to, by
+	// branch name. It works in bare repositories too.
+	ListBranchHeads(repoPath string) (map[string]string, error)
+
+	// ListWorktrees returns the directory of every working tree of the
+	// repository, the main one included, by the branch checked out there.
+	// Working trees with a detached HEAD are left out.
+	ListWorktrees(repoPath string) (map[string]string, error)
+
+	// AddWorktree checks out a branch in a new working tree at path,
+	// creating the branch first if createBranch is set
+	AddWorktree(repoPath, path, branchName string, createBranch bool) error
+
+	// ListCommits returns up to limit commits reachable from any of the
+	// included revisions but none of the excluded ones, newest first
+	ListCommits(repoPath string, included, excluded []string, limit int) ([]Commit, error)
+
+	// GetDefaultBranch returns the branch work is merged into, as set for
+	// the origin remote, else main or master
+	GetDefaultBranch(repoPath string) (string, error)
+
+	// FindMergeCommit returns the commit of target that merged branch into
+	// it, the branch head itself for a fast-forward, or "" if target doesn't
+	// contain the branch or the branch has no commits of its own
+	FindMergeCommit(repoPath, branch, target string) (string, error)
+
+	// FindSquashMerge returns the commit of target that brought in the
+	// changes of branch as a squash or a rebase, matching commits by patch
+	// ID, or "" if there is none
+	FindSquashMerge(repoPath, branch, target string) (string, error)
+
+	// DeleteBranch deletes a local branch. Unless force is set it refuses
+	// branches that aren't merged.
+	DeleteBranch(repoPath, branchName string, force bool) error
 }
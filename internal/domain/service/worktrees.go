@@ -0,0 +1,36 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// WorktreePath returns the directory of the worktree for a branch of the
+// repository at repoRoot. dirFormat is the directory holding the worktrees,
+// where "~" stands for home and {repo} for the repository name; without it
+// they go in <repo>.worktrees next to the repository.
+func WorktreePath(dirFormat, repoRoot, branch, home string) string {
+	repoName := filepath.Base(repoRoot)
+
+	dir := filepath.Join(filepath.Dir(repoRoot), repoName+".worktrees")
+	if dirFormat != "" {
+		dir = strings.ReplaceAll(dirFormat, "{repo}", repoName)
+		if dir == "~" || strings.HasPrefix(dir, "~/") {
+			dir = filepath.Join(home, dir[1:])
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(repoRoot, dir)
+		}
+	}
+
+	// Branches such as feature/PRO-1 make one directory, not two
+	return filepath.Join(dir, strings.ReplaceAll(branch, "/", "-"))
+}
+
+// WorktreeSessionName returns the name of the terminal session for a task
+// worktree of the repository at repoRoot. Dots and colons, which tmux
+// doesn't allow in session names, become dashes.
+func WorktreeSessionName(repoRoot, shortID string) string {
+	name := filepath.Base(repoRoot) + "-" + shortID
+	return strings.NewReplacer(".", "-", ":", "-").Replace(name)
+}
@@ -0,0 +1,49 @@
+package service
+
+import "testing"
+
+func TestWorktreePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		dirFormat string
+		branch    string
+		want      string
+	}{
+		{
+			name:   "default next to the repository",
+			branch: "PRO-7-cart",
+			want:   "/src/shop.worktrees/PRO-7-cart",
+		},
+		{
+			name:      "home and repo name",
+			dirFormat: "~/worktrees/{repo}",
+			branch:    "PRO-7-cart",
+			want:      "/home/ann/worktrees/shop/PRO-7-cart",
+		},
+		{
+			name:      "relative to the repository",
+			dirFormat: ".worktrees",
+			branch:    "PRO-7-cart",
+			want:      "/src/shop/.worktrees/PRO-7-cart",
+		},
+		{
+			name:   "branch with slashes",
+			branch: "feature/PRO-7-cart",
+			want:   "/src/shop.worktrees/feature-PRO-7-cart",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorktreePath(tt.dirFormat, "/src/shop", tt.branch, "/home/ann"); got != tt.want {
+				t.Errorf("WorktreePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorktreeSessionName(t *testing.T) {
+	if got := WorktreeSessionName("/src/shop.io", "PRO-7"); got != "shop-io-PRO-7" {
+		t.Errorf("WorktreeSessionName() = %q, want %q", got, "shop-io-PRO-7")
+	}
+}
@@ -0,0 +1,170 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronExpressionErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@sometimes",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseCronExpression(expr); err == nil {
+				t.Errorf("expected %q to be rejected", expr)
+			}
+		})
+	}
+}
+
+func TestCronExpressionNext(t *testing.T) {
+	// Monday
+	start := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every minute is strictly after",
+			expr:     "* * * * *",
+			after:    start,
+			expected: start.Add(time.Minute),
+		},
+		{
+			name:     "seconds are truncated",
+			expr:     "* * * * *",
+			after:    start.Add(30 * time.Second),
+			expected: start.Add(time.Minute),
+		},
+		{
+			name:     "fixed time later today",
+			expr:     "0 9 * * *",
+			after:    start,
+			expected: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "fixed time passed rolls to tomorrow",
+			expr:     "0 8 * * *",
+			after:    start,
+			expected: time.Date(2025, 6, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "step",
+			expr:     "*/20 * * * *",
+			after:    start,
+			expected: time.Date(2025, 6, 2, 8, 40, 0, 0, time.UTC),
+		},
+		{
+			name:     "range with step",
+			expr:     "0 9-17/4 * * *",
+			after:    time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday names",
+			expr:     "0 9 * * FRI",
+			after:    start,
+			expected: time.Date(2025, 6, 6, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday list",
+			expr:     "0 9 * * 1,3",
+			after:    time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 0 * * 7",
+			after:    start,
+			expected: time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 0 5 * SAT",
+			after:    start,
+			expected: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "month names roll over the year",
+			expr:     "0 0 1 JAN *",
+			after:    start,
+			expected: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily shortcut",
+			expr:     "@daily",
+			after:    start,
+			expected: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly shortcut",
+			expr:     "@weekly",
+			after:    start,
+			expected: time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "impossible date",
+			expr:     "0 0 30 2 *",
+			after:    start,
+			expected: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronExpression(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cron.Next(tt.after); !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCronExpressionNextInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	cron, err := ParseCronExpression("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 08:30 UTC is already 10:30 in Berlin, so the next 09:00 is tomorrow
+	after := time.Date(2025, 6, 2, 8, 30, 0, 0, time.UTC).In(berlin)
+	expected := time.Date(2025, 6, 3, 7, 0, 0, 0, time.UTC)
+	if got := cron.Next(after); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// 02:30 doesn't exist on the day daylight saving time starts
+	cron, err = ParseCronExpression("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after = time.Date(2025, 3, 29, 12, 0, 0, 0, berlin)
+	expected = time.Date(2025, 3, 31, 2, 30, 0, 0, berlin)
+	if got := cron.Next(after); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
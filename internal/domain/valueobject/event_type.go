@@ -22,10 +22,21 @@ const (
 	EventTaskOverdue        EventType = "task.overdue"
 	EventTaskCompletedOnTime EventType = "task.completed_on_time"
 
+	// Aging events, when a task has been in its column longer than the
+	// column's aging policy allows
+	EventTaskStale     EventType = "task.stale"
+	EventTaskEscalated EventType = "task.escalated"
+
 	// Column events
 	EventColumnCreated      EventType = "column.created"
 	EventColumnDeleted      EventType = "column.deleted"
 	EventColumnWIPReached   EventType = "column.wip_reached"
+
+	// Action lifecycle events. These describe the action system itself and
+	// are deliberately not valid triggers so actions can't re-trigger themselves.
+	EventActionExecuted EventType = "action.executed"
+	EventActionFailed   EventType = "action.failed"
+	EventActionDisabled EventType = "action.disabled"
 )
 
 // IsValid checks if the event type is valid
@@ -34,7 +45,7 @@ func (e EventType) IsValid() bool {
 	case EventTaskCreated, EventTaskUpdated, EventTaskDeleted, EventTaskMoved,
 		EventTaskStatusChanged, EventTaskPriorityChanged, EventTaskDueDateSet,
 		EventTaskDueDateChanged, EventTaskCompleted, EventTaskDueApproaching,
-		EventTaskOverdue, EventTaskCompletedOnTime, EventColumnCreated,
+		EventTaskOverdue, EventTaskCompletedOnTime, EventTaskStale, EventTaskEscalated, EventColumnCreated,
 		EventColumnDeleted, EventColumnWIPReached:
 		return true
 	default:
@@ -45,6 +45,14 @@ func ParsePriority(s string) (Priority, error) {
 	return PriorityNone, fmt.Errorf("invalid priority: %s", s)
 }
 
+// Raised returns the next higher priority; critical stays critical
+func (p Priority) Raised() Priority {
+	if p >= PriorityCritical {
+		return PriorityCritical
+	}
+	return p + 1
+}
+
 // IsValid checks if the priority is valid
 func (p Priority) IsValid() bool {
 	_, ok := priorityNames[p]
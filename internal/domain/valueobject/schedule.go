@@ -56,10 +56,12 @@ func NewRelativeCreationSchedule(offset time.Duration) *Schedule {
 
 // NewRecurringSchedule creates a recurring schedule with cron expression
 func NewRecurringSchedule(cronExpr string) (*Schedule, error) {
-	// TODO: Validate cron expression
 	if cronExpr == "" {
 		return nil, fmt.Errorf("cron expression cannot be empty")
 	}
+	if _, err := ParseCronExpression(cronExpr); err != nil {
+		return nil, err
+	}
 	return &Schedule{
 		Type:     ScheduleTypeRecurring,
 		CronExpr: cronExpr,
@@ -74,8 +76,17 @@ func (s *Schedule) IsValid() bool {
 	case ScheduleTypeRelativeDueDate, ScheduleTypeRelativeCreation:
 		return s.Offset != nil
 	case ScheduleTypeRecurring:
-		return s.CronExpr != ""
+		_, err := ParseCronExpression(s.CronExpr)
+		return err == nil
 	default:
 		return false
 	}
 }
+
+// Cron parses the cron expression of a recurring schedule
+func (s *Schedule) Cron() (*CronExpression, error) {
+	if s.Type != ScheduleTypeRecurring {
+		return nil, fmt.Errorf("schedule is not recurring")
+	}
+	return ParseCronExpression(s.CronExpr)
+}
@@ -15,6 +15,12 @@ const (
 	defaultDataDirName    = ".local/share/mkanban"
 )
 
+// Storage backends selectable with storage.backend
+const (
+	StorageBackendFilesystem = "filesystem"
+	StorageBackendSQLite     = "sqlite"
+)
+
 // Config holds application configuration
 type Config struct {
 	Storage         StorageConfig         `yaml:"storage"`
@@ -25,18 +31,33 @@ type Config struct {
 	Actions         ActionsConfig         `yaml:"actions"`
 	TimeTracking    TimeTrackingConfig    `yaml:"time_tracking"`
 	Calendar        CalendarConfig        `yaml:"calendar"`
+	Sync            SyncConfig            `yaml:"sync"`
+	Backup          BackupConfig          `yaml:"backup"`
 }
 
 // StorageConfig holds storage-related configuration
 type StorageConfig struct {
-	BoardsPath string `yaml:"boards_path"`
-	DataPath   string `yaml:"data_path"`
+	BoardsPath   string `yaml:"boards_path"`
+	DataPath     string `yaml:"data_path"`
+	Backend      string `yaml:"backend"`       // "filesystem" or "sqlite"
+	DatabasePath string `yaml:"database_path"` // SQLite database, defaults to mkanban.db in the data path
+	AutoMigrate  bool   `yaml:"auto_migrate"`  // daemon backs up and migrates an outdated data directory on start
 }
 
 // DaemonConfig holds daemon-related configuration
 type DaemonConfig struct {
-	SocketDir  string `yaml:"socket_dir"`
-	SocketName string `yaml:"socket_name"`
+	SocketDir  string    `yaml:"socket_dir"`
+	SocketName string    `yaml:"socket_name"`
+	Web        WebConfig `yaml:"web"`
+}
+
+// WebConfig holds configuration for the browser UI served by the daemon
+type WebConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	ListenAddr string `yaml:"listen_addr"` // e.g. "127.0.0.1:8420"
+	// Host names the UI may be reached by besides localhost and the listen
+	// address, e.g. when serving on the LAN
+	AllowedHosts []string `yaml:"allowed_hosts"`
 }
 
 // TUIConfig holds TUI styling configuration
@@ -60,7 +81,10 @@ type StylesConfig struct {
 	Overdue           TextStyle         `yaml:"overdue"`
 	Priority          PriorityColors    `yaml:"priority"`
 	DueDateUrgency    DueDateColors     `yaml:"due_date_urgency"`
+	Aging             AgingColors       `yaml:"aging"`
 	ScrollIndicator   TextStyle         `yaml:"scroll_indicator"`
+	SwimlaneTitle     TextStyle         `yaml:"swimlane_title"`
+	Progress          TextStyle         `yaml:"progress"`
 }
 
 // ColumnStyle represents column styling
@@ -103,43 +127,92 @@ type DueDateColors struct {
 	FarFuture string `yaml:"far_future"`
 }
 
+// AgingColors holds colors for the aging levels of tasks in columns with an
+// aging policy
+type AgingColors struct {
+	Stale     string `yaml:"stale"`
+	Escalated string `yaml:"escalated"`
+}
+
 // KeybindingsConfig holds keybinding configuration
 type KeybindingsConfig struct {
-	Up     []string `yaml:"up"`
-	Down   []string `yaml:"down"`
-	Left   []string `yaml:"left"`
-	Right  []string `yaml:"right"`
-	Move   []string `yaml:"move"`
-	Add    []string `yaml:"add"`
-	Delete []string `yaml:"delete"`
-	Quit   []string `yaml:"quit"`
+	Up         []string `yaml:"up"`
+	Down       []string `yaml:"down"`
+	Left       []string `yaml:"left"`
+	Right      []string `yaml:"right"`
+	Move       []string `yaml:"move"`
+	Add        []string `yaml:"add"`
+	Delete     []string `yaml:"delete"`
+	Quit       []string `yaml:"quit"`
+	LaneUp     []string `yaml:"lane_up,omitempty"`     // move the task to the lane above
+	LaneDown   []string `yaml:"lane_down,omitempty"`   // move the task to the lane below
+	ToggleLane []string `yaml:"toggle_lane,omitempty"` // collapse or expand the lane
 }
 
 // SessionTrackingConfig holds session tracking configuration
 type SessionTrackingConfig struct {
 	Enabled          bool   `yaml:"enabled"`
 	PollInterval     int    `yaml:"poll_interval"` // in seconds
-	TrackerType      string `yaml:"tracker_type"`  // "tmux", "zellij", etc.
+	TrackerType      string `yaml:"tracker_type"`  // "tmux", "zellij", "screen", "shell" or "auto"
 	GeneralBoardName string `yaml:"general_board_name"`
 	GitSync          GitSyncConfig `yaml:"git_sync"`
+	// BoardLayouts plan the boards of repositories without a .mkanban.yml
+	BoardLayouts []BoardLayoutConfig `yaml:"board_layouts,omitempty"`
+}
+
+// BoardLayoutConfig maps the directories of a repository to boards. It's
+// also the format of a repository's .mkanban.yml, where Repo is unused.
+type BoardLayoutConfig struct {
+	Repo         string            `yaml:"repo,omitempty"` // glob of the repository's path or name
+	DefaultBoard string            `yaml:"default_board,omitempty"`
+	Boards       []BoardRuleConfig `yaml:"boards"`
+	// Workspaces adds a board for every workspace member the repository's
+	// go.work, Cargo.toml, pnpm-workspace.yaml, package.json or Gradle
+	// settings declare. Repositories without a layout always get them.
+	Workspaces bool `yaml:"workspaces,omitempty"`
+}
+
+// BoardRuleConfig maps directories to a board
+type BoardRuleConfig struct {
+	Name  string   `yaml:"name"`
+	Paths []string `yaml:"paths"` // globs relative to the repository root
 }
 
 // GitSyncConfig holds git synchronization configuration
 type GitSyncConfig struct {
-	Enabled            bool `yaml:"enabled"`
-	AutoSyncBranches   bool `yaml:"auto_sync_branches"`
-	WatchForChanges    bool `yaml:"watch_for_changes"`
+	Enabled               bool `yaml:"enabled"`
+	AutoSyncBranches      bool `yaml:"auto_sync_branches"`
+	WatchForChanges       bool `yaml:"watch_for_changes"`
 	CreateTasksForRemotes bool `yaml:"create_tasks_for_remotes"`
+	// ScanCommits attaches commits that mention a task, or sit on its
+	// branch, to the task's activity
+	ScanCommits   bool     `yaml:"scan_commits"`
+	CloseKeywords []string `yaml:"close_keywords"` // e.g. "fixes", default close/fix/resolve and their forms
+	// WorktreeDir is where "task checkout --worktree" puts task worktrees,
+	// {repo} being the repository name; default <repo>.worktrees next to it
+	WorktreeDir  string `yaml:"worktree_dir"`
+	WorktreeTmux string `yaml:"worktree_tmux"` // "session", "window" or "" to open nothing
+	// DetectMerges completes the tasks of branches merged into the default
+	// branch, which is origin's HEAD, main or master unless DefaultBranch
+	DetectMerges         bool   `yaml:"detect_merges"`
+	DetectSquashMerges   bool   `yaml:"detect_squash_merges"`
+	DeleteMergedBranches bool   `yaml:"delete_merged_branches"`
+	DefaultBranch        string `yaml:"default_branch"`
 }
 
 // ActionsConfig holds actions/reminders configuration
 type ActionsConfig struct {
-	Enabled          bool                 `yaml:"enabled"`
-	CheckInterval    int                  `yaml:"check_interval"` // in seconds, for time-based actions
+	Enabled              bool             `yaml:"enabled"`
+	CheckInterval        int              `yaml:"check_interval"` // in seconds, for time-based actions
 	NotificationsEnabled bool             `yaml:"notifications_enabled"`
-	ScriptsEnabled   bool                 `yaml:"scripts_enabled"`
-	ScriptsDir       string               `yaml:"scripts_dir"`
-	Templates        []ActionTemplate     `yaml:"templates"`
+	ScriptsEnabled       bool             `yaml:"scripts_enabled"`
+	ScriptsDir           string           `yaml:"scripts_dir"`
+	Templates            []ActionTemplate `yaml:"templates"`
+	MaxRetries           int              `yaml:"max_retries"`            // retries after a failed execution
+	RetryBackoff         int              `yaml:"retry_backoff"`          // in seconds, doubled on each retry
+	DisableAfterFailures int              `yaml:"disable_after_failures"` // 0 never disables
+	HistoryLimit         int              `yaml:"history_limit"`          // executions kept per action
+	Timezone             string           `yaml:"timezone"`               // IANA zone for recurring schedules, empty for local time
 }
 
 // ActionTemplate represents a reusable action template
@@ -150,6 +223,23 @@ type ActionTemplate struct {
 	Trigger     TriggerConfig          `yaml:"trigger"`
 	ActionType  ActionTypeConfig       `yaml:"action_type"`
 	Conditions  []ConditionConfig      `yaml:"conditions,omitempty"`
+	Aggregate   *AggregateConfig       `yaml:"aggregate,omitempty"`
+}
+
+// AggregateConfig makes an action run once per board or column over the
+// tasks its conditions match, instead of once per task
+type AggregateConfig struct {
+	GroupBy    string                     `yaml:"group_by"` // "board" or "column"
+	Conditions []AggregateConditionConfig `yaml:"conditions,omitempty"`
+}
+
+// AggregateConditionConfig represents a condition over the matched tasks,
+// e.g. {function: count, operator: gt, value: wip_limit}
+type AggregateConditionConfig struct {
+	Function string      `yaml:"function"` // count, sum, avg, min, max
+	Field    string      `yaml:"field,omitempty"`
+	Operator string      `yaml:"operator"`
+	Value    interface{} `yaml:"value"`
 }
 
 // TriggerConfig represents trigger configuration
@@ -169,15 +259,24 @@ type ScheduleConfig struct {
 
 // ActionTypeConfig represents action type configuration
 type ActionTypeConfig struct {
-	Type           string            `yaml:"type"` // "notification", "script", "task_mutation", "task_movement", "task_creation"
+	Type           string            `yaml:"type"` // "notification", "script", "command", "webhook", "task_mutation", "task_movement", "task_creation"
 	// For notifications
 	Title          string            `yaml:"title,omitempty"`
 	Message        string            `yaml:"message,omitempty"`
-	// For scripts
+	// For scripts and commands (script_env is passed to both)
 	ScriptPath     string            `yaml:"script_path,omitempty"`
 	ScriptEnv      map[string]string `yaml:"script_env,omitempty"`
+	Command        string            `yaml:"command,omitempty"`
+	Args           []string          `yaml:"args,omitempty"`
+	// For webhooks (timeout also applies to commands)
+	URL            string            `yaml:"url,omitempty"`
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	Secret         string            `yaml:"secret,omitempty"`
+	Retries        int               `yaml:"retries,omitempty"`
+	Timeout        string            `yaml:"timeout,omitempty"` // e.g. "10s"
 	// For task mutations
 	UpdatePriority string            `yaml:"update_priority,omitempty"`
+	BumpPriority   bool              `yaml:"bump_priority,omitempty"`
 	UpdateStatus   string            `yaml:"update_status,omitempty"`
 	AddTags        []string          `yaml:"add_tags,omitempty"`
 	RemoveTags     []string          `yaml:"remove_tags,omitempty"`
@@ -243,6 +342,25 @@ type CalendarConfig struct {
 	CallbackPort    int               `yaml:"callback_port"`
 }
 
+// SyncConfig holds settings for syncing the data directory through git
+type SyncConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Remote      string `yaml:"remote"`       // git URL of the shared copy
+	Branch      string `yaml:"branch"`
+	Interval    int    `yaml:"interval"`     // seconds between pulls and pushes
+	CommitDelay int    `yaml:"commit_delay"` // seconds after a change before committing
+}
+
+// BackupConfig holds settings for the daemon's snapshots of the data
+// directory
+type BackupConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	Dir        string `yaml:"dir"`         // defaults to snapshots/ in the data path
+	Interval   int    `yaml:"interval"`    // seconds between snapshots
+	KeepHourly int    `yaml:"keep_hourly"` // hours the newest snapshot of each hour is kept
+	KeepDaily  int    `yaml:"keep_daily"`  // days the newest snapshot of each day is kept
+}
+
 // Loader handles loading and saving configuration
 type Loader struct {
 	configPath string
@@ -320,12 +438,18 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 
 	config := &Config{
 		Storage: StorageConfig{
-			BoardsPath: boardsPath,
-			DataPath:   dataDir,
+			BoardsPath:  boardsPath,
+			DataPath:    dataDir,
+			Backend:     StorageBackendFilesystem,
+			AutoMigrate: true,
 		},
 		Daemon: DaemonConfig{
 			SocketDir:  socketDir,
 			SocketName: "mkanbad.sock",
+			Web: WebConfig{
+				Enabled:    false,
+				ListenAddr: "127.0.0.1:8420",
+			},
 		},
 		TUI: TUIConfig{
 			Styles: StylesConfig{
@@ -399,32 +523,48 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 					Upcoming:  "#A8DADC",
 					FarFuture: "#999999",
 				},
+				Aging: AgingColors{
+					Stale:     "#FFE66D",
+					Escalated: "#FF6B6B",
+				},
 				ScrollIndicator: TextStyle{
 					Foreground: "#999999",
 					Bold:       true,
 				},
+				SwimlaneTitle: TextStyle{
+					Foreground: "#A8DADC",
+					Bold:       true,
+				},
+				Progress: TextStyle{
+					Foreground: "#06D6A0",
+				},
 			},
 		},
 		Keybindings: KeybindingsConfig{
-			Up:     []string{"up", "k"},
-			Down:   []string{"down", "j"},
-			Left:   []string{"left", "h"},
-			Right:  []string{"right", "l"},
-			Move:   []string{"m", "enter"},
-			Add:    []string{"a"},
-			Delete: []string{"d"},
-			Quit:   []string{"q", "ctrl+c"},
+			Up:         []string{"up", "k"},
+			Down:       []string{"down", "j"},
+			Left:       []string{"left", "h"},
+			Right:      []string{"right", "l"},
+			Move:       []string{"m", "enter"},
+			Add:        []string{"a"},
+			Delete:     []string{"d"},
+			Quit:       []string{"q", "ctrl+c"},
+			LaneUp:     []string{"K"},
+			LaneDown:   []string{"J"},
+			ToggleLane: []string{"z"},
 		},
 		SessionTracking: SessionTrackingConfig{
 			Enabled:          true,
 			PollInterval:     5,
-			TrackerType:      "tmux",
+			TrackerType:      "auto",
 			GeneralBoardName: "General Tasks",
 			GitSync: GitSyncConfig{
 				Enabled:               true,
 				AutoSyncBranches:      true,
 				WatchForChanges:       true,
 				CreateTasksForRemotes: false,
+				ScanCommits:           true,
+				DetectMerges:          true,
 			},
 		},
 		Actions: ActionsConfig{
@@ -433,6 +573,10 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 			NotificationsEnabled: true,
 			ScriptsEnabled:       true,
 			ScriptsDir:           filepath.Join(homeDir, ".config", "mkanban", "scripts"),
+			MaxRetries:           2,
+			RetryBackoff:         5,
+			DisableAfterFailures: 5,
+			HistoryLimit:         100,
 			Templates: []ActionTemplate{
 				{
 					ID:          "due-tomorrow-reminder",
@@ -451,6 +595,53 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 						Message: "A task is due tomorrow!",
 					},
 				},
+				{
+					ID:          "overdue-digest",
+					Name:        "Overdue Digest",
+					Description: "Every morning, list the overdue tasks of each board",
+					Trigger: TriggerConfig{
+						Type: "time",
+						Schedule: &ScheduleConfig{
+							Type:     "recurring",
+							CronExpr: "0 9 * * *",
+						},
+					},
+					ActionType: ActionTypeConfig{
+						Type:    "notification",
+						Title:   "{{.Count}} overdue on {{.Board.Name}}",
+						Message: "{{range .Tasks}}{{.ID}} {{.Title}}\n{{end}}",
+					},
+					Conditions: []ConditionConfig{
+						{Field: "is_overdue", Operator: "eq", Value: true},
+					},
+					Aggregate: &AggregateConfig{GroupBy: "board"},
+				},
+				{
+					ID:          "tag-stale",
+					Name:        "Tag Stale Tasks",
+					Description: "Tag tasks that outstay their column's stale threshold",
+					Trigger: TriggerConfig{
+						Type:  "event",
+						Event: "task.stale",
+					},
+					ActionType: ActionTypeConfig{
+						Type:    "task_mutation",
+						AddTags: []string{"stale"},
+					},
+				},
+				{
+					ID:          "escalate-aging",
+					Name:        "Escalate Aging Tasks",
+					Description: "Raise the priority of tasks that outstay their column's escalation threshold",
+					Trigger: TriggerConfig{
+						Type:  "event",
+						Event: "task.escalated",
+					},
+					ActionType: ActionTypeConfig{
+						Type:         "task_mutation",
+						BumpPriority: true,
+					},
+				},
 			},
 		},
 		TimeTracking: TimeTrackingConfig{
@@ -482,6 +673,18 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 			ConflictPolicy:  "newer_wins",
 			CallbackPort:    8085,
 		},
+		Sync: SyncConfig{
+			Enabled:     false,
+			Branch:      "main",
+			Interval:    300,
+			CommitDelay: 10,
+		},
+		Backup: BackupConfig{
+			Enabled:    true,
+			Interval:   3600,
+			KeepHourly: 24,
+			KeepDaily:  30,
+		},
 	}
 
 	// Save the default config
@@ -0,0 +1,42 @@
+package external
+
+import (
+	"fmt"
+	"strings"
+
+	"mkanban/internal/domain/service"
+)
+
+// ExportImporter implements BoardImporter for the local export files of
+// Trello, GitHub, Taskwarrior and for CSV
+type ExportImporter struct{}
+
+// NewExportImporter creates a new ExportImporter
+func NewExportImporter() *ExportImporter {
+	return &ExportImporter{}
+}
+
+// Formats returns the export formats that can be read
+func (e *ExportImporter) Formats() []string {
+	return []string{"trello", "github", "taskwarrior", "csv"}
+}
+
+// Parse reads an export in the given format
+func (e *ExportImporter) Parse(format string, data []byte, options service.ImportOptions) ([]service.ImportedBoard, error) {
+	if format != "csv" && len(options.Fields) > 0 {
+		return nil, fmt.Errorf("field mappings only apply to csv imports")
+	}
+
+	switch format {
+	case "trello":
+		return parseTrello(data)
+	case "github":
+		return parseGitHub(data)
+	case "taskwarrior":
+		return parseTaskwarrior(data)
+	case "csv":
+		return parseCSV(data, options.Fields)
+	default:
+		return nil, fmt.Errorf("unknown import format %q, expected one of %s", format, strings.Join(e.Formats(), ", "))
+	}
+}
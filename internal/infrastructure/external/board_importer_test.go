@@ -0,0 +1,213 @@
+package external
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+const trelloExportJSON = `{
+  "id": "5f1a00000000000000000001",
+  "name": "Shop",
+  "desc": "Web shop",
+  "lists": [
+    {"id": "l2", "name": "Doing", "pos": 2},
+    {"id": "l1", "name": "Backlog", "pos": 1},
+    {"id": "l3", "name": "Old", "closed": true, "pos": 3}
+  ],
+  "cards": [
+    {"id": "5f1a0000000000000000000c", "name": "Checkout", "desc": "Pay by card", "idList": "l2", "pos": 1,
+     "due": "2024-03-01T12:00:00.000Z", "labels": [{"name": "payments", "color": "green"}, {"name": "", "color": "red"}]},
+    {"id": "c2", "name": "Archived", "idList": "l1", "closed": true},
+    {"id": "c3", "name": "Old card", "idList": "l3"}
+  ],
+  "checklists": [
+    {"idCard": "5f1a0000000000000000000c", "pos": 1, "checkItems": [
+      {"name": "Refunds", "state": "incomplete", "pos": 2},
+      {"name": "Card form", "state": "complete", "pos": 1}
+    ]}
+  ],
+  "actions": [
+    {"id": "a1", "type": "commentCard", "date": "2024-02-01T10:00:00.000Z",
+     "data": {"text": "Use Stripe", "card": {"id": "5f1a0000000000000000000c"}},
+     "memberCreator": {"fullName": "Ann", "username": "ann"}},
+    {"id": "a2", "type": "updateCard", "date": "2024-02-02T10:00:00.000Z", "data": {"card": {"id": "5f1a0000000000000000000c"}}}
+  ]
+}`
+
+func TestParseTrello(t *testing.T) {
+	boards, err := NewExportImporter().Parse("trello", []byte(trelloExportJSON), service.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boards) != 1 {
+		t.Fatalf("boards = %d, want 1", len(boards))
+	}
+	board := boards[0]
+	if board.Name != "Shop" || board.Source != "trello" || strings.Join(board.Columns, ",") != "Backlog,Doing" {
+		t.Errorf("board = %+v, want Shop with the open lists in order", board)
+	}
+	if len(board.Tasks) != 1 {
+		t.Fatalf("tasks = %+v, want only the open card of an open list", board.Tasks)
+	}
+
+	task := board.Tasks[0]
+	if task.Column != "Doing" || task.Description != "Pay by card" || strings.Join(task.Tags, ",") != "payments,red" {
+		t.Errorf("task = %+v", task)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("due date = %v", task.DueDate)
+	}
+	if task.CreatedAt.Year() != 2020 {
+		t.Errorf("created at = %v, want the time in the card ID", task.CreatedAt)
+	}
+	want := []service.ImportedChecklistItem{{Title: "Card form", Done: true}, {Title: "Refunds"}}
+	if len(task.Checklist) != 2 || task.Checklist[0] != want[0] || task.Checklist[1] != want[1] {
+		t.Errorf("checklist = %+v, want %+v", task.Checklist, want)
+	}
+	if len(task.Comments) != 1 || task.Comments[0].Text != "Use Stripe" || task.Comments[0].Author != "Ann" {
+		t.Errorf("comments = %+v", task.Comments)
+	}
+}
+
+func TestParseGitHub(t *testing.T) {
+	issues := `[
+  {"number": 2, "title": "Fix login", "body": "- [ ] Reproduce", "state": "OPEN",
+   "url": "https://github.com/acme/shop/issues/2", "createdAt": "2024-01-02T00:00:00Z",
+   "labels": [{"name": "bug"}], "milestone": {"title": "v1", "dueOn": "2024-06-01T00:00:00Z"},
+   "comments": [{"id": "IC_1", "author": {"login": "bob"}, "body": "Seen on Safari", "createdAt": "2024-01-03T00:00:00Z"}]},
+  {"number": 1, "title": "Set up CI", "state": "CLOSED", "url": "https://github.com/acme/shop/issues/1",
+   "closedAt": "2024-01-05T00:00:00Z"}
+]`
+	boards, err := NewExportImporter().Parse("github", []byte(issues), service.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := boards[0]
+	if board.Name != "shop" || board.SourceID != "acme/shop" || len(board.Tasks) != 2 {
+		t.Fatalf("board = %+v", board)
+	}
+	open, closed := board.Tasks[0], board.Tasks[1]
+	if open.Column != "To Do" || open.SourceID != "https://github.com/acme/shop/issues/2" || open.DueDate == nil ||
+		strings.Join(open.Tags, ",") != "bug" || len(open.Comments) != 1 || open.Comments[0].Author != "bob" {
+		t.Errorf("open issue = %+v", open)
+	}
+	if closed.Column != "Done" || closed.CompletedAt == nil {
+		t.Errorf("closed issue = %+v", closed)
+	}
+
+	items := `{"items": [
+  {"id": "PVTI_1", "title": "Draft idea", "status": "Todo", "labels": ["idea"], "due date": "2024-04-01", "content": {"type": "DraftIssue", "body": "Maybe"}},
+  {"id": "PVTI_2", "title": "Fix login", "status": "In Progress", "content": {"type": "Issue", "url": "https://github.com/acme/shop/issues/2"}},
+  {"id": "PVTI_3", "title": "Triage"}
+], "totalCount": 3}`
+	boards, err = NewExportImporter().Parse("github", []byte(items), service.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board = boards[0]
+	if strings.Join(board.Columns, ",") != "Todo,In Progress,No Status" || len(board.Tasks) != 3 {
+		t.Fatalf("project board = %+v", board)
+	}
+	if draft := board.Tasks[0]; draft.SourceID != "PVTI_1" || draft.Description != "Maybe" || draft.DueDate == nil || draft.Tags[0] != "idea" {
+		t.Errorf("draft = %+v", draft)
+	}
+	if issue := board.Tasks[1]; issue.SourceID != "https://github.com/acme/shop/issues/2" {
+		t.Errorf("an issue should be identified by its URL, got %q", issue.SourceID)
+	}
+}
+
+func TestParseTaskwarrior(t *testing.T) {
+	export := `[
+  {"uuid": "u1", "description": "Buy seeds", "status": "pending", "project": "garden", "priority": "H",
+   "tags": ["shopping"], "entry": "20240101T100000Z", "due": "20240301T000000Z",
+   "annotations": [{"entry": "20240102T100000Z", "description": "Tomatoes"}]},
+  {"uuid": "u2", "description": "Dig beds", "status": "pending", "project": "garden", "start": "20240105T100000Z"},
+  {"uuid": "u3", "description": "Rake leaves", "status": "completed", "project": "garden", "end": "20240110T100000Z"},
+  {"uuid": "u4", "description": "Call mom", "status": "pending"},
+  {"uuid": "u5", "description": "Gone", "status": "deleted", "project": "garden"}
+]`
+	boards, err := NewExportImporter().Parse("taskwarrior", []byte(export), service.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boards) != 2 || boards[0].Name != "garden" || boards[1].Name != "Inbox" {
+		t.Fatalf("boards = %+v, want garden and Inbox", boards)
+	}
+
+	tasks := boards[0].Tasks
+	if len(tasks) != 3 {
+		t.Fatalf("tasks = %+v, want the deleted task left out", tasks)
+	}
+	seeds := tasks[0]
+	if seeds.Column != "To Do" || seeds.Priority != "high" || seeds.DueDate == nil || seeds.CreatedAt.IsZero() ||
+		len(seeds.Comments) != 1 || seeds.Comments[0].Text != "Tomatoes" {
+		t.Errorf("pending task = %+v", seeds)
+	}
+	if tasks[1].Column != "In Progress" {
+		t.Errorf("started task column = %q", tasks[1].Column)
+	}
+	if tasks[2].Column != "Done" || tasks[2].CompletedAt == nil {
+		t.Errorf("completed task = %+v", tasks[2])
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	data := "Issue key,Summary,Status,Labels,Due,Checklist\n" +
+		"SHOP-1,Checkout,In Progress,\"payments, web\",2024-03-01,[x] Card form; Refunds\n" +
+		"SHOP-2,Search,Backlog,,,\n" +
+		",Untracked,Backlog,,,\n"
+
+	boards, err := NewExportImporter().Parse("csv", []byte(data), service.ImportOptions{
+		Fields: map[string]string{"column": "status"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := boards[0]
+	if board.Name != "Imported" || strings.Join(board.Columns, ",") != "In Progress,Backlog" || len(board.Tasks) != 3 {
+		t.Fatalf("board = %+v", board)
+	}
+
+	checkout := board.Tasks[0]
+	if checkout.SourceID != "SHOP-1" || strings.Join(checkout.Tags, ",") != "payments,web" || checkout.DueDate == nil {
+		t.Errorf("task = %+v", checkout)
+	}
+	want := []service.ImportedChecklistItem{{Title: "Card form", Done: true}, {Title: "Refunds"}}
+	if len(checkout.Checklist) != 2 || checkout.Checklist[0] != want[0] || checkout.Checklist[1] != want[1] {
+		t.Errorf("checklist = %+v, want %+v", checkout.Checklist, want)
+	}
+	if id := board.Tasks[2].SourceID; id != "title:Untracked" {
+		t.Errorf("a row without an ID should be identified by its title, got %q", id)
+	}
+
+	tests := []struct {
+		name   string
+		data   string
+		fields map[string]string
+		want   string
+	}{
+		{"no title", "Key,Status\nA,Done\n", nil, "no title column"},
+		{"unknown field", data, map[string]string{"owner": "Summary"}, "unknown CSV field"},
+		{"missing header", data, map[string]string{"title": "Name"}, `no CSV column "Name"`},
+		{"bad date", "title,due\nCheckout,soon\n", nil, "row 2: invalid due date"},
+	}
+	for _, tt := range tests {
+		_, err := NewExportImporter().Parse("csv", []byte(tt.data), service.ImportOptions{Fields: tt.fields})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestExportImporterRejectsUnknownFormats(t *testing.T) {
+	importer := NewExportImporter()
+	if _, err := importer.Parse("jira", []byte("{}"), service.ImportOptions{}); err == nil {
+		t.Error("an unknown format should be refused")
+	}
+	if _, err := importer.Parse("trello", []byte(trelloExportJSON), service.ImportOptions{Fields: map[string]string{"title": "Name"}}); err == nil {
+		t.Error("field mappings should only apply to csv")
+	}
+}
@@ -0,0 +1,383 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterDelimiter opens and closes the YAML frontmatter of a Markdown
+// file
+const frontmatterDelimiter = "---"
+
+// mergeYAML merges two edits of a YAML document field by field against
+// their common base, which is nil when both sides added the document. A
+// field changed on one side only takes that change. Fields changed on both
+// sides are merged further when they are mappings or lists, and otherwise
+// take the value of the side modified last, going by the "modified" field
+// of the nearest mapping that has one; ours wins when neither says.
+// Counters like next_task_num take the larger value.
+func mergeYAML(base, ours, theirs []byte) ([]byte, error) {
+	baseNode, err := parseYAMLDocument(base)
+	if err != nil {
+		return nil, err
+	}
+	ourNode, err := parseYAMLDocument(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirNode, err := parseYAMLDocument(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeYAMLNodes(baseNode, ourNode, theirNode, false, "")
+	if merged == nil {
+		return []byte{}, nil
+	}
+	return yaml.Marshal(merged)
+}
+
+// mergeFrontmatter merges two edits of a Markdown file with YAML
+// frontmatter: the frontmatter field by field and the body line by line,
+// with conflicting lines taken from the side modified last
+func mergeFrontmatter(base, ours, theirs []byte) ([]byte, error) {
+	baseFront, baseBody := splitFrontmatter(base)
+	ourFront, ourBody := splitFrontmatter(ours)
+	theirFront, theirBody := splitFrontmatter(theirs)
+	if ourFront == nil || theirFront == nil {
+		return mergeText(base, ours, theirs, false)
+	}
+
+	front, err := mergeYAML(baseFront, ourFront, theirFront)
+	if err != nil {
+		return nil, err
+	}
+	body, err := mergeText(baseBody, ourBody, theirBody, modifiedLater(theirFront, ourFront))
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(frontmatterDelimiter + "\n")
+	b.Write(front)
+	b.WriteString(frontmatterDelimiter + "\n")
+	b.Write(body)
+	return b.Bytes(), nil
+}
+
+// mergeText merges two edits of a text file line by line with git
+// merge-file. Lines changed differently on both sides are taken from
+// theirs when theirsWins is set, from ours otherwise.
+func mergeText(base, ours, theirs []byte, theirsWins bool) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "mkanban-merge-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{"ours", "base", "theirs"}
+	for i, content := range [][]byte{ours, base, theirs} {
+		if err := os.WriteFile(filepath.Join(dir, files[i]), content, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	favor := "--ours"
+	if theirsWins {
+		favor = "--theirs"
+	}
+	cmd := exec.Command("git", "merge-file", "-p", favor, files[0], files[1], files[2])
+	cmd.Dir = dir
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git merge-file: %w - %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out.Bytes(), nil
+}
+
+// splitFrontmatter splits a Markdown file into its YAML frontmatter and
+// body. The frontmatter is nil when the file has none.
+func splitFrontmatter(content []byte) ([]byte, []byte) {
+	opening := []byte(frontmatterDelimiter + "\n")
+	if !bytes.HasPrefix(content, opening) {
+		return nil, content
+	}
+	rest := content[len(opening):]
+
+	closing := []byte("\n" + frontmatterDelimiter + "\n")
+	end := bytes.Index(rest, closing)
+	if end < 0 {
+		return nil, content
+	}
+	return rest[:end+1], rest[end+len(closing):]
+}
+
+// modifiedLater reports whether YAML document a has a later "modified"
+// field than document b
+func modifiedLater(a, b []byte) bool {
+	aNode, err := parseYAMLDocument(a)
+	if err != nil {
+		return false
+	}
+	bNode, err := parseYAMLDocument(b)
+	if err != nil {
+		return false
+	}
+	aTime, aOK := modifiedTime(aNode)
+	bTime, bOK := modifiedTime(bNode)
+	return aOK && bOK && aTime.After(bTime)
+}
+
+// parseYAMLDocument parses a YAML document, returning its root node or nil
+// when it is empty
+func parseYAMLDocument(content []byte) (*yaml.Node, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil, nil
+	}
+	return document.Content[0], nil
+}
+
+// mergeYAMLNodes merges two edits of a YAML node against their base. A nil
+// node is one that doesn't exist on that side.
+func mergeYAMLNodes(base, ours, theirs *yaml.Node, theirsWins bool, key string) *yaml.Node {
+	switch {
+	case yamlNodesEqual(ours, theirs):
+		return ours
+	case yamlNodesEqual(base, ours):
+		return theirs
+	case yamlNodesEqual(base, theirs):
+		return ours
+	}
+
+	// Deleted on one side and changed on the other: keep the change
+	if ours == nil {
+		return theirs
+	}
+	if theirs == nil {
+		return ours
+	}
+
+	if ours.Kind == yaml.MappingNode && theirs.Kind == yaml.MappingNode {
+		return mergeYAMLMappings(base, ours, theirs, theirsWins)
+	}
+	if ours.Kind == yaml.SequenceNode && theirs.Kind == yaml.SequenceNode {
+		return mergeYAMLSequences(base, ours, theirs, theirsWins)
+	}
+
+	if key == "next_task_num" {
+		ourNum, ourErr := strconv.Atoi(ours.Value)
+		theirNum, theirErr := strconv.Atoi(theirs.Value)
+		if ourErr == nil && theirErr == nil && theirNum > ourNum {
+			return theirs
+		}
+		return ours
+	}
+
+	if theirsWins {
+		return theirs
+	}
+	return ours
+}
+
+// mergeYAMLMappings merges the fields of a mapping changed on both sides.
+// The side with the later "modified" field wins conflicting fields below
+// it.
+func mergeYAMLMappings(base, ours, theirs *yaml.Node, theirsWins bool) *yaml.Node {
+	ourTime, ourOK := modifiedTime(ours)
+	theirTime, theirOK := modifiedTime(theirs)
+	if ourOK && theirOK && !ourTime.Equal(theirTime) {
+		theirsWins = theirTime.After(ourTime)
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: ours.Tag, Style: ours.Style}
+	keys := yamlMappingKeys(ours)
+	for _, key := range yamlMappingKeys(theirs) {
+		if yamlMappingValue(ours, key) == nil {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		value := mergeYAMLNodes(
+			yamlMappingValue(base, key),
+			yamlMappingValue(ours, key),
+			yamlMappingValue(theirs, key),
+			theirsWins,
+			key,
+		)
+		if value == nil {
+			continue
+		}
+		merged.Content = append(merged.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+	return merged
+}
+
+// mergeYAMLSequences merges a list changed on both sides. Lists of mappings
+// with an "id", like time logs, are merged item by item; other lists, like
+// tags, as sets keeping the additions and removals of both sides.
+func mergeYAMLSequences(base, ours, theirs *yaml.Node, theirsWins bool) *yaml.Node {
+	merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: ours.Tag, Style: ours.Style}
+
+	if yamlItemsHaveIDs(ours) && yamlItemsHaveIDs(theirs) && (base == nil || yamlItemsHaveIDs(base)) {
+		ids := yamlItemIDs(ours)
+		for _, id := range yamlItemIDs(theirs) {
+			if yamlItemByID(ours, id) == nil {
+				ids = append(ids, id)
+			}
+		}
+		for _, id := range ids {
+			item := mergeYAMLNodes(yamlItemByID(base, id), yamlItemByID(ours, id), yamlItemByID(theirs, id), theirsWins, "")
+			if item != nil {
+				merged.Content = append(merged.Content, item)
+			}
+		}
+		return merged
+	}
+
+	baseItems := yamlItemSet(base)
+	theirItems := yamlItemSet(theirs)
+	ourItems := yamlItemSet(ours)
+	for _, item := range ours.Content {
+		key := yamlNodeKey(item)
+		if baseItems[key] && !theirItems[key] {
+			continue // removed by theirs
+		}
+		merged.Content = append(merged.Content, item)
+	}
+	for _, item := range theirs.Content {
+		key := yamlNodeKey(item)
+		if !ourItems[key] && !baseItems[key] {
+			merged.Content = append(merged.Content, item) // added by theirs
+		}
+	}
+	return merged
+}
+
+// modifiedTime returns the "modified" field of a mapping
+func modifiedTime(node *yaml.Node) (time.Time, bool) {
+	value := yamlMappingValue(node, "modified")
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, value.Value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// yamlMappingKeys returns the keys of a mapping in order
+func yamlMappingKeys(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// yamlMappingValue returns the value of a mapping's key, nil when the node
+// isn't a mapping or lacks the key
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlItemsHaveIDs reports whether a non-empty list holds only mappings
+// with an "id"
+func yamlItemsHaveIDs(node *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+	for _, item := range node.Content {
+		if id := yamlMappingValue(item, "id"); id == nil || id.Value == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlItemIDs returns the ids of the mappings of a list in order
+func yamlItemIDs(node *yaml.Node) []string {
+	var ids []string
+	for _, item := range node.Content {
+		ids = append(ids, yamlMappingValue(item, "id").Value)
+	}
+	return ids
+}
+
+// yamlItemByID returns the mapping of a list with the given id
+func yamlItemByID(node *yaml.Node, id string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for _, item := range node.Content {
+		if value := yamlMappingValue(item, "id"); value != nil && value.Value == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// yamlItemSet returns the items of a list by their yamlNodeKey
+func yamlItemSet(node *yaml.Node) map[string]bool {
+	items := make(map[string]bool)
+	if node == nil {
+		return items
+	}
+	for _, item := range node.Content {
+		items[yamlNodeKey(item)] = true
+	}
+	return items
+}
+
+// yamlNodesEqual reports whether two nodes hold the same data
+func yamlNodesEqual(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return yamlNodeKey(a) == yamlNodeKey(b)
+}
+
+// yamlNodeKey returns the canonical YAML of a node, which is equal for
+// nodes holding the same data regardless of their formatting
+func yamlNodeKey(node *yaml.Node) string {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return node.Value
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return node.Value
+	}
+	return string(data)
+}
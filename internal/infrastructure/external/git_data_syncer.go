@@ -0,0 +1,581 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+)
+
+const (
+	// syncRemoteName is the name of the remote the data directory syncs with
+	syncRemoteName = "origin"
+
+	// syncPushAttempts bounds the pull and push rounds of a sync that loses
+	// the race against another machine pushing at the same time
+	syncPushAttempts = 3
+
+	// syncPlaceholder keeps an otherwise empty directory in the repository
+	syncPlaceholder = ".gitkeep"
+)
+
+// syncIgnored are the files of the data directory that belong to this
+// machine only and are never synced
+var syncIgnored = []string{
+	"mkanbad.sock",
+	"mkanban.pid",
+	shellSessionsFile,
+	"*.tmp",
+	"*.lock",
+	"*.db",
+	"*.db-wal",
+	"*.db-shm",
+	"backups/",
+	"snapshots/",
+}
+
+// GitDataSyncer implements service.DataSyncer by keeping the data directory
+// in a git repository that is merged with and pushed to a remote
+type GitDataSyncer struct {
+	root     string
+	branch   string
+	database bool
+	mu       sync.Mutex
+}
+
+// NewGitDataSyncer creates a syncer for the data directory at root, syncing
+// the given branch. database tells that the data is kept in a database
+// rather than files, which the syncer refuses to sync.
+func NewGitDataSyncer(root, branch string, database bool) *GitDataSyncer {
+	if branch == "" {
+		branch = "main"
+	}
+	return &GitDataSyncer{
+		root:     root,
+		branch:   branch,
+		database: database,
+	}
+}
+
+// Init makes the data directory a git repository with everything in it
+// committed, and points it at remote when given
+func (s *GitDataSyncer) Init(ctx context.Context, remote string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.database {
+		return service.ErrSyncDatabaseBackend
+	}
+
+	if err := os.MkdirAll(s.root, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	if !s.initialized() {
+		if _, err := s.git(ctx, "init", "--quiet", "--initial-branch="+s.branch); err != nil {
+			return err
+		}
+	}
+
+	// Commits need an identity, which a fresh machine may not have
+	if email, _ := s.git(ctx, "config", "user.email"); email == "" {
+		hostname, _ := os.Hostname()
+		if _, err := s.git(ctx, "config", "user.name", "mkanban"); err != nil {
+			return err
+		}
+		if _, err := s.git(ctx, "config", "user.email", "mkanban@"+hostname); err != nil {
+			return err
+		}
+	}
+
+	if err := s.writeIgnoreFile(); err != nil {
+		return err
+	}
+
+	if remote != "" {
+		if current, _ := s.git(ctx, "remote", "get-url", syncRemoteName); current == "" {
+			_, err := s.git(ctx, "remote", "add", syncRemoteName, remote)
+			if err != nil {
+				return err
+			}
+		} else if current != remote {
+			if _, err := s.git(ctx, "remote", "set-url", syncRemoteName, remote); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := s.commit(ctx, "Start syncing mkanban data")
+	return err
+}
+
+// Commit records all changes of the data directory
+func (s *GitDataSyncer) Commit(ctx context.Context, message string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.database {
+		return false, service.ErrSyncDatabaseBackend
+	}
+	if !s.initialized() {
+		return false, service.ErrSyncNotInitialized
+	}
+	return s.commit(ctx, message)
+}
+
+// Sync commits pending changes, merges the remote branch and pushes. A push
+// rejected because another machine pushed first is retried after merging
+// again.
+func (s *GitDataSyncer) Sync(ctx context.Context) (*service.DataSyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.database {
+		return nil, service.ErrSyncDatabaseBackend
+	}
+	if !s.initialized() {
+		return nil, service.ErrSyncNotInitialized
+	}
+
+	// Merges rewrite data files, snapshots wait for them
+	unlock, err := filesystem.NewDataLock(s.root).Shared()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if remote, _ := s.git(ctx, "remote", "get-url", syncRemoteName); remote == "" {
+		return nil, service.ErrSyncNoRemote
+	}
+
+	result := &service.DataSyncResult{}
+	committed, err := s.commit(ctx, s.commitMessage("Update"))
+	if err != nil {
+		return nil, err
+	}
+	result.Committed = committed
+
+	remoteRef := syncRemoteName + "/" + s.branch
+	for attempt := 1; ; attempt++ {
+		if _, err := s.git(ctx, "fetch", "--quiet", syncRemoteName); err != nil {
+			return nil, err
+		}
+
+		ahead := 0
+		if s.refExists(ctx, remoteRef) {
+			behind, err := s.countCommits(ctx, "HEAD.."+remoteRef)
+			if err != nil {
+				return nil, err
+			}
+			if behind > 0 {
+				if err := s.merge(ctx, remoteRef, result); err != nil {
+					return nil, err
+				}
+				result.Pulled += behind
+			}
+			if ahead, err = s.countCommits(ctx, remoteRef+"..HEAD"); err != nil {
+				return nil, err
+			}
+		} else if ahead, err = s.countCommits(ctx, "HEAD"); err != nil {
+			return nil, err
+		}
+
+		if ahead == 0 {
+			break
+		}
+		_, err := s.git(ctx, "push", "--quiet", syncRemoteName, "HEAD:refs/heads/"+s.branch)
+		if err == nil {
+			result.Pushed = ahead
+			break
+		}
+		if attempt == syncPushAttempts || !isPushRejection(err) {
+			return nil, err
+		}
+	}
+
+	// Point the remote-tracking ref at what was pushed for Status
+	if result.Pushed > 0 {
+		if _, err := s.git(ctx, "update-ref", "refs/remotes/"+remoteRef, "HEAD"); err != nil {
+			return nil, err
+		}
+	}
+
+	result.SyncedAt = time.Now()
+	return result, nil
+}
+
+// Status reports the state of the repository against the remote as of the
+// last fetch
+func (s *GitDataSyncer) Status(ctx context.Context) (*service.DataSyncStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &service.DataSyncStatus{Branch: s.branch}
+	if !s.initialized() {
+		return status, nil
+	}
+	status.Initialized = true
+	status.Remote, _ = s.git(ctx, "remote", "get-url", syncRemoteName)
+
+	changes, err := s.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if changes != "" {
+		status.Pending = len(strings.Split(changes, "\n"))
+	}
+
+	if s.refExists(ctx, "HEAD") {
+		timestamp, err := s.git(ctx, "log", "-1", "--format=%ct")
+		if err != nil {
+			return nil, err
+		}
+		if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+			status.LastCommit = time.Unix(seconds, 0)
+		}
+
+		remoteRef := syncRemoteName + "/" + s.branch
+		if s.refExists(ctx, remoteRef) {
+			if status.Ahead, err = s.countCommits(ctx, remoteRef+"..HEAD"); err != nil {
+				return nil, err
+			}
+			if status.Behind, err = s.countCommits(ctx, "HEAD.."+remoteRef); err != nil {
+				return nil, err
+			}
+		} else if status.Ahead, err = s.countCommits(ctx, "HEAD"); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// merge merges the remote branch into the local one. Files changed on both
+// sides are merged field by field, tasks moved to different columns on
+// each side end up in one, and local tasks that got the same number as a
+// remote task are renumbered before the merge is committed.
+func (s *GitDataSyncer) merge(ctx context.Context, remoteRef string, result *service.DataSyncResult) error {
+	_, mergeErr := s.git(ctx, "merge", "--quiet", "--no-commit", "--no-edit", "--allow-unrelated-histories", remoteRef)
+	if !s.refExists(ctx, "MERGE_HEAD") {
+		// Fast-forwarded, or failed before merging anything
+		return mergeErr
+	}
+
+	merged, err := s.resolveConflicts(ctx)
+	if err != nil {
+		s.git(ctx, "merge", "--abort")
+		return err
+	}
+	result.Merged = append(result.Merged, merged...)
+
+	renumbered, err := s.fixTaskNumbers(ctx)
+	if err != nil {
+		s.git(ctx, "merge", "--abort")
+		return err
+	}
+	result.Renumbered = append(result.Renumbered, renumbered...)
+
+	if _, err := s.git(ctx, "add", "--all"); err != nil {
+		return err
+	}
+	_, err = s.git(ctx, "commit", "--quiet", "--no-verify", "-m", s.commitMessage("Merge changes"))
+	return err
+}
+
+// conflictedFile is a file git couldn't merge, with its content in the
+// common base and on each side; a side's content is nil when it deleted
+// the file
+type conflictedFile struct {
+	path   string
+	base   []byte
+	ours   []byte
+	theirs []byte
+}
+
+// resolveConflicts resolves every file git left unmerged, returning the
+// paths of those merged from both sides
+func (s *GitDataSyncer) resolveConflicts(ctx context.Context) ([]string, error) {
+	conflicts, err := s.conflictedFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []string
+	for _, conflict := range conflicts {
+		path := filepath.Join(s.root, filepath.FromSlash(conflict.path))
+
+		var content []byte
+		switch {
+		case conflict.ours != nil && conflict.theirs != nil:
+			if content, err = s.mergeFile(ctx, conflict); err != nil {
+				return nil, fmt.Errorf("failed to merge %s: %w", conflict.path, err)
+			}
+			merged = append(merged, conflict.path)
+		case conflict.ours != nil:
+			content = conflict.ours
+		case conflict.theirs != nil:
+			content = conflict.theirs
+		default:
+			// Deleted, or renamed, on both sides
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			if _, err := s.git(ctx, "rm", "--quiet", "--cached", "--ignore-unmatch", "--", conflict.path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Changed on one side and deleted on the other keeps the change;
+		// where a task was moved away, fixTaskNumbers drops the leftover
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return nil, err
+		}
+		if _, err := s.git(ctx, "add", "--", conflict.path); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeFile merges a file changed on both sides: YAML field by field,
+// Markdown with frontmatter by field and by line, and other files by line
+// with conflicting lines taken from the side whose task, or other
+// metadata next to the file, was modified last
+func (s *GitDataSyncer) mergeFile(ctx context.Context, conflict conflictedFile) ([]byte, error) {
+	switch filepath.Ext(conflict.path) {
+	case ".yml", ".yaml":
+		if content, err := mergeYAML(conflict.base, conflict.ours, conflict.theirs); err == nil {
+			return content, nil
+		}
+	case ".md":
+		if front, _ := splitFrontmatter(conflict.ours); front != nil {
+			if content, err := mergeFrontmatter(conflict.base, conflict.ours, conflict.theirs); err == nil {
+				return content, nil
+			}
+		}
+	}
+
+	metadata := filepath.ToSlash(filepath.Join(filepath.Dir(conflict.path), "metadata.yml"))
+	ourMetadata, _ := s.gitBytes(ctx, "show", "HEAD:"+metadata)
+	theirMetadata, _ := s.gitBytes(ctx, "show", "MERGE_HEAD:"+metadata)
+	return mergeText(conflict.base, conflict.ours, conflict.theirs, modifiedLater(theirMetadata, ourMetadata))
+}
+
+// conflictedFiles lists the unmerged files of the index with the content
+// of their stages
+func (s *GitDataSyncer) conflictedFiles(ctx context.Context) ([]conflictedFile, error) {
+	out, err := s.gitBytes(ctx, "ls-files", "--unmerged", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*conflictedFile)
+	var paths []string
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> <object> <stage>\t<path>
+		info, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 3 {
+			continue
+		}
+
+		file, seen := files[path]
+		if !seen {
+			file = &conflictedFile{path: path}
+			files[path] = file
+			paths = append(paths, path)
+		}
+
+		content, err := s.gitBytes(ctx, "cat-file", "blob", fields[1])
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			content = []byte{} // empty, which isn't deleted
+		}
+		switch fields[2] {
+		case "1":
+			file.base = content
+		case "2":
+			file.ours = content
+		case "3":
+			file.theirs = content
+		}
+	}
+
+	// The stages of a file renamed differently on each side hold git's
+	// attempt at merging it, so take each side from its commit instead
+	sort.Strings(paths)
+	conflicts := make([]conflictedFile, 0, len(paths))
+	for _, path := range paths {
+		file := files[path]
+		if file.ours != nil {
+			if content, err := s.gitBytes(ctx, "show", "HEAD:"+path); err == nil {
+				file.ours = append([]byte{}, content...)
+			}
+		}
+		if file.theirs != nil {
+			if content, err := s.gitBytes(ctx, "show", "MERGE_HEAD:"+path); err == nil {
+				file.theirs = append([]byte{}, content...)
+			}
+		}
+		conflicts = append(conflicts, *file)
+	}
+	return conflicts, nil
+}
+
+// commit stages and commits every change, reporting whether there were any
+func (s *GitDataSyncer) commit(ctx context.Context, message string) (bool, error) {
+	if err := s.keepEmptyDirs(); err != nil {
+		return false, err
+	}
+	if _, err := s.git(ctx, "add", "--all"); err != nil {
+		return false, err
+	}
+	changes, err := s.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if changes == "" {
+		return false, nil
+	}
+	if _, err := s.git(ctx, "commit", "--quiet", "--no-verify", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// commitMessage returns a commit message naming this machine
+func (s *GitDataSyncer) commitMessage(summary string) string {
+	if hostname, err := os.Hostname(); err == nil {
+		return fmt.Sprintf("%s from %s", summary, hostname)
+	}
+	return summary
+}
+
+// keepEmptyDirs puts a placeholder in every empty directory, which git
+// wouldn't track otherwise, since boards need their columns folder and
+// columns their tasks folder even when there is nothing in them
+func (s *GitDataSyncer) keepEmptyDirs() error {
+	return filepath.WalkDir(s.root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		if entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil || len(entries) > 0 {
+			return err
+		}
+		return os.WriteFile(filepath.Join(path, syncPlaceholder), nil, 0644)
+	})
+}
+
+// writeIgnoreFile keeps the machine-local files of the data directory out
+// of the repository
+func (s *GitDataSyncer) writeIgnoreFile() error {
+	path := filepath.Join(s.root, ".gitignore")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	lines := strings.Split(string(existing), "\n")
+	present := make(map[string]bool)
+	for _, line := range lines {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	content := strings.TrimRight(string(existing), "\n")
+	for _, pattern := range syncIgnored {
+		if present[pattern] {
+			continue
+		}
+		if content != "" {
+			content += "\n"
+		}
+		content += pattern
+	}
+	if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return nil
+}
+
+// initialized reports whether the data directory is a git repository of
+// its own
+func (s *GitDataSyncer) initialized() bool {
+	_, err := os.Stat(filepath.Join(s.root, ".git"))
+	return err == nil
+}
+
+// refExists reports whether a ref resolves to a commit
+func (s *GitDataSyncer) refExists(ctx context.Context, ref string) bool {
+	_, err := s.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// countCommits counts the commits of a revision range
+func (s *GitDataSyncer) countCommits(ctx context.Context, revisions string) (int, error) {
+	out, err := s.git(ctx, "rev-list", "--count", revisions)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(out)
+}
+
+// isPushRejection reports whether a push failed because the remote has
+// commits that weren't merged yet
+func isPushRejection(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "[rejected]") ||
+		strings.Contains(message, "non-fast-forward") ||
+		strings.Contains(message, "fetch first")
+}
+
+// git runs a git command in the data directory and returns its trimmed
+// output
+func (s *GitDataSyncer) git(ctx context.Context, args ...string) (string, error) {
+	out, err := s.gitBytes(ctx, args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// gitBytes runs a git command in the data directory and returns its output
+func (s *GitDataSyncer) gitBytes(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = s.root
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(out.String())
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && message != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out.Bytes(), nil
+}
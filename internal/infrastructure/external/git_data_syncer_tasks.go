@@ -0,0 +1,365 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+)
+
+// nextTaskNumRE matches the task counter of a board's metadata.yml
+var nextTaskNumRE = regexp.MustCompile(`(?m)^next_task_num: *(\d+) *$`)
+
+// syncedTask is a task folder of a board in the merged data directory
+type syncedTask struct {
+	dir      string
+	id       *valueobject.TaskID
+	complete bool // has its task.md
+	created  time.Time
+	modified time.Time
+	remote   bool // the remote has a task folder of this name on the board
+}
+
+// fixTaskNumbers repairs the task folders of every board after a merge.
+// Copies of a task that each side moved to a different column are reduced
+// to the one modified last. Tasks that got the same number on different
+// machines keep it for the one the remote has, and the others are
+// renumbered past the board's highest number, along with references to
+// them in parents, descriptions and time logs.
+func (s *GitDataSyncer) fixTaskNumbers(ctx context.Context) ([]service.RenumberedTask, error) {
+	remoteTasks, err := s.remoteTaskFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	boardDirs, err := filepath.Glob(filepath.Join(s.root, "projects", "*", "boards", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var renumbered []service.RenumberedTask
+	for _, boardDir := range boardDirs {
+		rel, err := filepath.Rel(s.root, boardDir)
+		if err != nil {
+			return nil, err
+		}
+		tasks := boardTaskFolders(boardDir, remoteTasks[filepath.ToSlash(rel)])
+		if tasks, err = dropMovedCopies(tasks); err != nil {
+			return nil, err
+		}
+
+		projectSlug := filepath.Base(filepath.Dir(filepath.Dir(boardDir)))
+		boardID, err := valueobject.BuildBoardID(projectSlug, filepath.Base(boardDir))
+		if err != nil {
+			continue
+		}
+		boardRenumbered, err := s.renumberDuplicates(boardDir, boardID, tasks)
+		if err != nil {
+			return nil, err
+		}
+		renumbered = append(renumbered, boardRenumbered...)
+	}
+	return renumbered, nil
+}
+
+// remoteTaskFolders returns the names of the task folders the remote side
+// of a merge has, by board directory
+func (s *GitDataSyncer) remoteTaskFolders(ctx context.Context) (map[string]map[string]bool, error) {
+	out, err := s.gitBytes(ctx, "ls-tree", "-r", "--name-only", "-z", "MERGE_HEAD", "--", "projects")
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make(map[string]map[string]bool)
+	for _, path := range strings.Split(string(out), "\x00") {
+		parts := strings.Split(path, "/")
+		// projects/<project>/boards/<board>/columns/<column>/[tasks/]<task>/<file>
+		if len(parts) < 8 || parts[4] != "columns" {
+			continue
+		}
+		folder := parts[6]
+		if folder == "tasks" {
+			folder = parts[7]
+		}
+		board := strings.Join(parts[:4], "/")
+		if folders[board] == nil {
+			folders[board] = make(map[string]bool)
+		}
+		folders[board][folder] = true
+	}
+	return folders, nil
+}
+
+// boardTaskFolders lists the task folders of a board's columns, in the
+// tasks folder of a column or, as older data has them, right in it
+func boardTaskFolders(boardDir string, remote map[string]bool) []syncedTask {
+	columnDirs, _ := filepath.Glob(filepath.Join(boardDir, "columns", "*"))
+
+	var tasks []syncedTask
+	for _, columnDir := range columnDirs {
+		for _, dir := range []string{filepath.Join(columnDir, "tasks"), columnDir} {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+				id, err := valueobject.ParseTaskID(entry.Name())
+				if err != nil {
+					continue
+				}
+
+				task := syncedTask{
+					dir:    filepath.Join(dir, entry.Name()),
+					id:     id,
+					remote: remote[entry.Name()],
+				}
+				_, err = os.Stat(filepath.Join(task.dir, "task.md"))
+				task.complete = err == nil
+				task.created, task.modified = taskFolderTimes(task.dir)
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks
+}
+
+// taskFolderTimes reads the created and modified times of a task folder
+// from its metadata.yml, or the frontmatter of an older task.md
+func taskFolderTimes(dir string) (time.Time, time.Time) {
+	content, err := os.ReadFile(filepath.Join(dir, "metadata.yml"))
+	if err != nil {
+		markdown, err := os.ReadFile(filepath.Join(dir, "task.md"))
+		if err != nil {
+			return time.Time{}, time.Time{}
+		}
+		content, _ = splitFrontmatter(markdown)
+	}
+
+	node, err := parseYAMLDocument(content)
+	if err != nil || node == nil {
+		return time.Time{}, time.Time{}
+	}
+	modified, _ := modifiedTime(node)
+	var created time.Time
+	if value := yamlMappingValue(node, "created"); value != nil {
+		created, _ = time.Parse(time.RFC3339Nano, value.Value)
+	}
+	return created, modified
+}
+
+// dropMovedCopies removes all but one copy of task folders that exist in
+// several columns, which happens when each side moved the task elsewhere
+// or moved it while the other changed it. The complete copy modified last
+// is kept.
+func dropMovedCopies(tasks []syncedTask) ([]syncedTask, error) {
+	copies := make(map[string][]int)
+	for i, task := range tasks {
+		name := filepath.Base(task.dir)
+		copies[name] = append(copies[name], i)
+	}
+
+	dropped := make(map[int]bool)
+	for _, indexes := range copies {
+		if len(indexes) < 2 {
+			continue
+		}
+		sort.SliceStable(indexes, func(a, b int) bool {
+			ta, tb := tasks[indexes[a]], tasks[indexes[b]]
+			if ta.complete != tb.complete {
+				return ta.complete
+			}
+			return ta.modified.After(tb.modified)
+		})
+		for _, i := range indexes[1:] {
+			if err := os.RemoveAll(tasks[i].dir); err != nil {
+				return nil, fmt.Errorf("failed to remove moved task copy: %w", err)
+			}
+			dropped[i] = true
+		}
+	}
+
+	kept := make([]syncedTask, 0, len(tasks))
+	for i, task := range tasks {
+		if !dropped[i] {
+			kept = append(kept, task)
+		}
+	}
+	return kept, nil
+}
+
+// renumberDuplicates gives tasks of a board that share a number new
+// numbers, keeping it for one the remote has or else the oldest
+func (s *GitDataSyncer) renumberDuplicates(boardDir, boardID string, tasks []syncedTask) ([]service.RenumberedTask, error) {
+	metadataPath := filepath.Join(boardDir, "metadata.yml")
+	metadata, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return nil, nil // not a board
+	}
+
+	next := 1
+	if match := nextTaskNumRE.FindSubmatch(metadata); match != nil {
+		next, _ = strconv.Atoi(string(match[1]))
+	}
+	byNumber := make(map[string][]syncedTask)
+	var numbers []string
+	for _, task := range tasks {
+		if task.id.Number() >= next {
+			next = task.id.Number() + 1
+		}
+		number := task.id.ShortID()
+		if byNumber[number] == nil {
+			numbers = append(numbers, number)
+		}
+		byNumber[number] = append(byNumber[number], task)
+	}
+	sort.Strings(numbers)
+
+	var renumbered []service.RenumberedTask
+	for _, number := range numbers {
+		sharing := byNumber[number]
+		if len(sharing) < 2 {
+			continue
+		}
+		sort.SliceStable(sharing, func(a, b int) bool {
+			if sharing[a].remote != sharing[b].remote {
+				return sharing[a].remote
+			}
+			if !sharing[a].created.Equal(sharing[b].created) {
+				return sharing[a].created.Before(sharing[b].created)
+			}
+			return sharing[a].id.String() < sharing[b].id.String()
+		})
+
+		for _, task := range sharing[1:] {
+			branch := taskFolderBranch(task.dir)
+			newID, err := s.renumberTask(task, next)
+			if err != nil {
+				return nil, err
+			}
+			renumbered = append(renumbered, service.RenumberedTask{
+				BoardID: boardID,
+				OldID:   task.id.String(),
+				NewID:   newID,
+				Branch:  branch,
+			})
+			next++
+		}
+	}
+
+	current := nextTaskNumRE.FindSubmatch(metadata)
+	if current != nil && string(current[1]) != strconv.Itoa(next) {
+		metadata = nextTaskNumRE.ReplaceAll(metadata, []byte("next_task_num: "+strconv.Itoa(next)))
+		if err := os.WriteFile(metadataPath, metadata, 0644); err != nil {
+			return nil, fmt.Errorf("failed to update board task counter: %w", err)
+		}
+	}
+	return renumbered, nil
+}
+
+// taskFolderBranch reads the git branch of a task folder from its
+// metadata.yml, "" when it has none
+func taskFolderBranch(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "metadata.yml"))
+	if err != nil {
+		return ""
+	}
+	node, err := parseYAMLDocument(content)
+	if err != nil {
+		return ""
+	}
+	if branch := yamlMappingValue(yamlMappingValue(node, "git"), "branch"); branch != nil {
+		return branch.Value
+	}
+	return ""
+}
+
+// renumberTask moves a task folder to the given number and rewrites the
+// references to the task's old ID in all project files. The task's git
+// metadata is left alone: its branch and worktree keep the old ID, as do
+// commit messages and sessions, and the task has to stay linked to them.
+func (s *GitDataSyncer) renumberTask(task syncedTask, number int) (string, error) {
+	oldID := task.id.String()
+	newID := fmt.Sprintf("%s-%03d-%s", task.id.Prefix(), number, task.id.Slug())
+	newShortID := fmt.Sprintf("%s-%03d", task.id.Prefix(), number)
+
+	newDir := filepath.Join(filepath.Dir(task.dir), newID)
+	if err := os.Rename(task.dir, newDir); err != nil {
+		return "", fmt.Errorf("failed to renumber task %s: %w", oldID, err)
+	}
+
+	shortIDRE := regexp.MustCompile(`(?m)^id: *` + regexp.QuoteMeta(task.id.ShortID()) + ` *$`)
+	for _, name := range []string{"metadata.yml", "task.md"} {
+		path := filepath.Join(newDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if updated := shortIDRE.ReplaceAll(content, []byte("id: "+newShortID)); string(updated) != string(content) {
+			if err := os.WriteFile(path, updated, 0644); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// Full IDs are unique, unlike the short IDs both tasks had
+	referenceRE := regexp.MustCompile(`(^|[^A-Za-z0-9-])` + regexp.QuoteMeta(oldID) + `($|[^A-Za-z0-9-])`)
+	ownMetadata := filepath.Join(newDir, "metadata.yml")
+	err := filepath.WalkDir(filepath.Join(s.root, "projects"), func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if ext := filepath.Ext(path); ext != ".yml" && ext != ".md" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(content), oldID) {
+			return nil
+		}
+		replace := func(text []byte) []byte {
+			return referenceRE.ReplaceAll(text, []byte("${1}"+newID+"${2}"))
+		}
+		if path == ownMetadata {
+			return os.WriteFile(path, replaceOutsideGit(content, replace), 0644)
+		}
+		return os.WriteFile(path, replace(content), 0644)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update references to %s: %w", oldID, err)
+	}
+
+	return newID, nil
+}
+
+// replaceOutsideGit applies replace to the lines of a task's metadata.yml
+// that aren't part of its git block
+func replaceOutsideGit(content []byte, replace func([]byte) []byte) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	inGit := false
+	var out strings.Builder
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if trimmed != "" && trimmed[0] != ' ' && trimmed[0] != '\t' {
+			inGit = strings.HasPrefix(trimmed, "git:")
+		}
+		if inGit {
+			out.WriteString(line)
+		} else {
+			out.Write(replace([]byte(line)))
+		}
+	}
+	return []byte(out.String())
+}
@@ -0,0 +1,291 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mkanban/internal/domain/service"
+)
+
+const syncBoardDir = "projects/shop/boards/web"
+
+// writeDataFile writes a file of a data directory
+func writeDataFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	path = filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readDataFile reads a file of a data directory
+func readDataFile(t *testing.T, root, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// writeSyncTask writes the folder of a task of the test board
+func writeSyncTask(t *testing.T, root, column, id, metadata, body string) {
+	t.Helper()
+	dir := syncBoardDir + "/columns/" + column + "/tasks/" + id
+	writeDataFile(t, root, dir+"/metadata.yml", metadata)
+	writeDataFile(t, root, dir+"/task.md", body)
+}
+
+// newSyncedMachines returns two data directories syncing through the same
+// bare repository, both holding the test board with task WEB-001
+func newSyncedMachines(t *testing.T) (*GitDataSyncer, *GitDataSyncer, string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	git(t, dir, "init", "--bare", "--initial-branch=main", remote)
+
+	first := filepath.Join(dir, "first")
+	writeDataFile(t, first, syncBoardDir+"/metadata.yml",
+		"id: shop/web\nprefix: WEB\nmodified: \"2024-01-01T09:00:00Z\"\nnext_task_num: 2\n")
+	writeSyncTask(t, first, "todo", "WEB-001-checkout",
+		"id: WEB-001\ncreated: 2024-01-01T09:00:00Z\nmodified: 2024-01-01T09:00:00Z\npriority: none\ntags:\n    - payments\n",
+		"# Checkout\n\nPay by card\n\nShip it\n")
+	writeDataFile(t, first, "mkanban.pid", "1234")
+
+	firstSyncer := NewGitDataSyncer(first, "main", false)
+	if err := firstSyncer.Init(ctx, remote); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	second := filepath.Join(dir, "second")
+	secondSyncer := NewGitDataSyncer(second, "main", false)
+	if err := secondSyncer.Init(ctx, remote); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secondSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return firstSyncer, secondSyncer, first, second
+}
+
+func TestGitDataSyncerPullsAndPushes(t *testing.T) {
+	firstSyncer, secondSyncer, first, second := newSyncedMachines(t)
+	ctx := context.Background()
+
+	if !strings.Contains(readDataFile(t, second, syncBoardDir+"/columns/todo/tasks/WEB-001-checkout/task.md"), "Pay by card") {
+		t.Fatal("second machine didn't get the task")
+	}
+	if _, err := os.Stat(filepath.Join(second, "mkanban.pid")); !os.IsNotExist(err) {
+		t.Error("machine-local file was synced")
+	}
+
+	writeDataFile(t, second, syncBoardDir+"/columns/todo/tasks/WEB-001-checkout/task.md", "# Checkout\n\nPay by card or invoice\n\nShip it\n")
+	result, err := secondSyncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Committed || result.Pushed != 1 {
+		t.Errorf("result = %+v, want a commit pushed", result)
+	}
+
+	status, err := firstSyncer.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Initialized || status.Pending != 0 || status.Ahead != 0 {
+		t.Errorf("status = %+v, want a clean repository", status)
+	}
+
+	if result, err = firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if result.Pulled == 0 || !strings.Contains(readDataFile(t, first, syncBoardDir+"/columns/todo/tasks/WEB-001-checkout/task.md"), "or invoice") {
+		t.Errorf("result = %+v, want the edit pulled", result)
+	}
+}
+
+func TestGitDataSyncerMergesFieldByField(t *testing.T) {
+	firstSyncer, secondSyncer, first, second := newSyncedMachines(t)
+	ctx := context.Background()
+	task := syncBoardDir + "/columns/todo/tasks/WEB-001-checkout"
+
+	// The first machine raises the priority and edits the first line, the
+	// second tags the task and edits the last line an hour later
+	writeSyncTask(t, first, "todo", "WEB-001-checkout",
+		"id: WEB-001\ncreated: 2024-01-01T09:00:00Z\nmodified: 2024-01-02T10:00:00Z\npriority: high\ntags:\n    - payments\n",
+		"# Checkout\n\nPay by card or invoice\n\nShip it\n")
+	writeSyncTask(t, second, "todo", "WEB-001-checkout",
+		"id: WEB-001\ncreated: 2024-01-01T09:00:00Z\nmodified: 2024-01-02T11:00:00Z\npriority: none\ntags:\n    - payments\n    - ui\n",
+		"# Checkout\n\nPay by card\n\nShip it on Friday\n")
+
+	if _, err := firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	result, err := secondSyncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Merged) == 0 {
+		t.Errorf("result = %+v, want merged files", result)
+	}
+
+	metadata := readDataFile(t, second, task+"/metadata.yml")
+	for _, want := range []string{"priority: high", "- ui", "- payments", "modified: 2024-01-02T11:00:00Z"} {
+		if !strings.Contains(metadata, want) {
+			t.Errorf("merged metadata lacks %q:\n%s", want, metadata)
+		}
+	}
+	if body := readDataFile(t, second, task+"/task.md"); body != "# Checkout\n\nPay by card or invoice\n\nShip it on Friday\n" {
+		t.Errorf("merged body = %q", body)
+	}
+	if strings.Contains(git(t, second, "status", "--porcelain"), "UU") {
+		t.Error("merge left conflicts")
+	}
+}
+
+func TestGitDataSyncerRenumbersClashingTasks(t *testing.T) {
+	firstSyncer, secondSyncer, first, second := newSyncedMachines(t)
+	ctx := context.Background()
+	board := syncBoardDir + "/metadata.yml"
+
+	// Both machines create task 2 offline; the second also makes it the
+	// parent of a new task 3
+	writeDataFile(t, first, board, "id: shop/web\nprefix: WEB\nmodified: \"2024-01-02T10:00:00Z\"\nnext_task_num: 3\n")
+	writeSyncTask(t, first, "todo", "WEB-002-login", "id: WEB-002\ncreated: 2024-01-02T10:00:00Z\nmodified: 2024-01-02T10:00:00Z\n", "# Login\n")
+	writeDataFile(t, second, board, "id: shop/web\nprefix: WEB\nmodified: \"2024-01-02T11:00:00Z\"\nnext_task_num: 4\n")
+	writeSyncTask(t, second, "todo", "WEB-002-search",
+		"id: WEB-002\ncreated: 2024-01-02T11:00:00Z\nmodified: 2024-01-02T11:00:00Z\ngit:\n  branch: WEB-002-search\n", "# Search\n")
+	writeSyncTask(t, second, "doing", "WEB-003-filters",
+		"id: WEB-003\nparent_id: WEB-002-search\ncreated: 2024-01-02T11:00:00Z\nmodified: 2024-01-02T11:00:00Z\n", "# Filters\n\nPart of WEB-002-search.\n")
+
+	if _, err := firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	result, err := secondSyncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := service.RenumberedTask{BoardID: "shop/web", OldID: "WEB-002-search", NewID: "WEB-004-search", Branch: "WEB-002-search"}
+	if len(result.Renumbered) != 1 || result.Renumbered[0] != want {
+		t.Fatalf("renumbered = %+v, want %+v", result.Renumbered, want)
+	}
+
+	renumbered := syncBoardDir + "/columns/todo/tasks/WEB-004-search/metadata.yml"
+	if metadata := readDataFile(t, second, renumbered); !strings.Contains(metadata, "id: WEB-004\n") ||
+		!strings.Contains(metadata, "branch: WEB-002-search\n") {
+		t.Errorf("renumbered metadata = %q, want the new ID and the branch kept", metadata)
+	}
+	child := syncBoardDir + "/columns/doing/tasks/WEB-003-filters"
+	if !strings.Contains(readDataFile(t, second, child+"/metadata.yml"), "parent_id: WEB-004-search") ||
+		!strings.Contains(readDataFile(t, second, child+"/task.md"), "Part of WEB-004-search.") {
+		t.Error("references to the renumbered task weren't updated")
+	}
+	if !strings.Contains(readDataFile(t, second, board), "next_task_num: 5") {
+		t.Errorf("board = %q, want the counter past the new number", readDataFile(t, second, board))
+	}
+
+	if _, err := firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"WEB-002-login", "WEB-004-search"} {
+		if _, err := os.Stat(filepath.Join(first, syncBoardDir, "columns", "todo", "tasks", id)); err != nil {
+			t.Errorf("first machine lacks %s: %v", id, err)
+		}
+	}
+}
+
+func TestGitDataSyncerKeepsOneCopyOfMovedTask(t *testing.T) {
+	firstSyncer, secondSyncer, first, second := newSyncedMachines(t)
+	ctx := context.Background()
+	todo := filepath.Join(syncBoardDir, "columns", "todo", "tasks", "WEB-001-checkout")
+
+	if err := os.RemoveAll(filepath.Join(first, todo)); err != nil {
+		t.Fatal(err)
+	}
+	writeSyncTask(t, first, "doing", "WEB-001-checkout",
+		"id: WEB-001\ncreated: 2024-01-01T09:00:00Z\nmodified: 2024-01-02T10:00:00Z\npriority: none\n", "# Checkout\n\nPay by card\n\nShip it\n")
+	if err := os.RemoveAll(filepath.Join(second, todo)); err != nil {
+		t.Fatal(err)
+	}
+	writeSyncTask(t, second, "done", "WEB-001-checkout",
+		"id: WEB-001\ncreated: 2024-01-01T09:00:00Z\nmodified: 2024-01-02T11:00:00Z\npriority: none\n", "# Checkout\n\nPay by card\n\nShip it\n")
+
+	if _, err := firstSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secondSyncer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(second, syncBoardDir, "columns", "*", "tasks", "WEB-001-checkout"))
+	if len(matches) != 1 || filepath.Base(filepath.Dir(filepath.Dir(matches[0]))) != "done" {
+		t.Errorf("task copies = %v, want the one moved last", matches)
+	}
+}
+
+func TestGitDataSyncerRefusesDatabaseBackend(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	syncer := NewGitDataSyncer(root, "main", true)
+
+	if err := syncer.Init(ctx, ""); !errors.Is(err, service.ErrSyncDatabaseBackend) {
+		t.Errorf("Init() error = %v, want ErrSyncDatabaseBackend", err)
+	}
+	if _, err := syncer.Sync(ctx); !errors.Is(err, service.ErrSyncDatabaseBackend) {
+		t.Errorf("Sync() error = %v, want ErrSyncDatabaseBackend", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git")); !os.IsNotExist(err) {
+		t.Error("data directory was made a repository")
+	}
+}
+
+func TestMergeYAML(t *testing.T) {
+	base := "id: WEB-001\nmodified: 2024-01-01T09:00:00Z\ntitle: Old\ntags:\n    - a\n    - b\n"
+	ours := "id: WEB-001\nmodified: 2024-01-02T09:00:00Z\ntitle: Ours\ntags:\n    - a\n"
+	theirs := "id: WEB-001\nmodified: 2024-01-03T09:00:00Z\ntitle: Theirs\ntags:\n    - a\n    - b\n    - c\nstatus: done\n"
+
+	merged, err := mergeYAML([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id: WEB-001\nmodified: 2024-01-03T09:00:00Z\ntitle: Theirs\ntags:\n    - a\n    - c\nstatus: done\n"
+	if string(merged) != want {
+		t.Errorf("merged =\n%s\nwant\n%s", merged, want)
+	}
+
+	logs := func(entries ...string) []byte {
+		return []byte("logs:\n" + strings.Join(entries, ""))
+	}
+	first := "    - id: l1\n      modified: 2024-01-01T09:00:00Z\n      description: first\n"
+	second := "    - id: l2\n      modified: 2024-01-01T09:00:00Z\n"
+	third := "    - id: l3\n      modified: 2024-01-01T09:00:00Z\n"
+	edited := "    - id: l1\n      modified: 2024-01-01T10:00:00Z\n      description: edited\n"
+
+	merged, err = mergeYAML(logs(first), logs(first, second), logs(edited, third))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := string(logs(edited, second, third)); string(merged) != want {
+		t.Errorf("merged logs =\n%s\nwant\n%s", merged, want)
+	}
+}
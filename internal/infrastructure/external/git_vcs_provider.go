@@ -2,11 +2,16 @@ package external
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
 )
 
 // GitVCSProvider implements VCSProvider for Git
@@ -48,6 +53,37 @@ func (g *GitVCSProvider) GetRepositoryRoot(path string) (string, error) {
 	return root, nil
 }
 
+// GetMainRepositoryRoot returns the root directory of the main working tree,
+// also from inside a linked worktree. For bare repositories it returns the
+// repository directory.
+func (g *GitVCSProvider) GetMainRepositoryRoot(path string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = path
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	commonDir := strings.TrimSpace(out.String())
+	if commonDir == "" {
+		return "", fmt.Errorf("empty git directory for path: %s", path)
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(path, commonDir)
+	}
+	commonDir = filepath.Clean(commonDir)
+
+	// The main working tree holds the common .git directory
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir), nil
+	}
+	return commonDir, nil
+}
+
 // GetCurrentBranch returns the name of the currently checked out branch
 func (g *GitVCSProvider) GetCurrentBranch(repoPath string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
@@ -116,8 +152,9 @@ func (g *GitVCSProvider) ListBranches(repoPath string) ([]string, error) {
 
 // GetRefsPath returns the path to the git refs directory for watching
 func (g *GitVCSProvider) GetRefsPath(repoPath string) string {
-	// Get the .git directory
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
+	// Get the .git directory, shared by all worktrees, which have their own
+	// git directory without refs/heads
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
 	cmd.Dir = repoPath
 
 	var out bytes.Buffer
@@ -194,3 +231,300 @@ func (g *GitVCSProvider) CreateAndCheckoutBranch(repoPath, branchName string) er
 
 	return nil
 }
+
+// ListWorktrees returns the directory of every worktree by its branch
+func (g *GitVCSProvider) ListWorktrees(repoPath string) (map[string]string, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = repoPath
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w - %s", err, stderr.String())
+	}
+
+	// Worktrees are blocks of "key value" lines separated by blank lines
+	worktrees := make(map[string]string)
+	path := ""
+	for _, line := range strings.Split(out.String(), "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "worktree":
+			path = value
+		case "branch":
+			if path != "" {
+				worktrees[strings.TrimPrefix(value, "refs/heads/")] = path
+			}
+		case "":
+			path = ""
+		}
+	}
+
+	return worktrees, nil
+}
+
+// AddWorktree checks out a branch in a new worktree at path
+func (g *GitVCSProvider) AddWorktree(repoPath, path, branchName string, createBranch bool) error {
+	args := []string{"worktree", "add", path, branchName}
+	if createBranch {
+		args = []string{"worktree", "add", "-b", branchName, path}
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to add worktree for branch %s: %w - %s", branchName, err, stderr.String())
+	}
+
+	return nil
+}
+
+// ListBranchHeads returns the commit each local branch points to
+func (g *GitVCSProvider) ListBranchHeads(repoPath string) (map[string]string, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(objectname) %(refname:short)", "refs/heads")
+	cmd.Dir = repoPath
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list branch heads: %w - %s", err, stderr.String())
+	}
+
+	heads := make(map[string]string)
+	for _, line := range strings.Split(out.String(), "\n") {
+		hash, branch, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok {
+			heads[branch] = hash
+		}
+	}
+
+	return heads, nil
+}
+
+// ListCommits returns the commits reachable from included but not from
+// excluded, newest first
+func (g *GitVCSProvider) ListCommits(repoPath string, included, excluded []string, limit int) ([]service.Commit, error) {
+	if len(included) == 0 {
+		return nil, nil
+	}
+
+	// Fields are separated by unit separators and commits by record
+	// separators, which don't appear in commit messages
+	args := []string{"log", "--format=%H%x1f%an%x1f%at%x1f%s%x1f%b%x1e"}
+	if limit > 0 {
+		args = append(args, "--max-count="+strconv.Itoa(limit))
+	}
+	args = append(args, included...)
+	if len(excluded) > 0 {
+		args = append(args, "--not")
+		args = append(args, excluded...)
+	}
+	args = append(args, "--")
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w - %s", err, stderr.String())
+	}
+
+	var commits []service.Commit
+	for _, record := range strings.Split(out.String(), "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, service.Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Time:    time.Unix(seconds, 0),
+			Subject: fields[3],
+			Body:    strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return commits, nil
+}
+
+// GetDefaultBranch returns the branch origin's HEAD points to, falling back
+// to main or master
+func (g *GitVCSProvider) GetDefaultBranch(repoPath string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoPath
+
+	if out, err := cmd.Output(); err == nil {
+		branch := strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/")
+		if exists, _ := g.BranchExists(repoPath, branch); exists {
+			return branch, nil
+		}
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if exists, _ := g.BranchExists(repoPath, branch); exists {
+			return branch, nil
+		}
+	}
+
+	return "", fmt.Errorf("no default branch found in %s", repoPath)
+}
+
+// FindMergeCommit follows the first parents of target, the commits made on
+// it, back to the first one containing the head of branch. That commit is
+// the merge, unless its parent contains the head too, which was then
+// fast-forwarded onto target. A branch only counts as fast-forwarded when it
+// has commits of its own; one still at the commit it was created at was
+// never worked on.
+func (g *GitVCSProvider) FindMergeCommit(repoPath, branch, target string) (string, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", branch, target)
+	cmd.Dir = repoPath
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to check whether %s is merged into %s: %w", branch, target, err)
+	}
+
+	descendants, err := g.git(repoPath, "rev-list", "--first-parent", "--ancestry-path", "--reverse", branch+".."+target)
+	if err != nil {
+		return "", err
+	}
+	if merge, _, _ := strings.Cut(descendants, "\n"); merge != "" {
+		cmd := exec.Command("git", "merge-base", "--is-ancestor", branch, merge+"^1")
+		cmd.Dir = repoPath
+		if cmd.Run() != nil {
+			return merge, nil
+		}
+	}
+
+	// Fast-forwarded, the branch head is on target
+	head, err := g.git(repoPath, "rev-parse", branch)
+	if err != nil {
+		return "", err
+	}
+	moved, err := g.movedSinceCreated(repoPath, branch, head)
+	if err != nil || !moved {
+		return "", err
+	}
+	return head, nil
+}
+
+// movedSinceCreated reports whether branch has left head, the commit it
+// points at, since it was created, going by its reflog. Without a reflog
+// there's no telling, so it reports false.
+func (g *GitVCSProvider) movedSinceCreated(repoPath, branch, head string) (bool, error) {
+	reflog, err := g.git(repoPath, "reflog", "show", "--format=%H", "refs/heads/"+branch, "--")
+	if err != nil {
+		return false, err
+	}
+	entries := strings.Fields(reflog)
+	if len(entries) == 0 {
+		return false, nil
+	}
+	// The oldest entry is the commit the branch was created at
+	return entries[len(entries)-1] != head, nil
+}
+
+// FindSquashMerge compares patch IDs, which stay the same when a change is
+// applied on another base
+func (g *GitVCSProvider) FindSquashMerge(repoPath, branch, target string) (string, error) {
+	base, err := g.git(repoPath, "merge-base", branch, target)
+	if err != nil {
+		return "", err
+	}
+
+	// Patch IDs of the commits target got since branch forked from it
+	targetPatches, err := g.patchIDs(repoPath, "log", "-p", "--no-merges", "--max-count="+strconv.Itoa(squashScanLimit), base+".."+target)
+	if err != nil {
+		return "", err
+	}
+
+	branchDiff, err := g.patchIDs(repoPath, "diff", base, branch)
+	if err != nil {
+		return "", err
+	}
+	if len(branchDiff) == 0 {
+		// Nothing to merge
+		return "", nil
+	}
+
+	branchPatches, err := g.patchIDs(repoPath, "log", "-p", "--no-merges", base+".."+branch)
+	if err != nil {
+		return "", err
+	}
+	return matchSquash(targetPatches, branchDiff[0][0], branchPatches), nil
+}
+
+// DeleteBranch deletes a local branch
+func (g *GitVCSProvider) DeleteBranch(repoPath, branchName string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	if _, err := g.git(repoPath, "branch", flag, branchName); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// squashScanLimit bounds the commits of the target branch searched for a
+// squash merge
+const squashScanLimit = 1000
+
+// patchIDs pipes the patches git prints for args through git patch-id and
+// returns its pairs of patch ID and commit
+func (g *GitVCSProvider) patchIDs(repoPath string, args ...string) ([][2]string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	patches, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patches: %w", err)
+	}
+
+	cmd = exec.Command("git", "patch-id", "--stable")
+	cmd.Dir = repoPath
+	cmd.Stdin = bytes.NewReader(patches)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute patch IDs: %w", err)
+	}
+
+	var ids [][2]string
+	for _, line := range strings.Split(string(out), "\n") {
+		if patch, commit, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			ids = append(ids, [2]string{patch, commit})
+		}
+	}
+	return ids, nil
+}
+
+// git runs a git command in repoPath and returns its trimmed output
+func (g *GitVCSProvider) git(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w - %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(out.String()), nil
+}
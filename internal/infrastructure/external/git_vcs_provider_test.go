@@ -0,0 +1,231 @@
+package external
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// git runs a git command in dir and returns its trimmed output
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Ann", "GIT_AUTHOR_EMAIL=ann@example.com",
+		"GIT_COMMITTER_NAME=Ann", "GIT_COMMITTER_EMAIL=ann@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newBareRepo pushes a main branch and a task branch to a bare repository
+// and returns the path of the bare repository
+func newBareRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	bare := filepath.Join(dir, "origin.git")
+	work := filepath.Join(dir, "work")
+	git(t, dir, "init", "--bare", "--initial-branch=main", bare)
+	git(t, dir, "init", "--initial-branch=main", work)
+
+	git(t, work, "commit", "--allow-empty", "-m", "Initial commit")
+	git(t, work, "checkout", "-b", "PRO-12-login")
+	git(t, work, "commit", "--allow-empty", "-m", "Add login form", "-m", "Part of PRO-12.")
+	git(t, work, "commit", "--allow-empty", "-m", "Fixes PRO-12: redirect")
+	git(t, work, "push", bare, "main", "PRO-12-login")
+	return bare
+}
+
+func TestGitVCSProviderCommits(t *testing.T) {
+	bare := newBareRepo(t)
+	provider := NewGitVCSProvider()
+
+	if !provider.IsRepository(bare) {
+		t.Fatal("a bare repository should be a repository")
+	}
+
+	heads, err := provider.ListBranchHeads(bare)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heads) != 2 || heads["main"] == "" || heads["PRO-12-login"] == "" {
+		t.Fatalf("heads = %v, want main and PRO-12-login", heads)
+	}
+
+	// The task branch without what main has
+	commits, err := provider.ListCommits(bare, []string{heads["PRO-12-login"]}, []string{heads["main"]}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	latest, first := commits[0], commits[1]
+	if latest.Subject != "Fixes PRO-12: redirect" || first.Subject != "Add login form" {
+		t.Errorf("subjects = %q, %q", latest.Subject, first.Subject)
+	}
+	if first.Body != "Part of PRO-12." || first.Author != "Ann" || first.Time.IsZero() || len(first.Hash) != 40 {
+		t.Errorf("commit = %+v", first)
+	}
+
+	// The limit keeps the newest commits
+	commits, err = provider.ListCommits(bare, []string{heads["PRO-12-login"]}, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].Hash != latest.Hash {
+		t.Errorf("limited commits = %+v, want only the latest", commits)
+	}
+}
+
+func TestGitVCSProviderWorktrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(dir, "shop")
+	git(t, dir, "init", "--initial-branch=main", repo)
+	git(t, repo, "commit", "--allow-empty", "-m", "Initial commit")
+	git(t, repo, "branch", "PRO-7-cart")
+
+	provider := NewGitVCSProvider()
+	cart := filepath.Join(dir, "shop.worktrees", "PRO-7-cart")
+	login := filepath.Join(dir, "shop.worktrees", "PRO-8-login")
+	if err := provider.AddWorktree(repo, cart, "PRO-7-cart", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.AddWorktree(repo, login, "PRO-8-login", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.AddWorktree(repo, filepath.Join(dir, "again"), "PRO-7-cart", false); err == nil {
+		t.Error("checking out a branch in a second worktree should fail")
+	}
+
+	worktrees, err := provider.ListWorktrees(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"main": repo, "PRO-7-cart": cart, "PRO-8-login": login}
+	if len(worktrees) != len(want) {
+		t.Errorf("worktrees = %v, want %v", worktrees, want)
+	}
+	for branch, path := range want {
+		if worktrees[branch] != path {
+			t.Errorf("worktree of %s = %q, want %q", branch, worktrees[branch], path)
+		}
+	}
+
+	// Every worktree, and directories inside it, lead back to the main one
+	web := filepath.Join(login, "web")
+	if err := os.Mkdir(web, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{repo, cart, web} {
+		root, err := provider.GetMainRepositoryRoot(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root != repo {
+			t.Errorf("main root of %s = %q, want %q", path, root, repo)
+		}
+	}
+	if branch, err := provider.GetCurrentBranch(cart); err != nil || branch != "PRO-7-cart" {
+		t.Errorf("branch of the worktree = %q, %v", branch, err)
+	}
+	if refs := provider.GetRefsPath(cart); refs != filepath.Join(repo, ".git", "refs", "heads") {
+		t.Errorf("refs path of the worktree = %q", refs)
+	}
+}
+
+func TestGitVCSProviderMerges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git(t, repo, "init", "--initial-branch=main")
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git(t, repo, "add", name)
+	}
+	write("README", "shop\n")
+	git(t, repo, "commit", "-m", "Initial commit")
+
+	// Merged with a merge commit, fast-forwarded, squashed, rebased and not
+	// merged at all
+	for _, branch := range []string{"merged", "ff", "squashed", "rebased", "open"} {
+		git(t, repo, "checkout", "-q", "-b", branch, "main")
+		write(branch+"-1", "one\n")
+		git(t, repo, "commit", "-m", branch+" one")
+		write(branch+"-2", "two\n")
+		git(t, repo, "commit", "-m", branch+" two")
+	}
+	// Created off main but never worked on
+	git(t, repo, "branch", "fresh", "main")
+	git(t, repo, "checkout", "-q", "main")
+	git(t, repo, "merge", "--ff-only", "ff")
+	git(t, repo, "merge", "--no-ff", "-m", "Merge branch merged", "merged")
+	git(t, repo, "merge", "--squash", "squashed")
+	git(t, repo, "commit", "-m", "Squashed")
+	squashCommit := git(t, repo, "rev-parse", "HEAD")
+	git(t, repo, "cherry-pick", "main..rebased")
+	rebaseCommit := git(t, repo, "rev-parse", "HEAD")
+	mergeCommit := git(t, repo, "rev-parse", "HEAD^{/Merge branch merged}")
+
+	provider := NewGitVCSProvider()
+	if branch, err := provider.GetDefaultBranch(repo); err != nil || branch != "main" {
+		t.Errorf("default branch = %q, %v, want main", branch, err)
+	}
+
+	merges := map[string]string{
+		"merged":   mergeCommit,
+		"ff":       git(t, repo, "rev-parse", "ff"),
+		"squashed": "",
+		"rebased":  "",
+		"open":     "",
+		"fresh":    "",
+	}
+	for branch, want := range merges {
+		if got, err := provider.FindMergeCommit(repo, branch, "main"); err != nil || got != want {
+			t.Errorf("merge commit of %s = %q, %v, want %q", branch, got, err, want)
+		}
+	}
+
+	squashes := map[string]string{
+		"squashed": squashCommit,
+		"rebased":  rebaseCommit,
+		"open":     "",
+	}
+	for branch, want := range squashes {
+		if got, err := provider.FindSquashMerge(repo, branch, "main"); err != nil || got != want {
+			t.Errorf("squash merge of %s = %q, %v, want %q", branch, got, err, want)
+		}
+	}
+
+	if err := provider.DeleteBranch(repo, "squashed", false); err == nil {
+		t.Error("deleting a squashed branch without force should fail")
+	}
+	if err := provider.DeleteBranch(repo, "squashed", true); err != nil {
+		t.Error(err)
+	}
+	if exists, _ := provider.BranchExists(repo, "squashed"); exists {
+		t.Error("squashed branch should be deleted")
+	}
+}
@@ -0,0 +1,365 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// HgVCSProvider implements VCSProvider for Mercurial. Bookmarks are the
+// branches, as they're what a branch-per-task workflow uses in Mercurial;
+// named branches are permanent and only stand in for the current branch
+// when no bookmark is active. Shares, made with the share extension that
+// comes with Mercurial, are the worktrees.
+type HgVCSProvider struct{}
+
+// NewHgVCSProvider creates a new HgVCSProvider
+func NewHgVCSProvider() *HgVCSProvider {
+	return &HgVCSProvider{}
+}
+
+// IsRepository checks if the given path is inside a Mercurial repository
+func (h *HgVCSProvider) IsRepository(path string) bool {
+	_, err := h.hg(path, "root")
+	return err == nil
+}
+
+// GetRepositoryRoot returns the root directory of the repository
+func (h *HgVCSProvider) GetRepositoryRoot(path string) (string, error) {
+	root, err := h.hg(path, "root")
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	if root == "" {
+		return "", fmt.Errorf("empty repository root for path: %s", path)
+	}
+	return root, nil
+}
+
+// GetMainRepositoryRoot returns the root of the repository a share was made
+// from, or the repository's own root
+func (h *HgVCSProvider) GetMainRepositoryRoot(path string) (string, error) {
+	root, err := h.GetRepositoryRoot(path)
+	if err != nil {
+		return "", err
+	}
+
+	// A share's .hg/sharedpath holds the .hg directory of its source
+	data, err := os.ReadFile(filepath.Join(root, ".hg", "sharedpath"))
+	if err != nil {
+		return root, nil
+	}
+	source := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(root, ".hg", source)
+	}
+	return filepath.Dir(filepath.Clean(source)), nil
+}
+
+// GetCurrentBranch returns the active bookmark, else the named branch
+func (h *HgVCSProvider) GetCurrentBranch(repoPath string) (string, error) {
+	bookmark, err := h.hg(repoPath, "log", "-r", ".", "-T", "{activebookmark}")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if bookmark != "" {
+		return bookmark, nil
+	}
+
+	branch, err := h.hg(repoPath, "branch")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return branch, nil
+}
+
+// ListBranches returns the bookmarks of the repository
+func (h *HgVCSProvider) ListBranches(repoPath string) ([]string, error) {
+	heads, err := h.ListBranchHeads(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(heads), nil
+}
+
+// GetRefsPath returns the .hg directory of the main repository, where
+// Mercurial rewrites the bookmarks file when they change
+func (h *HgVCSProvider) GetRefsPath(repoPath string) string {
+	root, err := h.GetMainRepositoryRoot(repoPath)
+	if err != nil {
+		return ""
+	}
+
+	hgDir := filepath.Join(root, ".hg")
+	if _, err := os.Stat(hgDir); err != nil {
+		return ""
+	}
+	return hgDir
+}
+
+// BranchExists checks if a bookmark with the given name exists
+func (h *HgVCSProvider) BranchExists(repoPath, branchName string) (bool, error) {
+	heads, err := h.ListBranchHeads(repoPath)
+	if err != nil {
+		return false, err
+	}
+	_, exists := heads[branchName]
+	return exists, nil
+}
+
+// CheckoutBranch updates to a bookmark, which activates it
+func (h *HgVCSProvider) CheckoutBranch(repoPath, branchName string) error {
+	if _, err := h.hg(repoPath, "update", branchName); err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// CreateAndCheckoutBranch creates an active bookmark at the working
+// directory's parent
+func (h *HgVCSProvider) CreateAndCheckoutBranch(repoPath, branchName string) error {
+	if _, err := h.hg(repoPath, "bookmark", branchName); err != nil {
+		return fmt.Errorf("failed to create and checkout branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// ListBranchHeads returns the changeset each bookmark points to
+func (h *HgVCSProvider) ListBranchHeads(repoPath string) (map[string]string, error) {
+	out, err := h.hg(repoPath, "bookmarks", "-T", "{bookmark} {node}\n")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch heads: %w", err)
+	}
+
+	heads := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		branch, hash, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok {
+			heads[branch] = hash
+		}
+	}
+	return heads, nil
+}
+
+// ListWorktrees returns the active bookmark of this working directory. A
+// repository doesn't know its shares, so they aren't listed.
+func (h *HgVCSProvider) ListWorktrees(repoPath string) (map[string]string, error) {
+	root, err := h.GetRepositoryRoot(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	bookmark, err := h.hg(repoPath, "log", "-r", ".", "-T", "{activebookmark}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	worktrees := make(map[string]string)
+	if bookmark != "" {
+		worktrees[bookmark] = root
+	}
+	return worktrees, nil
+}
+
+// AddWorktree shares the repository at path, with its bookmarks, and
+// updates the share to the bookmark, creating it at the repository's
+// working directory parent if createBranch is set
+func (h *HgVCSProvider) AddWorktree(repoPath, path, branchName string, createBranch bool) error {
+	root, err := h.GetRepositoryRoot(repoPath)
+	if err != nil {
+		return err
+	}
+	parent, err := h.hg(repoPath, "log", "-r", ".", "-T", "{node}")
+	if err != nil {
+		return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+	}
+
+	if _, err := h.hg(repoPath, "--config", "extensions.share=", "share", "--bookmarks", "--noupdate", root, path); err != nil {
+		return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+	}
+
+	if createBranch {
+		if _, err := h.hg(path, "update", "--rev", parent); err != nil {
+			return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+		}
+		if _, err := h.hg(path, "bookmark", branchName); err != nil {
+			return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+		}
+		return nil
+	}
+
+	if _, err := h.hg(path, "update", branchName); err != nil {
+		return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// ListCommits returns the changesets that are ancestors of included but
+// not of excluded, newest first
+func (h *HgVCSProvider) ListCommits(repoPath string, included, excluded []string, limit int) ([]service.Commit, error) {
+	if len(included) == 0 {
+		return nil, nil
+	}
+
+	revset := "(::" + revsetUnion(included) + ")"
+	if len(excluded) > 0 {
+		revset += " - (::" + revsetUnion(excluded) + ")"
+	}
+	args := []string{"log", "-r", "reverse(" + revset + ")",
+		"-T", `{node}\x1f{author|person}\x1f{date|hgdate}\x1f{desc}\x1e`}
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+
+	out, err := h.hg(repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var commits []service.Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		// hgdate is the Unix time and the timezone offset
+		date := strings.Fields(fields[2])
+		if len(date) == 0 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(date[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		subject, body, _ := strings.Cut(fields[3], "\n")
+		commits = append(commits, service.Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Time:    time.Unix(seconds, 0),
+			Subject: subject,
+			Body:    strings.TrimSpace(body),
+		})
+	}
+
+	return commits, nil
+}
+
+// GetDefaultBranch returns the @ bookmark Mercurial checks out on clone,
+// else main or master, else the default named branch
+func (h *HgVCSProvider) GetDefaultBranch(repoPath string) (string, error) {
+	heads, err := h.ListBranchHeads(repoPath)
+	if err != nil {
+		return "", err
+	}
+	for _, bookmark := range []string{"@", "main", "master"} {
+		if _, ok := heads[bookmark]; ok {
+			return bookmark, nil
+		}
+	}
+
+	if tip, err := h.hg(repoPath, "log", "-r", `"default"`, "-T", "{node}"); err == nil && tip != "" {
+		return "default", nil
+	}
+	return "", fmt.Errorf("no default branch found in %s", repoPath)
+}
+
+// FindMergeCommit returns the oldest merge of target with the head of
+// branch as a parent, else the head itself if target contains it, which
+// then moved along with target
+func (h *HgVCSProvider) FindMergeCommit(repoPath, branch, target string) (string, error) {
+	head := strconv.Quote(branch)
+	onTarget := "(::" + strconv.Quote(target) + ")"
+
+	merged, err := h.hg(repoPath, "log", "-r", head+" and "+onTarget, "-T", "{node}")
+	if err != nil {
+		return "", fmt.Errorf("failed to check whether %s is merged into %s: %w", branch, target, err)
+	}
+	if merged == "" {
+		return "", nil
+	}
+
+	merge, err := h.hg(repoPath, "log", "-r", "min(children("+head+") and merge() and "+onTarget+")", "-T", "{node}")
+	if err != nil {
+		return "", err
+	}
+	if merge != "" {
+		return merge, nil
+	}
+	return merged, nil
+}
+
+// FindSquashMerge compares patch IDs of the changesets target got since
+// branch forked from it with those of branch, see matchSquash
+func (h *HgVCSProvider) FindSquashMerge(repoPath, branch, target string) (string, error) {
+	head := strconv.Quote(branch)
+	base, err := h.hg(repoPath, "log", "-r", "ancestor("+head+", "+strconv.Quote(target)+")", "-T", "{node}")
+	if err != nil {
+		return "", err
+	}
+	if base == "" {
+		return "", nil
+	}
+	// Changesets of a head since the fork, without merges
+	since := func(revision string) string {
+		return "reverse((::" + revision + ") - (::" + base + ") - merge())"
+	}
+
+	targetLog, err := h.hg(repoPath, "log", "--patch", "--git",
+		"-r", "limit("+since(strconv.Quote(target))+", "+strconv.Itoa(squashScanLimit)+")",
+		"-T", `\x1e{node}\n`)
+	if err != nil {
+		return "", err
+	}
+	branchDiff, err := h.hg(repoPath, "diff", "--git", "-r", base, "-r", head)
+	if err != nil {
+		return "", err
+	}
+	branchLog, err := h.hg(repoPath, "log", "--patch", "--git", "-r", since(head), "-T", `\x1e{node}\n`)
+	if err != nil {
+		return "", err
+	}
+
+	return matchSquash(splitPatches(targetLog), patchID(branchDiff), splitPatches(branchLog)), nil
+}
+
+// DeleteBranch deletes a bookmark. Unless force is set it refuses bookmarks
+// the working directory's parent doesn't contain.
+func (h *HgVCSProvider) DeleteBranch(repoPath, branchName string, force bool) error {
+	if !force {
+		merged, err := h.hg(repoPath, "log", "-r", strconv.Quote(branchName)+" and ::.", "-T", "{node}")
+		if err != nil {
+			return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+		}
+		if merged == "" {
+			return fmt.Errorf("failed to delete branch %s: not merged", branchName)
+		}
+	}
+
+	if _, err := h.hg(repoPath, "bookmark", "--delete", branchName); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// hg runs a Mercurial command in repoPath and returns its trimmed output.
+// HGPLAIN keeps user settings such as aliases and localization out of it.
+func (h *HgVCSProvider) hg(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("hg", args...)
+	cmd.Dir = repoPath
+	cmd.Env = append(os.Environ(), "HGPLAIN=1")
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("hg %s: %w - %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(out.String()), nil
+}
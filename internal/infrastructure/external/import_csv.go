@@ -0,0 +1,220 @@
+package external
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// csvFields are the task fields read from a CSV file, with the headers
+// they are read from unless mapped to another
+var csvFields = map[string][]string{
+	"id":          {"id", "key", "issue key"},
+	"title":       {"title", "name", "summary"},
+	"description": {"description", "body", "notes"},
+	"column":      {"column", "status", "list", "state"},
+	"board":       {"board"},
+	"project":     {"project"},
+	"priority":    {"priority"},
+	"tags":        {"tags", "labels"},
+	"due":         {"due", "due date", "deadline"},
+	"created":     {"created", "created at"},
+	"checklist":   {"checklist", "subtasks"},
+}
+
+// csvDateFormats are the date formats read from CSV files
+var csvDateFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006",
+}
+
+// csvDefaultName names the board and project of rows that don't name theirs
+const csvDefaultName = "Imported"
+
+// parseCSV reads a CSV file with a header row and a task per row. Fields
+// are read from the columns given by fields or else from the headers named
+// in csvFields. Rows are grouped into boards by their project and board,
+// and their columns are the board's columns in order of appearance. Tags
+// and checklist items are separated by commas or semicolons, and checklist
+// items starting with "[x]" are done. Rows without an ID are identified by
+// their title.
+func parseCSV(data []byte, fields map[string]string) ([]service.ImportedBoard, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid CSV: no header row")
+	}
+
+	columns, err := csvColumns(records[0], fields)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("no title column in CSV, map one with title=<header>")
+	}
+
+	var boards []service.ImportedBoard
+	index := make(map[string]int)
+	for n, record := range records[1:] {
+		value := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		title := value("title")
+		if title == "" {
+			continue
+		}
+
+		project := firstNonEmpty(value("project"), csvDefaultName)
+		boardName := firstNonEmpty(value("board"), csvDefaultName)
+		key := project + "\x00" + boardName
+		i, ok := index[key]
+		if !ok {
+			i = len(boards)
+			index[key] = i
+			boards = append(boards, service.ImportedBoard{Source: "csv", Project: project, Name: boardName})
+		}
+		board := &boards[i]
+
+		task := service.ImportedTask{
+			SourceID:    firstNonEmpty(value("id"), "title:"+title),
+			Title:       title,
+			Description: value("description"),
+			Column:      firstNonEmpty(value("column"), "To Do"),
+			Priority:    strings.ToLower(value("priority")),
+			Tags:        splitList(value("tags")),
+		}
+		// Rows are numbered like spreadsheets do, the header being row 1
+		if task.DueDate, err = parseCSVDate(value("due")); err != nil {
+			return nil, fmt.Errorf("row %d: invalid due date: %w", n+2, err)
+		}
+		created, err := parseCSVDate(value("created"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid created date: %w", n+2, err)
+		}
+		if created != nil {
+			task.CreatedAt = *created
+		}
+		for _, item := range splitList(value("checklist")) {
+			title, done := strings.CutPrefix(item, "[x]")
+			if !done {
+				title = strings.TrimPrefix(item, "[ ]")
+			}
+			if title = strings.TrimSpace(title); title != "" {
+				task.Checklist = append(task.Checklist, service.ImportedChecklistItem{Title: title, Done: done})
+			}
+		}
+
+		if !containsString(board.Columns, task.Column) {
+			board.Columns = append(board.Columns, task.Column)
+		}
+		board.Tasks = append(board.Tasks, task)
+	}
+
+	return boards, nil
+}
+
+// csvColumns returns the index of the column of each field in header
+func csvColumns(header []string, fields map[string]string) (map[string]int, error) {
+	positions := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	for _, field := range sortedFieldNames(fields) {
+		if _, ok := csvFields[field]; !ok {
+			return nil, fmt.Errorf("unknown CSV field %q", field)
+		}
+		i, ok := positions[strings.ToLower(strings.TrimSpace(fields[field]))]
+		if !ok {
+			return nil, fmt.Errorf("no CSV column %q for %s", fields[field], field)
+		}
+		columns[field] = i
+	}
+	for field, headers := range csvFields {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, name := range headers {
+			if i, ok := positions[name]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	return columns, nil
+}
+
+// sortedFieldNames returns the fields of a mapping in order, so errors
+// name the same field on every run
+func sortedFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseCSVDate parses a date in any of csvDateFormats. An empty value is no
+// date.
+func parseCSVDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, format := range csvDateFormats {
+		if date, err := time.Parse(format, value); err == nil {
+			return &date, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognized date %q", value)
+}
+
+// splitList splits a cell holding a list separated by commas or semicolons
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// firstNonEmpty returns the first of values that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// containsString reports whether values holds value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
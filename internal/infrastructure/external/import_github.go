@@ -0,0 +1,211 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// githubIssue is an issue or pull request as listed by
+// gh issue list --json number,title,body,state,labels,comments,createdAt,closedAt,milestone,url
+type githubIssue struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title string     `json:"title"`
+		DueOn *time.Time `json:"dueOn"`
+	} `json:"milestone"`
+	Comments []struct {
+		ID     string `json:"id"`
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"comments"`
+}
+
+// githubProjectItem is an item as listed by gh project item-list --format json.
+// Custom fields are keys named after the field, read from Fields.
+type githubProjectItem struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Status  string   `json:"status"`
+	Labels  []string `json:"labels"`
+	Content struct {
+		Type       string `json:"type"`
+		Body       string `json:"body"`
+		Number     int    `json:"number"`
+		Repository string `json:"repository"`
+		URL        string `json:"url"`
+	} `json:"content"`
+	Fields map[string]json.RawMessage `json:"-"`
+}
+
+// githubNoStatus is the column of project items without a status, named
+// like GitHub names it
+const githubNoStatus = "No Status"
+
+// parseGitHub reads a list of GitHub issues, which become a board of the
+// repository with open and closed issues in To Do and Done, or the items of
+// a GitHub project, which become a board with a column per status. Task
+// lists in bodies become subtasks when the task is created.
+func parseGitHub(data []byte) ([]service.ImportedBoard, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return parseGitHubProject(data)
+	}
+
+	var issues []githubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("invalid GitHub export: %w", err)
+	}
+
+	board := service.ImportedBoard{
+		Source:  "github",
+		Columns: []string{"To Do", "Done"},
+	}
+	for _, issue := range issues {
+		if issue.Title == "" {
+			continue
+		}
+		owner, repo := githubRepository(issue.URL)
+		if board.Name == "" && repo != "" {
+			board.Project, board.Name, board.SourceID = repo, repo, owner+"/"+repo
+		}
+
+		task := service.ImportedTask{
+			SourceID:    issue.URL,
+			Title:       issue.Title,
+			Description: issue.Body,
+			Column:      "To Do",
+			CreatedAt:   issue.CreatedAt,
+		}
+		if task.SourceID == "" {
+			task.SourceID = "#" + strconv.Itoa(issue.Number)
+		}
+		if strings.EqualFold(issue.State, "closed") || strings.EqualFold(issue.State, "merged") {
+			task.Column = "Done"
+			task.CompletedAt = issue.ClosedAt
+		}
+		for _, label := range issue.Labels {
+			task.Tags = append(task.Tags, label.Name)
+		}
+		if issue.Milestone != nil {
+			task.DueDate = issue.Milestone.DueOn
+		}
+		for _, comment := range issue.Comments {
+			task.Comments = append(task.Comments, service.ImportedComment{
+				ID:     comment.ID,
+				Author: comment.Author.Login,
+				Text:   comment.Body,
+				Time:   comment.CreatedAt,
+			})
+		}
+		board.Tasks = append(board.Tasks, task)
+	}
+	if board.Name == "" {
+		board.Project, board.Name = "GitHub", "Issues"
+	}
+
+	return []service.ImportedBoard{board}, nil
+}
+
+// parseGitHubProject reads the items of a GitHub project
+func parseGitHubProject(data []byte) ([]service.ImportedBoard, error) {
+	var export struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid GitHub project export: %w", err)
+	}
+
+	board := service.ImportedBoard{
+		Source:  "github",
+		Project: "GitHub",
+		Name:    "Project",
+	}
+	seen := make(map[string]bool)
+	for _, raw := range export.Items {
+		var item githubProjectItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("invalid GitHub project item: %w", err)
+		}
+		if err := json.Unmarshal(raw, &item.Fields); err != nil {
+			return nil, fmt.Errorf("invalid GitHub project item: %w", err)
+		}
+		if item.Title == "" {
+			continue
+		}
+
+		column := item.Status
+		if column == "" {
+			column = githubNoStatus
+		}
+		if !seen[column] {
+			seen[column] = true
+			board.Columns = append(board.Columns, column)
+		}
+
+		task := service.ImportedTask{
+			SourceID:    item.ID,
+			Title:       item.Title,
+			Description: item.Content.Body,
+			Column:      column,
+			Tags:        item.Labels,
+			DueDate:     githubDueDate(item.Fields),
+		}
+		// Issues keep their ID when added to another project
+		if item.Content.URL != "" {
+			task.SourceID = item.Content.URL
+		}
+		board.Tasks = append(board.Tasks, task)
+	}
+
+	return []service.ImportedBoard{board}, nil
+}
+
+// githubDueDate returns the date of the first date field of a project item
+// whose name mentions "due", e.g. "due date" or "Due"
+func githubDueDate(fields map[string]json.RawMessage) *time.Time {
+	for name, raw := range fields {
+		if !strings.Contains(strings.ToLower(name), "due") {
+			continue
+		}
+		var value string
+		if json.Unmarshal(raw, &value) != nil {
+			continue
+		}
+		if due, err := time.Parse("2006-01-02", value); err == nil {
+			return &due
+		}
+	}
+	return nil
+}
+
+// githubRepository returns the owner and name of the repository of an issue
+// URL such as https://github.com/owner/repo/issues/1
+func githubRepository(url string) (string, string) {
+	_, path, ok := strings.Cut(url, "://")
+	if !ok {
+		return "", ""
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return "", ""
+	}
+	return parts[1], parts[2]
+}
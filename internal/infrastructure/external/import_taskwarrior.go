@@ -0,0 +1,125 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// taskwarriorTime is the format of dates in task export
+const taskwarriorTime = "20060102T150405Z"
+
+// taskwarriorTask is a task as written by task export
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Project     string   `json:"project"`
+	Priority    string   `json:"priority"`
+	Tags        []string `json:"tags"`
+	Entry       string   `json:"entry"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	Due         string   `json:"due"`
+	Annotations []struct {
+		Entry       string `json:"entry"`
+		Description string `json:"description"`
+	} `json:"annotations"`
+}
+
+// taskwarriorPriorities maps Taskwarrior's priorities to mkanban's
+var taskwarriorPriorities = map[string]string{
+	"H": "high",
+	"M": "medium",
+	"L": "low",
+}
+
+// taskwarriorNoProject is the board of tasks without a project
+const taskwarriorNoProject = "Inbox"
+
+// parseTaskwarrior reads the output of task export. Each Taskwarrior
+// project becomes a board of the project "Taskwarrior", with pending tasks
+// in To Do, started ones in In Progress and completed ones in Done.
+// Annotations become comments. Deleted tasks and recurring templates are
+// left out.
+func parseTaskwarrior(data []byte) ([]service.ImportedBoard, error) {
+	var tasks []taskwarriorTask
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("invalid Taskwarrior export: %w", err)
+	}
+
+	var boards []service.ImportedBoard
+	index := make(map[string]int)
+	for _, tw := range tasks {
+		column := "To Do"
+		switch tw.Status {
+		case "pending", "waiting":
+			if tw.Start != "" {
+				column = "In Progress"
+			}
+		case "completed":
+			column = "Done"
+		default:
+			continue
+		}
+
+		project := tw.Project
+		if project == "" {
+			project = taskwarriorNoProject
+		}
+		i, ok := index[project]
+		if !ok {
+			i = len(boards)
+			index[project] = i
+			boards = append(boards, service.ImportedBoard{
+				Source:   "taskwarrior",
+				SourceID: project,
+				Project:  "Taskwarrior",
+				Name:     project,
+				Columns:  []string{"To Do", "In Progress", "Done"},
+			})
+		}
+
+		task := service.ImportedTask{
+			SourceID:    tw.UUID,
+			Title:       tw.Description,
+			Column:      column,
+			Priority:    taskwarriorPriorities[strings.ToUpper(tw.Priority)],
+			Tags:        tw.Tags,
+			DueDate:     taskwarriorDate(tw.Due),
+			CompletedAt: taskwarriorDate(tw.End),
+		}
+		if entry := taskwarriorDate(tw.Entry); entry != nil {
+			task.CreatedAt = *entry
+		}
+		for _, annotation := range tw.Annotations {
+			comment := service.ImportedComment{
+				ID:   annotation.Entry,
+				Text: annotation.Description,
+			}
+			if entry := taskwarriorDate(annotation.Entry); entry != nil {
+				comment.Time = *entry
+			}
+			task.Comments = append(task.Comments, comment)
+		}
+		boards[i].Tasks = append(boards[i].Tasks, task)
+	}
+
+	return boards, nil
+}
+
+// taskwarriorDate parses a date of task export, which is nil when empty or
+// invalid
+func taskwarriorDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, err := time.Parse(taskwarriorTime, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,167 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// trelloExport is the part of a Trello board export (Menu, Print and export,
+// Export as JSON) that is imported
+type trelloExport struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		IDList      string     `json:"idList"`
+		Closed      bool       `json:"closed"`
+		Pos         float64    `json:"pos"`
+		Due         *time.Time `json:"due"`
+		DueComplete bool       `json:"dueComplete"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		IDCard     string  `json:"idCard"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+	Actions []struct {
+		ID   string    `json:"id"`
+		Type string    `json:"type"`
+		Date time.Time `json:"date"`
+		Data struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+		MemberCreator struct {
+			FullName string `json:"fullName"`
+			Username string `json:"username"`
+		} `json:"memberCreator"`
+	} `json:"actions"`
+}
+
+// parseTrello reads a Trello board export. Open lists become the columns
+// in their order, open cards their tasks, labels tags and checklists
+// subtasks. Archived lists and cards are left out.
+func parseTrello(data []byte) ([]service.ImportedBoard, error) {
+	var export trelloExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid Trello export: %w", err)
+	}
+	if export.ID == "" || export.Name == "" {
+		return nil, fmt.Errorf("invalid Trello export: no board")
+	}
+
+	lists := export.Lists
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	columns := make(map[string]string)
+	board := service.ImportedBoard{
+		Source:      "trello",
+		SourceID:    export.ID,
+		Project:     export.Name,
+		Name:        export.Name,
+		Description: export.Desc,
+	}
+	for _, list := range lists {
+		if list.Closed {
+			continue
+		}
+		columns[list.ID] = list.Name
+		board.Columns = append(board.Columns, list.Name)
+	}
+
+	checklists := export.Checklists
+	sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	checklistsByCard := make(map[string][]service.ImportedChecklistItem)
+	for _, checklist := range checklists {
+		items := checklist.CheckItems
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		for _, item := range items {
+			checklistsByCard[checklist.IDCard] = append(checklistsByCard[checklist.IDCard], service.ImportedChecklistItem{
+				Title: item.Name,
+				Done:  item.State == "complete",
+			})
+		}
+	}
+
+	commentsByCard := make(map[string][]service.ImportedComment)
+	for _, action := range export.Actions {
+		if action.Type != "commentCard" || action.Data.Text == "" {
+			continue
+		}
+		author := action.MemberCreator.FullName
+		if author == "" {
+			author = action.MemberCreator.Username
+		}
+		commentsByCard[action.Data.Card.ID] = append(commentsByCard[action.Data.Card.ID], service.ImportedComment{
+			ID:     action.ID,
+			Author: author,
+			Text:   action.Data.Text,
+			Time:   action.Date,
+		})
+	}
+
+	cards := export.Cards
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	for _, card := range cards {
+		column, ok := columns[card.IDList]
+		if card.Closed || !ok {
+			continue
+		}
+		task := service.ImportedTask{
+			SourceID:    card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Column:      column,
+			DueDate:     card.Due,
+			CreatedAt:   trelloCreatedAt(card.ID),
+			Checklist:   checklistsByCard[card.ID],
+			Comments:    commentsByCard[card.ID],
+		}
+		for _, label := range card.Labels {
+			// Unnamed labels are only a color
+			if label.Name != "" {
+				task.Tags = append(task.Tags, label.Name)
+			} else if label.Color != "" {
+				task.Tags = append(task.Tags, label.Color)
+			}
+		}
+		board.Tasks = append(board.Tasks, task)
+	}
+
+	return []service.ImportedBoard{board}, nil
+}
+
+// trelloCreatedAt returns when a Trello object was created, which its ID
+// starts with like a MongoDB ObjectId
+func trelloCreatedAt(id string) time.Time {
+	var seconds int64
+	if len(id) < 8 {
+		return time.Time{}
+	}
+	if _, err := fmt.Sscanf(id[:8], "%x", &seconds); err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
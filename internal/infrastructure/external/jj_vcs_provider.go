@@ -0,0 +1,389 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+)
+
+// noBookmark is the current branch of a working copy without bookmarks
+// among its ancestors
+const noBookmark = "@ (no bookmark)"
+
+// JjVCSProvider implements VCSProvider for Jujutsu, colocated with git or
+// not. Local bookmarks are the branches. A working copy commit has no
+// bookmark of its own in the usual workflow, so the current branch is the
+// nearest bookmark among its ancestors. Workspaces are the worktrees.
+// Commands that only read pass --ignore-working-copy, which skips the
+// snapshot that would record a new operation and wake the watcher again.
+type JjVCSProvider struct{}
+
+// NewJjVCSProvider creates a new JjVCSProvider
+func NewJjVCSProvider() *JjVCSProvider {
+	return &JjVCSProvider{}
+}
+
+// IsRepository checks if the given path is inside a Jujutsu workspace
+func (j *JjVCSProvider) IsRepository(path string) bool {
+	_, err := j.jj(path, "root", "--ignore-working-copy")
+	return err == nil
+}
+
+// GetRepositoryRoot returns the root directory of the workspace
+func (j *JjVCSProvider) GetRepositoryRoot(path string) (string, error) {
+	root, err := j.jj(path, "root", "--ignore-working-copy")
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	if root == "" {
+		return "", fmt.Errorf("empty repository root for path: %s", path)
+	}
+	return root, nil
+}
+
+// GetMainRepositoryRoot returns the root of the workspace holding the
+// repository, also from other workspaces of it
+func (j *JjVCSProvider) GetMainRepositoryRoot(path string) (string, error) {
+	root, err := j.GetRepositoryRoot(path)
+	if err != nil {
+		return "", err
+	}
+
+	repoDir, err := j.repoDir(root)
+	if err != nil {
+		return "", err
+	}
+	// The repository is the .jj/repo directory of the main workspace
+	return filepath.Dir(filepath.Dir(repoDir)), nil
+}
+
+// GetCurrentBranch returns the nearest bookmark at or below the working
+// copy commit
+func (j *JjVCSProvider) GetCurrentBranch(repoPath string) (string, error) {
+	out, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", "heads(::@ & bookmarks())",
+		"-T", `local_bookmarks.map(|b| b.name()).join(" ") ++ "\n"`)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	names := strings.Fields(out)
+	if len(names) == 0 {
+		return noBookmark, nil
+	}
+	return names[0], nil
+}
+
+// ListBranches returns the local bookmarks of the repository
+func (j *JjVCSProvider) ListBranches(repoPath string) ([]string, error) {
+	heads, err := j.ListBranchHeads(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(heads), nil
+}
+
+// GetRefsPath returns the directory of the repository's operation heads,
+// which every change to bookmarks or commits replaces
+func (j *JjVCSProvider) GetRefsPath(repoPath string) string {
+	root, err := j.GetRepositoryRoot(repoPath)
+	if err != nil {
+		return ""
+	}
+	repoDir, err := j.repoDir(root)
+	if err != nil {
+		return ""
+	}
+
+	headsPath := filepath.Join(repoDir, "op_heads", "heads")
+	if _, err := os.Stat(headsPath); err != nil {
+		return ""
+	}
+	return headsPath
+}
+
+// BranchExists checks if a local bookmark with the given name exists
+func (j *JjVCSProvider) BranchExists(repoPath, branchName string) (bool, error) {
+	heads, err := j.ListBranchHeads(repoPath)
+	if err != nil {
+		return false, err
+	}
+	_, exists := heads[branchName]
+	return exists, nil
+}
+
+// CheckoutBranch starts a new working copy commit on top of a bookmark
+func (j *JjVCSProvider) CheckoutBranch(repoPath, branchName string) error {
+	if _, err := j.jj(repoPath, "new", strconv.Quote(branchName)); err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// CreateAndCheckoutBranch creates a bookmark at the working copy commit
+func (j *JjVCSProvider) CreateAndCheckoutBranch(repoPath, branchName string) error {
+	if _, err := j.jj(repoPath, "bookmark", "create", branchName, "-r", "@"); err != nil {
+		return fmt.Errorf("failed to create and checkout branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// ListBranchHeads returns the commit each local bookmark points to
+func (j *JjVCSProvider) ListBranchHeads(repoPath string) (map[string]string, error) {
+	out, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", "bookmarks()",
+		"-T", `commit_id ++ " " ++ local_bookmarks.map(|b| b.name()).join(" ") ++ "\n"`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch heads: %w", err)
+	}
+
+	heads := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, branch := range fields[1:] {
+			heads[branch] = fields[0]
+		}
+	}
+	return heads, nil
+}
+
+// ListWorktrees returns the current branch of this workspace. Jujutsu
+// doesn't record where the other workspaces are, so they aren't listed.
+func (j *JjVCSProvider) ListWorktrees(repoPath string) (map[string]string, error) {
+	root, err := j.GetRepositoryRoot(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := j.GetCurrentBranch(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	worktrees := make(map[string]string)
+	if branch != noBookmark {
+		worktrees[branch] = root
+	}
+	return worktrees, nil
+}
+
+// AddWorktree adds a workspace at path with a working copy commit on top of
+// the bookmark, or, if createBranch is set, on the parents of this
+// workspace's working copy with a new bookmark at it
+func (j *JjVCSProvider) AddWorktree(repoPath, path, branchName string, createBranch bool) error {
+	if createBranch {
+		if _, err := j.jj(repoPath, "workspace", "add", path); err != nil {
+			return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+		}
+		return j.CreateAndCheckoutBranch(path, branchName)
+	}
+
+	if _, err := j.jj(repoPath, "workspace", "add", "-r", strconv.Quote(branchName), path); err != nil {
+		return fmt.Errorf("failed to add worktree for branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// ListCommits returns the commits that are ancestors of included but not
+// of excluded, newest first
+func (j *JjVCSProvider) ListCommits(repoPath string, included, excluded []string, limit int) ([]service.Commit, error) {
+	if len(included) == 0 {
+		return nil, nil
+	}
+
+	revset := "(::" + revsetUnion(included) + ") ~ root()"
+	if len(excluded) > 0 {
+		revset += " ~ (::" + revsetUnion(excluded) + ")"
+	}
+	args := []string{"log", "--ignore-working-copy", "--no-graph", "-r", revset,
+		"-T", `commit_id ++ "\x1f" ++ author.name() ++ "\x1f" ++ author.timestamp().utc().format("%s") ++ "\x1f" ++ description ++ "\x1e"`}
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+
+	out, err := j.jj(repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var commits []service.Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		subject, body, _ := strings.Cut(fields[3], "\n")
+		commits = append(commits, service.Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Time:    time.Unix(seconds, 0),
+			Subject: subject,
+			Body:    strings.TrimSpace(body),
+		})
+	}
+
+	return commits, nil
+}
+
+// GetDefaultBranch returns the local bookmark of trunk(), the main, master
+// or trunk bookmark of the origin or upstream remote, falling back to main
+// or master
+func (j *JjVCSProvider) GetDefaultBranch(repoPath string) (string, error) {
+	heads, err := j.ListBranchHeads(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", "trunk()", "-T", `local_bookmarks.map(|b| b.name()).join(" ")`)
+	if err == nil {
+		for _, bookmark := range strings.Fields(out) {
+			if _, ok := heads[bookmark]; ok {
+				return bookmark, nil
+			}
+		}
+	}
+
+	for _, bookmark := range []string{"main", "master"} {
+		if _, ok := heads[bookmark]; ok {
+			return bookmark, nil
+		}
+	}
+	return "", fmt.Errorf("no default branch found in %s", repoPath)
+}
+
+// FindMergeCommit returns a merge on target with the bookmarked commit as
+// a parent, else the commit itself if target contains it, which target was
+// then moved onto
+func (j *JjVCSProvider) FindMergeCommit(repoPath, branch, target string) (string, error) {
+	head := strconv.Quote(branch)
+	onTarget := "(::" + strconv.Quote(target) + ")"
+
+	merged, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", head+" & "+onTarget, "-T", "commit_id")
+	if err != nil {
+		return "", fmt.Errorf("failed to check whether %s is merged into %s: %w", branch, target, err)
+	}
+	if merged == "" {
+		return "", nil
+	}
+
+	merges, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", "roots(children("+head+") & merges() & "+onTarget+")", "-T", `commit_id ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+	if merge, _, _ := strings.Cut(merges, "\n"); merge != "" {
+		return merge, nil
+	}
+	return merged, nil
+}
+
+// FindSquashMerge compares patch IDs of the commits target got since branch
+// forked from it with those of branch, see matchSquash
+func (j *JjVCSProvider) FindSquashMerge(repoPath, branch, target string) (string, error) {
+	head := strconv.Quote(branch)
+	base, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+		"-r", "heads(::"+head+" & ::"+strconv.Quote(target)+")", "-T", `commit_id ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+	base, _, _ = strings.Cut(base, "\n")
+	if base == "" {
+		return "", nil
+	}
+
+	// Commits of a head since the fork, without merges
+	since := func(revision string) string {
+		return "((::" + revision + ") ~ (::" + base + ")) ~ merges()"
+	}
+
+	targetLog, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph", "--patch", "--git",
+		"-r", since(strconv.Quote(target)), "--limit", strconv.Itoa(squashScanLimit),
+		"-T", `"\x1e" ++ commit_id ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+	branchDiff, err := j.jj(repoPath, "diff", "--ignore-working-copy", "--git", "--from", base, "--to", head)
+	if err != nil {
+		return "", err
+	}
+	branchLog, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph", "--patch", "--git",
+		"-r", since(head), "-T", `"\x1e" ++ commit_id ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+
+	return matchSquash(splitPatches(targetLog), patchID(branchDiff), splitPatches(branchLog)), nil
+}
+
+// DeleteBranch deletes a local bookmark. Unless force is set it refuses
+// bookmarks the working copy commit doesn't contain.
+func (j *JjVCSProvider) DeleteBranch(repoPath, branchName string, force bool) error {
+	if !force {
+		merged, err := j.jj(repoPath, "log", "--ignore-working-copy", "--no-graph",
+			"-r", strconv.Quote(branchName)+" & ::@", "-T", "commit_id")
+		if err != nil {
+			return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+		}
+		if merged == "" {
+			return fmt.Errorf("failed to delete branch %s: not merged", branchName)
+		}
+	}
+
+	if _, err := j.jj(repoPath, "bookmark", "delete", branchName); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// repoDir returns the repository directory of a workspace: its own
+// .jj/repo, or in other workspaces the one that file points to
+func (j *JjVCSProvider) repoDir(root string) (string, error) {
+	repoPath := filepath.Join(root, ".jj", "repo")
+	info, err := os.Stat(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository of workspace %s: %w", root, err)
+	}
+	if info.IsDir() {
+		return repoPath, nil
+	}
+
+	data, err := os.ReadFile(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository of workspace %s: %w", root, err)
+	}
+	target := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, ".jj", target)
+	}
+	return filepath.Clean(target), nil
+}
+
+// jj runs a Jujutsu command in repoPath and returns its trimmed output
+func (j *JjVCSProvider) jj(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("jj", append([]string{"--no-pager", "--color=never"}, args...)...)
+	cmd.Dir = repoPath
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("jj %s: %w - %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(out.String()), nil
+}
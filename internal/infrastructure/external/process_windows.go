@@ -0,0 +1,16 @@
+//go:build windows
+
+package external
+
+import "os"
+
+// processAlive reports whether a process with the pid exists. Finding a
+// process on Windows opens a handle to it, which fails once it has exited.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
@@ -0,0 +1,189 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"mkanban/internal/domain/entity"
+)
+
+const sessionTypeScreen = "screen"
+
+// ScreenSessionTracker implements SessionTracker for GNU screen. Screen
+// doesn't report directories either, so a session's is that of its newest
+// process, usually the shell of the window opened last, read from /proc.
+type ScreenSessionTracker struct {
+	procDir string
+}
+
+// NewScreenSessionTracker creates a new ScreenSessionTracker
+func NewScreenSessionTracker() *ScreenSessionTracker {
+	return &ScreenSessionTracker{procDir: "/proc"}
+}
+
+// screenSession is a line of screen -ls
+type screenSession struct {
+	pid      int
+	name     string
+	attached bool
+}
+
+// IsAvailable checks if screen is installed and has sessions
+func (s *ScreenSessionTracker) IsAvailable() bool {
+	if _, err := exec.LookPath("screen"); err != nil {
+		return false
+	}
+
+	sessions, err := s.listSessions()
+	return err == nil && len(sessions) > 0
+}
+
+// ListSessions returns all screen sessions
+func (s *ScreenSessionTracker) ListSessions() ([]*entity.Session, error) {
+	listed, err := s.listSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(listed))
+	for _, ls := range listed {
+		session, err := s.session(ls)
+		if err != nil {
+			// Skip sessions whose directory can't be read
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// GetActiveSession returns the screen session mkanban runs in, else the
+// first attached one
+func (s *ScreenSessionTracker) GetActiveSession() (*entity.Session, error) {
+	listed, err := s.listSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	// $STY is the pid.name of the session we're in
+	if sty := os.Getenv("STY"); sty != "" {
+		for _, ls := range listed {
+			if strconv.Itoa(ls.pid)+"."+ls.name == sty {
+				return s.session(ls)
+			}
+		}
+	}
+
+	for _, ls := range listed {
+		if ls.attached {
+			return s.session(ls)
+		}
+	}
+
+	// No attached session found
+	return nil, nil
+}
+
+// session returns the session with its working directory
+func (s *ScreenSessionTracker) session(ls screenSession) (*entity.Session, error) {
+	workingDir, err := s.processDir(ls.pid)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewSession(ls.name, workingDir, sessionTypeScreen)
+}
+
+// listSessions parses screen -ls, whose sessions are lines like
+// "\t12345.name\t(Attached)"
+func (s *ScreenSessionTracker) listSessions() ([]screenSession, error) {
+	cmd := exec.Command("screen", "-ls")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	// screen -ls exits with 1 whether or not there are sessions, so only
+	// a failure to run it counts
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf("failed to list screen sessions: %w", err)
+		}
+	}
+
+	var sessions []screenSession
+	for _, line := range strings.Split(out.String(), "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pid, name, ok := strings.Cut(fields[0], ".")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(pid)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, screenSession{
+			pid:      n,
+			name:     name,
+			attached: strings.Contains(line, "(Attached)"),
+		})
+	}
+
+	return sessions, nil
+}
+
+// processDir returns the working directory of the newest child of the
+// process, else of the process itself
+func (s *ScreenSessionTracker) processDir(pid int) (string, error) {
+	newest := pid
+	entries, err := os.ReadDir(s.procDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list processes: %w", err)
+	}
+	for _, entry := range entries {
+		child, err := strconv.Atoi(entry.Name())
+		if err != nil || child <= newest {
+			continue
+		}
+		if parentPID(filepath.Join(s.procDir, entry.Name(), "stat")) == pid {
+			newest = child
+		}
+	}
+
+	dir, err := os.Readlink(filepath.Join(s.procDir, strconv.Itoa(newest), "cwd"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read working directory of process %d: %w", newest, err)
+	}
+	return dir, nil
+}
+
+// parentPID returns the parent of a process from its /proc stat file, or -1
+func parentPID(statPath string) int {
+	data, err := os.ReadFile(statPath)
+	if err != nil {
+		return -1
+	}
+	// The command name in parentheses may contain spaces, the parent
+	// follows the state after it
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 2 {
+		return -1
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return -1
+	}
+	return ppid
+}
@@ -1,10 +1,13 @@
 package external
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 )
 
 // ScriptExecutor executes user-defined scripts
@@ -21,10 +24,11 @@ func NewScriptExecutor(enabled bool, scriptsDir string) *ScriptExecutor {
 	}
 }
 
-// RunScript executes a script with the given environment variables
-func (e *ScriptExecutor) RunScript(scriptPath string, env map[string]string) error {
+// RunScript executes a script with the given environment variables and
+// returns its combined stdout and stderr
+func (e *ScriptExecutor) RunScript(scriptPath string, env map[string]string) (string, error) {
 	if !e.enabled {
-		return fmt.Errorf("script execution is disabled")
+		return "", fmt.Errorf("script execution is disabled")
 	}
 
 	// Resolve script path (support relative paths from scripts directory)
@@ -35,18 +39,18 @@ func (e *ScriptExecutor) RunScript(scriptPath string, env map[string]string) err
 
 	// Check if script exists
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		return fmt.Errorf("script not found: %s", fullPath)
+		return "", fmt.Errorf("script not found: %s", fullPath)
 	}
 
 	// Check if script is executable
 	info, err := os.Stat(fullPath)
 	if err != nil {
-		return fmt.Errorf("failed to stat script: %w", err)
+		return "", fmt.Errorf("failed to stat script: %w", err)
 	}
 
 	// On Unix-like systems, check executable bit
 	if info.Mode()&0111 == 0 {
-		return fmt.Errorf("script is not executable: %s", fullPath)
+		return "", fmt.Errorf("script is not executable: %s", fullPath)
 	}
 
 	// Prepare command
@@ -61,10 +65,43 @@ func (e *ScriptExecutor) RunScript(scriptPath string, env map[string]string) err
 	// Execute script
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("script execution failed: %w (output: %s)", err, string(output))
+		return string(output), fmt.Errorf("script execution failed: %w", err)
 	}
 
-	return nil
+	return string(output), nil
+}
+
+// RunCommand runs a command with arguments directly, without a shell or a
+// script file, and returns its combined stdout and stderr. The command is
+// looked up in PATH and killed when the timeout expires; a zero timeout means
+// no limit.
+func (e *ScriptExecutor) RunCommand(command string, args []string, env map[string]string, timeout time.Duration) (string, error) {
+	if !e.enabled {
+		return "", fmt.Errorf("script execution is disabled")
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return string(output), fmt.Errorf("command timed out after %s: %s", timeout, command)
+	}
+	if err != nil {
+		return string(output), fmt.Errorf("command execution failed: %w", err)
+	}
+
+	return string(output), nil
 }
 
 // ValidateScript checks if a script exists and is executable
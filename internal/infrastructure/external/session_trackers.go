@@ -0,0 +1,94 @@
+package external
+
+import (
+	"fmt"
+	"os"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
+)
+
+// NewSessionTracker returns the SessionTracker for a tracker type: "tmux",
+// "zellij", "screen", "shell", or "auto" or "" for AutoSessionTracker. Shell
+// sessions are kept in dataDir.
+func NewSessionTracker(trackerType, dataDir string) (service.SessionTracker, error) {
+	switch trackerType {
+	case sessionTypeTmux:
+		return NewTmuxSessionTracker(), nil
+	case sessionTypeZellij:
+		return NewZellijSessionTracker(), nil
+	case sessionTypeScreen:
+		return NewScreenSessionTracker(), nil
+	case sessionTypeShell:
+		return NewShellSessionTracker(dataDir), nil
+	case "", "auto":
+		return NewAutoSessionTracker(dataDir), nil
+	}
+	return nil, fmt.Errorf("unknown session tracker type %q, expected tmux, zellij, screen, shell or auto", trackerType)
+}
+
+// AutoSessionTracker implements SessionTracker with whichever multiplexer
+// is in use: the one mkanban runs inside, else the first of tmux, zellij
+// and screen with sessions. Without a multiplexer it falls back to the
+// shells reporting their prompts. It decides on every call, so a daemon
+// started before the multiplexer picks it up.
+type AutoSessionTracker struct {
+	trackers []autoTracker
+}
+
+// autoTracker is a tracker with the environment variable its multiplexer
+// sets inside its sessions, if any
+type autoTracker struct {
+	env     string
+	tracker service.SessionTracker
+}
+
+// NewAutoSessionTracker creates a new AutoSessionTracker
+func NewAutoSessionTracker(dataDir string) *AutoSessionTracker {
+	return &AutoSessionTracker{
+		trackers: []autoTracker{
+			{env: "TMUX", tracker: NewTmuxSessionTracker()},
+			{env: "ZELLIJ", tracker: NewZellijSessionTracker()},
+			{env: "STY", tracker: NewScreenSessionTracker()},
+			{tracker: NewShellSessionTracker(dataDir)},
+		},
+	}
+}
+
+// IsAvailable checks if any multiplexer or shell has sessions
+func (a *AutoSessionTracker) IsAvailable() bool {
+	return a.current() != nil
+}
+
+// ListSessions returns the sessions of the multiplexer in use
+func (a *AutoSessionTracker) ListSessions() ([]*entity.Session, error) {
+	tracker := a.current()
+	if tracker == nil {
+		return nil, fmt.Errorf("no terminal multiplexer or shell with sessions found")
+	}
+	return tracker.ListSessions()
+}
+
+// GetActiveSession returns the active session of the multiplexer in use
+func (a *AutoSessionTracker) GetActiveSession() (*entity.Session, error) {
+	tracker := a.current()
+	if tracker == nil {
+		return nil, nil
+	}
+	return tracker.GetActiveSession()
+}
+
+// current returns the tracker of the multiplexer in use, or nil
+func (a *AutoSessionTracker) current() service.SessionTracker {
+	for _, t := range a.trackers {
+		if t.env != "" && os.Getenv(t.env) != "" && t.tracker.IsAvailable() {
+			return t.tracker
+		}
+	}
+	for _, t := range a.trackers {
+		if t.tracker.IsAvailable() {
+			return t.tracker
+		}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package external
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBinary puts a shell script named name on a PATH of its own
+func fakeBinary(t *testing.T, name, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	for _, env := range []string{"TMUX", "ZELLIJ", "ZELLIJ_SESSION_NAME", "STY"} {
+		t.Setenv(env, "")
+	}
+}
+
+// zellijLayout is the layout of a session with two tabs, the second focused
+const zellijLayout = `layout {
+    cwd "/home/ann"
+    tab name="Tab #1" hide_floating_panes=true {
+        pane cwd="notes" focus=true
+    }
+    tab name="Tab #2" focus=true hide_floating_panes=true {
+        pane size=1 borderless=true {
+            plugin location="zellij:tab-bar"
+        }
+        pane split_direction="vertical" {
+            pane cwd="src/shop"
+            pane command="nvim" cwd="src/shop/web" focus=true {
+                args "main.go"
+            }
+        }
+    }
+    new_tab_template {
+        pane cwd="/tmp"
+    }
+}
+`
+
+func TestZellijSessionTracker(t *testing.T) {
+	fakeBinary(t, "zellij", `
+case "$1" in
+list-sessions)
+	echo "shop [Created 2h ago] (current)"
+	echo "blog [Created 10m ago]"
+	echo "old [Created 3d ago] (EXITED - attach to resurrect)"
+	;;
+--session)
+	if [ "$2" = shop ]; then
+		printf '%s' '`+zellijLayout+`'
+	else
+		printf 'layout {\n    cwd "/home/ann/blog"\n    tab focus=true {\n        pane\n    }\n}\n'
+	fi
+	;;
+esac
+`)
+
+	tracker := NewZellijSessionTracker()
+	if !tracker.IsAvailable() {
+		t.Fatal("zellij with sessions should be available")
+	}
+
+	sessions, err := tracker.ListSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range sessions {
+		got = append(got, s.Name()+":"+s.WorkingDir())
+	}
+	want := "shop:/home/ann/src/shop/web blog:/home/ann/blog"
+	if strings.Join(got, " ") != want {
+		t.Errorf("sessions = %v, want %s", got, want)
+	}
+
+	active, err := tracker.GetActiveSession()
+	if err != nil || active == nil || active.Name() != "shop" {
+		t.Fatalf("active session = %v, %v, want the current one", active, err)
+	}
+
+	t.Setenv("ZELLIJ_SESSION_NAME", "blog")
+	if active, _ := tracker.GetActiveSession(); active == nil || active.Name() != "blog" {
+		t.Errorf("active session = %v, want the one we're in", active)
+	}
+}
+
+func TestFocusedPaneDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{"focused pane of focused tab", zellijLayout, "/home/ann/src/shop/web"},
+		{"absolute pane directory", "layout {\n  cwd \"/home\"\n  tab focus=true {\n    pane cwd=\"/srv/app\" focus=true\n  }\n}\n", "/srv/app"},
+		{"pane without directory", "layout {\n  cwd \"/home/ann\"\n  tab focus=true {\n    pane focus=true\n  }\n}\n", "/home/ann"},
+		{"no focused pane", "layout {\n  cwd \"/home/ann\"\n  tab focus=true {\n    pane cwd=\"a\"\n    pane cwd=\"b\"\n  }\n}\n", "/home/ann/a"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := focusedPaneDir(tt.layout); got != tt.want {
+				t.Errorf("focusedPaneDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenSessionTracker(t *testing.T) {
+	if _, err := os.Stat("/proc/self/cwd"); err != nil {
+		t.Skip("no /proc")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This test's process stands in for the screen sessions
+	pid := os.Getpid()
+	fakeBinary(t, "screen", fmt.Sprintf(`
+echo "There are screens on:"
+printf '\t%[1]d.shop\t(18/10/26 12:00:00)\t(Detached)\n'
+printf '\t%[1]d.blog\t(18/10/26 12:30:00)\t(Attached)\n'
+printf '\t999999999.gone\t(Attached)\n'
+echo "3 Sockets in /run/screen/S-ann."
+exit 1
+`, pid))
+
+	tracker := NewScreenSessionTracker()
+	if !tracker.IsAvailable() {
+		t.Fatal("screen with sessions should be available")
+	}
+
+	sessions, err := tracker.ListSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].Name() != "shop" || sessions[1].Name() != "blog" {
+		t.Fatalf("sessions = %v, want shop and blog", sessions)
+	}
+	if dir := sessions[0].WorkingDir(); dir != wd {
+		t.Errorf("working dir = %q, want %q", dir, wd)
+	}
+
+	if active, err := tracker.GetActiveSession(); err != nil || active == nil || active.Name() != "blog" {
+		t.Errorf("active session = %v, %v, want the attached one", active, err)
+	}
+	t.Setenv("STY", fmt.Sprintf("%d.shop", pid))
+	if active, _ := tracker.GetActiveSession(); active == nil || active.Name() != "shop" {
+		t.Errorf("active session = %v, want the one we're in", active)
+	}
+}
+
+func TestAutoSessionTracker(t *testing.T) {
+	fakeBinary(t, "zellij", `
+case "$1" in
+list-sessions) echo "shop [Created 2h ago]" ;;
+*) printf 'layout {\n    cwd "/home/ann/shop"\n}\n' ;;
+esac
+`)
+
+	tracker, err := NewSessionTracker("auto", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tracker.IsAvailable() {
+		t.Fatal("zellij should be picked without tmux")
+	}
+	active, err := tracker.GetActiveSession()
+	if err != nil || active == nil || active.SessionType() != sessionTypeZellij || active.WorkingDir() != "/home/ann/shop" {
+		t.Errorf("active session = %+v, %v", active, err)
+	}
+
+	if _, err := NewSessionTracker("byobu", t.TempDir()); err == nil {
+		t.Error("unknown tracker types should fail")
+	}
+}
+
+func TestAutoSessionTrackerFallsBackToShells(t *testing.T) {
+	fakeBinary(t, "zellij", `echo "No active zellij sessions found." >&2; exit 1`)
+
+	dataDir := t.TempDir()
+	tracker := NewAutoSessionTracker(dataDir)
+	if tracker.IsAvailable() {
+		t.Fatal("nothing should be available without sessions or shells")
+	}
+
+	if err := NewShellSessionTracker(dataDir).Report(os.Getpid(), "/home/ann/shop"); err != nil {
+		t.Fatal(err)
+	}
+	active, err := tracker.GetActiveSession()
+	if err != nil || active == nil || active.SessionType() != sessionTypeShell || active.WorkingDir() != "/home/ann/shop" {
+		t.Errorf("active session = %+v, %v, want the reporting shell", active, err)
+	}
+}
+
+func TestShellSessionTracker(t *testing.T) {
+	tracker := NewShellSessionTracker(t.TempDir())
+	running := map[int]bool{101: true, 102: true, 103: true, 104: true}
+	tracker.alive = func(pid int) bool { return running[pid] }
+
+	if tracker.IsAvailable() {
+		t.Fatal("no shell has reported yet")
+	}
+	if err := tracker.Report(101, "relative/dir"); err == nil {
+		t.Error("relative directories should be refused")
+	}
+
+	reports := []struct {
+		pid int
+		dir string
+	}{
+		{101, "/home/ann/shop/"},
+		{102, "/home/ann/blog"},
+		{103, "/srv/blog"},
+		{104, "/home/ann/shop"},
+		{102, "/home/ann/notes"}, // cd'd elsewhere
+	}
+	for _, r := range reports {
+		if err := tracker.Report(r.pid, r.dir); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	sessions, err := tracker.ListSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range sessions {
+		got = append(got, s.Name()+"="+s.WorkingDir())
+	}
+	want := "notes=/home/ann/notes shop=/home/ann/shop blog=/srv/blog"
+	if strings.Join(got, " ") != want {
+		t.Errorf("sessions = %v, want %s", got, want)
+	}
+
+	// The active shell exits, the one that prompted before it takes over
+	delete(running, 102)
+	active, err := tracker.GetActiveSession()
+	if err != nil || active == nil || active.Name() != "shop" {
+		t.Errorf("active session = %v, %v, want shop", active, err)
+	}
+
+	// Names clash once both blogs have shells
+	running[105] = true
+	if err := tracker.Report(105, "/home/ann/blog"); err != nil {
+		t.Fatal(err)
+	}
+	sessions, _ = tracker.ListSessions()
+	if len(sessions) != 3 || sessions[0].Name() != "home-ann-blog" || sessions[2].Name() != "srv-blog" {
+		t.Errorf("clashing sessions should be named after their paths, got %v", sessions)
+	}
+}
@@ -0,0 +1,192 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mkanban/internal/domain/entity"
+)
+
+const (
+	sessionTypeShell = "shell"
+
+	// shellSessionsFile is where the daemon keeps the shells reported to it
+	shellSessionsFile = "shells.json"
+)
+
+// ShellSessionTracker implements SessionTracker for plain terminals. Shells
+// set up with mkanban shell-init report their directory to the daemon on
+// every prompt, which keeps them in a file under the data directory. A
+// session is a directory with live shells in it, and the active one has the
+// shell that prompted last, the one the user is typing in.
+type ShellSessionTracker struct {
+	path  string
+	mu    sync.Mutex
+	alive func(pid int) bool
+}
+
+// ShellReport is the last prompt of a shell
+type ShellReport struct {
+	PID        int       `json:"pid"`
+	Dir        string    `json:"dir"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// NewShellSessionTracker creates a new ShellSessionTracker keeping its
+// shells in dataDir
+func NewShellSessionTracker(dataDir string) *ShellSessionTracker {
+	return &ShellSessionTracker{
+		path:  filepath.Join(dataDir, shellSessionsFile),
+		alive: processAlive,
+	}
+}
+
+// Report records a prompt of the shell with the given pid in dir, and
+// forgets shells that have exited since
+func (s *ShellSessionTracker) Report(pid int, dir string) error {
+	if pid <= 0 {
+		return fmt.Errorf("invalid shell pid %d", pid)
+	}
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("shell directory must be absolute: %q", dir)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shells, err := s.liveShells()
+	if err != nil {
+		return err
+	}
+
+	report := ShellReport{PID: pid, Dir: filepath.Clean(dir), ReportedAt: time.Now()}
+	replaced := false
+	for i := range shells {
+		if shells[i].PID == pid {
+			shells[i] = report
+			replaced = true
+		}
+	}
+	if !replaced {
+		shells = append(shells, report)
+	}
+
+	return s.save(shells)
+}
+
+// IsAvailable checks if any reporting shell is still running
+func (s *ShellSessionTracker) IsAvailable() bool {
+	shells, err := s.liveShells()
+	return err == nil && len(shells) > 0
+}
+
+// ListSessions returns a session for each directory with live shells, the
+// most recently active first
+func (s *ShellSessionTracker) ListSessions() ([]*entity.Session, error) {
+	shells, err := s.liveShells()
+	if err != nil {
+		return nil, err
+	}
+	return shellSessions(shells)
+}
+
+// GetActiveSession returns the session of the shell that prompted last
+func (s *ShellSessionTracker) GetActiveSession() (*entity.Session, error) {
+	sessions, err := s.ListSessions()
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+// liveShells returns the reported shells that are still running
+func (s *ShellSessionTracker) liveShells() ([]ShellReport, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shell sessions: %w", err)
+	}
+
+	var shells []ShellReport
+	if err := json.Unmarshal(data, &shells); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+
+	live := shells[:0]
+	for _, shell := range shells {
+		if s.alive(shell.PID) {
+			live = append(live, shell)
+		}
+	}
+	return live, nil
+}
+
+// save writes the shells, replacing the file so readers never see half of it
+func (s *ShellSessionTracker) save(shells []ShellReport) error {
+	data, err := json.MarshalIndent(shells, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode shell sessions: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write shell sessions: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write shell sessions: %w", err)
+	}
+	return nil
+}
+
+// shellSessions groups shells by directory, most recent prompt first. A
+// session is named after its directory, or its whole path where names clash.
+func shellSessions(shells []ShellReport) ([]*entity.Session, error) {
+	latest := make(map[string]time.Time)
+	for _, shell := range shells {
+		if shell.ReportedAt.After(latest[shell.Dir]) {
+			latest[shell.Dir] = shell.ReportedAt
+		}
+	}
+
+	dirs := make([]string, 0, len(latest))
+	names := make(map[string]int)
+	for dir := range latest {
+		dirs = append(dirs, dir)
+		names[filepath.Base(dir)]++
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if !latest[dirs[i]].Equal(latest[dirs[j]]) {
+			return latest[dirs[i]].After(latest[dirs[j]])
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	sessions := make([]*entity.Session, 0, len(dirs))
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if names[name] > 1 || name == string(filepath.Separator) {
+			name = strings.Trim(strings.ReplaceAll(dir, string(filepath.Separator), "-"), "-")
+		}
+		if name == "" {
+			name = "root"
+		}
+		session, err := entity.NewSession(name, dir, sessionTypeShell)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
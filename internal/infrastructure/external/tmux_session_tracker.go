@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"mkanban/internal/domain/entity"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -123,3 +124,58 @@ func (t *TmuxSessionTracker) GetActiveSession() (*entity.Session, error) {
 	// No attached session found
 	return nil, nil
 }
+
+// OpenSession creates a detached tmux session in dir unless one with the
+// name exists, then switches to it when running inside tmux
+func (t *TmuxSessionTracker) OpenSession(name, dir string) (bool, error) {
+	// '=' makes tmux match the name exactly instead of by prefix
+	if err := exec.Command("tmux", "has-session", "-t", "="+name).Run(); err != nil {
+		if err := runTmux("new-session", "-d", "-s", name, "-c", dir); err != nil {
+			return false, fmt.Errorf("failed to create tmux session %s: %w", name, err)
+		}
+	}
+
+	if os.Getenv("TMUX") == "" {
+		return false, nil
+	}
+
+	if err := runTmux("switch-client", "-t", "="+name); err != nil {
+		return false, fmt.Errorf("failed to switch to tmux session %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// OpenWindow selects the window with the name in the current tmux session,
+// creating it in dir unless it exists
+func (t *TmuxSessionTracker) OpenWindow(name, dir string) error {
+	if os.Getenv("TMUX") == "" {
+		return fmt.Errorf("not inside a tmux session")
+	}
+
+	out, err := exec.Command("tmux", "list-windows", "-F", "#{window_name}").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	for _, window := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if window == name {
+			return runTmux("select-window", "-t", ":"+name)
+		}
+	}
+
+	if err := runTmux("new-window", "-n", name, "-c", dir); err != nil {
+		return fmt.Errorf("failed to create tmux window %s: %w", name, err)
+	}
+	return nil
+}
+
+// runTmux runs a tmux command, returning its error output with the error
+func runTmux(args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("tmux", args...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w - %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package external
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// patchMarker starts each commit in the patches of hg and jj log, followed
+// by the commit's hash
+const patchMarker = "\x1e"
+
+// patchID hashes a diff in git format like git patch-id --stable: only the
+// changed lines and file names count, without whitespace, and the order of
+// the files doesn't matter. The same change gets the same ID on any base.
+// A diff without changes has an empty ID.
+func patchID(diff string) string {
+	var files []string
+	var file strings.Builder
+	changed := false
+	flush := func() {
+		if file.Len() > 0 {
+			sum := sha1.Sum([]byte(file.String()))
+			files = append(files, hex.EncodeToString(sum[:]))
+			file.Reset()
+		}
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			file.WriteString(strings.Join(strings.Fields(line), ""))
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			file.WriteString(strings.Join(strings.Fields(line), ""))
+		case strings.HasPrefix(line, "+"), strings.HasPrefix(line, "-"):
+			changed = true
+			file.WriteString(strings.Join(strings.Fields(line), ""))
+		}
+	}
+	flush()
+	if !changed {
+		return ""
+	}
+
+	sort.Strings(files)
+	sum := sha1.Sum([]byte(strings.Join(files, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// splitPatches returns the pairs of patch ID and commit of log output where
+// every commit starts with patchMarker and its hash on a line of its own,
+// followed by its diff. Commits without changes are left out.
+func splitPatches(out string) [][2]string {
+	var ids [][2]string
+	for _, record := range strings.Split(out, patchMarker) {
+		commit, diff, _ := strings.Cut(record, "\n")
+		commit = strings.TrimSpace(commit)
+		if commit == "" {
+			continue
+		}
+		if id := patchID(diff); id != "" {
+			ids = append(ids, [2]string{id, commit})
+		}
+	}
+	return ids
+}
+
+// matchSquash finds the commit of target that brought in a branch, given
+// the pairs of patch ID and commit of target since the fork point and of the
+// branch, both newest first, and the patch ID of the branch's whole diff. A
+// commit matching the whole diff is a squash merge; commits matching each
+// commit of the branch are a rebase, merged by the one matching its head.
+func matchSquash(targetPatches [][2]string, branchDiff string, branchPatches [][2]string) string {
+	if branchDiff == "" {
+		// Nothing to merge
+		return ""
+	}
+
+	commitsByPatch := make(map[string]string)
+	for _, patch := range targetPatches {
+		// Oldest commit wins, logs list newest first
+		commitsByPatch[patch[0]] = patch[1]
+	}
+
+	if commit, ok := commitsByPatch[branchDiff]; ok {
+		return commit
+	}
+
+	if len(branchPatches) == 0 {
+		return ""
+	}
+	for _, patch := range branchPatches {
+		if _, ok := commitsByPatch[patch[0]]; !ok {
+			return ""
+		}
+	}
+	return commitsByPatch[branchPatches[0][0]]
+}
+
+// revsetUnion returns a revset of the union of revisions, quoted so names
+// with slashes or dashes stay symbols. Mercurial and Jujutsu both read it.
+func revsetUnion(revisions []string) string {
+	quoted := make([]string, len(revisions))
+	for i, revision := range revisions {
+		quoted[i] = strconv.Quote(revision)
+	}
+	return "(" + strings.Join(quoted, " | ") + ")"
+}
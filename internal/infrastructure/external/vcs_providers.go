@@ -0,0 +1,165 @@
+package external
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"mkanban/internal/domain/service"
+)
+
+// AutoVCSProvider implements VCSProvider for git, Jujutsu and Mercurial,
+// picking the provider of each repository by the nearest .jj, .hg or .git
+// at or above the path. Jujutsu comes first, so colocated repositories are
+// read through their bookmarks. A VCS that isn't installed is passed over,
+// and paths outside any repository, bare git repositories among them, go to
+// git.
+type AutoVCSProvider struct {
+	markers  []vcsMarker
+	fallback service.VCSProvider
+	lookPath func(file string) (string, error)
+}
+
+// vcsMarker is the directory a VCS keeps at the root of its checkouts, with
+// the command that reads it
+type vcsMarker struct {
+	dir      string
+	command  string
+	provider service.VCSProvider
+}
+
+// NewAutoVCSProvider creates a new AutoVCSProvider
+func NewAutoVCSProvider() *AutoVCSProvider {
+	git := NewGitVCSProvider()
+	return &AutoVCSProvider{
+		markers: []vcsMarker{
+			{dir: ".jj", command: "jj", provider: NewJjVCSProvider()},
+			{dir: ".hg", command: "hg", provider: NewHgVCSProvider()},
+			{dir: ".git", command: "git", provider: git},
+		},
+		fallback: git,
+		lookPath: exec.LookPath,
+	}
+}
+
+// providerFor returns the provider of the repository at path
+func (a *AutoVCSProvider) providerFor(path string) service.VCSProvider {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return a.fallback
+	}
+
+	for {
+		for _, marker := range a.markers {
+			// Git worktrees have a .git file
+			if _, err := os.Stat(filepath.Join(dir, marker.dir)); err != nil {
+				continue
+			}
+			if _, err := a.lookPath(marker.command); err == nil {
+				return marker.provider
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return a.fallback
+		}
+		dir = parent
+	}
+}
+
+// IsRepository checks if the given path is in a repository
+func (a *AutoVCSProvider) IsRepository(path string) bool {
+	return a.providerFor(path).IsRepository(path)
+}
+
+// GetRepositoryRoot returns the root directory of the repository
+func (a *AutoVCSProvider) GetRepositoryRoot(path string) (string, error) {
+	return a.providerFor(path).GetRepositoryRoot(path)
+}
+
+// GetMainRepositoryRoot returns the root directory of the main checkout
+func (a *AutoVCSProvider) GetMainRepositoryRoot(path string) (string, error) {
+	return a.providerFor(path).GetMainRepositoryRoot(path)
+}
+
+// GetCurrentBranch returns the name of the current branch
+func (a *AutoVCSProvider) GetCurrentBranch(repoPath string) (string, error) {
+	return a.providerFor(repoPath).GetCurrentBranch(repoPath)
+}
+
+// ListBranches returns the local branches of the repository
+func (a *AutoVCSProvider) ListBranches(repoPath string) ([]string, error) {
+	return a.providerFor(repoPath).ListBranches(repoPath)
+}
+
+// GetRefsPath returns the path to watch for branch changes
+func (a *AutoVCSProvider) GetRefsPath(repoPath string) string {
+	return a.providerFor(repoPath).GetRefsPath(repoPath)
+}
+
+// BranchExists checks if a branch with the given name exists
+func (a *AutoVCSProvider) BranchExists(repoPath, branchName string) (bool, error) {
+	return a.providerFor(repoPath).BranchExists(repoPath, branchName)
+}
+
+// CheckoutBranch checks out an existing branch
+func (a *AutoVCSProvider) CheckoutBranch(repoPath, branchName string) error {
+	return a.providerFor(repoPath).CheckoutBranch(repoPath, branchName)
+}
+
+// CreateAndCheckoutBranch creates a new branch and checks it out
+func (a *AutoVCSProvider) CreateAndCheckoutBranch(repoPath, branchName string) error {
+	return a.providerFor(repoPath).CreateAndCheckoutBranch(repoPath, branchName)
+}
+
+// ListBranchHeads returns the commit each local branch points to
+func (a *AutoVCSProvider) ListBranchHeads(repoPath string) (map[string]string, error) {
+	return a.providerFor(repoPath).ListBranchHeads(repoPath)
+}
+
+// ListWorktrees returns the directory of every working tree by its branch
+func (a *AutoVCSProvider) ListWorktrees(repoPath string) (map[string]string, error) {
+	return a.providerFor(repoPath).ListWorktrees(repoPath)
+}
+
+// AddWorktree checks out a branch in a new working tree at path
+func (a *AutoVCSProvider) AddWorktree(repoPath, path, branchName string, createBranch bool) error {
+	return a.providerFor(repoPath).AddWorktree(repoPath, path, branchName, createBranch)
+}
+
+// ListCommits returns the commits of included but not excluded, newest first
+func (a *AutoVCSProvider) ListCommits(repoPath string, included, excluded []string, limit int) ([]service.Commit, error) {
+	return a.providerFor(repoPath).ListCommits(repoPath, included, excluded, limit)
+}
+
+// GetDefaultBranch returns the branch work is merged into
+func (a *AutoVCSProvider) GetDefaultBranch(repoPath string) (string, error) {
+	return a.providerFor(repoPath).GetDefaultBranch(repoPath)
+}
+
+// FindMergeCommit returns the commit of target that merged branch into it
+func (a *AutoVCSProvider) FindMergeCommit(repoPath, branch, target string) (string, error) {
+	return a.providerFor(repoPath).FindMergeCommit(repoPath, branch, target)
+}
+
+// FindSquashMerge returns the commit of target that squashed branch into it
+func (a *AutoVCSProvider) FindSquashMerge(repoPath, branch, target string) (string, error) {
+	return a.providerFor(repoPath).FindSquashMerge(repoPath, branch, target)
+}
+
+// DeleteBranch deletes a local branch
+func (a *AutoVCSProvider) DeleteBranch(repoPath, branchName string, force bool) error {
+	return a.providerFor(repoPath).DeleteBranch(repoPath, branchName, force)
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,270 @@
+package external
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAutoVCSProvider(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"colocated/.jj", "colocated/.git", "colocated/web/src", "hg/.hg", "hg/docs", "git/.git", "plain"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A linked git worktree has a .git file
+	if err := os.MkdirAll(filepath.Join(root, "worktree"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "worktree", ".git"), []byte("gitdir: ../git/.git/worktrees/wt\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path      string
+		installed string
+		want      string
+	}{
+		{"colocated", "git hg jj", "jj"},
+		{"colocated/web/src", "git hg jj", "jj"},
+		{"colocated/web/src", "git hg", "git"},
+		{"hg/docs", "git hg jj", "hg"},
+		{"hg/docs", "git jj", "git"},
+		{"git", "git hg jj", "git"},
+		{"worktree", "git hg jj", "git"},
+		{"plain", "git hg jj", "git"},
+	}
+	for _, tt := range tests {
+		provider := NewAutoVCSProvider()
+		provider.lookPath = func(file string) (string, error) {
+			if strings.Contains(" "+tt.installed+" ", " "+file+" ") {
+				return "/usr/bin/" + file, nil
+			}
+			return "", exec.ErrNotFound
+		}
+
+		got := "git"
+		switch provider.providerFor(filepath.Join(root, tt.path)).(type) {
+		case *JjVCSProvider:
+			got = "jj"
+		case *HgVCSProvider:
+			got = "hg"
+		}
+		if got != tt.want {
+			t.Errorf("provider of %s with %s installed = %s, want %s", tt.path, tt.installed, got, tt.want)
+		}
+	}
+}
+
+// gitDiff is a diff in git format changing two files
+func gitDiff(line int, first, second string) string {
+	return fmt.Sprintf(`diff --git a/%[2]s b/%[2]s
+--- a/%[2]s
++++ b/%[2]s
+@@ -%[1]d,3 +%[1]d,3 @@ func main() {
+ 	context
+-	old()
++	new()
+diff --git a/%[3]s b/%[3]s
+--- a/%[3]s
++++ b/%[3]s
+@@ -1 +1,2 @@
+ package main
++// Added
+`, line, first, second)
+}
+
+func TestPatchID(t *testing.T) {
+	id := patchID(gitDiff(10, "main.go", "util.go"))
+	if id == "" {
+		t.Fatal("a diff with changes should have an ID")
+	}
+
+	if got := patchID(gitDiff(42, "main.go", "util.go")); got != id {
+		t.Error("the ID shouldn't depend on line numbers")
+	}
+	if got := patchID(strings.ReplaceAll(gitDiff(10, "main.go", "util.go"), "\tnew()", "  new( )")); got != id {
+		t.Error("the ID shouldn't depend on whitespace")
+	}
+	swapped := gitDiff(10, "util.go", "main.go")
+	if got := patchID(swapped); got == id {
+		t.Error("changes to other files should have another ID")
+	}
+	if got := patchID(strings.ReplaceAll(gitDiff(10, "main.go", "util.go"), "new()", "other()")); got == id {
+		t.Error("other changes should have another ID")
+	}
+	if got := patchID("diff --git a/x b/x\nold mode 100644\nnew mode 100755\n"); got != "" {
+		t.Errorf("a diff without changed lines should have no ID, got %q", got)
+	}
+}
+
+func TestSplitPatches(t *testing.T) {
+	out := patchMarker + "c3\n" + gitDiff(1, "a.go", "b.go") +
+		patchMarker + "c2\n" +
+		patchMarker + "c1\n" + gitDiff(5, "a.go", "b.go")
+
+	patches := splitPatches(out)
+	if len(patches) != 2 || patches[0][1] != "c3" || patches[1][1] != "c1" {
+		t.Fatalf("patches = %v, want c3 and c1 without the empty c2", patches)
+	}
+	if patches[0][0] != patches[1][0] {
+		t.Error("the same change should have the same ID")
+	}
+}
+
+func TestMatchSquash(t *testing.T) {
+	target := [][2]string{{"p9", "t3"}, {"p2", "t2"}, {"p1", "t1"}, {"p1", "t0"}}
+
+	tests := []struct {
+		name   string
+		diff   string
+		branch [][2]string
+		want   string
+	}{
+		{"squashed", "p9", [][2]string{{"p2", "b2"}, {"p5", "b1"}}, "t3"},
+		{"rebased", "p7", [][2]string{{"p2", "b2"}, {"p1", "b1"}}, "t2"},
+		{"partly rebased", "p7", [][2]string{{"p2", "b2"}, {"p5", "b1"}}, ""},
+		{"oldest match wins", "p1", nil, "t0"},
+		{"nothing to merge", "", [][2]string{{"p2", "b2"}}, ""},
+	}
+	for _, tt := range tests {
+		if got := matchSquash(target, tt.diff, tt.branch); got != tt.want {
+			t.Errorf("%s: matchSquash() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHgVCSProvider(t *testing.T) {
+	if _, err := exec.LookPath("hg"); err != nil {
+		t.Skip("hg not installed")
+	}
+	repo := t.TempDir()
+	hg := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("hg", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "HGPLAIN=1", "HGUSER=Ann <ann@example.com>", "HGRCPATH=")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("hg %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hg("init")
+	write("README", "shop\n")
+	hg("commit", "-A", "-m", "Initial commit")
+	hg("bookmark", "main")
+	provider := NewHgVCSProvider()
+
+	if !provider.IsRepository(repo) || provider.GetRefsPath(repo) != filepath.Join(repo, ".hg") {
+		t.Fatal("the repository and its .hg should be found")
+	}
+	if err := provider.CreateAndCheckoutBranch(repo, "PRO-12-login"); err != nil {
+		t.Fatal(err)
+	}
+	write("login.go", "package login\n")
+	hg("commit", "-A", "-m", "Add login form", "-m", "Part of PRO-12.")
+
+	if branch, err := provider.GetCurrentBranch(repo); err != nil || branch != "PRO-12-login" {
+		t.Errorf("current branch = %q, %v", branch, err)
+	}
+	branches, err := provider.ListBranches(repo)
+	if err != nil || strings.Join(branches, " ") != "PRO-12-login main" {
+		t.Errorf("branches = %v, %v", branches, err)
+	}
+
+	commits, err := provider.ListCommits(repo, []string{"PRO-12-login"}, []string{"main"}, 10)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("commits = %+v, %v", commits, err)
+	}
+	if c := commits[0]; c.Subject != "Add login form" || c.Body != "Part of PRO-12." || c.Author != "Ann" || c.Time.IsZero() {
+		t.Errorf("commit = %+v", c)
+	}
+
+	// Graft the change onto main, like a rebase would
+	if err := provider.CheckoutBranch(repo, "main"); err != nil {
+		t.Fatal(err)
+	}
+	hg("graft", "-r", "PRO-12-login")
+	if merge, err := provider.FindMergeCommit(repo, "PRO-12-login", "main"); err != nil || merge != "" {
+		t.Errorf("a grafted branch isn't merged, got %q, %v", merge, err)
+	}
+	merge, err := provider.FindSquashMerge(repo, "PRO-12-login", "main")
+	if err != nil || merge == "" {
+		t.Errorf("the graft should match, got %q, %v", merge, err)
+	}
+	if err := provider.DeleteBranch(repo, "PRO-12-login", false); err == nil {
+		t.Error("an unmerged bookmark shouldn't be deleted without force")
+	}
+	if err := provider.DeleteBranch(repo, "PRO-12-login", true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJjVCSProvider(t *testing.T) {
+	if _, err := exec.LookPath("jj"); err != nil {
+		t.Skip("jj not installed")
+	}
+	repo := t.TempDir()
+	jj := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("jj", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "JJ_USER=Ann", "JJ_EMAIL=ann@example.com", "JJ_CONFIG=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("jj %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	jj("git", "init", "--colocate")
+	jj("describe", "-m", "Initial commit")
+	jj("bookmark", "create", "main", "-r", "@")
+	jj("new", "-m", "Add login form\n\nPart of PRO-12.")
+	if err := os.WriteFile(filepath.Join(repo, "login.go"), []byte("package login\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	provider := NewJjVCSProvider()
+	if err := provider.CreateAndCheckoutBranch(repo, "PRO-12-login"); err != nil {
+		t.Fatal(err)
+	}
+	jj("new")
+
+	if !provider.IsRepository(repo) || provider.GetRefsPath(repo) == "" {
+		t.Fatal("the repository and its operation heads should be found")
+	}
+	if branch, err := provider.GetCurrentBranch(repo); err != nil || branch != "PRO-12-login" {
+		t.Errorf("current branch = %q, %v, want the nearest bookmark", branch, err)
+	}
+	heads, err := provider.ListBranchHeads(repo)
+	if err != nil || len(heads) != 2 || heads["main"] == "" || heads["PRO-12-login"] == "" {
+		t.Errorf("heads = %v, %v", heads, err)
+	}
+	if root, err := provider.GetMainRepositoryRoot(repo); err != nil || root != repo {
+		t.Errorf("main root = %q, %v", root, err)
+	}
+
+	commits, err := provider.ListCommits(repo, []string{"PRO-12-login"}, []string{"main"}, 10)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("commits = %+v, %v", commits, err)
+	}
+	if c := commits[0]; c.Subject != "Add login form" || c.Body != "Part of PRO-12." || c.Hash != heads["PRO-12-login"] {
+		t.Errorf("commit = %+v", c)
+	}
+
+	if merge, err := provider.FindMergeCommit(repo, "PRO-12-login", "main"); err != nil || merge != "" {
+		t.Errorf("an unmerged bookmark has no merge, got %q, %v", merge, err)
+	}
+	jj("bookmark", "set", "main", "-r", "PRO-12-login")
+	if merge, err := provider.FindMergeCommit(repo, "PRO-12-login", "main"); err != nil || merge != heads["PRO-12-login"] {
+		t.Errorf("a fast-forward should be merged by the head, got %q, %v", merge, err)
+	}
+}
@@ -0,0 +1,193 @@
+package external
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mkanban/internal/application/dto"
+	"mkanban/internal/domain/entity"
+)
+
+const (
+	// defaultWebhookTimeout applies when a webhook action sets no timeout
+	defaultWebhookTimeout = 10 * time.Second
+	// maxWebhookResponse is how much of a response body is kept as output
+	maxWebhookResponse = 4096
+
+	// SignatureHeader carries "sha256=<hex HMAC of the body>" for signed webhooks
+	SignatureHeader = "X-Mkanban-Signature"
+	// EventHeader carries the type of the event that triggered the webhook
+	EventHeader = "X-Mkanban-Event"
+	// DeliveryHeader carries a unique ID per delivery, the same across retries
+	DeliveryHeader = "X-Mkanban-Delivery"
+)
+
+// WebhookPayload is the JSON body of a webhook
+type WebhookPayload struct {
+	DeliveryID string        `json:"delivery_id"`
+	SentAt     time.Time     `json:"sent_at"`
+	Event      *WebhookEvent `json:"event,omitempty"`
+	Board      *WebhookBoard `json:"board,omitempty"`
+	Column     string        `json:"column,omitempty"`
+	Task       *dto.TaskDTO  `json:"task,omitempty"`
+	Tasks      []dto.TaskDTO `json:"tasks,omitempty"`
+}
+
+// WebhookEvent describes the event that triggered a webhook
+type WebhookEvent struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	BoardID   string                 `json:"board_id,omitempty"`
+	ColumnID  string                 `json:"column_id,omitempty"`
+	TaskID    string                 `json:"task_id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WebhookBoard identifies the board of a webhook
+type WebhookBoard struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// HTTPWebhookSender delivers webhooks over HTTP. Requests that fail to
+// connect, time out, or get a 429 or 5xx response are retried with
+// exponential backoff.
+type HTTPWebhookSender struct {
+	client  *http.Client
+	backoff time.Duration
+}
+
+// NewHTTPWebhookSender creates a new webhook sender
+func NewHTTPWebhookSender() *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client:  &http.Client{},
+		backoff: time.Second,
+	}
+}
+
+// SendWebhook posts the payload for the request and returns the status and
+// body of the last response
+func (s *HTTPWebhookSender) SendWebhook(req *entity.WebhookRequest) (string, error) {
+	payload := buildWebhookPayload(req)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	backoff := s.backoff
+	var output string
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		output, retryable, err = s.post(req, payload, body, timeout)
+		if err == nil || !retryable || attempt >= req.Retries {
+			return output, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post makes a single delivery attempt and reports whether a failure is
+// worth retrying
+func (s *HTTPWebhookSender) post(req *entity.WebhookRequest, payload *WebhookPayload, body []byte, timeout time.Duration) (string, bool, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", false, fmt.Errorf("invalid webhook request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "mkanban-webhook")
+	httpReq.Header.Set(DeliveryHeader, payload.DeliveryID)
+	if payload.Event != nil {
+		httpReq.Header.Set(EventHeader, payload.Event.Type)
+	}
+	if req.Secret != "" {
+		httpReq.Header.Set(SignatureHeader, SignPayload(req.Secret, body))
+	}
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	client := *s.client
+	client.Timeout = timeout
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return "", true, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponse))
+	output := resp.Status
+	if len(respBody) > 0 {
+		output += "\n" + string(respBody)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return output, false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return output, retryable, fmt.Errorf("webhook returned %s", resp.Status)
+}
+
+// SignPayload returns the signature header value for a body: the hex
+// HMAC-SHA256 of the body under the secret, prefixed with "sha256="
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// buildWebhookPayload converts the request context to the JSON payload
+func buildWebhookPayload(req *entity.WebhookRequest) *WebhookPayload {
+	payload := &WebhookPayload{
+		DeliveryID: uuid.New().String(),
+		SentAt:     time.Now(),
+	}
+
+	if e := req.Event; e != nil {
+		payload.Event = &WebhookEvent{
+			ID:        e.ID,
+			Type:      string(e.Type),
+			Timestamp: e.Timestamp,
+			BoardID:   e.BoardID,
+			ColumnID:  e.ColumnID,
+			Metadata:  e.Metadata,
+		}
+		if e.TaskID != nil {
+			payload.Event.TaskID = e.TaskID.String()
+		}
+	}
+	if req.Board != nil {
+		payload.Board = &WebhookBoard{ID: req.Board.ID(), Name: req.Board.Name()}
+	}
+	if req.Column != nil {
+		payload.Column = req.Column.Name()
+	}
+	if req.Task != nil {
+		task := dto.TaskToDTO(req.Task)
+		if req.Column != nil {
+			task.ColumnName = req.Column.Name()
+		}
+		payload.Task = &task
+	}
+	for _, task := range req.Tasks {
+		payload.Tasks = append(payload.Tasks, dto.TaskToDTO(task))
+	}
+
+	return payload
+}
@@ -0,0 +1,255 @@
+package external
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+)
+
+// newWebhookContext builds a board with one column holding one task and
+// the event of that task being moved
+func newWebhookContext(t *testing.T) *entity.ActionContext {
+	t.Helper()
+
+	board, err := entity.NewBoard("demo/demo", "demo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	column, err := entity.NewColumn("doing", "", 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddColumn(column); err != nil {
+		t.Fatal(err)
+	}
+	id, err := valueobject.NewTaskID("DEM", 1, "fix-login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := entity.NewTask(id, "Fix login", "", valueobject.PriorityHigh, valueobject.StatusInProgress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := column.AddTask(task); err != nil {
+		t.Fatal(err)
+	}
+
+	return &entity.ActionContext{
+		Task:   task,
+		Column: column,
+		Board:  board,
+		Event:  entity.NewDomainEvent(valueobject.EventTaskMoved, board.ID(), column.Name(), id, nil),
+	}
+}
+
+// newTestSender returns a sender that doesn't wait between retries
+func newTestSender() *HTTPWebhookSender {
+	sender := NewHTTPWebhookSender()
+	sender.backoff = time.Millisecond
+	return sender
+}
+
+func TestWebhookActionDelivery(t *testing.T) {
+	var (
+		body    []byte
+		headers http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ctx := newWebhookContext(t)
+	ctx.WebhookSender = newTestSender()
+	action := entity.NewWebhookAction(server.URL + "/hook")
+	action.Secret = "s3cret"
+	action.Headers["Authorization"] = "Bearer token"
+
+	if err := action.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := action.Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(ctx.Output, "200 OK") || !strings.Contains(ctx.Output, "ok") {
+		t.Errorf("unexpected output %q", ctx.Output)
+	}
+	if got := headers.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected JSON content type, got %q", got)
+	}
+	if got := headers.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("expected custom header, got %q", got)
+	}
+	if got := headers.Get(EventHeader); got != string(valueobject.EventTaskMoved) {
+		t.Errorf("expected event header %q, got %q", valueobject.EventTaskMoved, got)
+	}
+	if got, want := headers.Get(SignatureHeader), SignPayload("s3cret", body); got != want {
+		t.Errorf("expected signature %q, got %q", want, got)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.DeliveryID == "" || payload.DeliveryID != headers.Get(DeliveryHeader) {
+		t.Errorf("expected delivery ID %q in payload, got %q", headers.Get(DeliveryHeader), payload.DeliveryID)
+	}
+	if payload.Event == nil || payload.Event.TaskID != ctx.Task.ID().String() {
+		t.Errorf("expected event for %s, got %+v", ctx.Task.ID(), payload.Event)
+	}
+	if payload.Board == nil || payload.Board.Name != "demo" || payload.Column != "doing" {
+		t.Errorf("unexpected board %+v and column %q", payload.Board, payload.Column)
+	}
+	if payload.Task == nil || payload.Task.Title != "Fix login" || payload.Task.Priority != "high" || payload.Task.ColumnName != "doing" {
+		t.Errorf("unexpected task %+v", payload.Task)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		retries   int
+		wantErr   bool
+		wantCalls int32
+	}{
+		{"success needs no retry", []int{200}, 3, false, 1},
+		{"retries server errors", []int{503, 502, 200}, 3, false, 3},
+		{"retries rate limiting", []int{429, 200}, 1, false, 2},
+		{"gives up after the retries", []int{500, 500, 500}, 2, true, 3},
+		{"client errors are not retried", []int{404, 200}, 3, true, 1},
+		{"no retries by default", []int{500, 200}, 0, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			var deliveries []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				deliveries = append(deliveries, r.Header.Get(DeliveryHeader))
+				w.WriteHeader(tt.statuses[int(n)-1])
+			}))
+			defer server.Close()
+
+			_, err := newTestSender().SendWebhook(&entity.WebhookRequest{URL: server.URL, Retries: tt.retries})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%t, got %v", tt.wantErr, err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, calls)
+			}
+			for _, id := range deliveries {
+				if id != deliveries[0] {
+					t.Errorf("expected one delivery ID across retries, got %v", deliveries)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	_, err := newTestSender().SendWebhook(&entity.WebhookRequest{URL: server.URL, Timeout: 50 * time.Millisecond, Retries: 1})
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("timeout not applied, took %s", elapsed)
+	}
+}
+
+func TestWebhookActionValidation(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/mkanban", false},
+		{"http://localhost:8080", false},
+		{"", true},
+		{"hooks.example.com/mkanban", true},
+		{"ftp://example.com", true},
+		{"https://", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := entity.NewWebhookAction(tt.url).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCommandAction(t *testing.T) {
+	ctx := newWebhookContext(t)
+	ctx.CommandRunner = NewScriptExecutor(true, t.TempDir())
+
+	action := entity.NewCommandAction("sh", []string{"-c", `echo "$1 $TASK_STATUS $GREETING"`, "sh", "{{.Task.Title}}"})
+	action.EnvVars["GREETING"] = "hi"
+	if err := action.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := action.Execute(ctx); err != nil {
+		t.Fatalf("command failed: %v (%s)", err, ctx.Output)
+	}
+	if got := strings.TrimSpace(ctx.Output); got != "Fix login in_progress hi" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		command string
+		args    []string
+		timeout time.Duration
+		wantErr string
+	}{
+		{"runs", true, "true", nil, 0, ""},
+		{"failure", true, "false", nil, 0, "command execution failed"},
+		{"unknown command", true, "mkanban-no-such-command", nil, 0, "command execution failed"},
+		{"timeout", true, "sleep", []string{"5"}, 50 * time.Millisecond, "timed out"},
+		{"disabled", false, "true", nil, 0, "disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewScriptExecutor(tt.enabled, t.TempDir())
+			_, err := executor.RunCommand(tt.command, tt.args, nil, tt.timeout)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"mkanban/internal/domain/entity"
+)
+
+const sessionTypeZellij = "zellij"
+
+// ZellijSessionTracker implements SessionTracker for zellij. Zellij doesn't
+// report the directory of a session, so it's read from the session's
+// layout: the focused pane of the focused tab.
+type ZellijSessionTracker struct{}
+
+// NewZellijSessionTracker creates a new ZellijSessionTracker
+func NewZellijSessionTracker() *ZellijSessionTracker {
+	return &ZellijSessionTracker{}
+}
+
+// zellijSession is a line of zellij list-sessions
+type zellijSession struct {
+	name    string
+	current bool
+}
+
+// IsAvailable checks if zellij is installed and has running sessions
+func (z *ZellijSessionTracker) IsAvailable() bool {
+	if _, err := exec.LookPath("zellij"); err != nil {
+		return false
+	}
+
+	sessions, err := z.listSessions()
+	return err == nil && len(sessions) > 0
+}
+
+// ListSessions returns all running zellij sessions
+func (z *ZellijSessionTracker) ListSessions() ([]*entity.Session, error) {
+	listed, err := z.listSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(listed))
+	for _, s := range listed {
+		session, err := z.session(s.name)
+		if err != nil {
+			// Skip sessions whose layout can't be read
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// GetActiveSession returns the zellij session mkanban runs in, else the
+// one zellij marks as current, else the first running one. Zellij doesn't
+// say which sessions have clients attached.
+func (z *ZellijSessionTracker) GetActiveSession() (*entity.Session, error) {
+	listed, err := z.listSessions()
+	if err != nil {
+		return nil, err
+	}
+	if len(listed) == 0 {
+		return nil, nil
+	}
+
+	active := listed[0].name
+	for _, s := range listed {
+		if s.current {
+			active = s.name
+			break
+		}
+	}
+	if name := os.Getenv("ZELLIJ_SESSION_NAME"); name != "" {
+		for _, s := range listed {
+			if s.name == name {
+				active = name
+			}
+		}
+	}
+
+	return z.session(active)
+}
+
+// session returns the session with its working directory
+func (z *ZellijSessionTracker) session(name string) (*entity.Session, error) {
+	cmd := exec.Command("zellij", "--session", name, "action", "dump-layout")
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to read layout of zellij session %s: %w - %s", name, err, stderr.String())
+	}
+
+	workingDir := focusedPaneDir(out.String())
+	if workingDir == "" {
+		return nil, fmt.Errorf("no working directory in layout of zellij session %s", name)
+	}
+	return entity.NewSession(name, workingDir, sessionTypeZellij)
+}
+
+// listSessions parses zellij list-sessions, leaving out exited sessions
+// that can only be resurrected
+func (z *ZellijSessionTracker) listSessions() ([]zellijSession, error) {
+	cmd := exec.Command("zellij", "list-sessions", "--no-formatting")
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Zellij fails when there are no sessions at all
+		if strings.Contains(stderr.String(), "No active zellij sessions") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list zellij sessions: %w - %s", err, stderr.String())
+	}
+
+	var sessions []zellijSession
+	for _, line := range strings.Split(out.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.Contains(line, "EXITED") {
+			continue
+		}
+		sessions = append(sessions, zellijSession{
+			name:    fields[0],
+			current: strings.Contains(line, "(current)"),
+		})
+	}
+
+	return sessions, nil
+}
+
+var (
+	// layoutCwdPattern matches the cwd of a whole layout
+	layoutCwdPattern = regexp.MustCompile(`^\s*cwd\s+"([^"]*)"`)
+	// paneCwdPattern matches the cwd attribute of a pane
+	paneCwdPattern = regexp.MustCompile(`\bcwd="([^"]*)"`)
+)
+
+// focusedPaneDir returns the directory of the focused pane of the focused
+// tab in a KDL layout from zellij action dump-layout. Pane directories are
+// relative to the layout's; panes without one are in it.
+func focusedPaneDir(layout string) string {
+	layoutDir := ""
+	paneDir := ""
+	found := false
+
+	depth := 0
+	tabDepth := -1 // depth inside the focused tab, -1 outside it
+	for _, line := range strings.Split(layout, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if match := layoutCwdPattern.FindStringSubmatch(line); match != nil && tabDepth < 0 {
+			layoutDir = match[1]
+		}
+		if strings.HasPrefix(trimmed, "tab ") && strings.Contains(trimmed, "focus=true") {
+			tabDepth = depth + 1
+		}
+		if tabDepth >= 0 && !found && strings.HasPrefix(trimmed, "pane") {
+			match := paneCwdPattern.FindStringSubmatch(trimmed)
+			if strings.Contains(trimmed, "focus=true") {
+				found = true
+				paneDir = ""
+				if match != nil {
+					paneDir = match[1]
+				}
+			} else if match != nil && paneDir == "" {
+				// The first pane with a directory, unless one has focus
+				paneDir = match[1]
+			}
+		}
+
+		depth += strings.Count(trimmed, "{") - strings.Count(trimmed, "}")
+		if tabDepth >= 0 && depth < tabDepth {
+			tabDepth = -1
+		}
+	}
+
+	switch {
+	case paneDir == "":
+		return layoutDir
+	case filepath.IsAbs(paneDir) || layoutDir == "":
+		return paneDir
+	default:
+		return filepath.Join(layoutDir, paneDir)
+	}
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/persistence/sqlite"
+)
+
+// defaultDatabaseFile is the SQLite database in the data path when
+// storage.database_path is not configured
+const defaultDatabaseFile = "mkanban.db"
+
+// Repositories are the repositories of one storage backend
+type Repositories struct {
+	Backend          string
+	Boards           repository.BoardRepository
+	Projects         repository.ProjectRepository
+	Notes            repository.NoteRepository
+	TimeLogs         repository.TimeLogRepository
+	Actions          repository.ActionRepository
+	ActionExecutions repository.ActionExecutionRepository
+
+	store *sqlite.Store
+}
+
+// NewRepositories creates the repositories of the given backend, the
+// configured one when backend is empty
+func NewRepositories(cfg *config.Config, backend string) (*Repositories, error) {
+	if backend == "" {
+		backend = Backend(cfg)
+	}
+
+	switch backend {
+	case config.StorageBackendFilesystem:
+		return &Repositories{
+			Backend:          backend,
+			Boards:           filesystem.NewBoardRepository(cfg.Storage.DataPath),
+			Projects:         filesystem.NewProjectRepository(cfg.Storage.DataPath),
+			Notes:            filesystem.NewNoteRepository(cfg.Storage.DataPath),
+			TimeLogs:         filesystem.NewTimeLogRepository(cfg.Storage.DataPath),
+			Actions:          filesystem.NewActionRepository(cfg),
+			ActionExecutions: filesystem.NewActionExecutionRepository(cfg),
+		}, nil
+	case config.StorageBackendSQLite:
+		store := sqlite.NewStore(DatabasePath(cfg))
+		return &Repositories{
+			Backend:          backend,
+			Boards:           sqlite.NewBoardRepository(store),
+			Projects:         sqlite.NewProjectRepository(store),
+			Notes:            sqlite.NewNoteRepository(store),
+			TimeLogs:         sqlite.NewTimeLogRepository(store),
+			Actions:          sqlite.NewActionRepository(store),
+			ActionExecutions: sqlite.NewActionExecutionRepository(store, cfg),
+			store:            store,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q, use %q or %q", backend, config.StorageBackendFilesystem, config.StorageBackendSQLite)
+	}
+}
+
+// Close releases what the repositories hold open
+func (r *Repositories) Close() error {
+	if r.store == nil {
+		return nil
+	}
+	return r.store.Close()
+}
+
+// Backend returns the configured storage backend
+func Backend(cfg *config.Config) string {
+	if cfg.Storage.Backend == "" {
+		return config.StorageBackendFilesystem
+	}
+	return cfg.Storage.Backend
+}
+
+// DatabasePath returns the path of the SQLite database
+func DatabasePath(cfg *config.Config) string {
+	if cfg.Storage.DatabasePath != "" {
+		return cfg.Storage.DatabasePath
+	}
+	return filepath.Join(cfg.Storage.DataPath, defaultDatabaseFile)
+}
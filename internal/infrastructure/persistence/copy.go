@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+)
+
+// CopyResult counts what a copy between backends wrote
+type CopyResult struct {
+	Projects         int `json:"projects"`
+	Boards           int `json:"boards"`
+	Tasks            int `json:"tasks"`
+	Notes            int `json:"notes"`
+	TimeLogs         int `json:"time_logs"`
+	Actions          int `json:"actions"`
+	ActionExecutions int `json:"action_executions"`
+}
+
+// Copy writes everything stored in from to to, which is how data moves
+// between the filesystem and SQLite layouts. Entities already in to are
+// overwritten by their copy and others are left alone, so a copy can be
+// repeated.
+func Copy(ctx context.Context, from, to *Repositories) (*CopyResult, error) {
+	result := &CopyResult{}
+
+	projects, err := from.Projects.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, project := range projects {
+		if err := to.Projects.Save(ctx, project); err != nil {
+			return nil, fmt.Errorf("failed to copy project %s: %w", project.Slug(), err)
+		}
+		result.Projects++
+	}
+
+	boards, err := from.Boards.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+	for _, board := range boards {
+		if err := to.Boards.Save(ctx, board); err != nil {
+			return nil, fmt.Errorf("failed to copy board %s: %w", board.ID(), err)
+		}
+		result.Boards++
+		result.Tasks += board.TotalTaskCount()
+	}
+
+	// Notes and time logs are found through their project, global notes
+	// on their own
+	notes, err := from.Notes.FindGlobal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list global notes: %w", err)
+	}
+	for _, project := range projects {
+		projectNotes, err := from.Notes.FindByProject(ctx, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notes of %s: %w", project.Slug(), err)
+		}
+		notes = append(notes, projectNotes...)
+
+		logs, err := from.TimeLogs.FindByProject(ctx, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list time logs of %s: %w", project.Slug(), err)
+		}
+		for _, log := range logs {
+			if err := to.TimeLogs.Save(ctx, log); err != nil {
+				return nil, fmt.Errorf("failed to copy time log %s: %w", log.ID(), err)
+			}
+			result.TimeLogs++
+		}
+	}
+	for _, note := range notes {
+		if err := to.Notes.Save(ctx, note); err != nil {
+			return nil, fmt.Errorf("failed to copy note %s: %w", note.ID(), err)
+		}
+		result.Notes++
+	}
+
+	actions, err := from.Actions.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actions: %w", err)
+	}
+	for _, action := range actions {
+		if err := copyAction(ctx, action, to); err != nil {
+			return nil, fmt.Errorf("failed to copy action %s: %w", action.ID(), err)
+		}
+		result.Actions++
+
+		executions, err := copyHistory(ctx, action.ID(), from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy history of action %s: %w", action.ID(), err)
+		}
+		result.ActionExecutions += executions
+	}
+
+	return result, nil
+}
+
+// copyAction creates the action in to, or updates the copy already there
+func copyAction(ctx context.Context, action *entity.Action, to *Repositories) error {
+	_, err := to.Actions.GetByID(ctx, action.ID())
+	if err == entity.ErrActionNotFound {
+		return to.Actions.Create(ctx, action)
+	}
+	if err != nil {
+		return err
+	}
+	return to.Actions.Update(ctx, action)
+}
+
+// copyHistory replaces the execution history of an action in to with the
+// one in from
+func copyHistory(ctx context.Context, actionID string, from, to *Repositories) (int, error) {
+	executions, err := from.ActionExecutions.ListByAction(ctx, actionID, 0)
+	if err != nil {
+		return 0, err
+	}
+	if err := to.ActionExecutions.DeleteByAction(ctx, actionID); err != nil {
+		return 0, err
+	}
+
+	// Histories are listed newest first and saved oldest first
+	for i := len(executions) - 1; i >= 0; i-- {
+		if err := to.ActionExecutions.Save(ctx, executions[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(executions), nil
+}
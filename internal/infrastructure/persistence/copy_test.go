@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+)
+
+func TestCopyRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	fs := newTestRepositories(t, config.StorageBackendFilesystem, t.TempDir())
+	db := newTestRepositories(t, config.StorageBackendSQLite, t.TempDir())
+	back := newTestRepositories(t, config.StorageBackendFilesystem, t.TempDir())
+
+	if err := fs.Projects.Save(ctx, newProject(t, "p-shop", "Shop")); err != nil {
+		t.Fatal(err)
+	}
+	board := newBoard(t, "shop/main", "Main")
+	todo := addColumn(t, board, "To Do", 0)
+	done := addColumn(t, board, "Done", 1)
+	docs := addTask(t, board, todo, "Write docs")
+	docs.AddTag("docs")
+	addTask(t, board, done, "Ship it")
+	if err := fs.Boards.Save(ctx, board); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	note := newNote(t, "note-0001", "Standup", entity.NoteTypeStandup, "p-shop", start, "Notes")
+	note.LinkTask(docs.ID())
+	if err := fs.Notes.Save(ctx, note); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Notes.Save(ctx, newNote(t, "note-0002", "Ideas", entity.NoteTypeGeneral, "", start, "Someday")); err != nil {
+		t.Fatal(err)
+	}
+	log := newTimeLog(t, "log-1", "p-shop", start)
+	log.SetTaskID(docs.ID())
+	if err := log.Stop(start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.TimeLogs.Save(ctx, log); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Actions.Create(ctx, newEventAction(t, "a-one", valueobject.ActionScopeBoard, "shop/main")); err != nil {
+		t.Fatal(err)
+	}
+	for i, id := range []string{"e1", "e2"} {
+		if err := fs.ActionExecutions.Save(ctx, newExecution(id, "a-one", start.Add(time.Duration(i)*time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := &CopyResult{Projects: 1, Boards: 1, Tasks: 2, Notes: 2, TimeLogs: 1, Actions: 1, ActionExecutions: 2}
+	for _, step := range []struct {
+		name     string
+		from, to *Repositories
+	}{
+		{"to sqlite", fs, db},
+		{"again, over the first copy", fs, db},
+		{"back to the filesystem", db, back},
+	} {
+		result, err := Copy(ctx, step.from, step.to)
+		if err != nil {
+			t.Fatalf("copy %s: %v", step.name, err)
+		}
+		if !reflect.DeepEqual(result, want) {
+			t.Errorf("copy %s = %+v, want %+v", step.name, result, want)
+		}
+	}
+
+	copied, err := back.Boards.FindByID(ctx, "shop/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := boardLayout(copied), boardLayout(board); !reflect.DeepEqual(got, want) {
+		t.Errorf("copied layout = %v, want %v", got, want)
+	}
+	task, _, err := copied.FindTask(docs.ID())
+	if err != nil || !reflect.DeepEqual(task.Tags(), []string{"docs"}) {
+		t.Errorf("copied task = %v, %v", task, err)
+	}
+
+	copiedNote, err := back.Notes.FindByID(ctx, "note-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked := copiedNote.LinkedTasks(); len(linked) != 1 || !linked[0].Equal(docs.ID()) {
+		t.Errorf("copied note links %v", linked)
+	}
+	logs, err := back.TimeLogs.FindByTask(ctx, docs.ID())
+	if err != nil || len(logs) != 1 || logs[0].Duration() != time.Hour {
+		t.Errorf("copied time logs = %v, %v", logs, err)
+	}
+	history, err := back.ActionExecutions.ListByAction(ctx, "a-one", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := executionIDs(history); !reflect.DeepEqual(got, []string{"e2", "e1"}) {
+		t.Errorf("copied history = %v", got)
+	}
+}
@@ -0,0 +1,182 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/mapper"
+)
+
+// defaultHistoryLimit is used when actions.history_limit is not configured
+const defaultHistoryLimit = 100
+
+// ActionExecutionRepositoryImpl implements the ActionExecutionRepository interface.
+// Each action's history is kept in its own file, oldest execution first, and
+// trimmed to the configured history limit on every save.
+type ActionExecutionRepositoryImpl struct {
+	config *config.Config
+	mu     sync.RWMutex
+}
+
+// NewActionExecutionRepository creates a new action execution repository
+func NewActionExecutionRepository(cfg *config.Config) repository.ActionExecutionRepository {
+	return &ActionExecutionRepositoryImpl{
+		config: cfg,
+	}
+}
+
+// getHistoryPath returns the path to the action history directory
+func (r *ActionExecutionRepositoryImpl) getHistoryPath() string {
+	return filepath.Join(r.config.Storage.DataPath, "actions", "history")
+}
+
+// getHistoryFilePath returns the path to an action's history file
+func (r *ActionExecutionRepositoryImpl) getHistoryFilePath(actionID string) string {
+	return filepath.Join(r.getHistoryPath(), fmt.Sprintf("%s.yml", actionID))
+}
+
+// Save appends an execution record to the action's history
+func (r *ActionExecutionRepositoryImpl) Save(ctx context.Context, execution *entity.ActionExecution) error {
+	unlock, err := NewDataLock(r.config.Storage.DataPath).Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.getHistoryPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create action history directory: %w", err)
+	}
+
+	filePath := r.getHistoryFilePath(execution.ActionID)
+	history, err := r.loadHistory(filePath)
+	if err != nil {
+		return err
+	}
+
+	history.Executions = append(history.Executions, mapper.ActionExecutionToStorage(execution))
+
+	limit := r.config.Actions.HistoryLimit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if len(history.Executions) > limit {
+		history.Executions = history.Executions[len(history.Executions)-limit:]
+	}
+
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal action history: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write action history: %w", err)
+	}
+
+	return nil
+}
+
+// ListByAction retrieves the most recent executions of an action, newest first
+func (r *ActionExecutionRepositoryImpl) ListByAction(ctx context.Context, actionID string, limit int) ([]*entity.ActionExecution, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	history, err := r.loadHistory(r.getHistoryFilePath(actionID))
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]*entity.ActionExecution, 0, len(history.Executions))
+	for i := len(history.Executions) - 1; i >= 0; i-- {
+		executions = append(executions, mapper.ActionExecutionFromStorage(history.Executions[i]))
+	}
+
+	return truncateExecutions(executions, limit), nil
+}
+
+// ListRecent retrieves the most recent executions across all actions, newest first
+func (r *ActionExecutionRepositoryImpl) ListRecent(ctx context.Context, limit int) ([]*entity.ActionExecution, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	files, err := filepath.Glob(filepath.Join(r.getHistoryPath(), "*.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list action history files: %w", err)
+	}
+
+	executions := make([]*entity.ActionExecution, 0)
+	for _, file := range files {
+		history, err := r.loadHistory(file)
+		if err != nil {
+			continue // Skip unreadable history files
+		}
+		for _, stored := range history.Executions {
+			executions = append(executions, mapper.ActionExecutionFromStorage(stored))
+		}
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartedAt.After(executions[j].StartedAt)
+	})
+
+	return truncateExecutions(executions, limit), nil
+}
+
+// DeleteByAction removes the history file of an action
+func (r *ActionExecutionRepositoryImpl) DeleteByAction(ctx context.Context, actionID string) error {
+	unlock, err := NewDataLock(r.config.Storage.DataPath).Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Remove(r.getHistoryFilePath(actionID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete action history: %w", err)
+	}
+	return nil
+}
+
+// loadHistory reads a history file, returning an empty history if it doesn't exist
+func (r *ActionExecutionRepositoryImpl) loadHistory(filePath string) (*mapper.ActionHistoryStorage, error) {
+	history := &mapper.ActionHistoryStorage{}
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action history: %w", err)
+	}
+
+	if strings.TrimSpace(string(data)) == "" {
+		return history, nil
+	}
+	if err := yaml.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal action history: %w", err)
+	}
+
+	return history, nil
+}
+
+// truncateExecutions limits a newest-first execution list; zero means no limit
+func truncateExecutions(executions []*entity.ActionExecution, limit int) []*entity.ActionExecution {
+	if limit > 0 && len(executions) > limit {
+		return executions[:limit]
+	}
+	return executions
+}
@@ -13,6 +13,7 @@ import (
 	"mkanban/internal/domain/repository"
 	"mkanban/internal/domain/valueobject"
 	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/mapper"
 )
 
 // ActionRepositoryImpl implements the ActionRepository interface
@@ -40,6 +41,12 @@ func (r *ActionRepositoryImpl) getActionFilePath(id string) string {
 
 // Create creates a new action
 func (r *ActionRepositoryImpl) Create(ctx context.Context, action *entity.Action) error {
+	unlock, err := NewDataLock(r.config.Storage.DataPath).Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -55,7 +62,7 @@ func (r *ActionRepositoryImpl) Create(ctx context.Context, action *entity.Action
 	}
 
 	// Convert action to storage model
-	storageAction := r.toStorageAction(action)
+	storageAction := mapper.ActionToStorage(action)
 
 	// Marshal to YAML
 	data, err := yaml.Marshal(storageAction)
@@ -73,6 +80,12 @@ func (r *ActionRepositoryImpl) Create(ctx context.Context, action *entity.Action
 
 // Update updates an existing action
 func (r *ActionRepositoryImpl) Update(ctx context.Context, action *entity.Action) error {
+	unlock, err := NewDataLock(r.config.Storage.DataPath).Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -84,7 +97,7 @@ func (r *ActionRepositoryImpl) Update(ctx context.Context, action *entity.Action
 	}
 
 	// Convert action to storage model
-	storageAction := r.toStorageAction(action)
+	storageAction := mapper.ActionToStorage(action)
 
 	// Marshal to YAML
 	data, err := yaml.Marshal(storageAction)
@@ -102,6 +115,12 @@ func (r *ActionRepositoryImpl) Update(ctx context.Context, action *entity.Action
 
 // Delete deletes an action by ID
 func (r *ActionRepositoryImpl) Delete(ctx context.Context, id string) error {
+	unlock, err := NewDataLock(r.config.Storage.DataPath).Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -139,13 +158,13 @@ func (r *ActionRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.
 	}
 
 	// Unmarshal from YAML
-	var storageAction StorageAction
+	var storageAction mapper.ActionStorage
 	if err := yaml.Unmarshal(data, &storageAction); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal action: %w", err)
 	}
 
 	// Convert to domain entity
-	return r.fromStorageAction(&storageAction)
+	return mapper.ActionFromStorage(&storageAction)
 }
 
 // ListAll retrieves all actions
@@ -173,12 +192,12 @@ func (r *ActionRepositoryImpl) ListAll(ctx context.Context) ([]*entity.Action, e
 			continue // Skip files that can't be read
 		}
 
-		var storageAction StorageAction
+		var storageAction mapper.ActionStorage
 		if err := yaml.Unmarshal(data, &storageAction); err != nil {
 			continue // Skip invalid files
 		}
 
-		action, err := r.fromStorageAction(&storageAction)
+		action, err := mapper.ActionFromStorage(&storageAction)
 		if err != nil {
 			continue // Skip invalid actions
 		}
@@ -270,43 +289,3 @@ func (r *ActionRepositoryImpl) UpdateLastRun(ctx context.Context, id string) err
 	action.MarkAsRun()
 	return r.Update(ctx, action)
 }
-
-// StorageAction represents an action in storage format
-type StorageAction struct {
-	ID          string                 `yaml:"id"`
-	Name        string                 `yaml:"name"`
-	Description string                 `yaml:"description"`
-	Scope       string                 `yaml:"scope"`
-	ScopeID     string                 `yaml:"scope_id"`
-	Enabled     bool                   `yaml:"enabled"`
-	Trigger     config.TriggerConfig   `yaml:"trigger"`
-	ActionType  config.ActionTypeConfig `yaml:"action_type"`
-	Conditions  []config.ConditionConfig `yaml:"conditions,omitempty"`
-	CreatedAt   string                 `yaml:"created_at"`
-	ModifiedAt  string                 `yaml:"modified_at"`
-	LastRun     *string                `yaml:"last_run,omitempty"`
-}
-
-// toStorageAction converts a domain action to storage format
-func (r *ActionRepositoryImpl) toStorageAction(action *entity.Action) *StorageAction {
-	// TODO: Implement proper conversion from domain entities to storage format
-	// This is a placeholder that needs to be implemented based on the actual
-	// trigger and action type implementations
-	return &StorageAction{
-		ID:          action.ID(),
-		Name:        action.Name(),
-		Description: action.Description(),
-		Scope:       action.Scope().String(),
-		ScopeID:     action.ScopeID(),
-		Enabled:     action.Enabled(),
-		CreatedAt:   action.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		ModifiedAt:  action.ModifiedAt().Format("2006-01-02T15:04:05Z07:00"),
-	}
-}
-
-// fromStorageAction converts a storage action to domain entity
-func (r *ActionRepositoryImpl) fromStorageAction(storageAction *StorageAction) (*entity.Action, error) {
-	// TODO: Implement proper conversion from storage format to domain entities
-	// This is a placeholder that needs to be implemented
-	return nil, fmt.Errorf("not implemented")
-}
@@ -18,24 +18,33 @@ import (
 // BoardRepositoryImpl implements BoardRepository using filesystem storage
 type BoardRepositoryImpl struct {
 	pathBuilder *PathBuilder
+	lock        *filesystem.FileLock
 }
 
 // NewBoardRepository creates a new filesystem-based board repository
 func NewBoardRepository(rootPath string) repository.BoardRepository {
 	return &BoardRepositoryImpl{
 		pathBuilder: NewPathBuilder(rootPath),
+		lock:        NewDataLock(rootPath),
 	}
 }
 
 // Save persists a board to the filesystem
 func (r *BoardRepositoryImpl) Save(ctx context.Context, board *entity.Board) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	boardDir, err := r.pathBuilder.BoardDir(board.ID())
 	if err != nil {
 		return err
 	}
 
-	// Ensure board directory exists
-	if err := filesystem.EnsureDir(boardDir, 0755); err != nil {
+	// Ensure board directory exists, with a columns directory even before
+	// the board has columns so it can be loaded
+	if err := filesystem.EnsureDir(filepath.Join(boardDir, "columns"), 0755); err != nil {
 		return fmt.Errorf("failed to create board directory: %w", err)
 	}
 
@@ -138,6 +147,12 @@ func (r *BoardRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Board, err
 
 // Delete removes a board from storage
 func (r *BoardRepositoryImpl) Delete(ctx context.Context, id string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	boardDir, err := r.pathBuilder.BoardDir(id)
 	if err != nil {
 		return err
@@ -643,6 +658,12 @@ func (r *BoardRepositoryImpl) loadColumnLegacy(boardID, columnFolderName string)
 
 // SaveTask persists a single task without rewriting the entire board
 func (r *BoardRepositoryImpl) SaveTask(ctx context.Context, boardID string, columnName string, task *entity.Task) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	return r.saveTask(boardID, columnName, task)
 }
 
@@ -1033,6 +1054,12 @@ func (r *BoardRepositoryImpl) cleanupOldTasks(boardID string, columnFolderName s
 
 // MigrateColumnsToSubdirectory migrates columns from board root to columns/ subdirectory
 func (r *BoardRepositoryImpl) MigrateColumnsToSubdirectory(ctx context.Context, boardID string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	boardDir, err := r.pathBuilder.BoardDir(boardID)
 	if err != nil {
 		return err
@@ -1105,6 +1132,12 @@ func (r *BoardRepositoryImpl) MigrateColumnsToSubdirectory(ctx context.Context,
 
 // MigrateColumnsToNewFormat migrates old column format to new normalized format
 func (r *BoardRepositoryImpl) MigrateColumnsToNewFormat(ctx context.Context, boardID string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	boardDir, err := r.pathBuilder.BoardDir(boardID)
 	if err != nil {
 		return err
@@ -1234,6 +1267,12 @@ func (r *BoardRepositoryImpl) MigrateColumnsToNewFormat(ctx context.Context, boa
 
 // MigrateTasksToSubdirectory migrates tasks from column root to tasks/ subdirectory
 func (r *BoardRepositoryImpl) MigrateTasksToSubdirectory(ctx context.Context, boardID string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	boardDir, err := r.pathBuilder.BoardDir(boardID)
 	if err != nil {
 		return err
@@ -1334,3 +1373,59 @@ func (r *BoardRepositoryImpl) MigrateTasksToSubdirectory(ctx context.Context, bo
 
 	return nil
 }
+
+// MigrateBoardToNewFormat migrates a board.md with frontmatter to metadata.yml and board.md
+func (r *BoardRepositoryImpl) MigrateBoardToNewFormat(ctx context.Context, boardID string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	metadataYamlPath, err := r.pathBuilder.BoardMetadataYaml(boardID)
+	if err != nil {
+		return err
+	}
+	contentPath, err := r.pathBuilder.BoardContent(boardID)
+	if err != nil {
+		return err
+	}
+
+	// Boards with metadata.yml are already migrated, boards without board.md aren't boards
+	if exists, _ := filesystem.Exists(metadataYamlPath); exists {
+		return nil
+	}
+	data, err := os.ReadFile(contentPath)
+	if err != nil {
+		return nil
+	}
+
+	doc, err := serialization.ParseFrontmatter(data)
+	if err != nil {
+		return nil
+	}
+	board, err := mapper.BoardFromLegacyStorage(doc, boardID)
+	if err != nil {
+		return fmt.Errorf("failed to read legacy board.md: %w", err)
+	}
+	if projectSlug, _, err := valueobject.ParseBoardID(boardID); err == nil {
+		board.SetProjectID(projectSlug)
+	}
+
+	metadata, err := mapper.BoardMetadataToStorage(board)
+	if err != nil {
+		return err
+	}
+	metadataYaml, err := serialization.SerializeYaml(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+	if err := filesystem.SafeWrite(metadataYamlPath, metadataYaml, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata.yml: %w", err)
+	}
+	if err := filesystem.SafeWrite(contentPath, mapper.BoardContentToMarkdown(board), 0644); err != nil {
+		return fmt.Errorf("failed to write board.md: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package filesystem
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pkgfs "mkanban/pkg/filesystem"
+)
+
+// Folders of the data directory holding archives of it
+const (
+	BackupsDir   = "backups"
+	SnapshotsDir = "snapshots"
+)
+
+// DataLockFile is the lock file of the data directory. Writers hold it
+// shared, in the daemon and the CLI alike, and snapshots hold it exclusive,
+// so none catches data half written.
+const DataLockFile = "mkanban.lock"
+
+// NewDataLock returns the lock of the data directory at root
+func NewDataLock(root string) *pkgfs.FileLock {
+	return pkgfs.NewFileLock(filepath.Join(root, DataLockFile))
+}
+
+// machineLocalFiles are files of the data directory that only matter to
+// the processes running on this machine
+var machineLocalFiles = map[string]bool{
+	".git":        true,
+	"mkanban.pid": true,
+	"shells.json": true,
+}
+
+// SkipArchived reports the files and folders of the data directory, by
+// their slash separated relative path, that are left out of its backups
+// and snapshots: the archives themselves, the sync repository and files
+// that only matter while a process runs
+func SkipArchived(rel string, entry fs.DirEntry) bool {
+	if rel == BackupsDir || rel == SnapshotsDir || machineLocalFiles[rel] {
+		return true
+	}
+	name := path.Base(rel)
+	return strings.HasSuffix(name, ".tmp") || strings.HasSuffix(name, ".lock") || strings.HasSuffix(name, ".db-shm")
+}
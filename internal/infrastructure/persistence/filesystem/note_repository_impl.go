@@ -22,15 +22,23 @@ const (
 
 type NoteRepositoryImpl struct {
 	pathBuilder *ProjectPathBuilder
+	lock        *filesystem.FileLock
 }
 
 func NewNoteRepository(rootPath string) repository.NoteRepository {
 	return &NoteRepositoryImpl{
 		pathBuilder: NewProjectPathBuilder(rootPath),
+		lock:        NewDataLock(rootPath),
 	}
 }
 
 func (r *NoteRepositoryImpl) Save(ctx context.Context, note *entity.Note) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	noteDir := r.getNoteDir(note)
 
 	if err := filesystem.EnsureDir(noteDir, 0755); err != nil {
@@ -183,6 +191,12 @@ func (r *NoteRepositoryImpl) Search(ctx context.Context, projectID string, query
 }
 
 func (r *NoteRepositoryImpl) Delete(ctx context.Context, id string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	note, err := r.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -16,15 +16,23 @@ import (
 
 type ProjectRepositoryImpl struct {
 	pathBuilder *ProjectPathBuilder
+	lock        *filesystem.FileLock
 }
 
 func NewProjectRepository(rootPath string) repository.ProjectRepository {
 	return &ProjectRepositoryImpl{
 		pathBuilder: NewProjectPathBuilder(rootPath),
+		lock:        NewDataLock(rootPath),
 	}
 }
 
 func (r *ProjectRepositoryImpl) Save(ctx context.Context, project *entity.Project) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	projectDir := r.pathBuilder.ProjectDir(project.Slug())
 
 	if err := filesystem.EnsureDir(projectDir, 0755); err != nil {
@@ -132,6 +140,12 @@ func (r *ProjectRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Project,
 }
 
 func (r *ProjectRepositoryImpl) Delete(ctx context.Context, id string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	project, err := r.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -18,15 +18,23 @@ import (
 
 type TimeLogRepositoryImpl struct {
 	pathBuilder *ProjectPathBuilder
+	lock        *filesystem.FileLock
 }
 
 func NewTimeLogRepository(rootPath string) repository.TimeLogRepository {
 	return &TimeLogRepositoryImpl{
 		pathBuilder: NewProjectPathBuilder(rootPath),
+		lock:        NewDataLock(rootPath),
 	}
 }
 
 func (r *TimeLogRepositoryImpl) Save(ctx context.Context, log *entity.TimeLog) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	projectSlug, err := r.getProjectSlug(ctx, log.ProjectID())
 	if err != nil {
 		return err
@@ -192,6 +200,12 @@ func (r *TimeLogRepositoryImpl) FindRunning(ctx context.Context) ([]*entity.Time
 }
 
 func (r *TimeLogRepositoryImpl) Delete(ctx context.Context, id string) error {
+	unlock, err := r.lock.Shared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	projectsRoot := r.pathBuilder.ProjectsRoot()
 
 	entries, err := os.ReadDir(projectsRoot)
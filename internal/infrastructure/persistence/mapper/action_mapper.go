@@ -0,0 +1,515 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+)
+
+// ActionStorage represents an action in storage format (actions/<id>.yml)
+type ActionStorage struct {
+	ID                  string                   `yaml:"id"`
+	Name                string                   `yaml:"name"`
+	Description         string                   `yaml:"description"`
+	Scope               string                   `yaml:"scope"`
+	ScopeID             string                   `yaml:"scope_id"`
+	Enabled             bool                     `yaml:"enabled"`
+	Trigger             config.TriggerConfig     `yaml:"trigger"`
+	ActionType          config.ActionTypeConfig  `yaml:"action_type"`
+	Conditions          []config.ConditionConfig `yaml:"conditions,omitempty"`
+	Aggregate           *config.AggregateConfig  `yaml:"aggregate,omitempty"`
+	CreatedAt           time.Time                `yaml:"created_at"`
+	ModifiedAt          time.Time                `yaml:"modified_at"`
+	LastRun             *time.Time               `yaml:"last_run,omitempty"`
+	ConsecutiveFailures int                      `yaml:"consecutive_failures,omitempty"`
+}
+
+// ActionToStorage converts an Action entity to storage format
+func ActionToStorage(action *entity.Action) *ActionStorage {
+	return &ActionStorage{
+		ID:                  action.ID(),
+		Name:                action.Name(),
+		Description:         action.Description(),
+		Scope:               action.Scope().String(),
+		ScopeID:             action.ScopeID(),
+		Enabled:             action.Enabled(),
+		Trigger:             TriggerToConfig(action.Trigger()),
+		ActionType:          ActionTypeToConfig(action.ActionType()),
+		Conditions:          ConditionsToConfig(action.Conditions()),
+		Aggregate:           AggregationToConfig(action.Aggregation()),
+		CreatedAt:           action.CreatedAt(),
+		ModifiedAt:          action.ModifiedAt(),
+		LastRun:             action.LastRun(),
+		ConsecutiveFailures: action.ConsecutiveFailures(),
+	}
+}
+
+// ActionFromStorage converts storage format to an Action entity
+func ActionFromStorage(storage *ActionStorage) (*entity.Action, error) {
+	trigger, err := TriggerFromConfig(storage.Trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	actionType, err := ActionTypeFromConfig(storage.ActionType)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := ConditionsFromConfig(storage.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation, err := AggregationFromConfig(storage.Aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := entity.NewAction(
+		storage.ID,
+		storage.Name,
+		storage.Description,
+		valueobject.ActionScope(storage.Scope),
+		storage.ScopeID,
+		trigger,
+		actionType,
+		conditions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	action.UpdateAggregation(aggregation)
+	action.Restore(storage.Enabled, storage.CreatedAt, storage.ModifiedAt, storage.LastRun, storage.ConsecutiveFailures)
+	return action, nil
+}
+
+// ActionToTemplate converts an Action to the ActionTemplate shape used in
+// config files, dropping scope and run state
+func ActionToTemplate(action *entity.Action) config.ActionTemplate {
+	return config.ActionTemplate{
+		ID:          action.ID(),
+		Name:        action.Name(),
+		Description: action.Description(),
+		Trigger:     TriggerToConfig(action.Trigger()),
+		ActionType:  ActionTypeToConfig(action.ActionType()),
+		Conditions:  ConditionsToConfig(action.Conditions()),
+		Aggregate:   AggregationToConfig(action.Aggregation()),
+	}
+}
+
+// TriggerFromConfig converts a trigger configuration to a Trigger
+func TriggerFromConfig(cfg config.TriggerConfig) (entity.Trigger, error) {
+	switch entity.TriggerType(cfg.Type) {
+	case entity.TriggerTypeEvent:
+		return entity.NewEventTrigger(valueobject.EventType(cfg.Event))
+	case entity.TriggerTypeTime:
+		if cfg.Schedule == nil {
+			return nil, entity.ErrInvalidSchedule
+		}
+		schedule, err := scheduleFromConfig(*cfg.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		return entity.NewTimeTrigger(schedule)
+	default:
+		return nil, fmt.Errorf("%w: %q", entity.ErrInvalidTrigger, cfg.Type)
+	}
+}
+
+// TriggerToConfig converts a Trigger to its configuration form
+func TriggerToConfig(trigger entity.Trigger) config.TriggerConfig {
+	switch t := trigger.(type) {
+	case *entity.EventTrigger:
+		return config.TriggerConfig{
+			Type:  string(entity.TriggerTypeEvent),
+			Event: string(t.EventType()),
+		}
+	case *entity.TimeTrigger:
+		return config.TriggerConfig{
+			Type:     string(entity.TriggerTypeTime),
+			Schedule: scheduleToConfig(t.Schedule()),
+		}
+	default:
+		return config.TriggerConfig{Type: string(trigger.Type())}
+	}
+}
+
+func scheduleFromConfig(cfg config.ScheduleConfig) (*valueobject.Schedule, error) {
+	switch valueobject.ScheduleType(cfg.Type) {
+	case valueobject.ScheduleTypeAbsolute:
+		t, err := time.Parse(time.RFC3339, cfg.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule time %q: %w", cfg.Time, err)
+		}
+		return valueobject.NewAbsoluteSchedule(t), nil
+	case valueobject.ScheduleTypeRelativeDueDate:
+		offset, err := ParseOffset(cfg.Offset)
+		if err != nil {
+			return nil, err
+		}
+		return valueobject.NewRelativeDueDateSchedule(offset), nil
+	case valueobject.ScheduleTypeRelativeCreation:
+		offset, err := ParseOffset(cfg.Offset)
+		if err != nil {
+			return nil, err
+		}
+		return valueobject.NewRelativeCreationSchedule(offset), nil
+	case valueobject.ScheduleTypeRecurring:
+		return valueobject.NewRecurringSchedule(cfg.CronExpr)
+	default:
+		return nil, fmt.Errorf("%w: unknown schedule type %q", entity.ErrInvalidSchedule, cfg.Type)
+	}
+}
+
+func scheduleToConfig(schedule *valueobject.Schedule) *config.ScheduleConfig {
+	cfg := &config.ScheduleConfig{
+		Type:     string(schedule.Type),
+		CronExpr: schedule.CronExpr,
+	}
+	if schedule.Time != nil {
+		cfg.Time = schedule.Time.Format(time.RFC3339)
+	}
+	if schedule.Offset != nil {
+		cfg.Offset = FormatOffset(*schedule.Offset)
+	}
+	return cfg
+}
+
+// ParseOffset parses a duration string, additionally accepting whole days ("2d")
+func ParseOffset(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset %q", s)
+	}
+	return d, nil
+}
+
+// FormatOffset formats a duration the way ParseOffset reads it
+func FormatOffset(d time.Duration) string {
+	day := 24 * time.Hour
+	if d != 0 && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
+
+// ActionTypeFromConfig converts an action type configuration to an ActionType
+func ActionTypeFromConfig(cfg config.ActionTypeConfig) (entity.ActionType, error) {
+	var actionType entity.ActionType
+
+	switch entity.ActionTypeEnum(cfg.Type) {
+	case entity.ActionTypeNotification:
+		actionType = entity.NewNotificationAction(cfg.Title, cfg.Message, nil)
+	case entity.ActionTypeScript:
+		actionType = entity.NewScriptAction(cfg.ScriptPath, cfg.ScriptEnv)
+	case entity.ActionTypeCommand:
+		command := entity.NewCommandAction(cfg.Command, cfg.Args)
+		for key, value := range cfg.ScriptEnv {
+			command.EnvVars[key] = value
+		}
+		timeout, err := actionTimeout(cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		command.Timeout = timeout
+		actionType = command
+	case entity.ActionTypeWebhook:
+		webhook := entity.NewWebhookAction(cfg.URL)
+		for key, value := range cfg.Headers {
+			webhook.Headers[key] = value
+		}
+		webhook.Secret = cfg.Secret
+		webhook.Retries = cfg.Retries
+		timeout, err := actionTimeout(cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Timeout = timeout
+		actionType = webhook
+	case entity.ActionTypeTaskMutation:
+		mutation := entity.NewTaskMutationAction()
+		if cfg.UpdatePriority != "" {
+			priority, err := valueobject.ParsePriority(cfg.UpdatePriority)
+			if err != nil {
+				return nil, err
+			}
+			mutation.UpdatePriority = &priority
+		}
+		mutation.BumpPriority = cfg.BumpPriority
+		if cfg.UpdateStatus != "" {
+			status, err := valueobject.ParseStatus(cfg.UpdateStatus)
+			if err != nil {
+				return nil, err
+			}
+			mutation.UpdateStatus = &status
+		}
+		mutation.AddTags = append(mutation.AddTags, cfg.AddTags...)
+		mutation.RemoveTags = append(mutation.RemoveTags, cfg.RemoveTags...)
+		for key, value := range cfg.SetMetadata {
+			mutation.SetMetadata[key] = value
+		}
+		actionType = mutation
+	case entity.ActionTypeTaskMovement:
+		actionType = entity.NewTaskMovementAction(cfg.TargetColumn)
+	case entity.ActionTypeTaskCreation:
+		creation := entity.NewTaskCreationAction(cfg.TaskTitle, cfg.TaskDescription, cfg.TaskColumn)
+		if cfg.TaskPriority != "" {
+			priority, err := valueobject.ParsePriority(cfg.TaskPriority)
+			if err != nil {
+				return nil, err
+			}
+			creation.Priority = priority
+		}
+		if cfg.TaskStatus != "" {
+			status, err := valueobject.ParseStatus(cfg.TaskStatus)
+			if err != nil {
+				return nil, err
+			}
+			creation.Status = status
+		}
+		creation.Tags = append(creation.Tags, cfg.TaskTags...)
+		for key, value := range cfg.TaskMetadata {
+			creation.Metadata[key] = value
+		}
+		actionType = creation
+	default:
+		return nil, fmt.Errorf("%w: %q", entity.ErrInvalidActionType, cfg.Type)
+	}
+
+	if err := actionType.Validate(); err != nil {
+		return nil, err
+	}
+	return actionType, nil
+}
+
+// ActionTypeToConfig converts an ActionType to its configuration form
+func ActionTypeToConfig(actionType entity.ActionType) config.ActionTypeConfig {
+	cfg := config.ActionTypeConfig{Type: string(actionType.Type())}
+
+	switch a := actionType.(type) {
+	case *entity.NotificationAction:
+		cfg.Title = a.Title
+		cfg.Message = a.Message
+	case *entity.ScriptAction:
+		cfg.ScriptPath = a.ScriptPath
+		cfg.ScriptEnv = a.EnvVars
+	case *entity.CommandAction:
+		cfg.Command = a.Command
+		cfg.Args = a.Args
+		if len(a.EnvVars) > 0 {
+			cfg.ScriptEnv = a.EnvVars
+		}
+		if a.Timeout > 0 {
+			cfg.Timeout = a.Timeout.String()
+		}
+	case *entity.WebhookAction:
+		cfg.URL = a.URL
+		if len(a.Headers) > 0 {
+			cfg.Headers = a.Headers
+		}
+		cfg.Secret = a.Secret
+		cfg.Retries = a.Retries
+		if a.Timeout > 0 {
+			cfg.Timeout = a.Timeout.String()
+		}
+	case *entity.TaskMutationAction:
+		if a.UpdatePriority != nil {
+			cfg.UpdatePriority = a.UpdatePriority.String()
+		}
+		cfg.BumpPriority = a.BumpPriority
+		if a.UpdateStatus != nil {
+			cfg.UpdateStatus = a.UpdateStatus.String()
+		}
+		cfg.AddTags = a.AddTags
+		cfg.RemoveTags = a.RemoveTags
+		cfg.SetMetadata = a.SetMetadata
+	case *entity.TaskMovementAction:
+		cfg.TargetColumn = a.TargetColumn
+	case *entity.TaskCreationAction:
+		cfg.TaskTitle = a.Title
+		cfg.TaskDescription = a.Description
+		cfg.TaskPriority = a.Priority.String()
+		cfg.TaskStatus = a.Status.String()
+		cfg.TaskColumn = a.ColumnName
+		cfg.TaskTags = a.Tags
+		cfg.TaskMetadata = a.Metadata
+	}
+
+	return cfg
+}
+
+// actionTimeout parses the optional timeout of a command or webhook
+func actionTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q", s)
+	}
+	return timeout, nil
+}
+
+// ConditionsFromConfig converts condition configurations to an AND condition group
+func ConditionsFromConfig(cfgs []config.ConditionConfig) (*entity.ConditionGroup, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	conditions := make([]*entity.Condition, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Field == "" {
+			return nil, fmt.Errorf("condition field cannot be empty")
+		}
+		conditions = append(conditions, entity.NewCondition(cfg.Field, entity.ConditionOperator(cfg.Operator), conditionValue(cfg.Value)))
+	}
+
+	return entity.NewConditionGroup(entity.LogicalAnd, conditions...), nil
+}
+
+// conditionValue normalizes decoded YAML lists to the []string the
+// in/not_in operators compare against
+func conditionValue(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		values = append(values, fmt.Sprint(item))
+	}
+	return values
+}
+
+// ConditionsToConfig converts a condition group to condition configurations
+func ConditionsToConfig(group *entity.ConditionGroup) []config.ConditionConfig {
+	if group == nil {
+		return nil
+	}
+
+	cfgs := make([]config.ConditionConfig, 0, len(group.Conditions))
+	for _, condition := range group.Conditions {
+		cfgs = append(cfgs, config.ConditionConfig{
+			Field:    condition.Field,
+			Operator: string(condition.Operator),
+			Value:    condition.Value,
+		})
+	}
+	return cfgs
+}
+
+// AggregationFromConfig converts an aggregate configuration to an Aggregation;
+// nil configuration means a per-task action
+func AggregationFromConfig(cfg *config.AggregateConfig) (*entity.Aggregation, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	conditions := make([]*entity.AggregateCondition, 0, len(cfg.Conditions))
+	for _, c := range cfg.Conditions {
+		condition, err := entity.NewAggregateCondition(entity.AggregateFunction(c.Function), c.Field, entity.ConditionOperator(c.Operator), c.Value)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return entity.NewAggregation(entity.AggregateGroup(cfg.GroupBy), conditions...)
+}
+
+// AggregationToConfig converts an Aggregation to its configuration form
+func AggregationToConfig(aggregation *entity.Aggregation) *config.AggregateConfig {
+	if aggregation == nil {
+		return nil
+	}
+
+	cfg := &config.AggregateConfig{GroupBy: string(aggregation.GroupBy)}
+	for _, c := range aggregation.Conditions {
+		cfg.Conditions = append(cfg.Conditions, config.AggregateConditionConfig{
+			Function: string(c.Function),
+			Field:    c.Field,
+			Operator: string(c.Operator),
+			Value:    c.Value,
+		})
+	}
+	return cfg
+}
+
+// ActionExecutionStorage represents one execution record in an action's history file
+type ActionExecutionStorage struct {
+	ID          string    `yaml:"id"`
+	ActionID    string    `yaml:"action_id"`
+	ActionName  string    `yaml:"action_name"`
+	TriggerType string    `yaml:"trigger_type"`
+	EventType   string    `yaml:"event_type,omitempty"`
+	EventID     string    `yaml:"event_id,omitempty"`
+	BoardID     string    `yaml:"board_id,omitempty"`
+	TaskID      string    `yaml:"task_id,omitempty"`
+	Attempt     int       `yaml:"attempt"`
+	StartedAt   time.Time `yaml:"started_at"`
+	FinishedAt  time.Time `yaml:"finished_at"`
+	Outcome     string    `yaml:"outcome"`
+	Error       string    `yaml:"error,omitempty"`
+	Output      string    `yaml:"output,omitempty"`
+}
+
+// ActionHistoryStorage represents the history file of a single action (actions/history/<id>.yml)
+type ActionHistoryStorage struct {
+	Executions []*ActionExecutionStorage `yaml:"executions"`
+}
+
+// ActionExecutionToStorage converts an ActionExecution to storage format
+func ActionExecutionToStorage(execution *entity.ActionExecution) *ActionExecutionStorage {
+	return &ActionExecutionStorage{
+		ID:          execution.ID,
+		ActionID:    execution.ActionID,
+		ActionName:  execution.ActionName,
+		TriggerType: string(execution.TriggerType),
+		EventType:   string(execution.EventType),
+		EventID:     execution.EventID,
+		BoardID:     execution.BoardID,
+		TaskID:      execution.TaskID,
+		Attempt:     execution.Attempt,
+		StartedAt:   execution.StartedAt,
+		FinishedAt:  execution.FinishedAt,
+		Outcome:     string(execution.Outcome),
+		Error:       execution.Error,
+		Output:      execution.Output,
+	}
+}
+
+// ActionExecutionFromStorage converts storage format to an ActionExecution
+func ActionExecutionFromStorage(storage *ActionExecutionStorage) *entity.ActionExecution {
+	return &entity.ActionExecution{
+		ID:          storage.ID,
+		ActionID:    storage.ActionID,
+		ActionName:  storage.ActionName,
+		TriggerType: entity.TriggerType(storage.TriggerType),
+		EventType:   valueobject.EventType(storage.EventType),
+		EventID:     storage.EventID,
+		BoardID:     storage.BoardID,
+		TaskID:      storage.TaskID,
+		Attempt:     storage.Attempt,
+		StartedAt:   storage.StartedAt,
+		FinishedAt:  storage.FinishedAt,
+		Outcome:     entity.ExecutionOutcome(storage.Outcome),
+		Error:       storage.Error,
+		Output:      storage.Output,
+	}
+}
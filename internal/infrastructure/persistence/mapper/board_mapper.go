@@ -28,6 +28,9 @@ func BoardMetadataToStorage(board *entity.Board) (map[string]interface{}, error)
 	if board.ProjectID() != "" {
 		metadata["project_id"] = board.ProjectID()
 	}
+	if board.Swimlanes() != nil {
+		metadata["swimlanes"] = board.Swimlanes().String()
+	}
 
 	return metadata, nil
 }
@@ -60,6 +63,14 @@ func BoardFromStorage(metadataDoc *serialization.FrontmatterDocument, name strin
 		board.SetProjectID(projectID)
 	}
 
+	swimlanes, err := entity.ParseSwimlanes(metadataDoc.GetString("swimlanes"))
+	if err != nil {
+		return nil, fmt.Errorf("board %s: %w", id, err)
+	}
+	if swimlanes != nil {
+		board.SetSwimlanes(swimlanes)
+	}
+
 	return board, nil
 }
 
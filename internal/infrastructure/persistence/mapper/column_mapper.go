@@ -4,14 +4,29 @@ import (
 	"fmt"
 	"mkanban/internal/domain/entity"
 	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
 	"mkanban/internal/infrastructure/serialization"
 )
 
 // ColumnStorage represents column metadata storage format (metadata.yml)
 type ColumnStorage struct {
-	Order    int    `yaml:"order"`
-	WIPLimit int    `yaml:"wip_limit"`
-	Color    string `yaml:"color,omitempty"`
+	Order         int                 `yaml:"order"`
+	WIPLimit      int                 `yaml:"wip_limit"`
+	Color         string              `yaml:"color,omitempty"`
+	StaleAfter    int                 `yaml:"stale_after,omitempty"`
+	EscalateAfter int                 `yaml:"escalate_after,omitempty"`
+	Rules         []ColumnRuleStorage `yaml:"rules,omitempty"`
+}
+
+// ColumnRuleStorage represents a column entry or exit rule in metadata.yml
+type ColumnRuleStorage struct {
+	On        string                  `yaml:"on"`
+	Type      string                  `yaml:"type"`
+	Severity  string                  `yaml:"severity"`
+	Field     string                  `yaml:"field,omitempty"`
+	Condition *config.ConditionConfig `yaml:"condition,omitempty"`
+	Script    string                  `yaml:"script,omitempty"`
+	Message   string                  `yaml:"message,omitempty"`
 }
 
 // ColumnMetadataToStorage converts a Column entity to metadata storage format
@@ -25,9 +40,65 @@ func ColumnMetadataToStorage(column *entity.Column) (map[string]interface{}, err
 		metadata["color"] = column.Color().String()
 	}
 
+	if policy := column.AgingPolicy(); policy != nil {
+		if policy.StaleAfter > 0 {
+			metadata["stale_after"] = policy.StaleAfter
+		}
+		if policy.EscalateAfter > 0 {
+			metadata["escalate_after"] = policy.EscalateAfter
+		}
+	}
+
+	if rules := column.Rules(); len(rules) > 0 {
+		storage := make([]ColumnRuleStorage, 0, len(rules))
+		for _, rule := range rules {
+			storage = append(storage, ColumnRuleToStorage(rule))
+		}
+		metadata["rules"] = storage
+	}
+
 	return metadata, nil
 }
 
+// ColumnRuleToStorage converts a ColumnRule entity to storage format
+func ColumnRuleToStorage(rule *entity.ColumnRule) ColumnRuleStorage {
+	storage := ColumnRuleStorage{
+		On:       string(rule.On),
+		Type:     string(rule.Type),
+		Severity: string(rule.Severity),
+		Field:    rule.Field,
+		Script:   rule.Script,
+		Message:  rule.Message,
+	}
+	if rule.Condition != nil {
+		storage.Condition = &config.ConditionConfig{
+			Field:    rule.Condition.Field,
+			Operator: string(rule.Condition.Operator),
+			Value:    rule.Condition.Value,
+		}
+	}
+	return storage
+}
+
+// ColumnRuleFromStorage converts storage format to a validated ColumnRule entity
+func ColumnRuleFromStorage(storage ColumnRuleStorage) (*entity.ColumnRule, error) {
+	rule := &entity.ColumnRule{
+		On:       entity.ColumnRuleHook(storage.On),
+		Type:     entity.ColumnRuleType(storage.Type),
+		Severity: entity.RuleSeverity(storage.Severity),
+		Field:    storage.Field,
+		Script:   storage.Script,
+		Message:  storage.Message,
+	}
+	if storage.Condition != nil {
+		rule.Condition = entity.NewCondition(storage.Condition.Field, entity.ConditionOperator(storage.Condition.Operator), conditionValue(storage.Condition.Value))
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
 // ColumnContentToMarkdown converts a Column entity to markdown content (column.md)
 func ColumnContentToMarkdown(column *entity.Column) []byte {
 	return serialization.SerializeMarkdownWithTitle(column.DisplayName(), column.Description())
@@ -54,6 +125,30 @@ func ColumnFromStorage(metadataDoc *serialization.FrontmatterDocument, name stri
 		return nil, fmt.Errorf("failed to create column: %w", err)
 	}
 
+	staleAfter := metadataDoc.GetInt("stale_after")
+	escalateAfter := metadataDoc.GetInt("escalate_after")
+	if staleAfter != 0 || escalateAfter != 0 {
+		policy, err := entity.NewAgingPolicy(staleAfter, escalateAfter)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		column.UpdateAgingPolicy(policy)
+	}
+
+	var rules []ColumnRuleStorage
+	if err := metadataDoc.Decode("rules", &rules); err != nil {
+		return nil, fmt.Errorf("column %s: %w", name, err)
+	}
+	for _, storage := range rules {
+		rule, err := ColumnRuleFromStorage(storage)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		if err := column.AddRule(rule); err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+	}
+
 	return column, nil
 }
 
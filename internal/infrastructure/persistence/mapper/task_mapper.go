@@ -12,24 +12,47 @@ import (
 type GitMetadata struct {
 	Branch          string `yaml:"branch,omitempty"`
 	IsCurrentBranch string `yaml:"is_current_branch,omitempty"`
+	Worktree        string `yaml:"worktree,omitempty"`
+}
+
+// ActivityStorage represents an activity entry of a task
+type ActivityStorage struct {
+	Kind    string    `yaml:"kind"`
+	Ref     string    `yaml:"ref"`
+	Summary string    `yaml:"summary,omitempty"`
+	Author  string    `yaml:"author,omitempty"`
+	Time    time.Time `yaml:"time"`
 }
 
 // TaskStorage represents task storage format
 type TaskStorage struct {
-	ID            string         `yaml:"id"`
-	ParentID      string         `yaml:"parent_id,omitempty"`
-	Created       time.Time      `yaml:"created"`
-	Modified      time.Time      `yaml:"modified"`
-	DueDate       *time.Time     `yaml:"due_date,omitempty"`
-	CompletedDate *time.Time     `yaml:"completed_date,omitempty"`
-	Priority      string         `yaml:"priority"`
-	Status        string         `yaml:"status"`
-	Tags          []string       `yaml:"tags,omitempty"`
-	Git           *GitMetadata   `yaml:"git,omitempty"`
-	ScheduledDate *time.Time     `yaml:"scheduled_date,omitempty"`
-	ScheduledTime *time.Time     `yaml:"scheduled_time,omitempty"`
-	TimeBlock     *time.Duration `yaml:"time_block,omitempty"`
-	TaskType      string         `yaml:"task_type,omitempty"`
+	ID            string            `yaml:"id"`
+	ParentID      string            `yaml:"parent_id,omitempty"`
+	ParentBoardID string            `yaml:"parent_board_id,omitempty"`
+	Created       time.Time         `yaml:"created"`
+	Modified      time.Time         `yaml:"modified"`
+	DueDate       *time.Time        `yaml:"due_date,omitempty"`
+	CompletedDate *time.Time        `yaml:"completed_date,omitempty"`
+	Priority      string            `yaml:"priority"`
+	Status        string            `yaml:"status"`
+	Tags          []string          `yaml:"tags,omitempty"`
+	Git           *GitMetadata      `yaml:"git,omitempty"`
+	ScheduledDate *time.Time        `yaml:"scheduled_date,omitempty"`
+	ScheduledTime *time.Time        `yaml:"scheduled_time,omitempty"`
+	TimeBlock     *time.Duration    `yaml:"time_block,omitempty"`
+	Estimate      *time.Duration    `yaml:"estimate,omitempty"`
+	TaskType      string            `yaml:"task_type,omitempty"`
+	ColumnEntered *time.Time        `yaml:"column_entered,omitempty"`
+	Aging         string            `yaml:"aging,omitempty"`
+	Activity      []ActivityStorage `yaml:"activity,omitempty"`
+	Metadata      map[string]string `yaml:"metadata,omitempty"`
+}
+
+// gitMetadataKeys are the task metadata stored under git
+var gitMetadataKeys = map[string]bool{
+	"git_branch":        true,
+	"is_current_branch": true,
+	"git_worktree":      true,
 }
 
 // TaskToStorage converts a Task entity to storage format
@@ -47,28 +70,58 @@ func TaskToStorage(task *entity.Task) (*TaskStorage, []byte, error) {
 		ScheduledDate: task.ScheduledDate(),
 		ScheduledTime: task.ScheduledTime(),
 		TimeBlock:     task.TimeBlock(),
+		Estimate:      task.EstimatedTime(),
 	}
 
 	if task.TaskType() != entity.TaskTypeRegular {
 		storage.TaskType = string(task.TaskType())
 	}
 
+	// Tasks that never moved entered their column when they were created
+	if entered := task.ColumnEnteredAt(); !entered.Equal(task.CreatedAt()) {
+		storage.ColumnEntered = &entered
+	}
+	storage.Aging = string(task.AgingLevel())
+
 	// Store parent ID if this is a subtask
 	if task.ParentID() != nil {
-		storage.ParentID = task.ParentID().ShortID()
+		storage.ParentID = task.ParentID().String()
+		storage.ParentBoardID = task.ParentBoardID()
 	}
 
 	// Extract git metadata if present
 	gitBranch, hasGitBranch := task.GetMetadata("git_branch")
 	isCurrentBranch, hasIsCurrentBranch := task.GetMetadata("is_current_branch")
+	gitWorktree, hasGitWorktree := task.GetMetadata("git_worktree")
 
-	if hasGitBranch || hasIsCurrentBranch {
+	if hasGitBranch || hasIsCurrentBranch || hasGitWorktree {
 		storage.Git = &GitMetadata{
 			Branch:          gitBranch,
 			IsCurrentBranch: isCurrentBranch,
+			Worktree:        gitWorktree,
 		}
 	}
 
+	for key, value := range task.Metadata() {
+		if gitMetadataKeys[key] {
+			continue
+		}
+		if storage.Metadata == nil {
+			storage.Metadata = make(map[string]string)
+		}
+		storage.Metadata[key] = value
+	}
+
+	for _, entry := range task.Activity() {
+		storage.Activity = append(storage.Activity, ActivityStorage{
+			Kind:    string(entry.Kind),
+			Ref:     entry.Ref,
+			Summary: entry.Summary,
+			Author:  entry.Author,
+			Time:    entry.Time,
+		})
+	}
+
 	// Serialize markdown with title and description
 	markdownContent := serialization.SerializeMarkdownWithTitle(task.Title(), task.Description())
 
@@ -122,9 +175,6 @@ func TaskFromStorage(metadata *TaskStorage, markdownContent []byte, taskID *valu
 	}
 
 	// Parse optional dates
-	if metadata.DueDate != nil {
-		_ = task.SetDueDate(*metadata.DueDate)
-	}
 	if metadata.ScheduledDate != nil {
 		task.SetScheduledDate(*metadata.ScheduledDate)
 	}
@@ -134,6 +184,9 @@ func TaskFromStorage(metadata *TaskStorage, markdownContent []byte, taskID *valu
 	if metadata.TimeBlock != nil {
 		task.SetTimeBlock(*metadata.TimeBlock)
 	}
+	if metadata.Estimate != nil {
+		task.SetEstimatedTime(*metadata.Estimate)
+	}
 	if metadata.TaskType != "" {
 		task.SetTaskType(entity.TaskType(metadata.TaskType))
 	}
@@ -148,6 +201,7 @@ func TaskFromStorage(metadata *TaskStorage, markdownContent []byte, taskID *valu
 		parentID, err := valueobject.ParseTaskID(metadata.ParentID)
 		if err == nil {
 			task.SetParentID(parentID)
+			task.SetParentBoardID(metadata.ParentBoardID)
 		}
 	}
 
@@ -159,7 +213,35 @@ func TaskFromStorage(metadata *TaskStorage, markdownContent []byte, taskID *valu
 		if metadata.Git.IsCurrentBranch != "" {
 			task.SetMetadata("is_current_branch", metadata.Git.IsCurrentBranch)
 		}
+		if metadata.Git.Worktree != "" {
+			task.SetMetadata("git_worktree", metadata.Git.Worktree)
+		}
 	}
 
+	for key, value := range metadata.Metadata {
+		task.SetMetadata(key, value)
+	}
+
+	// Restore the current column stay
+	if metadata.ColumnEntered != nil {
+		task.EnterColumn(*metadata.ColumnEntered)
+	}
+	if level := entity.AgingLevel(metadata.Aging); level.IsValid() {
+		task.SetAgingLevel(level)
+	}
+
+	for _, entry := range metadata.Activity {
+		task.AddActivity(entity.Activity{
+			Kind:    entity.ActivityKind(entry.Kind),
+			Ref:     entry.Ref,
+			Summary: entry.Summary,
+			Author:  entry.Author,
+			Time:    entry.Time,
+		})
+	}
+
+	// Restore timestamps last, the setters above touch the modified time
+	task.RestoreTimestamps(metadata.Created, metadata.Modified, metadata.DueDate, metadata.CompletedDate)
+
 	return task, nil
 }
@@ -44,7 +44,8 @@ func TimeLogToStorage(log *entity.TimeLog) *TimeLogStorage {
 		storage.EndTime = log.EndTime()
 	}
 
-	if log.Duration() > 0 {
+	// A running log's duration keeps growing, storing it would stop the log
+	if !log.IsRunning() && log.Duration() > 0 {
 		storage.Duration = int64(log.Duration().Seconds())
 	}
 
@@ -0,0 +1,106 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+)
+
+// Migration is one versioned step of the data directory's layout. Its
+// version is its position in migrations, counting from 1.
+type Migration struct {
+	ID          string
+	Description string
+
+	// Up upgrades the data directory at root. It must leave data that is
+	// already in the newer layout alone.
+	Up func(ctx context.Context, root string) error
+}
+
+// migrations are the layout changes of the data directory, oldest first.
+// New migrations are appended; released ones are never edited or
+// reordered, since their position is the version they migrate to.
+var migrations = []Migration{
+	{
+		ID:          "columns-subdirectory",
+		Description: "Move column folders of boards into a columns/ folder",
+		Up:          forEachBoard((*filesystem.BoardRepositoryImpl).MigrateColumnsToSubdirectory),
+	},
+	{
+		ID:          "column-metadata",
+		Description: "Split column.md frontmatter into metadata.yml and column.md",
+		Up:          forEachBoard((*filesystem.BoardRepositoryImpl).MigrateColumnsToNewFormat),
+	},
+	{
+		ID:          "tasks-subdirectory",
+		Description: "Move task folders of columns into a tasks/ folder",
+		Up:          forEachBoard((*filesystem.BoardRepositoryImpl).MigrateTasksToSubdirectory),
+	},
+	{
+		ID:          "board-metadata",
+		Description: "Split board.md frontmatter into metadata.yml and board.md",
+		Up:          forEachBoard((*filesystem.BoardRepositoryImpl).MigrateBoardToNewFormat),
+	},
+}
+
+// forEachBoard makes a migration of the board folders under root out of a
+// migration of one board
+func forEachBoard(migrate func(*filesystem.BoardRepositoryImpl, context.Context, string) error) func(context.Context, string) error {
+	return func(ctx context.Context, root string) error {
+		repo := filesystem.NewBoardRepository(root).(*filesystem.BoardRepositoryImpl)
+
+		boardIDs, err := listBoardIDs(root)
+		if err != nil {
+			return err
+		}
+		for _, boardID := range boardIDs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := migrate(repo, ctx, boardID); err != nil {
+				return fmt.Errorf("board %s: %w", boardID, err)
+			}
+		}
+		return nil
+	}
+}
+
+// listBoardIDs returns the IDs of the board folders under root, whether
+// they load or not
+func listBoardIDs(root string) ([]string, error) {
+	paths := filesystem.NewProjectPathBuilder(root)
+
+	projectEntries, err := os.ReadDir(paths.ProjectsRoot())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects directory: %w", err)
+	}
+
+	var boardIDs []string
+	for _, projectEntry := range projectEntries {
+		if !projectEntry.IsDir() {
+			continue
+		}
+
+		boardEntries, err := os.ReadDir(paths.ProjectBoardsDir(projectEntry.Name()))
+		if err != nil {
+			continue
+		}
+		for _, boardEntry := range boardEntries {
+			if !boardEntry.IsDir() {
+				continue
+			}
+			boardID, err := valueobject.BuildBoardID(projectEntry.Name(), boardEntry.Name())
+			if err != nil {
+				continue
+			}
+			boardIDs = append(boardIDs, boardID)
+		}
+	}
+	return boardIDs, nil
+}
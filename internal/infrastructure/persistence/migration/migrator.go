@@ -0,0 +1,221 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/serialization"
+	pkgfs "mkanban/pkg/filesystem"
+)
+
+const (
+	// schemaFile records the version of the data directory
+	schemaFile = "schema.yml"
+
+	// projectsDir is where the data a migration may need to upgrade lives
+	projectsDir = "projects"
+)
+
+// schemaStorage is the content of schema.yml
+type schemaStorage struct {
+	Version int              `yaml:"version"`
+	Applied []appliedStorage `yaml:"applied,omitempty"`
+}
+
+// appliedStorage records when a migration ran
+type appliedStorage struct {
+	ID        string    `yaml:"id"`
+	AppliedAt time.Time `yaml:"applied_at"`
+}
+
+// Migrator implements service.DataMigrator by running the registered
+// migrations against the data directory and recording the version reached
+// in its schema.yml
+type Migrator struct {
+	root       string
+	migrations []Migration
+	now        func() time.Time
+}
+
+// NewMigrator creates a migrator of the data directory at root
+func NewMigrator(root string) *Migrator {
+	return &Migrator{
+		root:       root,
+		migrations: migrations,
+		now:        time.Now,
+	}
+}
+
+// Init records a data directory without projects as up to date
+func (m *Migrator) Init(ctx context.Context) error {
+	if exists, err := pkgfs.Exists(filepath.Join(m.root, schemaFile)); err != nil || exists {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(m.root, projectsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read projects directory: %w", err)
+	}
+	if len(entries) > 0 {
+		// Data from before versioning, which is migrated from version 0
+		return nil
+	}
+
+	return m.writeSchema(m.root, &schemaStorage{Version: len(m.migrations)})
+}
+
+// Status reports the version of the data directory
+func (m *Migrator) Status(ctx context.Context) (*service.DataSchemaStatus, error) {
+	schema, err := m.readSchema()
+	if err != nil {
+		return nil, err
+	}
+	return m.status(schema), nil
+}
+
+// Migrate applies the pending migrations in order, recording each one as
+// it completes so a failed run resumes where it stopped
+func (m *Migrator) Migrate(ctx context.Context, opts service.DataMigrateOptions) (*service.DataMigrateResult, error) {
+	// A migration is one write, snapshots wait for all of it
+	unlock, err := filesystem.NewDataLock(m.root).Shared()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	schema, err := m.readSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.DataMigrateResult{
+		From:   schema.Version,
+		To:     schema.Version,
+		DryRun: opts.DryRun,
+	}
+	if schema.Version == len(m.migrations) {
+		return result, nil
+	}
+
+	root := m.root
+	if opts.DryRun {
+		scratch, err := os.MkdirTemp("", "mkanban-migrate-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+		}
+		defer os.RemoveAll(scratch)
+
+		if err := pkgfs.CopyTree(m.root, scratch, filesystem.SkipArchived); err != nil {
+			return nil, fmt.Errorf("failed to copy data directory: %w", err)
+		}
+		root = scratch
+	} else if opts.Backup {
+		name := fmt.Sprintf("schema-v%d-%s.tar.gz", schema.Version, m.now().Format("20060102-150405"))
+		result.Backup = filepath.Join(m.root, filesystem.BackupsDir, name)
+		if err := pkgfs.WriteArchive(result.Backup, m.root, filesystem.SkipArchived); err != nil {
+			return nil, fmt.Errorf("failed to back up data directory: %w", err)
+		}
+	}
+
+	before, err := pkgfs.HashTree(root, filesystem.SkipArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	for version := schema.Version + 1; version <= len(m.migrations); version++ {
+		migration := m.migrations[version-1]
+		if err := migration.Up(ctx, root); err != nil {
+			return nil, fmt.Errorf("migration %d (%s) failed: %w", version, migration.ID, err)
+		}
+
+		appliedAt := m.now()
+		schema.Version = version
+		schema.Applied = append(schema.Applied, appliedStorage{ID: migration.ID, AppliedAt: appliedAt})
+		if err := m.writeSchema(root, schema); err != nil {
+			return nil, err
+		}
+
+		result.To = version
+		result.Applied = append(result.Applied, service.DataMigration{
+			Version:     version,
+			ID:          migration.ID,
+			Description: migration.Description,
+			AppliedAt:   appliedAt,
+		})
+	}
+
+	after, err := pkgfs.HashTree(root, filesystem.SkipArchived)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range pkgfs.DiffTrees(before, after) {
+		result.Changes = append(result.Changes, service.DataFileChange{Change: change.Change, Path: change.Path})
+	}
+
+	return result, nil
+}
+
+// status lists the applied and pending migrations of a schema
+func (m *Migrator) status(schema *schemaStorage) *service.DataSchemaStatus {
+	appliedAt := make(map[string]time.Time, len(schema.Applied))
+	for _, applied := range schema.Applied {
+		appliedAt[applied.ID] = applied.AppliedAt
+	}
+
+	status := &service.DataSchemaStatus{
+		Version: schema.Version,
+		Latest:  len(m.migrations),
+	}
+	for i, migration := range m.migrations {
+		info := service.DataMigration{
+			Version:     i + 1,
+			ID:          migration.ID,
+			Description: migration.Description,
+		}
+		if info.Version <= schema.Version {
+			info.AppliedAt = appliedAt[migration.ID]
+			status.Applied = append(status.Applied, info)
+		} else {
+			status.Pending = append(status.Pending, info)
+		}
+	}
+	return status
+}
+
+// readSchema reads schema.yml, which is missing from data directories
+// written before versioning
+func (m *Migrator) readSchema() (*schemaStorage, error) {
+	schema := &schemaStorage{}
+
+	data, err := os.ReadFile(filepath.Join(m.root, schemaFile))
+	if os.IsNotExist(err) {
+		return schema, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", schemaFile, err)
+	}
+	if err := serialization.ParseYaml(data, schema); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", schemaFile, err)
+	}
+
+	if schema.Version > len(m.migrations) {
+		return nil, fmt.Errorf("%w (version %d, this mkanban knows up to %d)", service.ErrDataSchemaTooNew, schema.Version, len(m.migrations))
+	}
+	return schema, nil
+}
+
+func (m *Migrator) writeSchema(root string, schema *schemaStorage) error {
+	data, err := serialization.SerializeYaml(schema)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", schemaFile, err)
+	}
+	if err := pkgfs.SafeWrite(filepath.Join(root, schemaFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", schemaFile, err)
+	}
+	return nil
+}
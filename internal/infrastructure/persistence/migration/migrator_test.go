@@ -0,0 +1,275 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/serialization"
+	pkgfs "mkanban/pkg/filesystem"
+)
+
+func TestMigrateLegacyLayout(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	want := writeLegacyBoard(t, root)
+	migrator := NewMigrator(root)
+
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != 0 || status.Latest != len(migrations) || len(status.Pending) != len(migrations) {
+		t.Fatalf("status of legacy data = %+v, want version 0 with every migration pending", status)
+	}
+
+	legacy, err := pkgfs.HashTree(root, filesystem.SkipArchived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dryRun, err := migrator.Migrate(ctx, service.DataMigrateOptions{DryRun: true, Backup: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryRun.To != len(migrations) || len(dryRun.Changes) == 0 || dryRun.Backup != "" {
+		t.Errorf("dry run = %+v, want every migration applied with changes and no backup", dryRun)
+	}
+	if untouched, _ := pkgfs.HashTree(root, filesystem.SkipArchived); !reflect.DeepEqual(untouched, legacy) {
+		t.Error("dry run changed the data directory")
+	}
+
+	result, err := migrator.Migrate(ctx, service.DataMigrateOptions{Backup: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Changes, dryRun.Changes) {
+		t.Errorf("changes = %v, dry run reported %v", result.Changes, dryRun.Changes)
+	}
+	if _, err := os.Stat(result.Backup); err != nil {
+		t.Errorf("backup: %v", err)
+	}
+
+	board, err := filesystem.NewBoardRepository(root).FindByID(ctx, "shop/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := boardLayout(board); !reflect.DeepEqual(got, want) {
+		t.Errorf("migrated layout = %v, want %v", got, want)
+	}
+	if board.NextTaskNum() != 3 || board.ProjectID() != "shop" {
+		t.Errorf("migrated board next task %d, project %q", board.NextTaskNum(), board.ProjectID())
+	}
+
+	status, err = migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.UpToDate() || status.Applied[0].AppliedAt.IsZero() {
+		t.Errorf("status after migrating = %+v", status)
+	}
+	again, err := migrator.Migrate(ctx, service.DataMigrateOptions{Backup: true})
+	if err != nil || len(again.Applied) != 0 || again.Backup != "" {
+		t.Errorf("second migration = %+v, %v, want nothing to do", again, err)
+	}
+}
+
+func TestMigrateStopsAtFailure(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeLegacyBoard(t, root)
+
+	var ran []string
+	step := func(id string, err error) Migration {
+		return Migration{ID: id, Up: func(ctx context.Context, root string) error {
+			ran = append(ran, id)
+			return err
+		}}
+	}
+	migrator := NewMigrator(root)
+	migrator.migrations = []Migration{step("one", nil), step("two", errors.New("boom")), step("three", nil)}
+
+	if _, err := migrator.Migrate(ctx, service.DataMigrateOptions{}); err == nil {
+		t.Fatal("migration succeeded past a failing step")
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != 1 {
+		t.Errorf("version after failure = %d, want 1", status.Version)
+	}
+
+	// Fixed, a rerun resumes at the failed step
+	migrator.migrations[1] = step("two", nil)
+	if _, err := migrator.Migrate(ctx, service.DataMigrateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"one", "two", "two", "three"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestInitAndNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	migrator := NewMigrator(root)
+
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != len(migrations) || !status.UpToDate() {
+		t.Errorf("status of a new data directory = %+v, want up to date", status)
+	}
+
+	migrator.migrations = migrations[:1]
+	if _, err := migrator.Status(ctx); !errors.Is(err, service.ErrDataSchemaTooNew) {
+		t.Errorf("status of a newer data directory: %v, want ErrDataSchemaTooNew", err)
+	}
+	if _, err := migrator.Migrate(ctx, service.DataMigrateOptions{}); !errors.Is(err, service.ErrDataSchemaTooNew) {
+		t.Errorf("migrating a newer data directory: %v, want ErrDataSchemaTooNew", err)
+	}
+}
+
+// writeLegacyBoard saves a board under root and rewrites it into the
+// oldest layout: columns and tasks directly in their parent folder, and
+// frontmatter in board.md and column.md. It returns the board's layout.
+func writeLegacyBoard(t *testing.T, root string) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	board, err := entity.NewBoard("shop/main", "Main", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board.SetProjectID("shop")
+	for order, name := range []string{"To Do", "Done"} {
+		column, err := entity.NewColumnWithDisplayName(valueobject.GenerateSlug(name), name, "", order, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := board.AddColumn(column); err != nil {
+			t.Fatal(err)
+		}
+		taskID, err := board.GenerateNextTaskID(valueobject.GenerateSlug("Task in " + name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		task, err := entity.NewTask(taskID, "Task in "+name, "", valueobject.PriorityNone, valueobject.StatusTodo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := column.AddTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := filesystem.NewBoardRepository(root).Save(ctx, board); err != nil {
+		t.Fatal(err)
+	}
+
+	boardDir := filepath.Join(root, "projects", "shop", "boards", "main")
+	columnsDir := filepath.Join(boardDir, "columns")
+	entries, err := os.ReadDir(columnsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		columnDir := filepath.Join(columnsDir, entry.Name())
+		tasks, err := os.ReadDir(filepath.Join(columnDir, "tasks"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, task := range tasks {
+			mustRename(t, filepath.Join(columnDir, "tasks", task.Name()), filepath.Join(columnDir, task.Name()))
+		}
+		mustRemove(t, filepath.Join(columnDir, "tasks"))
+
+		metadata := readYaml(t, filepath.Join(columnDir, "metadata.yml"))
+		content, err := os.ReadFile(filepath.Join(columnDir, "column.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		markdown, err := serialization.ParseMarkdownWithTitle(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metadata["display_name"] = markdown.Title
+		writeFrontmatter(t, filepath.Join(columnDir, "column.md"), metadata, "")
+		mustRemove(t, filepath.Join(columnDir, "metadata.yml"))
+
+		mustRename(t, columnDir, filepath.Join(boardDir, entry.Name()))
+	}
+	mustRemove(t, columnsDir)
+
+	metadata := readYaml(t, filepath.Join(boardDir, "metadata.yml"))
+	writeFrontmatter(t, filepath.Join(boardDir, "board.md"), map[string]interface{}{
+		"id":            metadata["id"],
+		"next_task_num": metadata["next_task_num"],
+	}, "")
+	mustRemove(t, filepath.Join(boardDir, "metadata.yml"))
+
+	return boardLayout(board)
+}
+
+// boardLayout lists the tasks of a board as "Column: task ID", in column
+// order
+func boardLayout(board *entity.Board) []string {
+	var layout []string
+	for _, column := range board.Columns() {
+		for _, task := range column.Tasks() {
+			layout = append(layout, column.DisplayName()+": "+task.ID().String())
+		}
+	}
+	return layout
+}
+
+func readYaml(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]interface{}{}
+	if err := serialization.ParseYaml(data, &values); err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func writeFrontmatter(t *testing.T, path string, frontmatter map[string]interface{}, content string) {
+	t.Helper()
+	data, err := serialization.SerializeFrontmatter(frontmatter, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustRename(t *testing.T, from, to string) {
+	t.Helper()
+	if err := os.Rename(from, to); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustRemove(t *testing.T, path string) {
+	t.Helper()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,672 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/config"
+)
+
+// backends are the storage backends every repository must behave the same
+// on
+var backends = []string{config.StorageBackendFilesystem, config.StorageBackendSQLite}
+
+// TestRepositoryConformance runs the same expectations against the
+// repositories of every backend
+func TestRepositoryConformance(t *testing.T) {
+	suites := []struct {
+		name string
+		run  func(t *testing.T, repos *Repositories)
+	}{
+		{"projects", testProjects},
+		{"boards", testBoards},
+		{"notes", testNotes},
+		{"time logs", testTimeLogs},
+		{"actions", testActions},
+		{"action executions", testActionExecutions},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend, func(t *testing.T) {
+			for _, suite := range suites {
+				t.Run(suite.name, func(t *testing.T) {
+					suite.run(t, newTestRepositories(t, backend, t.TempDir()))
+				})
+			}
+		})
+	}
+}
+
+func newTestRepositories(t *testing.T, backend, dataPath string) *Repositories {
+	t.Helper()
+
+	cfg := &config.Config{
+		Storage: config.StorageConfig{DataPath: dataPath, Backend: backend},
+		Actions: config.ActionsConfig{HistoryLimit: 3},
+	}
+	repos, err := NewRepositories(cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repos.Close() })
+	return repos
+}
+
+func testProjects(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	shop := newProject(t, "p-shop", "Shop")
+	blog := newProject(t, "p-blog", "Blog")
+	blog.SetWorkingDir("/src/blog")
+	blog.SetMetadata("owner", "sam")
+	for _, project := range []*entity.Project{shop, blog} {
+		if err := repos.Projects.Save(ctx, project); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := repos.Projects.FindBySlug(ctx, "blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID() != "p-blog" || found.WorkingDir() != "/src/blog" || found.Metadata()["owner"] != "sam" {
+		t.Errorf("FindBySlug(blog) = %s %q %v", found.ID(), found.WorkingDir(), found.Metadata())
+	}
+
+	shop.UpdateDescription("Online shop")
+	if err := repos.Projects.Save(ctx, shop); err != nil {
+		t.Fatal(err)
+	}
+	found, err = repos.Projects.FindByID(ctx, "p-shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Description() != "Online shop" {
+		t.Errorf("description after update = %q", found.Description())
+	}
+
+	all, err := repos.Projects.FindAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := projectIDs(all); !reflect.DeepEqual(got, []string{"p-blog", "p-shop"}) {
+		t.Errorf("FindAll = %v", got)
+	}
+
+	if err := repos.Projects.Delete(ctx, "p-blog"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := repos.Projects.Exists(ctx, "p-blog"); err != nil || exists {
+		t.Errorf("Exists after delete = %v, %v", exists, err)
+	}
+	if _, err := repos.Projects.FindByID(ctx, "p-blog"); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("FindByID after delete = %v, want ErrProjectNotFound", err)
+	}
+	if _, err := repos.Projects.FindBySlug(ctx, "missing"); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("FindBySlug(missing) = %v, want ErrProjectNotFound", err)
+	}
+	if err := repos.Projects.Delete(ctx, "p-blog"); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("second Delete = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func testBoards(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	board := newBoard(t, "shop/main", "Main")
+	todo := addColumn(t, board, "To Do", 0)
+	doing := addColumn(t, board, "Doing", 1)
+	docs := addTask(t, board, todo, "Write docs")
+	docs.UpdateDescription("Usage and install")
+	docs.AddTag("docs")
+	if err := docs.UpdatePriority(valueobject.PriorityHigh); err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2027, 1, 10, 0, 0, 0, 0, time.UTC)
+	if err := docs.SetDueDate(due); err != nil {
+		t.Fatal(err)
+	}
+	ship := addTask(t, board, doing, "Ship it")
+	if err := repos.Boards.Save(ctx, board); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := repos.Boards.FindByID(ctx, "shop/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name() != "Main" || found.ProjectID() != "shop" || found.NextTaskNum() != 3 {
+		t.Errorf("board = %q in %q, next %d", found.Name(), found.ProjectID(), found.NextTaskNum())
+	}
+	want := []string{"To Do: " + docs.ID().String(), "Doing: " + ship.ID().String()}
+	if got := boardLayout(found); !reflect.DeepEqual(got, want) {
+		t.Errorf("layout = %v, want %v", got, want)
+	}
+	loaded, _, err := found.FindTask(docs.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Description() != "Usage and install" || loaded.Priority() != valueobject.PriorityHigh ||
+		!reflect.DeepEqual(loaded.Tags(), []string{"docs"}) || loaded.DueDate() == nil || !loaded.DueDate().Equal(due) {
+		t.Errorf("task = %q %v %v %v", loaded.Description(), loaded.Priority(), loaded.Tags(), loaded.DueDate())
+	}
+
+	// A single task is saved without the rest of the board
+	loaded.UpdateDescription("Usage, install and FAQ")
+	if err := repos.Boards.SaveTask(ctx, "shop/main", "to-do", loaded); err != nil {
+		t.Fatal(err)
+	}
+	found, err = repos.Boards.FindByID(ctx, "shop/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _, err = found.FindTask(docs.ID()); err != nil || loaded.Description() != "Usage, install and FAQ" {
+		t.Errorf("task after SaveTask = %v, %v", loaded, err)
+	}
+
+	// Columns and tasks removed from the board are gone once it is saved
+	column, err := found.GetColumn("doing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := column.RemoveTask(ship.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := found.RemoveColumn("doing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Boards.Save(ctx, found); err != nil {
+		t.Fatal(err)
+	}
+	found, err = repos.Boards.FindByID(ctx, "shop/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := found.ColumnCount(); got != 1 || found.TotalTaskCount() != 1 {
+		t.Errorf("after removing a column: %d columns, %d tasks", got, found.TotalTaskCount())
+	}
+
+	other := newBoard(t, "blog/posts", "Posts")
+	if err := repos.Boards.Save(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	byName, err := repos.Boards.FindByName(ctx, "blog", "Posts")
+	if err != nil || byName.ID() != "blog/posts" {
+		t.Errorf("FindByName(blog, Posts) = %v, %v", byName, err)
+	}
+	if _, err := repos.Boards.FindByName(ctx, "shop", "Posts"); !errors.Is(err, entity.ErrBoardNotFound) {
+		t.Errorf("FindByName(shop, Posts) = %v, want ErrBoardNotFound", err)
+	}
+
+	all, err := repos.Boards.FindAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, b := range all {
+		ids = append(ids, b.ID())
+	}
+	sort.Strings(ids)
+	if !reflect.DeepEqual(ids, []string{"blog/posts", "shop/main"}) {
+		t.Errorf("FindAll = %v", ids)
+	}
+
+	if err := repos.Boards.Delete(ctx, "blog/posts"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := repos.Boards.Exists(ctx, "blog/posts"); err != nil || exists {
+		t.Errorf("Exists after delete = %v, %v", exists, err)
+	}
+	if _, err := repos.Boards.FindByID(ctx, "blog/posts"); !errors.Is(err, entity.ErrBoardNotFound) {
+		t.Errorf("FindByID after delete = %v, want ErrBoardNotFound", err)
+	}
+	if err := repos.Boards.Delete(ctx, "blog/posts"); !errors.Is(err, entity.ErrBoardNotFound) {
+		t.Errorf("second Delete = %v, want ErrBoardNotFound", err)
+	}
+}
+
+func testNotes(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	if err := repos.Projects.Save(ctx, newProject(t, "p-shop", "Shop")); err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	standup := newNote(t, "note-0001", "Standup", entity.NoteTypeStandup, "p-shop", day, "Talked about Über pricing")
+	standup.AddTag("team")
+	retro := newNote(t, "note-0002", "Retro", entity.NoteTypeRetro, "p-shop", day.AddDate(0, 0, 3), "Went well")
+	retro.AddTag("team")
+	retro.AddTag("process")
+	global := newNote(t, "note-0003", "Ideas", entity.NoteTypeGeneral, "", day, "Someday")
+	for _, note := range []*entity.Note{standup, retro, global} {
+		if err := repos.Notes.Save(ctx, note); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checks := []struct {
+		name string
+		find func() ([]*entity.Note, error)
+		want []string
+	}{
+		{"FindByProject", func() ([]*entity.Note, error) { return repos.Notes.FindByProject(ctx, "p-shop") }, []string{"note-0001", "note-0002"}},
+		{"FindByDate", func() ([]*entity.Note, error) { return repos.Notes.FindByDate(ctx, "p-shop", day) }, []string{"note-0001"}},
+		{"FindByDateRange", func() ([]*entity.Note, error) {
+			return repos.Notes.FindByDateRange(ctx, "p-shop", day.Add(time.Hour), day.AddDate(0, 0, 3))
+		}, []string{"note-0002"}},
+		{"FindByType", func() ([]*entity.Note, error) { return repos.Notes.FindByType(ctx, "p-shop", entity.NoteTypeRetro) }, []string{"note-0002"}},
+		{"FindByTag", func() ([]*entity.Note, error) { return repos.Notes.FindByTag(ctx, "p-shop", "team") }, []string{"note-0001", "note-0002"}},
+		{"Search", func() ([]*entity.Note, error) { return repos.Notes.Search(ctx, "p-shop", "über") }, []string{"note-0001"}},
+		{"FindGlobal", func() ([]*entity.Note, error) { return repos.Notes.FindGlobal(ctx) }, []string{"note-0003"}},
+		{"FindGlobalByDate", func() ([]*entity.Note, error) { return repos.Notes.FindGlobalByDate(ctx, day) }, []string{"note-0003"}},
+	}
+	for _, check := range checks {
+		notes, err := check.find()
+		if err != nil {
+			t.Errorf("%s: %v", check.name, err)
+			continue
+		}
+		if got := noteIDs(notes); !reflect.DeepEqual(got, check.want) {
+			t.Errorf("%s = %v, want %v", check.name, got, check.want)
+		}
+	}
+
+	found, err := repos.Notes.FindByID(ctx, "note-0002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Content() != "Went well" || !found.HasTag("process") || found.ProjectID() != "p-shop" {
+		t.Errorf("FindByID = %q %v %q", found.Content(), found.Tags(), found.ProjectID())
+	}
+
+	if _, err := repos.Notes.FindByProject(ctx, "p-missing"); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("FindByProject(missing) = %v, want ErrProjectNotFound", err)
+	}
+	if err := repos.Notes.Delete(ctx, "note-0001"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.Notes.FindByID(ctx, "note-0001"); !errors.Is(err, entity.ErrNoteNotFound) {
+		t.Errorf("FindByID after delete = %v, want ErrNoteNotFound", err)
+	}
+	if err := repos.Notes.Delete(ctx, "note-0001"); !errors.Is(err, entity.ErrNoteNotFound) {
+		t.Errorf("second Delete = %v, want ErrNoteNotFound", err)
+	}
+}
+
+func testTimeLogs(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	orphan := newTimeLog(t, "log-0", "p-missing", start)
+	if err := repos.TimeLogs.Save(ctx, orphan); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Save for a missing project = %v, want ErrProjectNotFound", err)
+	}
+
+	if err := repos.Projects.Save(ctx, newProject(t, "p-shop", "Shop")); err != nil {
+		t.Fatal(err)
+	}
+	taskID, err := valueobject.NewTaskID("SHO", 1, "write-docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	march := newTimeLog(t, "log-1", "p-shop", start)
+	march.SetTaskID(taskID)
+	if err := march.Stop(start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	april := newTimeLog(t, "log-2", "p-shop", start.AddDate(0, 1, 0))
+	running := newTimeLog(t, "log-3", "p-shop", start.AddDate(0, 1, 1))
+	running.SetTaskID(taskID)
+	for _, log := range []*entity.TimeLog{march, april, running} {
+		if err := repos.TimeLogs.Save(ctx, log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checks := []struct {
+		name string
+		find func() ([]*entity.TimeLog, error)
+		want []string
+	}{
+		{"FindByProject", func() ([]*entity.TimeLog, error) { return repos.TimeLogs.FindByProject(ctx, "p-shop") }, []string{"log-1", "log-2", "log-3"}},
+		{"FindByTask", func() ([]*entity.TimeLog, error) { return repos.TimeLogs.FindByTask(ctx, taskID) }, []string{"log-1", "log-3"}},
+		{"FindByDateRange", func() ([]*entity.TimeLog, error) {
+			return repos.TimeLogs.FindByDateRange(ctx, "p-shop", start, start.AddDate(0, 1, 0))
+		}, []string{"log-1", "log-2"}},
+		{"FindRunning", func() ([]*entity.TimeLog, error) { return repos.TimeLogs.FindRunning(ctx) }, []string{"log-2", "log-3"}},
+	}
+	for _, check := range checks {
+		logs, err := check.find()
+		if err != nil {
+			t.Errorf("%s: %v", check.name, err)
+			continue
+		}
+		if got := timeLogIDs(logs); !reflect.DeepEqual(got, check.want) {
+			t.Errorf("%s = %v, want %v", check.name, got, check.want)
+		}
+	}
+
+	// Stopping a running log takes it out of FindRunning
+	if err := april.Stop(april.StartTime().Add(30 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.TimeLogs.Save(ctx, april); err != nil {
+		t.Fatal(err)
+	}
+	found, err := repos.TimeLogs.FindByID(ctx, "log-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.IsRunning() || found.Duration() != 30*time.Minute {
+		t.Errorf("stopped log: running %v, duration %v", found.IsRunning(), found.Duration())
+	}
+	if logs, err := repos.TimeLogs.FindRunning(ctx); err != nil || !reflect.DeepEqual(timeLogIDs(logs), []string{"log-3"}) {
+		t.Errorf("FindRunning after stop = %v, %v", timeLogIDs(logs), err)
+	}
+
+	if err := repos.TimeLogs.Delete(ctx, "log-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.TimeLogs.FindByID(ctx, "log-1"); !errors.Is(err, entity.ErrTimeLogNotFound) {
+		t.Errorf("FindByID after delete = %v, want ErrTimeLogNotFound", err)
+	}
+	if err := repos.TimeLogs.Delete(ctx, "log-1"); !errors.Is(err, entity.ErrTimeLogNotFound) {
+		t.Errorf("second Delete = %v, want ErrTimeLogNotFound", err)
+	}
+}
+
+func testActions(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	global := newEventAction(t, "a-global", valueobject.ActionScopeGlobal, "")
+	onBoard := newEventAction(t, "a-board", valueobject.ActionScopeBoard, "shop/main")
+	onBoard.Disable()
+	nightly := newTimeAction(t, "a-nightly", valueobject.ActionScopeBoard, "shop/main")
+	for _, action := range []*entity.Action{global, onBoard, nightly} {
+		if err := repos.Actions.Create(ctx, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repos.Actions.Create(ctx, global); err == nil {
+		t.Error("Create of an existing action succeeded")
+	}
+
+	checks := []struct {
+		name string
+		list func() ([]*entity.Action, error)
+		want []string
+	}{
+		{"ListAll", func() ([]*entity.Action, error) { return repos.Actions.ListAll(ctx) }, []string{"a-board", "a-global", "a-nightly"}},
+		{"ListGlobal", func() ([]*entity.Action, error) { return repos.Actions.ListGlobal(ctx) }, []string{"a-global"}},
+		{"ListByBoard", func() ([]*entity.Action, error) { return repos.Actions.ListByBoard(ctx, "shop/main") }, []string{"a-board", "a-nightly"}},
+		{"ListEnabled", func() ([]*entity.Action, error) { return repos.Actions.ListEnabled(ctx) }, []string{"a-global", "a-nightly"}},
+		{"ListByTriggerType", func() ([]*entity.Action, error) {
+			return repos.Actions.ListByTriggerType(ctx, entity.TriggerTypeTime)
+		}, []string{"a-nightly"}},
+	}
+	for _, check := range checks {
+		actions, err := check.list()
+		if err != nil {
+			t.Errorf("%s: %v", check.name, err)
+			continue
+		}
+		if got := actionIDs(actions); !reflect.DeepEqual(got, check.want) {
+			t.Errorf("%s = %v, want %v", check.name, got, check.want)
+		}
+	}
+
+	onBoard.Enable()
+	if err := repos.Actions.Update(ctx, onBoard); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Actions.UpdateLastRun(ctx, "a-board"); err != nil {
+		t.Fatal(err)
+	}
+	found, err := repos.Actions.GetByID(ctx, "a-board")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Enabled() || found.LastRun() == nil {
+		t.Errorf("after update: enabled %v, last run %v", found.Enabled(), found.LastRun())
+	}
+
+	if err := repos.Actions.Delete(ctx, "a-board"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.Actions.GetByID(ctx, "a-board"); !errors.Is(err, entity.ErrActionNotFound) {
+		t.Errorf("GetByID after delete = %v, want ErrActionNotFound", err)
+	}
+	if err := repos.Actions.Update(ctx, onBoard); !errors.Is(err, entity.ErrActionNotFound) {
+		t.Errorf("Update after delete = %v, want ErrActionNotFound", err)
+	}
+	if err := repos.Actions.Delete(ctx, "a-board"); !errors.Is(err, entity.ErrActionNotFound) {
+		t.Errorf("second Delete = %v, want ErrActionNotFound", err)
+	}
+}
+
+func testActionExecutions(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for i, id := range []string{"e1", "e2", "e3", "e4"} {
+		execution := newExecution(id, "a-one", start.Add(time.Duration(i)*time.Hour))
+		if err := repos.ActionExecutions.Save(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repos.ActionExecutions.Save(ctx, newExecution("f1", "a-two", start.Add(90*time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+
+	// The history limit of the test config keeps the last three
+	history, err := repos.ActionExecutions.ListByAction(ctx, "a-one", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := executionIDs(history); !reflect.DeepEqual(got, []string{"e4", "e3", "e2"}) {
+		t.Errorf("ListByAction = %v", got)
+	}
+	history, err = repos.ActionExecutions.ListByAction(ctx, "a-one", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := executionIDs(history); !reflect.DeepEqual(got, []string{"e4"}) {
+		t.Errorf("ListByAction limited = %v", got)
+	}
+
+	recent, err := repos.ActionExecutions.ListRecent(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := executionIDs(recent); !reflect.DeepEqual(got, []string{"e4", "e3", "f1"}) {
+		t.Errorf("ListRecent = %v", got)
+	}
+
+	if err := repos.ActionExecutions.DeleteByAction(ctx, "a-one"); err != nil {
+		t.Fatal(err)
+	}
+	history, err = repos.ActionExecutions.ListByAction(ctx, "a-one", 0)
+	if err != nil || len(history) != 0 {
+		t.Errorf("ListByAction after delete = %v, %v", executionIDs(history), err)
+	}
+}
+
+func newProject(t *testing.T, id, name string) *entity.Project {
+	t.Helper()
+	project, err := entity.NewProject(id, name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+func newBoard(t *testing.T, id, name string) *entity.Board {
+	t.Helper()
+	board, err := entity.NewBoard(id, name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func addColumn(t *testing.T, board *entity.Board, displayName string, order int) *entity.Column {
+	t.Helper()
+	column, err := entity.NewColumnWithDisplayName(valueobject.GenerateSlug(displayName), displayName, "", order, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddColumn(column); err != nil {
+		t.Fatal(err)
+	}
+	return column
+}
+
+func addTask(t *testing.T, board *entity.Board, column *entity.Column, title string) *entity.Task {
+	t.Helper()
+	taskID, err := board.GenerateNextTaskID(valueobject.GenerateSlug(title))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := entity.NewTask(taskID, title, "", valueobject.PriorityNone, valueobject.StatusTodo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := column.AddTask(task); err != nil {
+		t.Fatal(err)
+	}
+	return task
+}
+
+func newNote(t *testing.T, id, title string, noteType entity.NoteType, projectID string, date time.Time, content string) *entity.Note {
+	t.Helper()
+	note, err := entity.NewNote(id, title, noteType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projectID != "" {
+		note.SetProjectID(projectID)
+	}
+	note.SetDate(date)
+	note.SetContent(content)
+	return note
+}
+
+func newTimeLog(t *testing.T, id, projectID string, start time.Time) *entity.TimeLog {
+	t.Helper()
+	log, err := entity.NewTimeLog(id, projectID, entity.TimeLogSourceManual, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log
+}
+
+func newEventAction(t *testing.T, id string, scope valueobject.ActionScope, scopeID string) *entity.Action {
+	t.Helper()
+	trigger, err := entity.NewEventTrigger(valueobject.EventTaskCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := entity.NewAction(id, id, "", scope, scopeID, trigger,
+		entity.NewNotificationAction("title", "message", nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return action
+}
+
+func newTimeAction(t *testing.T, id string, scope valueobject.ActionScope, scopeID string) *entity.Action {
+	t.Helper()
+	schedule, err := valueobject.NewRecurringSchedule("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger, err := entity.NewTimeTrigger(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := entity.NewAction(id, id, "", scope, scopeID, trigger,
+		entity.NewNotificationAction("title", "message", nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return action
+}
+
+func newExecution(id, actionID string, startedAt time.Time) *entity.ActionExecution {
+	return &entity.ActionExecution{
+		ID:          id,
+		ActionID:    actionID,
+		ActionName:  actionID,
+		TriggerType: entity.TriggerTypeEvent,
+		Attempt:     1,
+		StartedAt:   startedAt,
+		FinishedAt:  startedAt.Add(time.Second),
+		Outcome:     entity.ExecutionOutcomeSuccess,
+	}
+}
+
+// boardLayout lists the tasks of a board as "Column: task ID", in column
+// order
+func boardLayout(board *entity.Board) []string {
+	var layout []string
+	for _, column := range board.Columns() {
+		for _, task := range column.Tasks() {
+			layout = append(layout, column.DisplayName()+": "+task.ID().String())
+		}
+	}
+	return layout
+}
+
+func projectIDs(projects []*entity.Project) []string {
+	var ids []string
+	for _, project := range projects {
+		ids = append(ids, project.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func noteIDs(notes []*entity.Note) []string {
+	var ids []string
+	for _, note := range notes {
+		ids = append(ids, note.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func timeLogIDs(logs []*entity.TimeLog) []string {
+	var ids []string
+	for _, log := range logs {
+		ids = append(ids, log.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func actionIDs(actions []*entity.Action) []string {
+	var ids []string
+	for _, action := range actions {
+		ids = append(ids, action.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// executionIDs keeps the order of the executions, which is part of what
+// the repositories promise
+func executionIDs(executions []*entity.ActionExecution) []string {
+	var ids []string
+	for _, execution := range executions {
+		ids = append(ids, execution.ID)
+	}
+	return ids
+}
@@ -0,0 +1,554 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/persistence/sqlite"
+	pkgfs "mkanban/pkg/filesystem"
+)
+
+const (
+	// snapshotExt ends the file name of every snapshot
+	snapshotExt = ".tar.gz"
+
+	// idFormat is the layout of snapshot IDs, the local time they were taken
+	idFormat = "20060102-150405"
+
+	// LatestSnapshot names the newest snapshot in place of its ID
+	LatestSnapshot = "latest"
+
+	// externalDatabaseDir holds the database in snapshots when it is kept
+	// outside the data directory
+	externalDatabaseDir = "database"
+
+	// Retention when none is configured
+	defaultKeepHourly = 24
+	defaultKeepDaily  = 30
+)
+
+// Snapshotter implements service.DataSnapshotter with gzip compressed tar
+// archives of the data directory, named <id>.<kind>.tar.gz. Snapshots and
+// restores hold the data lock exclusive, so they wait for the writes of
+// the daemon and the CLI in progress and hold off new ones.
+type Snapshotter struct {
+	root       string
+	dir        string
+	database   string
+	keepHourly int
+	keepDaily  int
+	lock       *pkgfs.FileLock
+	now        func() time.Time
+}
+
+// NewSnapshotter creates a snapshotter of the data directory at root,
+// keeping its snapshots in dir (snapshots/ in root when empty). database
+// is the SQLite database of the data, empty with the filesystem backend;
+// snapshots hold a consistent copy of it wherever it is kept. Scheduled
+// snapshots are pruned to the newest of each hour for keepHourly hours and
+// of each day for keepDaily days.
+func NewSnapshotter(root, dir, database string, keepHourly, keepDaily int) *Snapshotter {
+	if dir == "" {
+		dir = filepath.Join(root, filesystem.SnapshotsDir)
+	}
+	if keepHourly <= 0 {
+		keepHourly = defaultKeepHourly
+	}
+	if keepDaily <= 0 {
+		keepDaily = defaultKeepDaily
+	}
+	return &Snapshotter{
+		root:       root,
+		dir:        dir,
+		database:   database,
+		keepHourly: keepHourly,
+		keepDaily:  keepDaily,
+		lock:       filesystem.NewDataLock(root),
+		now:        time.Now,
+	}
+}
+
+// Create archives the data directory
+func (s *Snapshotter) Create(ctx context.Context, kind string) (*service.DataSnapshot, error) {
+	unlock, err := s.lock.Exclusive()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.create(ctx, kind)
+}
+
+func (s *Snapshotter) create(ctx context.Context, kind string) (*service.DataSnapshot, error) {
+	switch kind {
+	case service.SnapshotScheduled, service.SnapshotManual, service.SnapshotPreRestore:
+	default:
+		return nil, fmt.Errorf("unknown snapshot kind %q", kind)
+	}
+
+	// IDs are unique to the second, later snapshots of the same second get
+	// a counter
+	base := s.now().Format(idFormat)
+	id := base
+	for n := 2; ; n++ {
+		matches, err := filepath.Glob(filepath.Join(s.dir, id+".*"+snapshotExt))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	database, cleanup, err := s.copyDatabase(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	defer cleanup()
+
+	file := filepath.Join(s.dir, id+"."+kind+snapshotExt)
+	if err := pkgfs.WriteArchive(file, s.root, s.skip, database...); err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	snapshot, err := s.load(file)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// List returns the snapshots in the snapshot folder, newest first
+func (s *Snapshotter) List(ctx context.Context) ([]service.DataSnapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+
+	var snapshots []service.DataSnapshot
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), snapshotExt) {
+			continue
+		}
+		snapshot, err := s.load(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			// Not a snapshot this snapshotter wrote
+			continue
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].CreatedAt.Equal(snapshots[j].CreatedAt) {
+			return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+		}
+		return snapshots[i].ID > snapshots[j].ID
+	})
+	return snapshots, nil
+}
+
+// Diff lists the files within scope that changed since the snapshot
+func (s *Snapshotter) Diff(ctx context.Context, id string, scope service.SnapshotScope) ([]service.DataFileChange, error) {
+	snapshot, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.diff(ctx, snapshot, scope)
+	if err != nil {
+		return nil, err
+	}
+	return toDataFileChanges(changes), nil
+}
+
+// Restore rewrites the files within scope that changed since the
+// snapshot, and removes those added since
+func (s *Snapshotter) Restore(ctx context.Context, id string, scope service.SnapshotScope) (*service.SnapshotRestoreResult, error) {
+	unlock, err := s.lock.Exclusive()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	snapshot, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.diff(ctx, snapshot, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.SnapshotRestoreResult{Snapshot: *snapshot}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	undo, err := s.create(ctx, service.SnapshotPreRestore)
+	if err != nil {
+		return nil, err
+	}
+	result.Undo = undo
+
+	restore := make(map[string]bool)
+	var added []string
+	for _, change := range changes {
+		if change.Change == pkgfs.ChangeAdded {
+			added = append(added, change.Path)
+		} else {
+			restore[change.Path] = true
+		}
+	}
+
+	// Files added since go first, so folders of the snapshot they leave
+	// empty are put back below
+	for _, rel := range added {
+		if err := s.remove(rel); err != nil {
+			return nil, err
+		}
+	}
+
+	prefix := scopePrefix(scope)
+	err = pkgfs.ReadArchive(s.file(snapshot), func(header *tar.Header, r io.Reader) error {
+		name := strings.TrimSuffix(header.Name, "/")
+		if !inScope(name, prefix) {
+			return nil
+		}
+		target := s.target(name)
+
+		switch {
+		case header.Typeflag == tar.TypeDir:
+			return pkgfs.EnsureDir(target, 0755)
+		case header.Typeflag == tar.TypeReg && restore[name]:
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			if err := pkgfs.SafeWrite(target, data, fs.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+			if s.isDatabase(name) {
+				// The journal of the replaced database doesn't belong to the copy
+				return removeJournal(target)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore from snapshot %s: %w", snapshot.ID, err)
+	}
+
+	// Changes are reported the way the restore made them
+	for _, change := range changes {
+		switch change.Change {
+		case pkgfs.ChangeAdded:
+			change.Change = pkgfs.ChangeRemoved
+		case pkgfs.ChangeRemoved:
+			change.Change = pkgfs.ChangeAdded
+		}
+		result.Changes = append(result.Changes, service.DataFileChange{Change: change.Change, Path: change.Path})
+	}
+	return result, nil
+}
+
+// Prune removes the snapshots the retention rules no longer keep
+func (s *Snapshotter) Prune(ctx context.Context, now time.Time) ([]service.DataSnapshot, error) {
+	snapshots, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := retained(snapshots, now, s.keepHourly, s.keepDaily)
+	var pruned []service.DataSnapshot
+	for _, snapshot := range snapshots {
+		if keep[snapshot.ID] {
+			continue
+		}
+		if err := os.Remove(s.file(&snapshot)); err != nil && !os.IsNotExist(err) {
+			return pruned, fmt.Errorf("failed to remove snapshot %s: %w", snapshot.ID, err)
+		}
+		pruned = append(pruned, snapshot)
+	}
+	return pruned, nil
+}
+
+// retained picks the IDs of the snapshots kept as of now: the newest one,
+// manual ones, and otherwise the newest of each hour for keepHourly hours
+// and of each day for keepDaily days. snapshots are newest first.
+func retained(snapshots []service.DataSnapshot, now time.Time, keepHourly, keepDaily int) map[string]bool {
+	keep := make(map[string]bool)
+	hours := make(map[string]bool)
+	days := make(map[string]bool)
+
+	for i, snapshot := range snapshots {
+		age := now.Sub(snapshot.CreatedAt)
+		hour := snapshot.CreatedAt.Local().Format("2006010215")
+		day := snapshot.CreatedAt.Local().Format("20060102")
+
+		if i == 0 || snapshot.Kind == service.SnapshotManual {
+			keep[snapshot.ID] = true
+		}
+		if age < time.Duration(keepHourly)*time.Hour && !hours[hour] {
+			keep[snapshot.ID] = true
+		}
+		if age < time.Duration(keepDaily)*24*time.Hour && !days[day] {
+			keep[snapshot.ID] = true
+		}
+		hours[hour] = true
+		days[day] = true
+	}
+	return keep
+}
+
+// find looks a snapshot up by its ID, or the newest for "latest"
+func (s *Snapshotter) find(ctx context.Context, id string) (*service.DataSnapshot, error) {
+	snapshots, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.ID == id || (id == LatestSnapshot && i == 0) {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", service.ErrSnapshotNotFound, id)
+}
+
+// diff lists the files within scope from the snapshot to the data
+// directory as it is
+func (s *Snapshotter) diff(ctx context.Context, snapshot *service.DataSnapshot, scope service.SnapshotScope) ([]pkgfs.TreeChange, error) {
+	if s.database != "" && scopePrefix(scope) != "" {
+		return nil, service.ErrSnapshotScopeDatabase
+	}
+	if scope.BoardID != "" {
+		if _, _, err := valueobject.ParseBoardID(scope.BoardID); err != nil {
+			return nil, err
+		}
+	}
+	prefix := scopePrefix(scope)
+
+	before, err := pkgfs.HashArchive(s.file(snapshot))
+	if err != nil {
+		return nil, err
+	}
+	after, err := pkgfs.HashTree(s.root, s.skip)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.hashDatabase(ctx, after); err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, hashes := range []pkgfs.TreeHashes{before, after} {
+		for rel := range hashes {
+			if inScope(rel, prefix) {
+				found = true
+			} else {
+				delete(hashes, rel)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no %s in snapshot %s or in the data directory", scopeName(scope), snapshot.ID)
+	}
+
+	return pkgfs.DiffTrees(before, after), nil
+}
+
+// remove deletes a file of the data directory and the folders it leaves
+// empty
+func (s *Snapshotter) remove(rel string) error {
+	target := s.target(rel)
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", rel, err)
+	}
+	if s.isDatabase(rel) {
+		if err := removeJournal(target); err != nil {
+			return err
+		}
+	}
+
+	for dir := filepath.Dir(target); dir != s.root && strings.HasPrefix(dir, s.root); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			break
+		}
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// load describes the snapshot in an archive named <id>.<kind>.tar.gz
+func (s *Snapshotter) load(file string) (*service.DataSnapshot, error) {
+	name := strings.TrimSuffix(filepath.Base(file), snapshotExt)
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("not a snapshot: %s", file)
+	}
+	id, kind := name[:dot], name[dot+1:]
+
+	createdAt, err := time.ParseInLocation(idFormat, id[:min(len(id), len(idFormat))], time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("not a snapshot: %s", file)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.DataSnapshot{
+		ID:        id,
+		Kind:      kind,
+		CreatedAt: createdAt,
+		Size:      info.Size(),
+	}, nil
+}
+
+func (s *Snapshotter) file(snapshot *service.DataSnapshot) string {
+	return filepath.Join(s.dir, snapshot.ID+"."+snapshot.Kind+snapshotExt)
+}
+
+// skip leaves what archives of the data directory never hold out of
+// snapshots, the snapshot folder when it is inside the data directory, and
+// the files of the database, which snapshots hold a copy of instead
+func (s *Snapshotter) skip(rel string, entry fs.DirEntry) bool {
+	if filesystem.SkipArchived(rel, entry) {
+		return true
+	}
+	if s.isDatabase(strings.TrimSuffix(rel, "-wal")) {
+		return true
+	}
+	return filepath.Join(s.root, filepath.FromSlash(rel)) == filepath.Clean(s.dir)
+}
+
+// databaseName is the path of the database in snapshots: its path in the
+// data directory, or a folder of its own when it is kept elsewhere
+func (s *Snapshotter) databaseName() string {
+	rel, err := filepath.Rel(s.root, s.database)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path.Join(externalDatabaseDir, filepath.Base(s.database))
+	}
+	return filepath.ToSlash(rel)
+}
+
+// isDatabase reports whether a path of a snapshot is the database
+func (s *Snapshotter) isDatabase(name string) bool {
+	return s.database != "" && name == s.databaseName()
+}
+
+// target returns the file a path of a snapshot is restored to
+func (s *Snapshotter) target(name string) string {
+	if s.isDatabase(name) {
+		return s.database
+	}
+	return filepath.Join(s.root, filepath.FromSlash(name))
+}
+
+// copyDatabase copies the database, when there is one, to a temporary
+// file, returning it as a file of an archive along with the function
+// removing it
+func (s *Snapshotter) copyDatabase(ctx context.Context) ([]pkgfs.ArchiveFile, func(), error) {
+	if s.database == "" {
+		return nil, func() {}, nil
+	}
+	if _, err := os.Stat(s.database); os.IsNotExist(err) {
+		return nil, func() {}, nil
+	}
+
+	dir, err := os.MkdirTemp("", "mkanban-snapshot-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	copied := filepath.Join(dir, filepath.Base(s.database))
+	if err := sqlite.CopyDatabase(ctx, s.database, copied); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return []pkgfs.ArchiveFile{{Name: s.databaseName(), Path: copied}}, cleanup, nil
+}
+
+// hashDatabase adds the hash of a copy of the database to the hashes of
+// the data directory, taken the way snapshots take it
+func (s *Snapshotter) hashDatabase(ctx context.Context, hashes pkgfs.TreeHashes) error {
+	database, cleanup, err := s.copyDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	for _, file := range database {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			return err
+		}
+		hashes[file.Name] = sha256.Sum256(data)
+	}
+	return nil
+}
+
+// removeJournal removes the write-ahead log of a database
+func removeJournal(database string) error {
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(database + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove database journal: %w", err)
+		}
+	}
+	return nil
+}
+
+// scopePrefix is the folder, relative to the data directory, a scope
+// covers, empty for all of it
+func scopePrefix(scope service.SnapshotScope) string {
+	paths := filesystem.NewProjectPathBuilder("")
+	if scope.BoardID != "" {
+		projectSlug, boardSlug, _ := valueobject.ParseBoardID(scope.BoardID)
+		return path.Join(filepath.ToSlash(paths.ProjectBoardsDir(projectSlug)), boardSlug)
+	}
+	if scope.ProjectSlug != "" {
+		return filepath.ToSlash(paths.ProjectDir(scope.ProjectSlug))
+	}
+	return ""
+}
+
+func scopeName(scope service.SnapshotScope) string {
+	if scope.BoardID != "" {
+		return "board " + scope.BoardID
+	}
+	if scope.ProjectSlug != "" {
+		return "project " + scope.ProjectSlug
+	}
+	return "files"
+}
+
+func inScope(rel, prefix string) bool {
+	return prefix == "" || rel == prefix || strings.HasPrefix(rel, prefix+"/")
+}
+
+func toDataFileChanges(changes []pkgfs.TreeChange) []service.DataFileChange {
+	dataChanges := make([]service.DataFileChange, 0, len(changes))
+	for _, change := range changes {
+		dataChanges = append(dataChanges, service.DataFileChange{Change: change.Change, Path: change.Path})
+	}
+	return dataChanges
+}
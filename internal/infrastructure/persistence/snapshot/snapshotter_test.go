@@ -0,0 +1,366 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/service"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/filesystem"
+	"mkanban/internal/infrastructure/persistence/sqlite"
+)
+
+func TestRestoreBoard(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	boards := filesystem.NewBoardRepository(root)
+	snapshotter := NewSnapshotter(root, "", "", 0, 0)
+
+	main := saveBoard(t, boards, "shop/main", "Ship it")
+	ops := saveBoard(t, boards, "shop/ops", "Rotate keys")
+	snapshot, err := snapshotter.Create(ctx, service.SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMain, wantOps := boardLayout(main), boardLayout(ops)
+
+	// A script goes wild on both boards
+	addTask(t, main, "Oops")
+	if err := boards.Save(ctx, main); err != nil {
+		t.Fatal(err)
+	}
+	addTask(t, ops, "Keep me")
+	if err := boards.Save(ctx, ops); err != nil {
+		t.Fatal(err)
+	}
+	changedOps := boardLayout(ops)
+
+	changes, err := snapshotter.Diff(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) == 0 {
+		t.Fatal("diff found no changes to the board")
+	}
+	for _, change := range changes {
+		if !inScope(change.Path, "projects/shop/boards/main") {
+			t.Errorf("diff of shop/main has %s", change.Path)
+		}
+	}
+
+	result, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Undo == nil || len(result.Changes) != len(changes) {
+		t.Errorf("restore = %+v, want an undo snapshot and %d changes", result, len(changes))
+	}
+
+	if got := boardLayout(findBoard(t, boards, "shop/main")); !reflect.DeepEqual(got, wantMain) {
+		t.Errorf("restored shop/main = %v, want %v", got, wantMain)
+	}
+	if got := boardLayout(findBoard(t, boards, "shop/ops")); !reflect.DeepEqual(got, changedOps) {
+		t.Errorf("shop/ops = %v, want it left alone as %v", got, changedOps)
+	}
+	if changes, _ := snapshotter.Diff(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/main"}); len(changes) != 0 {
+		t.Errorf("diff after restoring = %v", changes)
+	}
+
+	// The whole tree goes back too, and the undo snapshot brings the
+	// restored board's changes back
+	if _, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := boardLayout(findBoard(t, boards, "shop/ops")); !reflect.DeepEqual(got, wantOps) {
+		t.Errorf("restored shop/ops = %v, want %v", got, wantOps)
+	}
+	if _, err := snapshotter.Restore(ctx, result.Undo.ID, service.SnapshotScope{ProjectSlug: "shop"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := boardLayout(findBoard(t, boards, "shop/main")); len(got) != len(wantMain)+1 {
+		t.Errorf("undone shop/main = %v, want the added task back", got)
+	}
+}
+
+func TestRestoreRemovesBoardsAddedSince(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	boards := filesystem.NewBoardRepository(root)
+	snapshotter := NewSnapshotter(root, "", "", 0, 0)
+
+	saveBoard(t, boards, "shop/main", "Ship it")
+	empty, err := entity.NewBoard("shop/empty", "Empty", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := boards.Save(ctx, empty); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := snapshotter.Create(ctx, service.SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveBoard(t, boards, "shop/extra", "Later")
+	saveBoard(t, boards, "shop/empty", "Fill it")
+
+	if _, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/extra"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "projects", "shop", "boards", "extra")); !os.IsNotExist(err) {
+		t.Errorf("board added since the snapshot is still there: %v", err)
+	}
+	findBoard(t, boards, "shop/main")
+
+	// Folders the snapshot had empty stay, though removing what was added
+	// since empties them
+	if _, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/empty"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := findBoard(t, boards, "shop/empty"); len(got.Columns()) != 0 {
+		t.Errorf("restored shop/empty has %d columns, want none", len(got.Columns()))
+	}
+
+	if _, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{BoardID: "shop/none"}); err == nil {
+		t.Error("restoring a board in neither the snapshot nor the data directory succeeded")
+	}
+	if _, err := snapshotter.Diff(ctx, "20000101-000000", service.SnapshotScope{}); !errors.Is(err, service.ErrSnapshotNotFound) {
+		t.Errorf("diff of an unknown snapshot: %v, want ErrSnapshotNotFound", err)
+	}
+}
+
+func TestListAndPrune(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	snapshotter := NewSnapshotter(root, filepath.Join(t.TempDir(), "kept"), "", 2, 2)
+	saveBoard(t, filesystem.NewBoardRepository(root), "shop/main", "Ship it")
+
+	start := time.Date(2026, 3, 1, 22, 0, 0, 0, time.Local)
+	for _, at := range []struct {
+		offset time.Duration
+		kind   string
+	}{
+		{0, service.SnapshotManual},
+		{10 * time.Minute, service.SnapshotScheduled},
+		{20 * time.Minute, service.SnapshotScheduled},
+		{time.Hour, service.SnapshotScheduled},
+		{25 * time.Hour, service.SnapshotScheduled},
+		{25*time.Hour + 30*time.Minute, service.SnapshotPreRestore},
+		{26 * time.Hour, service.SnapshotScheduled},
+	} {
+		snapshotter.now = func() time.Time { return start.Add(at.offset) }
+		if _, err := snapshotter.Create(ctx, at.kind); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listed, err := snapshotter.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 7 || !listed[0].CreatedAt.Equal(start.Add(26*time.Hour)) || listed[0].Size == 0 {
+		t.Fatalf("listed %+v, want 7 snapshots newest first", listed)
+	}
+
+	pruned, err := snapshotter.Prune(ctx, start.Add(26*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Kept: the manual one, the newest of each of the last 2 hours (26h,
+	// 25h30) and the newest of each of the last 2 days (25h30, 1h)
+	want := []string{
+		start.Add(10 * time.Minute).Format(idFormat),
+		start.Add(20 * time.Minute).Format(idFormat),
+		start.Add(25 * time.Hour).Format(idFormat),
+	}
+	if got := snapshotIDs(pruned); !reflect.DeepEqual(got, want) {
+		t.Errorf("pruned %v, want %v", got, want)
+	}
+	if left, _ := snapshotter.List(ctx); len(left) != 4 {
+		t.Errorf("%d snapshots left, want 4", len(left))
+	}
+}
+
+func TestSnapshotCopiesDatabase(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	// Kept outside the data directory
+	database := filepath.Join(t.TempDir(), "boards.db")
+	store := sqlite.NewStore(database)
+	boards := sqlite.NewBoardRepository(store)
+	snapshotter := NewSnapshotter(root, "", database, 0, 0)
+
+	main := saveBoard(t, boards, "shop/main", "Ship it")
+	want := boardLayout(main)
+	snapshot, err := snapshotter.Create(ctx, service.SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := snapshotter.Diff(ctx, snapshot.ID, service.SnapshotScope{}); err != nil || len(changes) != 0 {
+		t.Fatalf("diff right after the snapshot = %v, %v, want no changes", changes, err)
+	}
+
+	addTask(t, main, "Oops")
+	if err := boards.Save(ctx, main); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := snapshotter.Diff(ctx, snapshot.ID, service.SnapshotScope{})
+	wantChanges := []service.DataFileChange{{Change: "modified", Path: "database/boards.db"}}
+	if err != nil || !reflect.DeepEqual(changes, wantChanges) {
+		t.Fatalf("diff = %v, %v, want %v", changes, err, wantChanges)
+	}
+
+	// Restoring swaps the database, which is done with it closed
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snapshotter.Restore(ctx, snapshot.ID, service.SnapshotScope{}); err != nil {
+		t.Fatal(err)
+	}
+	restored := sqlite.NewStore(database)
+	defer restored.Close()
+	if got := boardLayout(findBoard(t, sqlite.NewBoardRepository(restored), "shop/main")); !reflect.DeepEqual(got, want) {
+		t.Errorf("restored shop/main = %v, want %v", got, want)
+	}
+}
+
+func TestSnapshotDatabaseRefusesScope(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	database := filepath.Join(root, "mkanban.db")
+	store := sqlite.NewStore(database)
+	defer store.Close()
+	boards := sqlite.NewBoardRepository(store)
+	snapshotter := NewSnapshotter(root, "", database, 0, 0)
+
+	main := saveBoard(t, boards, "shop/main", "Ship it")
+	snapshot, err := snapshotter.Create(ctx, service.SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTask(t, main, "Keep me")
+	if err := boards.Save(ctx, main); err != nil {
+		t.Fatal(err)
+	}
+	want := boardLayout(main)
+
+	scopes := []service.SnapshotScope{{BoardID: "shop/main"}, {ProjectSlug: "shop"}}
+	for _, scope := range scopes {
+		if _, err := snapshotter.Diff(ctx, snapshot.ID, scope); !errors.Is(err, service.ErrSnapshotScopeDatabase) {
+			t.Errorf("diff of %+v: %v, want ErrSnapshotScopeDatabase", scope, err)
+		}
+		if _, err := snapshotter.Restore(ctx, snapshot.ID, scope); !errors.Is(err, service.ErrSnapshotScopeDatabase) {
+			t.Errorf("restore of %+v: %v, want ErrSnapshotScopeDatabase", scope, err)
+		}
+	}
+
+	// Refused before anything was written, pre-restore snapshot included
+	snapshots, err := snapshotter.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := snapshotIDs(snapshots); len(ids) != 1 {
+		t.Errorf("snapshots = %v, want only %s", ids, snapshot.ID)
+	}
+	if got := boardLayout(findBoard(t, boards, "shop/main")); !reflect.DeepEqual(got, want) {
+		t.Errorf("shop/main = %v, want %v", got, want)
+	}
+}
+
+func TestSnapshotWaitsForWriters(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	snapshotter := NewSnapshotter(root, "", "", 0, 0)
+
+	unlock, err := filesystem.NewDataLock(root).Shared()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := snapshotter.Create(ctx, service.SnapshotManual)
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("snapshot taken while a write was in progress")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func saveBoard(t *testing.T, boards repository.BoardRepository, id, title string) *entity.Board {
+	t.Helper()
+	board, err := entity.NewBoard(id, id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	column, err := entity.NewColumnWithDisplayName("to-do", "To Do", "", 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.AddColumn(column); err != nil {
+		t.Fatal(err)
+	}
+	addTask(t, board, title)
+	if err := boards.Save(context.Background(), board); err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func addTask(t *testing.T, board *entity.Board, title string) {
+	t.Helper()
+	taskID, err := board.GenerateNextTaskID(valueobject.GenerateSlug(title))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := entity.NewTask(taskID, title, "", valueobject.PriorityNone, valueobject.StatusTodo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := board.Columns()[0].AddTask(task); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findBoard(t *testing.T, boards repository.BoardRepository, id string) *entity.Board {
+	t.Helper()
+	board, err := boards.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+// boardLayout lists the task IDs of a board, sorted
+func boardLayout(board *entity.Board) []string {
+	var layout []string
+	for _, column := range board.Columns() {
+		for _, task := range column.Tasks() {
+			layout = append(layout, task.ID().String())
+		}
+	}
+	sort.Strings(layout)
+	return layout
+}
+
+func snapshotIDs(snapshots []service.DataSnapshot) []string {
+	ids := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
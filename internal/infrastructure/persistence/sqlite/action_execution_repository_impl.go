@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+)
+
+// defaultHistoryLimit is used when actions.history_limit is not configured
+const defaultHistoryLimit = 100
+
+// ActionExecutionRepositoryImpl implements the ActionExecutionRepository
+// interface using SQLite storage. Each action's history is trimmed to the
+// configured history limit on every save.
+type ActionExecutionRepositoryImpl struct {
+	store  *Store
+	config *config.Config
+}
+
+// NewActionExecutionRepository creates a new SQLite-based action execution
+// repository
+func NewActionExecutionRepository(store *Store, cfg *config.Config) repository.ActionExecutionRepository {
+	return &ActionExecutionRepositoryImpl{
+		store:  store,
+		config: cfg,
+	}
+}
+
+// Save appends an execution record to the action's history
+func (r *ActionExecutionRepositoryImpl) Save(ctx context.Context, execution *entity.ActionExecution) error {
+	data, err := serialization.SerializeYaml(mapper.ActionExecutionToStorage(execution))
+	if err != nil {
+		return fmt.Errorf("failed to marshal action execution: %w", err)
+	}
+
+	limit := r.config.Actions.HistoryLimit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO action_executions (id, action_id, started_at, data) VALUES (?, ?, ?, ?)",
+			execution.ID, execution.ActionID, execution.StartedAt.UnixNano(), string(data))
+		if err != nil {
+			return fmt.Errorf("failed to save action execution: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM action_executions WHERE action_id = ? AND seq NOT IN (
+				SELECT seq FROM action_executions WHERE action_id = ? ORDER BY seq DESC LIMIT ?
+			)`,
+			execution.ActionID, execution.ActionID, limit)
+		if err != nil {
+			return fmt.Errorf("failed to trim action history: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListByAction retrieves the most recent executions of an action, newest first
+func (r *ActionExecutionRepositoryImpl) ListByAction(ctx context.Context, actionID string, limit int) ([]*entity.ActionExecution, error) {
+	return r.findExecutions(ctx, "SELECT data FROM action_executions WHERE action_id = ? ORDER BY seq DESC LIMIT ?",
+		actionID, queryLimit(limit))
+}
+
+// ListRecent retrieves the most recent executions across all actions, newest first
+func (r *ActionExecutionRepositoryImpl) ListRecent(ctx context.Context, limit int) ([]*entity.ActionExecution, error) {
+	return r.findExecutions(ctx, "SELECT data FROM action_executions ORDER BY started_at DESC, seq DESC LIMIT ?",
+		queryLimit(limit))
+}
+
+// DeleteByAction removes the history of an action
+func (r *ActionExecutionRepositoryImpl) DeleteByAction(ctx context.Context, actionID string) error {
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM action_executions WHERE action_id = ?", actionID); err != nil {
+			return fmt.Errorf("failed to delete action history: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ActionExecutionRepositoryImpl) findExecutions(ctx context.Context, query string, args ...any) ([]*entity.ActionExecution, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query action history: %w", err)
+	}
+	defer rows.Close()
+
+	executions := make([]*entity.ActionExecution, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var storage mapper.ActionExecutionStorage
+		if err := serialization.ParseYaml([]byte(data), &storage); err != nil {
+			continue // Skip invalid rows
+		}
+		executions = append(executions, mapper.ActionExecutionFromStorage(&storage))
+	}
+
+	return executions, rows.Err()
+}
+
+// queryLimit turns a limit where zero means no limit into a SQL LIMIT
+func queryLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+)
+
+// ActionRepositoryImpl implements the ActionRepository interface using
+// SQLite storage
+type ActionRepositoryImpl struct {
+	store *Store
+}
+
+// NewActionRepository creates a new SQLite-based action repository
+func NewActionRepository(store *Store) repository.ActionRepository {
+	return &ActionRepositoryImpl{store: store}
+}
+
+// Create creates a new action
+func (r *ActionRepositoryImpl) Create(ctx context.Context, action *entity.Action) error {
+	data, err := actionData(action)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO actions (id, scope, scope_id, enabled, trigger_type, data) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO NOTHING`,
+			action.ID(), string(action.Scope()), action.ScopeID(), boolInt(action.Enabled()),
+			string(action.Trigger().Type()), data)
+		if err != nil {
+			return fmt.Errorf("failed to create action: %w", err)
+		}
+		if created, _ := result.RowsAffected(); created == 0 {
+			return fmt.Errorf("action already exists: %s", action.ID())
+		}
+		return nil
+	})
+}
+
+// Update updates an existing action
+func (r *ActionRepositoryImpl) Update(ctx context.Context, action *entity.Action) error {
+	data, err := actionData(action)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE actions SET scope = ?, scope_id = ?, enabled = ?, trigger_type = ?, data = ?
+			WHERE id = ?`,
+			string(action.Scope()), action.ScopeID(), boolInt(action.Enabled()),
+			string(action.Trigger().Type()), data, action.ID())
+		if err != nil {
+			return fmt.Errorf("failed to update action: %w", err)
+		}
+		if updated, _ := result.RowsAffected(); updated == 0 {
+			return entity.ErrActionNotFound
+		}
+		return nil
+	})
+}
+
+// Delete deletes an action by ID
+func (r *ActionRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM actions WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete action: %w", err)
+		}
+		if deleted, _ := result.RowsAffected(); deleted == 0 {
+			return entity.ErrActionNotFound
+		}
+		return nil
+	})
+}
+
+// GetByID retrieves an action by ID
+func (r *ActionRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.Action, error) {
+	actions, err := r.findActions(ctx, "SELECT data FROM actions WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) == 0 {
+		return nil, entity.ErrActionNotFound
+	}
+	return actions[0], nil
+}
+
+// ListAll retrieves all actions
+func (r *ActionRepositoryImpl) ListAll(ctx context.Context) ([]*entity.Action, error) {
+	return r.findActions(ctx, "SELECT data FROM actions ORDER BY id")
+}
+
+// ListByScope retrieves actions for a specific scope
+func (r *ActionRepositoryImpl) ListByScope(ctx context.Context, scope valueobject.ActionScope, scopeID string) ([]*entity.Action, error) {
+	return r.findActions(ctx, "SELECT data FROM actions WHERE scope = ? AND scope_id = ? ORDER BY id",
+		string(scope), scopeID)
+}
+
+// ListGlobal retrieves all global actions
+func (r *ActionRepositoryImpl) ListGlobal(ctx context.Context) ([]*entity.Action, error) {
+	return r.ListByScope(ctx, valueobject.ActionScopeGlobal, "")
+}
+
+// ListByBoard retrieves actions for a specific board
+func (r *ActionRepositoryImpl) ListByBoard(ctx context.Context, boardID string) ([]*entity.Action, error) {
+	return r.ListByScope(ctx, valueobject.ActionScopeBoard, boardID)
+}
+
+// ListByColumn retrieves actions for a specific column
+func (r *ActionRepositoryImpl) ListByColumn(ctx context.Context, columnID string) ([]*entity.Action, error) {
+	return r.ListByScope(ctx, valueobject.ActionScopeColumn, columnID)
+}
+
+// ListByTask retrieves actions for a specific task
+func (r *ActionRepositoryImpl) ListByTask(ctx context.Context, taskID string) ([]*entity.Action, error) {
+	return r.ListByScope(ctx, valueobject.ActionScopeTask, taskID)
+}
+
+// ListEnabled retrieves all enabled actions
+func (r *ActionRepositoryImpl) ListEnabled(ctx context.Context) ([]*entity.Action, error) {
+	return r.findActions(ctx, "SELECT data FROM actions WHERE enabled = 1 ORDER BY id")
+}
+
+// ListByTriggerType retrieves actions by trigger type
+func (r *ActionRepositoryImpl) ListByTriggerType(ctx context.Context, triggerType entity.TriggerType) ([]*entity.Action, error) {
+	return r.findActions(ctx, "SELECT data FROM actions WHERE trigger_type = ? ORDER BY id", string(triggerType))
+}
+
+// UpdateLastRun updates the last run time for an action
+func (r *ActionRepositoryImpl) UpdateLastRun(ctx context.Context, id string) error {
+	action, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	action.MarkAsRun()
+	return r.Update(ctx, action)
+}
+
+// findActions loads the actions a query selects, skipping the ones that
+// can't be loaded
+func (r *ActionRepositoryImpl) findActions(ctx context.Context, query string, args ...any) ([]*entity.Action, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query actions: %w", err)
+	}
+	defer rows.Close()
+
+	actions := make([]*entity.Action, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var storage mapper.ActionStorage
+		if err := serialization.ParseYaml([]byte(data), &storage); err != nil {
+			continue // Skip invalid rows
+		}
+		action, err := mapper.ActionFromStorage(&storage)
+		if err != nil {
+			continue // Skip invalid actions
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, rows.Err()
+}
+
+func actionData(action *entity.Action) (string, error) {
+	data, err := serialization.SerializeYaml(mapper.ActionToStorage(action))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal action: %w", err)
+	}
+	return string(data), nil
+}
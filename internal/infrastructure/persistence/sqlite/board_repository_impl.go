@@ -0,0 +1,391 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+	"mkanban/pkg/slug"
+)
+
+// BoardRepositoryImpl implements BoardRepository using SQLite storage
+type BoardRepositoryImpl struct {
+	store *Store
+}
+
+// NewBoardRepository creates a new SQLite-based board repository
+func NewBoardRepository(store *Store) repository.BoardRepository {
+	return &BoardRepositoryImpl{store: store}
+}
+
+// Save persists a board with its columns and tasks, replacing the ones
+// stored before
+func (r *BoardRepositoryImpl) Save(ctx context.Context, board *entity.Board) error {
+	projectSlug, _, err := valueobject.ParseBoardID(board.ID())
+	if err != nil {
+		return err
+	}
+
+	metadata, err := mapper.BoardMetadataToStorage(board)
+	if err != nil {
+		return err
+	}
+	metadataYaml, err := serialization.SerializeYaml(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO boards (id, project_slug, name, metadata, content) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				project_slug = excluded.project_slug,
+				name = excluded.name,
+				metadata = excluded.metadata,
+				content = excluded.content`,
+			board.ID(), projectSlug, board.Name(), string(metadataYaml), string(mapper.BoardContentToMarkdown(board)))
+		if err != nil {
+			return fmt.Errorf("failed to save board: %w", err)
+		}
+
+		// Columns and tasks no longer on the board go with the old rows
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE board_id = ?", board.ID()); err != nil {
+			return fmt.Errorf("failed to clear tasks: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM columns WHERE board_id = ?", board.ID()); err != nil {
+			return fmt.Errorf("failed to clear columns: %w", err)
+		}
+
+		for _, column := range board.Columns() {
+			if err := r.saveColumn(ctx, tx, board.ID(), column); err != nil {
+				return fmt.Errorf("failed to save column %s: %w", column.Name(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveTask persists a single task without rewriting the entire board
+func (r *BoardRepositoryImpl) SaveTask(ctx context.Context, boardID string, columnName string, task *entity.Task) error {
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		return r.saveTask(ctx, tx, boardID, columnName, task)
+	})
+}
+
+// FindByID retrieves a board by its ID
+func (r *BoardRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Board, error) {
+	if _, _, err := valueobject.ParseBoardID(id); err != nil {
+		return nil, err
+	}
+
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata, content string
+	err = db.QueryRowContext(ctx, "SELECT metadata, content FROM boards WHERE id = ?", id).Scan(&metadata, &content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrBoardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query board: %w", err)
+	}
+
+	return r.loadBoard(ctx, db, id, metadata, content)
+}
+
+// FindAll retrieves all boards, ordered by ID
+func (r *BoardRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Board, error) {
+	return r.findBoards(ctx, "SELECT id, metadata, content FROM boards ORDER BY id")
+}
+
+// Delete removes a board from storage
+func (r *BoardRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if _, _, err := valueobject.ParseBoardID(id); err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM boards WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete board: %w", err)
+		}
+		if deleted, _ := result.RowsAffected(); deleted == 0 {
+			return entity.ErrBoardNotFound
+		}
+		return nil
+	})
+}
+
+// Exists checks if a board exists
+func (r *BoardRepositoryImpl) Exists(ctx context.Context, id string) (bool, error) {
+	if _, _, err := valueobject.ParseBoardID(id); err != nil {
+		return false, err
+	}
+
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var found int
+	err = db.QueryRowContext(ctx, "SELECT 1 FROM boards WHERE id = ?", id).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query board: %w", err)
+	}
+	return true, nil
+}
+
+// FindByName finds a board by its name within a project
+func (r *BoardRepositoryImpl) FindByName(ctx context.Context, projectID string, name string) (*entity.Board, error) {
+	boards, err := r.findBoards(ctx,
+		"SELECT id, metadata, content FROM boards WHERE project_slug = ? AND name = ? ORDER BY id",
+		projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(boards) == 0 {
+		return nil, entity.ErrBoardNotFound
+	}
+	return boards[0], nil
+}
+
+// findBoards loads the boards a query selects, skipping the ones that
+// can't be loaded
+func (r *BoardRepositoryImpl) findBoards(ctx context.Context, query string, args ...any) ([]*entity.Board, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query boards: %w", err)
+	}
+
+	type boardRow struct {
+		id, metadata, content string
+	}
+	var found []boardRow
+	for rows.Next() {
+		var row boardRow
+		if err := rows.Scan(&row.id, &row.metadata, &row.content); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		found = append(found, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	boards := make([]*entity.Board, 0, len(found))
+	for _, row := range found {
+		board, err := r.loadBoard(ctx, db, row.id, row.metadata, row.content)
+		if err != nil {
+			continue
+		}
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
+
+// loadBoard builds a board from its row, loading its columns and tasks
+func (r *BoardRepositoryImpl) loadBoard(ctx context.Context, db *sql.DB, id, metadata, content string) (*entity.Board, error) {
+	doc, err := metadataDocument(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse board metadata: %w", err)
+	}
+	markdownDoc, err := serialization.ParseMarkdownWithTitle([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse board content: %w", err)
+	}
+
+	board, err := mapper.BoardFromStorage(doc, markdownDoc.Title, markdownDoc.Content)
+	if err != nil {
+		return nil, err
+	}
+	if board.ProjectID() == "" {
+		projectSlug, _, err := valueobject.ParseBoardID(id)
+		if err == nil {
+			board.SetProjectID(projectSlug)
+		}
+	}
+
+	if err := r.loadColumns(ctx, db, board); err != nil {
+		return nil, fmt.Errorf("failed to load columns: %w", err)
+	}
+	return board, nil
+}
+
+// loadColumns loads the columns of a board with their tasks
+func (r *BoardRepositoryImpl) loadColumns(ctx context.Context, db *sql.DB, board *entity.Board) error {
+	tasks, err := r.loadTasks(ctx, db, board.ID())
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT name, metadata, content FROM columns WHERE board_id = ? ORDER BY name", board.ID())
+	if err != nil {
+		return fmt.Errorf("failed to query columns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, metadata, content string
+		if err := rows.Scan(&name, &metadata, &content); err != nil {
+			return err
+		}
+
+		column, err := loadColumn(name, metadata, content, tasks[name])
+		if err != nil {
+			// Skip columns that can't be loaded
+			continue
+		}
+		if err := board.AddColumn(column); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Reorder columns based on their order field
+	board.ReorderColumns()
+	return nil
+}
+
+// loadTasks loads the tasks of a board, by column
+func (r *BoardRepositoryImpl) loadTasks(ctx context.Context, db *sql.DB, boardID string) (map[string][]*entity.Task, error) {
+	rows, err := db.QueryContext(ctx, "SELECT column_name, id, metadata, content FROM tasks WHERE board_id = ? ORDER BY id", boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make(map[string][]*entity.Task)
+	for rows.Next() {
+		var columnName, id, metadata, content string
+		if err := rows.Scan(&columnName, &id, &metadata, &content); err != nil {
+			return nil, err
+		}
+
+		task, err := loadTask(id, metadata, content)
+		if err != nil {
+			// Skip tasks that can't be loaded
+			continue
+		}
+		tasks[columnName] = append(tasks[columnName], task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// saveColumn saves a column and all its tasks
+func (r *BoardRepositoryImpl) saveColumn(ctx context.Context, tx *sql.Tx, boardID string, column *entity.Column) error {
+	// Columns are keyed by the normalized display name, like the folder
+	// names of the filesystem layout
+	normalizedName := slug.Generate(column.DisplayName())
+
+	metadata, err := mapper.ColumnMetadataToStorage(column)
+	if err != nil {
+		return err
+	}
+	metadataYaml, err := serialization.SerializeYaml(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to serialize column metadata: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO columns (board_id, name, metadata, content) VALUES (?, ?, ?, ?)",
+		boardID, normalizedName, string(metadataYaml), string(mapper.ColumnContentToMarkdown(column)))
+	if err != nil {
+		return err
+	}
+
+	for _, task := range column.Tasks() {
+		if err := r.saveTask(ctx, tx, boardID, normalizedName, task); err != nil {
+			return fmt.Errorf("failed to save task %s: %w", task.ID(), err)
+		}
+	}
+	return nil
+}
+
+// saveTask saves a task, moving it to columnName if it was in another
+func (r *BoardRepositoryImpl) saveTask(ctx context.Context, tx *sql.Tx, boardID, columnName string, task *entity.Task) error {
+	storage, markdownContent, err := mapper.TaskToStorage(task)
+	if err != nil {
+		return err
+	}
+	metadataYaml, err := serialization.SerializeYaml(storage)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tasks (board_id, column_name, id, metadata, content) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (board_id, id) DO UPDATE SET
+			column_name = excluded.column_name,
+			metadata = excluded.metadata,
+			content = excluded.content`,
+		boardID, columnName, task.ID().String(), string(metadataYaml), string(markdownContent))
+	return err
+}
+
+// loadColumn builds a column from its row and adds its tasks
+func loadColumn(name, metadata, content string, tasks []*entity.Task) (*entity.Column, error) {
+	doc, err := metadataDocument(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse column metadata: %w", err)
+	}
+	markdownDoc, err := serialization.ParseMarkdownWithTitle([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse column content: %w", err)
+	}
+
+	column, err := mapper.ColumnFromStorage(doc, name, markdownDoc.Title, markdownDoc.Content)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if err := column.AddTask(task); err != nil {
+			return nil, err
+		}
+	}
+	return column, nil
+}
+
+// loadTask builds a task from its row
+func loadTask(id, metadata, content string) (*entity.Task, error) {
+	var storage mapper.TaskStorage
+	if err := serialization.ParseYaml([]byte(metadata), &storage); err != nil {
+		return nil, fmt.Errorf("failed to parse task metadata: %w", err)
+	}
+
+	taskID, err := valueobject.ParseTaskID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse task ID %s: %w", id, err)
+	}
+
+	return mapper.TaskFromStorage(&storage, []byte(content), taskID)
+}
+
+// metadataDocument parses a metadata document into the form the board and
+// column mappers read
+func metadataDocument(metadata string) (*serialization.FrontmatterDocument, error) {
+	var metadataMap map[string]interface{}
+	if err := serialization.ParseYaml([]byte(metadata), &metadataMap); err != nil {
+		return nil, err
+	}
+	return &serialization.FrontmatterDocument{Frontmatter: metadataMap}, nil
+}
@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+)
+
+// NoteRepositoryImpl implements NoteRepository using SQLite storage
+type NoteRepositoryImpl struct {
+	store *Store
+}
+
+// NewNoteRepository creates a new SQLite-based note repository
+func NewNoteRepository(store *Store) repository.NoteRepository {
+	return &NoteRepositoryImpl{store: store}
+}
+
+// Save persists a note with its tags
+func (r *NoteRepositoryImpl) Save(ctx context.Context, note *entity.Note) error {
+	data, err := serialization.SerializeYaml(mapper.NoteToStorage(note))
+	if err != nil {
+		return fmt.Errorf("failed to serialize note metadata: %w", err)
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notes (id, project_id, day, date, note_type, data, content) VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				project_id = excluded.project_id,
+				day = excluded.day,
+				date = excluded.date,
+				note_type = excluded.note_type,
+				data = excluded.data,
+				content = excluded.content`,
+			note.ID(), note.ProjectID(), note.Date().Format("2006-01-02"), note.Date().UnixNano(),
+			string(note.NoteType()), string(data), note.Content())
+		if err != nil {
+			return fmt.Errorf("failed to save note: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM note_tags WHERE note_id = ?", note.ID()); err != nil {
+			return fmt.Errorf("failed to save note tags: %w", err)
+		}
+		for _, tag := range note.Tags() {
+			if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO note_tags (note_id, tag) VALUES (?, ?)", note.ID(), tag); err != nil {
+				return fmt.Errorf("failed to save note tags: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindByID retrieves a note by its ID
+func (r *NoteRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Note, error) {
+	notes, err := r.findNotes(ctx, "SELECT data, content FROM notes WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, entity.ErrNoteNotFound
+	}
+	return notes[0], nil
+}
+
+// FindByProject retrieves all notes of a project
+func (r *NoteRepositoryImpl) FindByProject(ctx context.Context, projectID string) ([]*entity.Note, error) {
+	return r.findProjectNotes(ctx, projectID,
+		"SELECT data, content FROM notes WHERE project_id = ? ORDER BY day, id", projectID)
+}
+
+// FindByDate retrieves the notes of a project dated on the given day
+func (r *NoteRepositoryImpl) FindByDate(ctx context.Context, projectID string, date time.Time) ([]*entity.Note, error) {
+	return r.findProjectNotes(ctx, projectID,
+		"SELECT data, content FROM notes WHERE project_id = ? AND day = ? ORDER BY id",
+		projectID, date.Format("2006-01-02"))
+}
+
+// FindByDateRange retrieves the notes of a project dated between start and
+// end, both included
+func (r *NoteRepositoryImpl) FindByDateRange(ctx context.Context, projectID string, start, end time.Time) ([]*entity.Note, error) {
+	return r.findProjectNotes(ctx, projectID,
+		"SELECT data, content FROM notes WHERE project_id = ? AND date BETWEEN ? AND ? ORDER BY day, id",
+		projectID, start.UnixNano(), end.UnixNano())
+}
+
+// FindByType retrieves the notes of a project with the given type
+func (r *NoteRepositoryImpl) FindByType(ctx context.Context, projectID string, noteType entity.NoteType) ([]*entity.Note, error) {
+	return r.findProjectNotes(ctx, projectID,
+		"SELECT data, content FROM notes WHERE project_id = ? AND note_type = ? ORDER BY day, id",
+		projectID, string(noteType))
+}
+
+// FindByTag retrieves the notes of a project tagged with tag
+func (r *NoteRepositoryImpl) FindByTag(ctx context.Context, projectID string, tag string) ([]*entity.Note, error) {
+	return r.findProjectNotes(ctx, projectID, `
+		SELECT notes.data, notes.content FROM notes
+		JOIN note_tags ON note_tags.note_id = notes.id
+		WHERE notes.project_id = ? AND note_tags.tag = ?
+		ORDER BY notes.day, notes.id`,
+		projectID, tag)
+}
+
+// Search retrieves the notes of a project whose title or content contains
+// query, ignoring case
+func (r *NoteRepositoryImpl) Search(ctx context.Context, projectID string, query string) ([]*entity.Note, error) {
+	notes, err := r.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only folds the case of ASCII letters, so matching is done here
+	queryLower := strings.ToLower(query)
+	var result []*entity.Note
+	for _, note := range notes {
+		if strings.Contains(strings.ToLower(note.Title()), queryLower) ||
+			strings.Contains(strings.ToLower(note.Content()), queryLower) {
+			result = append(result, note)
+		}
+	}
+
+	return result, nil
+}
+
+// Delete removes a note
+func (r *NoteRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM notes WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete note: %w", err)
+		}
+		if deleted, _ := result.RowsAffected(); deleted == 0 {
+			return entity.ErrNoteNotFound
+		}
+		return nil
+	})
+}
+
+// FindGlobal retrieves the notes that belong to no project
+func (r *NoteRepositoryImpl) FindGlobal(ctx context.Context) ([]*entity.Note, error) {
+	return r.findNotes(ctx, "SELECT data, content FROM notes WHERE project_id = '' ORDER BY day, id")
+}
+
+// FindGlobalByDate retrieves the notes that belong to no project dated on
+// the given day
+func (r *NoteRepositoryImpl) FindGlobalByDate(ctx context.Context, date time.Time) ([]*entity.Note, error) {
+	return r.findNotes(ctx, "SELECT data, content FROM notes WHERE project_id = '' AND day = ? ORDER BY id",
+		date.Format("2006-01-02"))
+}
+
+// findProjectNotes runs a query for the notes of a project, which must
+// exist
+func (r *NoteRepositoryImpl) findProjectNotes(ctx context.Context, projectID string, query string, args ...any) ([]*entity.Note, error) {
+	if err := requireProject(ctx, r.store, projectID); err != nil {
+		return nil, err
+	}
+	return r.findNotes(ctx, query, args...)
+}
+
+// findNotes loads the notes a query selects, skipping the ones that can't
+// be loaded
+func (r *NoteRepositoryImpl) findNotes(ctx context.Context, query string, args ...any) ([]*entity.Note, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := make([]*entity.Note, 0)
+	for rows.Next() {
+		var data, content string
+		if err := rows.Scan(&data, &content); err != nil {
+			return nil, err
+		}
+
+		var storage mapper.NoteStorage
+		if err := serialization.ParseYaml([]byte(data), &storage); err != nil {
+			continue
+		}
+		note, err := mapper.NoteFromStorage(&storage, content)
+		if err != nil {
+			continue
+		}
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// requireProject returns ErrProjectNotFound unless the project exists
+func requireProject(ctx context.Context, store *Store, projectID string) error {
+	db, err := store.DB(ctx)
+	if err != nil {
+		return err
+	}
+
+	var found int
+	err = db.QueryRowContext(ctx, "SELECT 1 FROM projects WHERE id = ?", projectID).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ErrProjectNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query project: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+)
+
+// ProjectRepositoryImpl implements ProjectRepository using SQLite storage
+type ProjectRepositoryImpl struct {
+	store *Store
+}
+
+// NewProjectRepository creates a new SQLite-based project repository
+func NewProjectRepository(store *Store) repository.ProjectRepository {
+	return &ProjectRepositoryImpl{store: store}
+}
+
+// Save persists a project
+func (r *ProjectRepositoryImpl) Save(ctx context.Context, project *entity.Project) error {
+	data, err := serialization.SerializeYaml(mapper.ProjectToStorage(project))
+	if err != nil {
+		return fmt.Errorf("failed to serialize project: %w", err)
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO projects (id, slug, data) VALUES (?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET slug = excluded.slug, data = excluded.data`,
+			project.ID(), project.Slug(), string(data))
+		if err != nil {
+			return fmt.Errorf("failed to save project: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByID retrieves a project by its ID
+func (r *ProjectRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Project, error) {
+	return r.findOne(ctx, "SELECT data FROM projects WHERE id = ?", id)
+}
+
+// FindBySlug retrieves a project by its slug
+func (r *ProjectRepositoryImpl) FindBySlug(ctx context.Context, slug string) (*entity.Project, error) {
+	return r.findOne(ctx, "SELECT data FROM projects WHERE slug = ?", slug)
+}
+
+// FindAll retrieves all projects, ordered by slug
+func (r *ProjectRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Project, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT data FROM projects ORDER BY slug")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := make([]*entity.Project, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		project, err := projectFromRow(data)
+		if err != nil {
+			// Skip projects that can't be loaded
+			continue
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// Delete removes a project together with its boards, notes and time logs
+func (r *ProjectRepositoryImpl) Delete(ctx context.Context, id string) error {
+	project, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		statements := []struct {
+			query string
+			arg   string
+		}{
+			{"DELETE FROM boards WHERE project_slug = ?", project.Slug()},
+			{"DELETE FROM notes WHERE project_id = ?", project.ID()},
+			{"DELETE FROM time_logs WHERE project_id = ?", project.ID()},
+			{"DELETE FROM projects WHERE id = ?", project.ID()},
+		}
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement.query, statement.arg); err != nil {
+				return fmt.Errorf("failed to delete project: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Exists checks if a project exists
+func (r *ProjectRepositoryImpl) Exists(ctx context.Context, id string) (bool, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var found int
+	err = db.QueryRowContext(ctx, "SELECT 1 FROM projects WHERE id = ?", id).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query project: %w", err)
+	}
+	return true, nil
+}
+
+func (r *ProjectRepositoryImpl) findOne(ctx context.Context, query string, arg string) (*entity.Project, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var data string
+	err = db.QueryRowContext(ctx, query, arg).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project: %w", err)
+	}
+
+	return projectFromRow(data)
+}
+
+func projectFromRow(data string) (*entity.Project, error) {
+	var storage mapper.ProjectStorage
+	if err := serialization.ParseYaml([]byte(data), &storage); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+	return mapper.ProjectFromStorage(&storage)
+}
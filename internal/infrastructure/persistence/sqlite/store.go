@@ -0,0 +1,241 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	// Pure-Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// schemaVersion is stored in the database's user_version and bumped with
+// every change to schema
+const schemaVersion = 1
+
+// schema creates the tables of the SQLite backend. Rows keep the same
+// documents the filesystem layout keeps in files, so the storage mappers
+// are shared between the two, next to the columns queries filter on.
+const schema = `
+CREATE TABLE IF NOT EXISTS projects (
+	id   TEXT PRIMARY KEY,
+	slug TEXT NOT NULL UNIQUE,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS boards (
+	id           TEXT PRIMARY KEY,
+	project_slug TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	metadata     TEXT NOT NULL,
+	content      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS boards_project_name ON boards (project_slug, name);
+
+CREATE TABLE IF NOT EXISTS columns (
+	board_id TEXT NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+	name     TEXT NOT NULL,
+	metadata TEXT NOT NULL,
+	content  TEXT NOT NULL,
+	PRIMARY KEY (board_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	board_id    TEXT NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+	column_name TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	metadata    TEXT NOT NULL,
+	content     TEXT NOT NULL,
+	PRIMARY KEY (board_id, id)
+);
+CREATE INDEX IF NOT EXISTS tasks_column ON tasks (board_id, column_name);
+
+CREATE TABLE IF NOT EXISTS notes (
+	id         TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	day        TEXT NOT NULL,
+	date       INTEGER NOT NULL,
+	note_type  TEXT NOT NULL,
+	data       TEXT NOT NULL,
+	content    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notes_project_day ON notes (project_id, day);
+CREATE INDEX IF NOT EXISTS notes_project_date ON notes (project_id, date);
+CREATE INDEX IF NOT EXISTS notes_project_type ON notes (project_id, note_type);
+
+CREATE TABLE IF NOT EXISTS note_tags (
+	note_id TEXT NOT NULL REFERENCES notes (id) ON DELETE CASCADE,
+	tag     TEXT NOT NULL,
+	PRIMARY KEY (note_id, tag)
+);
+CREATE INDEX IF NOT EXISTS note_tags_tag ON note_tags (tag);
+
+CREATE TABLE IF NOT EXISTS time_logs (
+	id         TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	task_id    TEXT NOT NULL,
+	start_time INTEGER NOT NULL,
+	running    INTEGER NOT NULL,
+	data       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS time_logs_project_start ON time_logs (project_id, start_time);
+CREATE INDEX IF NOT EXISTS time_logs_task ON time_logs (task_id);
+CREATE INDEX IF NOT EXISTS time_logs_running ON time_logs (running) WHERE running = 1;
+
+CREATE TABLE IF NOT EXISTS actions (
+	id           TEXT PRIMARY KEY,
+	scope        TEXT NOT NULL,
+	scope_id     TEXT NOT NULL,
+	enabled      INTEGER NOT NULL,
+	trigger_type TEXT NOT NULL,
+	data         TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS actions_scope ON actions (scope, scope_id);
+CREATE INDEX IF NOT EXISTS actions_trigger_type ON actions (trigger_type);
+
+CREATE TABLE IF NOT EXISTS action_executions (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	id         TEXT NOT NULL,
+	action_id  TEXT NOT NULL,
+	started_at INTEGER NOT NULL,
+	data       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS action_executions_action ON action_executions (action_id, seq);
+CREATE INDEX IF NOT EXISTS action_executions_started ON action_executions (started_at);
+`
+
+// Store is a SQLite database shared by the repositories of the SQLite
+// backend. It is opened, and its schema created, on first use.
+type Store struct {
+	path string
+
+	once sync.Once
+	db   *sql.DB
+	err  error
+}
+
+// NewStore creates a store for the database at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the path of the database file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// DB returns the opened database
+func (s *Store) DB(ctx context.Context) (*sql.DB, error) {
+	s.once.Do(func() {
+		s.db, s.err = s.open(ctx)
+	})
+	return s.db, s.err
+}
+
+// Close closes the database if it was opened
+func (s *Store) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *Store) open(ctx context.Context) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// The CLI and the daemon use the database at the same time, so writers
+	// wait for each other instead of failing
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+s.path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := migrateSchema(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// CopyDatabase writes a consistent copy of the database at path to target,
+// which must not exist yet. Other connections go on reading and writing
+// the database meanwhile.
+func CopyDatabase(ctx context.Context, path, target string) error {
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", target); err != nil {
+		return fmt.Errorf("failed to copy database: %w", err)
+	}
+	return nil
+}
+
+// migrateSchema creates the schema of a new database and refuses one
+// written by a newer version
+func migrateSchema(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read database version: %w", err)
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("database version %d is newer than this mkanban supports (%d)", version, schemaVersion)
+	}
+	if version == schemaVersion {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to create database schema: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return fmt.Errorf("failed to set database version: %w", err)
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction, committing it when fn succeeds
+func withTx(ctx context.Context, store *Store, fn func(tx *sql.Tx) error) error {
+	db, err := store.DB(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// boolInt stores a bool in an INTEGER column
+func boolInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
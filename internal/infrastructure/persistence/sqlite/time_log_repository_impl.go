@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"mkanban/internal/domain/entity"
+	"mkanban/internal/domain/repository"
+	"mkanban/internal/domain/valueobject"
+	"mkanban/internal/infrastructure/persistence/mapper"
+	"mkanban/internal/infrastructure/serialization"
+)
+
+// TimeLogRepositoryImpl implements TimeLogRepository using SQLite storage
+type TimeLogRepositoryImpl struct {
+	store *Store
+}
+
+// NewTimeLogRepository creates a new SQLite-based time log repository
+func NewTimeLogRepository(store *Store) repository.TimeLogRepository {
+	return &TimeLogRepositoryImpl{store: store}
+}
+
+// Save persists a time log of an existing project
+func (r *TimeLogRepositoryImpl) Save(ctx context.Context, log *entity.TimeLog) error {
+	if err := requireProject(ctx, r.store, log.ProjectID()); err != nil {
+		return err
+	}
+
+	data, err := serialization.SerializeYaml(mapper.TimeLogToStorage(log))
+	if err != nil {
+		return fmt.Errorf("failed to serialize time log: %w", err)
+	}
+
+	taskID := ""
+	if log.TaskID() != nil {
+		taskID = log.TaskID().String()
+	}
+
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO time_logs (id, project_id, task_id, start_time, running, data) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				project_id = excluded.project_id,
+				task_id = excluded.task_id,
+				start_time = excluded.start_time,
+				running = excluded.running,
+				data = excluded.data`,
+			log.ID(), log.ProjectID(), taskID, log.StartTime().UnixNano(), boolInt(log.IsRunning()), string(data))
+		if err != nil {
+			return fmt.Errorf("failed to save time log: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByID retrieves a time log by its ID
+func (r *TimeLogRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.TimeLog, error) {
+	logs, err := r.findLogs(ctx, "SELECT data FROM time_logs WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, entity.ErrTimeLogNotFound
+	}
+	return logs[0], nil
+}
+
+// FindByProject retrieves all time logs of a project
+func (r *TimeLogRepositoryImpl) FindByProject(ctx context.Context, projectID string) ([]*entity.TimeLog, error) {
+	if err := requireProject(ctx, r.store, projectID); err != nil {
+		return nil, err
+	}
+	return r.findLogs(ctx, "SELECT data FROM time_logs WHERE project_id = ? ORDER BY start_time", projectID)
+}
+
+// FindByTask retrieves the time logs of a task
+func (r *TimeLogRepositoryImpl) FindByTask(ctx context.Context, taskID *valueobject.TaskID) ([]*entity.TimeLog, error) {
+	return r.findLogs(ctx, "SELECT data FROM time_logs WHERE task_id = ? ORDER BY start_time", taskID.String())
+}
+
+// FindByDateRange retrieves the time logs of a project started between
+// start and end, both included
+func (r *TimeLogRepositoryImpl) FindByDateRange(ctx context.Context, projectID string, start, end time.Time) ([]*entity.TimeLog, error) {
+	if err := requireProject(ctx, r.store, projectID); err != nil {
+		return nil, err
+	}
+	return r.findLogs(ctx,
+		"SELECT data FROM time_logs WHERE project_id = ? AND start_time BETWEEN ? AND ? ORDER BY start_time",
+		projectID, start.UnixNano(), end.UnixNano())
+}
+
+// FindRunning retrieves the time logs still running
+func (r *TimeLogRepositoryImpl) FindRunning(ctx context.Context) ([]*entity.TimeLog, error) {
+	return r.findLogs(ctx, "SELECT data FROM time_logs WHERE running = 1 ORDER BY start_time")
+}
+
+// Delete removes a time log
+func (r *TimeLogRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, r.store, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM time_logs WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete time log: %w", err)
+		}
+		if deleted, _ := result.RowsAffected(); deleted == 0 {
+			return entity.ErrTimeLogNotFound
+		}
+		return nil
+	})
+}
+
+// findLogs loads the time logs a query selects, skipping the ones that
+// can't be loaded
+func (r *TimeLogRepositoryImpl) findLogs(ctx context.Context, query string, args ...any) ([]*entity.TimeLog, error) {
+	db, err := r.store.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]*entity.TimeLog, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var storage mapper.TimeLogStorage
+		if err := serialization.ParseYaml([]byte(data), &storage); err != nil {
+			continue
+		}
+		log, err := mapper.TimeLogFromStorage(&storage)
+		if err != nil {
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
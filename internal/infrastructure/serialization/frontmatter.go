@@ -142,6 +142,23 @@ func (d *FrontmatterDocument) GetStringSlice(key string) []string {
 	return []string{}
 }
 
+// Decode decodes a structured frontmatter value, such as a list of maps,
+// into target. A missing key leaves target untouched.
+func (d *FrontmatterDocument) Decode(key string, target interface{}) error {
+	val, ok := d.Frontmatter[key]
+	if !ok || val == nil {
+		return nil
+	}
+	data, err := yaml.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	if err := yaml.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return nil
+}
+
 // MarkdownDocument represents a markdown document with title and content
 type MarkdownDocument struct {
 	Title   string
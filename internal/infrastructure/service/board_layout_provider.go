@@ -0,0 +1,294 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+	"mkanban/pkg/slug"
+)
+
+// boardLayoutFile declares the board layout of a repository at its root
+const boardLayoutFile = ".mkanban.yml"
+
+// FileBoardLayoutProvider implements BoardLayoutProvider. A repository's
+// .mkanban.yml comes first, then the first config layout matching the
+// repository. Without either, every workspace member gets a board.
+type FileBoardLayoutProvider struct {
+	layouts []config.BoardLayoutConfig
+}
+
+// NewFileBoardLayoutProvider creates a new FileBoardLayoutProvider
+func NewFileBoardLayoutProvider(layouts []config.BoardLayoutConfig) *FileBoardLayoutProvider {
+	return &FileBoardLayoutProvider{
+		layouts: layouts,
+	}
+}
+
+// LayoutFor returns the board layout of the repository at repoRoot
+func (p *FileBoardLayoutProvider) LayoutFor(repoRoot string) (*service.BoardLayout, error) {
+	declared, err := p.declaredLayout(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &service.BoardLayout{}
+	workspaces := declared == nil || declared.Workspaces
+	if declared != nil {
+		layout.DefaultBoard = declared.DefaultBoard
+		for _, board := range declared.Boards {
+			if board.Name == "" {
+				return nil, fmt.Errorf("board layout of %s: board without a name", repoRoot)
+			}
+			layout.Rules = append(layout.Rules, service.BoardRule{Board: board.Name, Paths: board.Paths})
+		}
+	}
+
+	if workspaces {
+		layout.AddRules(detectWorkspaces(repoRoot))
+	}
+	return layout, nil
+}
+
+// declaredLayout returns the layout of the repository's .mkanban.yml, else
+// of the config, or nil if neither has one
+func (p *FileBoardLayoutProvider) declaredLayout(repoRoot string) (*config.BoardLayoutConfig, error) {
+	data, err := os.ReadFile(filepath.Join(repoRoot, boardLayoutFile))
+	if err == nil {
+		var layout config.BoardLayoutConfig
+		if err := yaml.Unmarshal(data, &layout); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(repoRoot, boardLayoutFile), err)
+		}
+		return &layout, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", boardLayoutFile, err)
+	}
+
+	for i, layout := range p.layouts {
+		if matchesRepo(layout.Repo, repoRoot) {
+			return &p.layouts[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// matchesRepo reports whether pattern matches the repository's path or name
+func matchesRepo(pattern, repoRoot string) bool {
+	if pattern == "" {
+		return false
+	}
+	if strings.HasPrefix(pattern, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			pattern = filepath.Join(home, pattern[2:])
+		}
+	}
+	if matched, _ := filepath.Match(pattern, repoRoot); matched {
+		return true
+	}
+	matched, _ := filepath.Match(pattern, filepath.Base(repoRoot))
+	return matched
+}
+
+// detectWorkspaces returns a rule for each workspace member the manifests
+// at the repository root declare, named after the member's directory, or
+// its whole path where names clash
+func detectWorkspaces(repoRoot string) []service.BoardRule {
+	manifests := []struct {
+		file  string
+		parse func(data []byte) []string
+	}{
+		{"go.work", parseGoWork},
+		{"Cargo.toml", parseCargoWorkspace},
+		{"pnpm-workspace.yaml", parsePnpmWorkspace},
+		{"package.json", parsePackageJSONWorkspaces},
+		{"settings.gradle", parseGradleSettings},
+		{"settings.gradle.kts", parseGradleSettings},
+	}
+
+	members := make(map[string]bool)
+	for _, manifest := range manifests {
+		data, err := os.ReadFile(filepath.Join(repoRoot, manifest.file))
+		if err != nil {
+			continue
+		}
+		for _, pattern := range manifest.parse(data) {
+			for _, dir := range expandMembers(repoRoot, pattern) {
+				members[dir] = true
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(members))
+	names := make(map[string]int)
+	for dir := range members {
+		dirs = append(dirs, dir)
+		names[slug.Generate(filepath.Base(dir))]++
+	}
+	sort.Strings(dirs)
+
+	rules := make([]service.BoardRule, 0, len(dirs))
+	for _, dir := range dirs {
+		name := slug.Generate(filepath.Base(dir))
+		if names[name] > 1 {
+			name = slug.Generate(strings.ReplaceAll(dir, "/", "-"))
+		}
+		rules = append(rules, service.BoardRule{Board: name, Paths: []string{dir}})
+	}
+	return rules
+}
+
+// expandMembers returns the directories a member pattern matches, relative
+// to the repository root. "**" matches one directory level.
+func expandMembers(repoRoot, pattern string) []string {
+	pattern = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(pattern)), "./")
+	pattern = strings.ReplaceAll(pattern, "**", "*")
+	if pattern == "" || pattern == "." || strings.HasPrefix(pattern, "!") || strings.HasPrefix(pattern, "../") {
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(repoRoot, filepath.FromSlash(pattern)))
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		rel, err := filepath.Rel(repoRoot, match)
+		if err != nil || rel == "." {
+			continue
+		}
+		dirs = append(dirs, filepath.ToSlash(rel))
+	}
+	return dirs
+}
+
+// quotedPattern matches a single or double quoted string
+var quotedPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+
+// quotedStrings returns the quoted strings in text
+func quotedStrings(text string) []string {
+	var values []string
+	for _, match := range quotedPattern.FindAllStringSubmatch(text, -1) {
+		values = append(values, match[1]+match[2])
+	}
+	return values
+}
+
+// parseGoWork returns the module directories of a go.work file's use
+// directives, single or in a block
+func parseGoWork(data []byte) []string {
+	var dirs []string
+	inBlock := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case line == "use (" || line == "use(":
+			inBlock = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(line[len("use "):]), `"`))
+		}
+	}
+	return dirs
+}
+
+// cargoMembersPattern matches the members array of a Cargo workspace
+var cargoMembersPattern = regexp.MustCompile(`(?s)\bmembers\s*=\s*\[(.*?)\]`)
+
+// parseCargoWorkspace returns the members of the [workspace] table of a
+// Cargo.toml
+func parseCargoWorkspace(data []byte) []string {
+	var table strings.Builder
+	inWorkspace := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inWorkspace = trimmed == "[workspace]"
+			continue
+		}
+		if inWorkspace && !strings.HasPrefix(trimmed, "#") {
+			table.WriteString(line + "\n")
+		}
+	}
+
+	match := cargoMembersPattern.FindStringSubmatch(table.String())
+	if match == nil {
+		return nil
+	}
+	return quotedStrings(match[1])
+}
+
+// parsePnpmWorkspace returns the packages of a pnpm-workspace.yaml
+func parsePnpmWorkspace(data []byte) []string {
+	var workspace struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(data, &workspace); err != nil {
+		return nil
+	}
+	return workspace.Packages
+}
+
+// parsePackageJSONWorkspaces returns the workspaces of a package.json, as
+// an array or, as with Yarn, an object with packages
+func parsePackageJSONWorkspaces(data []byte) []string {
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Workspaces == nil {
+		return nil
+	}
+
+	var workspaces []string
+	if err := json.Unmarshal(manifest.Workspaces, &workspaces); err == nil {
+		return workspaces
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(manifest.Workspaces, &object); err == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// parseGradleSettings returns the project directories of the include
+// statements of a settings.gradle(.kts), where ":core:data" is core/data
+func parseGradleSettings(data []byte) []string {
+	var dirs []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "include") {
+			continue
+		}
+		rest := strings.TrimPrefix(line, "include")
+		if rest != "" && rest[0] != '(' && rest[0] != ' ' && rest[0] != '\t' {
+			// includeBuild and the like
+			continue
+		}
+		for _, project := range quotedStrings(rest) {
+			dirs = append(dirs, strings.ReplaceAll(strings.TrimPrefix(project, ":"), ":", "/"))
+		}
+	}
+	return dirs
+}
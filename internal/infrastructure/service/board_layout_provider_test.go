@@ -0,0 +1,141 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	domainService "mkanban/internal/domain/service"
+	"mkanban/internal/infrastructure/config"
+)
+
+// writeFiles creates files under root, with their directories
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWorkspaceManifests(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func([]byte) []string
+		data  string
+		want  []string
+	}{
+		{
+			name:  "go.work",
+			parse: parseGoWork,
+			data:  "go 1.22\n\nuse (\n\t./api // service\n\t\"./tools/cli\"\n)\nuse ./web\n",
+			want:  []string{"./api", "./tools/cli", "./web"},
+		},
+		{
+			name:  "Cargo.toml",
+			parse: parseCargoWorkspace,
+			data:  "[package]\nmembers = [\"not-this\"]\n\n[workspace]\nmembers = [\n  \"crates/*\",\n  'cli',\n]\nexclude = [\"crates/old\"]\n",
+			want:  []string{"crates/*", "cli"},
+		},
+		{
+			name:  "pnpm-workspace.yaml",
+			parse: parsePnpmWorkspace,
+			data:  "packages:\n  - 'apps/*'\n  - '!apps/legacy'\n",
+			want:  []string{"apps/*", "!apps/legacy"},
+		},
+		{
+			name:  "package.json array",
+			parse: parsePackageJSONWorkspaces,
+			data:  `{"name": "shop", "workspaces": ["packages/*"]}`,
+			want:  []string{"packages/*"},
+		},
+		{
+			name:  "package.json object",
+			parse: parsePackageJSONWorkspaces,
+			data:  `{"workspaces": {"packages": ["apps/web"], "nohoist": ["**/x"]}}`,
+			want:  []string{"apps/web"},
+		},
+		{
+			name:  "settings.gradle",
+			parse: parseGradleSettings,
+			data:  "rootProject.name = 'shop'\ninclude ':app', ':core:data'\ninclude(\":feature\")\nincludeBuild('build-logic')\n",
+			want:  []string{"app", "core/data", "feature"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.parse([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileBoardLayoutProvider(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.work":                  "use (\n\t./api\n\t./apps/web\n\t./libs/web\n)\n",
+		"api/go.mod":               "module api\n",
+		"apps/web/go.mod":          "module web\n",
+		"libs/web/go.mod":          "module web\n",
+		"shop/.mkanban.yml":        "default_board: main\nboards:\n  - name: backend\n    paths: [services/*]\n",
+		"shop/services/cart/x.go":  "package cart\n",
+		"store/.keep":              "",
+		"store/package.json":       `{"workspaces": ["packages/*"]}`,
+		"store/packages/ui/x.json": "{}",
+	})
+
+	provider := NewFileBoardLayoutProvider([]config.BoardLayoutConfig{
+		{Repo: "store", Boards: []config.BoardRuleConfig{{Name: "site", Paths: []string{"site"}}}, Workspaces: true},
+	})
+
+	tests := []struct {
+		name string
+		repo string
+		want *domainService.BoardLayout
+	}{
+		{
+			name: "detected workspaces",
+			repo: root,
+			want: &domainService.BoardLayout{Rules: []domainService.BoardRule{
+				{Board: "api", Paths: []string{"api"}},
+				{Board: "apps-web", Paths: []string{"apps/web"}},
+				{Board: "libs-web", Paths: []string{"libs/web"}},
+			}},
+		},
+		{
+			name: "declared in the repository",
+			repo: filepath.Join(root, "shop"),
+			want: &domainService.BoardLayout{DefaultBoard: "main", Rules: []domainService.BoardRule{
+				{Board: "backend", Paths: []string{"services/*"}},
+			}},
+		},
+		{
+			name: "config with workspaces",
+			repo: filepath.Join(root, "store"),
+			want: &domainService.BoardLayout{Rules: []domainService.BoardRule{
+				{Board: "site", Paths: []string{"site"}},
+				{Board: "ui", Paths: []string{"packages/ui"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.LayoutFor(tt.repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LayoutFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
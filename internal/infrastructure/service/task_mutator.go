@@ -51,7 +51,8 @@ func (s *TaskMutatorService) UpdateTask(boardID string, taskEntity *entity.Task)
 	return err
 }
 
-// MoveTask moves a task to another column
+// MoveTask moves a task to another column. Column rules apply as they do to
+// moves made by hand, so an action cannot move a task past a blocking rule.
 func (s *TaskMutatorService) MoveTask(boardID string, taskID *valueobject.TaskID, targetColumn string) error {
 	ctx := context.Background()
 
@@ -60,7 +61,7 @@ func (s *TaskMutatorService) MoveTask(boardID string, taskID *valueobject.TaskID
 		TargetColumnName: targetColumn,
 	}
 
-	_, err := s.moveTaskUseCase.Execute(ctx, boardID, moveReq)
+	_, _, err := s.moveTaskUseCase.Execute(ctx, boardID, moveReq)
 	return err
 }
 
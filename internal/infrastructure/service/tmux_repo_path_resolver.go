@@ -34,7 +34,7 @@ func NewTmuxRepoPathResolver(
 // the repo root from the session's working directory
 func (r *TmuxRepoPathResolver) GetRepoPathForBoard(board *entity.Board) (string, error) {
 	if !r.sessionTracker.IsAvailable() {
-		return "", fmt.Errorf("tmux session tracker is not available")
+		return "", fmt.Errorf("session tracker is not available")
 	}
 
 	workingDir := ""
@@ -0,0 +1,164 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SkipFunc reports whether a file or directory, given by its slash
+// separated path relative to the walked root, is left out
+type SkipFunc func(rel string, entry fs.DirEntry) bool
+
+// CopyTree copies the regular files and directories under src to dst,
+// leaving out what skip reports
+func CopyTree(src, dst string, skip SkipFunc) error {
+	return walkTree(src, skip, func(rel string, path string, entry fs.DirEntry) error {
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if entry.IsDir() {
+			return EnsureDir(target, 0755)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := EnsureDir(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// ArchiveFile is a file written to an archive under a name of its own, from
+// outside the archived directory
+type ArchiveFile struct {
+	Name string // slash separated path in the archive
+	Path string
+}
+
+// WriteArchive writes the regular files and directories under dir to a
+// gzip compressed tar archive at path, leaving out what skip reports, and
+// then the extra files
+func WriteArchive(path, dir string, skip SkipFunc, extra ...ArchiveFile) error {
+	if err := EnsureDir(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tempFile := path + ".tmp"
+	file, err := os.Create(tempFile)
+	if err != nil {
+		return fmt.Errorf("failed to create archive %s: %w", path, err)
+	}
+
+	err = writeArchive(file, dir, skip, extra)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to write archive %s: %w", path, err)
+	}
+
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to rename temp file to %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeArchive(w io.Writer, dir string, skip SkipFunc, extra []ArchiveFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := walkTree(dir, skip, func(rel string, path string, entry fs.DirEntry) error {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return writeArchiveEntry(tw, rel, path, info)
+	})
+	if err != nil {
+		return err
+	}
+	for _, file := range extra {
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveEntry(tw, file.Name, file.Path, info); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeArchiveEntry writes the file or directory at path to an archive
+// under name
+func writeArchiveEntry(tw *tar.Writer, name, path string, info fs.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// walkTree calls fn for the directories and regular files under root, in
+// lexical order, with their slash separated path relative to root. Sockets,
+// links and other special files are passed over.
+func walkTree(root string, skip SkipFunc, fn func(rel string, path string, entry fs.DirEntry) error) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if skip != nil && skip(rel, entry) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+		return fn(rel, path, entry)
+	})
+}
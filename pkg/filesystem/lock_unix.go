@@ -0,0 +1,60 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// FileLock is a lock on a file shared by processes through flock(2). Any
+// number of holders can take it shared while none has it exclusive. Every
+// acquisition opens the file anew, so goroutines of one process lock each
+// other out like separate processes do.
+type FileLock struct {
+	path string
+}
+
+// NewFileLock creates a lock on the file at path, which is created on
+// first use
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Shared takes the lock shared, waiting for an exclusive holder to release
+// it, and returns the function releasing it
+func (l *FileLock) Shared() (func(), error) {
+	return l.acquire(syscall.LOCK_SH)
+}
+
+// Exclusive takes the lock exclusive, waiting for all other holders to
+// release it, and returns the function releasing it
+func (l *FileLock) Exclusive() (func(), error) {
+	return l.acquire(syscall.LOCK_EX)
+}
+
+func (l *FileLock) acquire(how int) (func(), error) {
+	if err := EnsureDir(filepath.Dir(l.path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", l.path, err)
+	}
+
+	for {
+		err = syscall.Flock(int(file.Fd()), how)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", l.path, err)
+	}
+
+	// Closing the file releases the lock
+	return func() { file.Close() }, nil
+}
@@ -0,0 +1,68 @@
+//go:build windows
+
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const lockfileExclusiveLock = 0x2
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+// FileLock is a lock on a file shared by processes through LockFileEx. Any
+// number of holders can take it shared while none has it exclusive. Every
+// acquisition opens the file anew, so goroutines of one process lock each
+// other out like separate processes do.
+type FileLock struct {
+	path string
+}
+
+// NewFileLock creates a lock on the file at path, which is created on
+// first use
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Shared takes the lock shared, waiting for an exclusive holder to release
+// it, and returns the function releasing it
+func (l *FileLock) Shared() (func(), error) {
+	return l.acquire(0)
+}
+
+// Exclusive takes the lock exclusive, waiting for all other holders to
+// release it, and returns the function releasing it
+func (l *FileLock) Exclusive() (func(), error) {
+	return l.acquire(lockfileExclusiveLock)
+}
+
+func (l *FileLock) acquire(flags uintptr) (func(), error) {
+	if err := EnsureDir(filepath.Dir(l.path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", l.path, err)
+	}
+
+	// Lock the first byte, which is all holders ever lock
+	overlapped := new(syscall.Overlapped)
+	ok, _, errno := procLockFileEx.Call(file.Fd(), flags, 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+	if ok == 0 {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", l.path, errno)
+	}
+
+	return func() {
+		procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,133 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Kinds of TreeChange
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// TreeHashes maps the slash separated paths of the files of a tree to the
+// hash of their content
+type TreeHashes map[string][sha256.Size]byte
+
+// TreeChange is a file that differs between two trees
+type TreeChange struct {
+	Change string
+	Path   string
+}
+
+// HashTree hashes the regular files under root, leaving out what skip
+// reports
+func HashTree(root string, skip SkipFunc) (TreeHashes, error) {
+	hashes := make(TreeHashes)
+	err := walkTree(root, skip, func(rel string, path string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hashes[rel] = sha256.Sum256(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	return hashes, nil
+}
+
+// HashArchive hashes the regular files of an archive written by
+// WriteArchive
+func HashArchive(path string) (TreeHashes, error) {
+	hashes := make(TreeHashes)
+	err := ReadArchive(path, func(header *tar.Header, r io.Reader) error {
+		if header.Typeflag != tar.TypeReg {
+			return nil
+		}
+		hash := sha256.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			return err
+		}
+		var sum [sha256.Size]byte
+		copy(sum[:], hash.Sum(nil))
+		hashes[header.Name] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// ReadArchive calls fn for each entry of an archive written by
+// WriteArchive, in the order they were written. Directory names end in a
+// slash.
+func ReadArchive(path string, fn func(header *tar.Header, r io.Reader) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive %s: %w", path, err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		if strings.HasPrefix(header.Name, "/") || strings.Contains("/"+header.Name+"/", "/../") {
+			return fmt.Errorf("archive %s has an entry outside of it: %s", path, header.Name)
+		}
+		if err := fn(header, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// DiffTrees lists the files added, removed and modified from before to
+// after, sorted by path
+func DiffTrees(before, after TreeHashes) []TreeChange {
+	var changes []TreeChange
+	for rel, hash := range after {
+		old, ok := before[rel]
+		switch {
+		case !ok:
+			changes = append(changes, TreeChange{Change: ChangeAdded, Path: rel})
+		case old != hash:
+			changes = append(changes, TreeChange{Change: ChangeModified, Path: rel})
+		}
+	}
+	for rel := range before {
+		if _, ok := after[rel]; !ok {
+			changes = append(changes, TreeChange{Change: ChangeRemoved, Path: rel})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
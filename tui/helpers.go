@@ -87,6 +87,34 @@ func formatDueDate(dueDate *time.Time, isOverdue bool, cfg *config.Config) (stri
 	return fmt.Sprintf("%s📅 %s (%s)", prefix, dateStr, relativeTime), color
 }
 
+// formatAging formats how long an aging task has been in its column and returns
+// the formatted string and style color. Tasks that aren't aging yield "".
+func formatAging(task dto.TaskDTO, cfg *config.Config) (string, lipgloss.Color) {
+	agingColors := cfg.TUI.Styles.Aging
+
+	var color string
+	switch task.Aging {
+	case "stale":
+		color = agingColors.Stale
+		if color == "" {
+			color = "#FFE66D"
+		}
+	case "escalated":
+		color = agingColors.Escalated
+		if color == "" {
+			color = "#FF6B6B"
+		}
+	default:
+		return "", lipgloss.Color("")
+	}
+
+	days := "days"
+	if task.DaysInColumn == 1 {
+		days = "day"
+	}
+	return fmt.Sprintf("⏳ %d %s in column (%s)", task.DaysInColumn, days, task.Aging), lipgloss.Color(color)
+}
+
 // formatTags formats tags with icon and handles truncation
 func formatTags(tags []string, maxWidth int) string {
 	if len(tags) == 0 {
@@ -125,6 +153,18 @@ func formatTags(tags []string, maxWidth int) string {
 	return prefix + strings.Join(displayTags, "  ")
 }
 
+// formatProgress draws a bar of done subtasks out of total, followed by the
+// counts, sized to fit maxWidth
+func formatProgress(done, total, maxWidth int) string {
+	counts := fmt.Sprintf(" %d/%d", done, total)
+	barWidth := min(maxWidth-len(counts), 20)
+	if barWidth < 3 {
+		return strings.TrimSpace(counts)
+	}
+	filled := done * barWidth / total
+	return strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + counts
+}
+
 // truncateDescription extracts and truncates the description preview
 func truncateDescription(desc string, maxLen int) string {
 	if desc == "" {
@@ -197,7 +237,15 @@ func renderTaskCard(task dto.TaskDTO, width int, isSelected bool, cfg *config.Co
 		}
 	}
 
-	// Line 4: Due date (if exists)
+	// Line 4: Subtask progress (if the task has subtasks)
+	if task.SubtasksTotal > 0 {
+		progressLine := style.ProgressStyle.
+			Width(contentWidth).
+			Render(formatProgress(task.SubtasksDone, task.SubtasksTotal, contentWidth))
+		lines = append(lines, progressLine)
+	}
+
+	// Line 5: Due date (if exists)
 	if task.DueDate != nil {
 		dueDateStr, dueDateColor := formatDueDate(task.DueDate, task.IsOverdue, cfg)
 		if dueDateStr != "" {
@@ -212,6 +260,15 @@ func renderTaskCard(task dto.TaskDTO, width int, isSelected bool, cfg *config.Co
 		}
 	}
 
+	// Line 6: Aging (if the task outstayed its column's policy)
+	if agingStr, agingColor := formatAging(task, cfg); agingStr != "" {
+		agingLine := style.DueDateStyle.
+			Foreground(agingColor).
+			Width(contentWidth).
+			Render(agingStr)
+		lines = append(lines, agingLine)
+	}
+
 	// Join all lines with small spacing
 	cardContent := strings.Join(lines, "\n")
 
@@ -8,14 +8,17 @@ import (
 )
 
 type keyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Left   key.Binding
-	Right  key.Binding
-	Move   key.Binding
-	Add    key.Binding
-	Delete key.Binding
-	Quit   key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Move       key.Binding
+	Add        key.Binding
+	Delete     key.Binding
+	Quit       key.Binding
+	LaneUp     key.Binding
+	LaneDown   key.Binding
+	ToggleLane key.Binding
 }
 
 var keys keyMap
@@ -24,6 +27,18 @@ var keys keyMap
 func InitKeybindings(cfg *config.Config) {
 	kb := cfg.Keybindings
 
+	// Swimlane keys came later; configs written before them lack them
+	laneUp, laneDown, toggleLane := kb.LaneUp, kb.LaneDown, kb.ToggleLane
+	if len(laneUp) == 0 {
+		laneUp = []string{"K"}
+	}
+	if len(laneDown) == 0 {
+		laneDown = []string{"J"}
+	}
+	if len(toggleLane) == 0 {
+		toggleLane = []string{"z"}
+	}
+
 	keys = keyMap{
 		Up: key.NewBinding(
 			key.WithKeys(kb.Up...),
@@ -57,6 +72,18 @@ func InitKeybindings(cfg *config.Config) {
 			key.WithKeys(kb.Quit...),
 			key.WithHelp(formatKeysHelp(kb.Quit), "quit"),
 		),
+		LaneUp: key.NewBinding(
+			key.WithKeys(laneUp...),
+			key.WithHelp(formatKeysHelp(laneUp), "move task to lane above"),
+		),
+		LaneDown: key.NewBinding(
+			key.WithKeys(laneDown...),
+			key.WithHelp(formatKeysHelp(laneDown), "move task to lane below"),
+		),
+		ToggleLane: key.NewBinding(
+			key.WithKeys(toggleLane...),
+			key.WithHelp(formatKeysHelp(toggleLane), "collapse/expand lane"),
+		),
 	}
 }
 
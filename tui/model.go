@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"mkanban/internal/application/dto"
 	"mkanban/internal/daemon"
+	"mkanban/internal/domain/entity"
 	"mkanban/internal/infrastructure/config"
 )
 
@@ -22,6 +23,15 @@ type Model struct {
 	width                  int
 	height                 int
 	lastBoardID            string // track the last board ID to detect changes
+	notice                 string // shown above the help until the next key press
+	noticeIsError          bool
+
+	swimlanes      *entity.Swimlanes // nil for plain columns
+	fixedSwimlanes bool              // swimlanes chosen on the command line rather than by the board
+	lanes          []dto.SwimlaneDTO
+	focusedLane    int
+	laneOffset     int             // first lane shown
+	collapsedLanes map[string]bool // by lane key
 }
 
 // BoardUpdateMsg is a message sent when the board is updated
@@ -39,15 +49,56 @@ func NewModel(board *dto.BoardDTO, daemonClient *daemon.Client, cfg *config.Conf
 	// Initialize scroll offsets for each column
 	scrollOffsets := make([]int, len(board.Columns))
 
-	return Model{
-		board:         board,
-		daemonClient:  daemonClient,
-		config:        cfg,
-		boardID:       boardID,
-		focusedColumn: 0,
-		focusedTask:   0,
-		scrollOffsets: scrollOffsets,
-		lastBoardID:   board.ID,
+	m := Model{
+		board:          board,
+		daemonClient:   daemonClient,
+		config:         cfg,
+		boardID:        boardID,
+		focusedColumn:  0,
+		focusedTask:    0,
+		scrollOffsets:  scrollOffsets,
+		lastBoardID:    board.ID,
+		collapsedLanes: make(map[string]bool),
+	}
+	m.regroup()
+	return m
+}
+
+// SetSwimlanes shows the board in the given swimlanes instead of the
+// board's own; nil shows plain columns
+func (m *Model) SetSwimlanes(swimlanes *entity.Swimlanes) {
+	m.swimlanes = swimlanes
+	m.fixedSwimlanes = true
+	m.regroup()
+}
+
+// regroup splits the board into lanes again after it changed, keeping focus
+// on the same lane
+func (m *Model) regroup() {
+	if !m.fixedSwimlanes {
+		m.swimlanes, _ = entity.ParseSwimlanes(m.board.Swimlanes)
+	}
+	if m.swimlanes == nil {
+		m.lanes = nil
+		m.focusedLane = 0
+		m.laneOffset = 0
+		return
+	}
+
+	focusedKey := ""
+	if m.focusedLane < len(m.lanes) {
+		focusedKey = m.lanes[m.focusedLane].Key
+	}
+	m.lanes = dto.GroupSwimlanes(*m.board, m.swimlanes)
+	m.focusedLane = 0
+	for i, lane := range m.lanes {
+		if lane.Key == focusedKey {
+			m.focusedLane = i
+			break
+		}
+	}
+	if m.laneOffset > m.focusedLane {
+		m.laneOffset = m.focusedLane
 	}
 }
 
@@ -89,21 +140,54 @@ func (m Model) waitForNotification() tea.Cmd {
 	}
 }
 
+// columnTasks returns the tasks of a column that focus moves through: those
+// in the focused lane, none if it is collapsed, or the whole column without
+// swimlanes
+func (m Model) columnTasks(colIndex int) []dto.TaskDTO {
+	if colIndex < 0 || colIndex >= len(m.board.Columns) {
+		return nil
+	}
+	if m.lanes == nil {
+		return m.board.Columns[colIndex].Tasks
+	}
+	if m.focusedLane >= len(m.lanes) {
+		return nil
+	}
+	lane := m.lanes[m.focusedLane]
+	if m.collapsedLanes[lane.Key] {
+		return nil
+	}
+	return lane.Columns[colIndex].Tasks
+}
+
 // Helper to get task count in current column
 func (m Model) currentColumnTaskCount() int {
-	if m.focusedColumn < 0 || m.focusedColumn >= len(m.board.Columns) {
-		return 0
-	}
-	return len(m.board.Columns[m.focusedColumn].Tasks)
+	return len(m.columnTasks(m.focusedColumn))
 }
 
 // Helper to get current task
 func (m Model) currentTask() *dto.TaskDTO {
-	count := m.currentColumnTaskCount()
-	if count == 0 || m.focusedTask < 0 || m.focusedTask >= count {
+	tasks := m.columnTasks(m.focusedColumn)
+	if len(tasks) == 0 || m.focusedTask < 0 || m.focusedTask >= len(tasks) {
 		return nil
 	}
-	return &m.board.Columns[m.focusedColumn].Tasks[m.focusedTask]
+	return &tasks[m.focusedTask]
+}
+
+// visibleTaskCount returns how many task cards fit in a column, or in a
+// column of one lane
+func (m Model) visibleTaskCount() int {
+	// Estimate how many tasks can fit (rough estimate: ~6 lines per task card)
+	availableTaskHeight := m.height - 8
+	maxVisibleTasks := availableTaskHeight / 6
+	if m.lanes != nil {
+		// Leave room for the lanes around the focused one
+		maxVisibleTasks /= 2
+	}
+	if maxVisibleTasks < 1 {
+		maxVisibleTasks = 1
+	}
+	return maxVisibleTasks
 }
 
 // Helper to get scroll offset for current column
@@ -21,6 +21,8 @@ var (
 	TaskCardStyle        lipgloss.Style
 	SelectedTaskCardStyle lipgloss.Style
 	ScrollIndicatorStyle  lipgloss.Style
+	SwimlaneTitleStyle    lipgloss.Style
+	ProgressStyle         lipgloss.Style
 )
 
 // InitStyles initializes the styles from config
@@ -132,6 +134,22 @@ func InitStyles(cfg *config.Config) {
 	if styles.ScrollIndicator.Bold {
 		ScrollIndicatorStyle = ScrollIndicatorStyle.Bold(true)
 	}
+
+	// Swimlane title style, falling back to column titles for older configs
+	laneTitle := styles.SwimlaneTitle
+	if laneTitle.Foreground == "" {
+		laneTitle = styles.ColumnTitle
+	}
+	SwimlaneTitleStyle = lipgloss.NewStyle().Bold(laneTitle.Bold)
+	if laneTitle.Foreground != "" {
+		SwimlaneTitleStyle = SwimlaneTitleStyle.Foreground(lipgloss.Color(laneTitle.Foreground))
+	}
+
+	// Subtask progress bar style, in the tag color for older configs
+	ProgressStyle = TagStyle
+	if styles.Progress.Foreground != "" {
+		ProgressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.Progress.Foreground))
+	}
 }
 
 // getBorder returns the border style based on the name
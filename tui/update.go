@@ -2,7 +2,10 @@ package tui
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -67,6 +70,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case BoardUpdateMsg:
 		// Board has been reloaded
 		m.board = msg.board
+		m.regroup()
 		// Ensure scroll offsets array matches board columns
 		if len(m.scrollOffsets) != len(m.board.Columns) {
 			m.scrollOffsets = make([]int, len(m.board.Columns))
@@ -82,6 +86,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		m.notice = ""
 		switch {
 		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
@@ -106,6 +111,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.Delete):
 			m.deleteTask()
+
+		case key.Matches(msg, keys.LaneUp):
+			m.moveTaskToLane(-1)
+
+		case key.Matches(msg, keys.LaneDown):
+			m.moveTaskToLane(1)
+
+		case key.Matches(msg, keys.ToggleLane):
+			m.toggleLane()
 		}
 	}
 
@@ -119,12 +133,7 @@ func (m *Model) moveLeft() {
 		m.focusedTask = 0
 		m.clampTaskFocus()
 		// Update vertical scroll for new column
-		availableTaskHeight := m.height - 8
-		maxVisibleTasks := availableTaskHeight / 6
-		if maxVisibleTasks < 1 {
-			maxVisibleTasks = 1
-		}
-		m.updateScroll(maxVisibleTasks)
+		m.updateScroll(m.visibleTaskCount())
 		// Update horizontal scroll to keep column visible
 		m.updateHorizontalScroll(m.calculateVisibleColumns())
 	}
@@ -137,12 +146,7 @@ func (m *Model) moveRight() {
 		m.focusedTask = 0
 		m.clampTaskFocus()
 		// Update vertical scroll for new column
-		availableTaskHeight := m.height - 8
-		maxVisibleTasks := availableTaskHeight / 6
-		if maxVisibleTasks < 1 {
-			maxVisibleTasks = 1
-		}
-		m.updateScroll(maxVisibleTasks)
+		m.updateScroll(m.visibleTaskCount())
 		// Update horizontal scroll to keep column visible
 		m.updateHorizontalScroll(m.calculateVisibleColumns())
 	}
@@ -162,32 +166,122 @@ func (m *Model) calculateVisibleColumns() int {
 	return maxVisibleColumns
 }
 
-// moveUp moves focus to the task above
+// moveUp moves focus to the task above, crossing into the lane above from
+// the top of a lane
 func (m *Model) moveUp() {
 	if m.focusedTask > 0 {
 		m.focusedTask--
 		// Update scroll to keep task visible
-		availableTaskHeight := m.height - 8
-		maxVisibleTasks := availableTaskHeight / 6
-		if maxVisibleTasks < 1 {
-			maxVisibleTasks = 1
-		}
-		m.updateScroll(maxVisibleTasks)
+		m.updateScroll(m.visibleTaskCount())
+	} else if m.lanes != nil && m.focusedLane > 0 {
+		m.focusLane(m.focusedLane - 1)
+		m.focusedTask = m.currentColumnTaskCount() - 1
+		m.clampTaskFocus()
+		m.updateScroll(m.visibleTaskCount())
 	}
 }
 
-// moveDown moves focus to the task below
+// moveDown moves focus to the task below, crossing into the lane below from
+// the bottom of a lane
 func (m *Model) moveDown() {
 	taskCount := m.currentColumnTaskCount()
 	if m.focusedTask < taskCount-1 {
 		m.focusedTask++
 		// Update scroll to keep task visible
-		availableTaskHeight := m.height - 8
-		maxVisibleTasks := availableTaskHeight / 6
-		if maxVisibleTasks < 1 {
-			maxVisibleTasks = 1
+		m.updateScroll(m.visibleTaskCount())
+	} else if m.lanes != nil && m.focusedLane < len(m.lanes)-1 {
+		m.focusLane(m.focusedLane + 1)
+		m.focusedTask = 0
+		m.updateScroll(m.visibleTaskCount())
+	}
+}
+
+// focusLane moves focus to another lane, scrolling the lanes to show it
+func (m *Model) focusLane(lane int) {
+	m.focusedLane = lane
+	if m.focusedColumn < len(m.scrollOffsets) {
+		m.scrollOffsets[m.focusedColumn] = 0
+	}
+	m.updateLaneScroll()
+}
+
+// toggleLane collapses the focused lane, or expands it if it is collapsed
+func (m *Model) toggleLane() {
+	if m.lanes == nil || m.focusedLane >= len(m.lanes) {
+		return
+	}
+	laneKey := m.lanes[m.focusedLane].Key
+	if m.collapsedLanes[laneKey] {
+		delete(m.collapsedLanes, laneKey)
+	} else {
+		m.collapsedLanes[laneKey] = true
+	}
+	m.focusedTask = 0
+	m.clampTaskFocus()
+	m.updateScroll(m.visibleTaskCount())
+	m.updateLaneScroll()
+}
+
+// moveTaskToLane moves the focused task into the lane delta lanes away by
+// changing the tag, priority or parent the lanes group by
+func (m *Model) moveTaskToLane(delta int) {
+	task := m.currentTask()
+	if m.lanes == nil || task == nil {
+		return
+	}
+	target := m.focusedLane + delta
+	if target < 0 || target >= len(m.lanes) {
+		return
+	}
+	taskID, shortID := task.ID, task.ShortID
+	lane := m.lanes[target]
+
+	// Lanes chosen on the command line aren't the board's, so name them
+	swimlanes := ""
+	if m.fixedSwimlanes {
+		swimlanes = m.swimlanes.String()
+	}
+
+	ctx := context.Background()
+	updatedBoard, err := m.daemonClient.MoveTaskToLane(ctx, m.board.ID, taskID, swimlanes, lane.Key)
+	if err != nil {
+		m.setMoveNotice(shortID, lane.Title, err)
+		return
+	}
+
+	m.board = updatedBoard
+	delete(m.collapsedLanes, lane.Key)
+	m.focusedLane = target
+	m.regroup()
+	m.focusTask(taskID)
+}
+
+// focusTask focuses a task of the focused column and lane by ID
+func (m *Model) focusTask(taskID string) {
+	for i, task := range m.columnTasks(m.focusedColumn) {
+		if task.ID == taskID {
+			m.focusedTask = i
+			break
 		}
-		m.updateScroll(maxVisibleTasks)
+	}
+	m.clampTaskFocus()
+	m.updateScroll(m.visibleTaskCount())
+	m.updateLaneScroll()
+}
+
+// updateLaneScroll scrolls the lanes so the focused one is shown
+func (m *Model) updateLaneScroll() {
+	if m.lanes == nil {
+		m.laneOffset = 0
+		return
+	}
+	if m.focusedLane < m.laneOffset {
+		m.laneOffset = m.focusedLane
+		return
+	}
+
+	for m.laneOffset < m.focusedLane && m.lanesHeight(m.laneOffset, m.focusedLane) > m.lanesViewportHeight() {
+		m.laneOffset++
 	}
 }
 
@@ -204,21 +298,26 @@ func (m *Model) moveTask() {
 	}
 
 	// Get the current task
-	task := m.board.Columns[m.focusedColumn].Tasks[m.focusedTask]
+	task := *m.currentTask()
 
 	// Get target column name
 	targetColumnName := m.board.Columns[m.focusedColumn+1].Name
 
 	// Use the daemon client to move the task
 	ctx := context.Background()
-	updatedBoard, err := m.daemonClient.MoveTask(ctx, m.board.ID, task.ID, targetColumnName)
+	updatedBoard, warnings, err := m.daemonClient.MoveTask(ctx, m.board.ID, task.ID, targetColumnName)
 	if err != nil {
-		// Handle error (for now, just return)
+		m.setMoveNotice(task.ShortID, targetColumnName, err)
 		return
 	}
+	if len(warnings) > 0 {
+		m.notice = fmt.Sprintf("⚠ Moved %s to %s: %s", task.ShortID, targetColumnName, violationMessages(warnings))
+		m.noticeIsError = false
+	}
 
 	// Update local state
 	m.board = updatedBoard
+	m.regroup()
 
 	// Ensure scroll offsets array matches board columns
 	if len(m.scrollOffsets) != len(m.board.Columns) {
@@ -227,20 +326,43 @@ func (m *Model) moveTask() {
 
 	// Move focus to next column
 	m.focusedColumn++
-	m.focusedTask = len(m.board.Columns[m.focusedColumn].Tasks) - 1
+	m.focusedTask = m.currentColumnTaskCount() - 1
 	m.clampTaskFocus()
 
 	// Update vertical scroll for new position
-	availableTaskHeight := m.height - 8
-	maxVisibleTasks := availableTaskHeight / 6
-	if maxVisibleTasks < 1 {
-		maxVisibleTasks = 1
-	}
-	m.updateScroll(maxVisibleTasks)
+	m.updateScroll(m.visibleTaskCount())
 	// Update horizontal scroll to keep column visible
 	m.updateHorizontalScroll(m.calculateVisibleColumns())
 }
 
+// setMoveNotice reports a failed move, listing the column rules that refused it
+func (m *Model) setMoveNotice(taskID, targetColumnName string, err error) {
+	m.noticeIsError = true
+
+	var respErr *daemon.ResponseError
+	if errors.As(err, &respErr) && len(respErr.Violations) > 0 {
+		blocking := make([]dto.RuleViolationDTO, 0, len(respErr.Violations))
+		for _, violation := range respErr.Violations {
+			if violation.Severity == "block" {
+				blocking = append(blocking, violation)
+			}
+		}
+		m.notice = fmt.Sprintf("✗ Can't move %s to %s: %s", taskID, targetColumnName, violationMessages(blocking))
+		return
+	}
+
+	m.notice = fmt.Sprintf("✗ Can't move %s to %s: %v", taskID, targetColumnName, err)
+}
+
+// violationMessages joins the messages of column rule violations
+func violationMessages(violations []dto.RuleViolationDTO) string {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
 // addTask adds a new task to the current column
 func (m *Model) addTask() {
 	// Get current column name
@@ -255,12 +377,24 @@ func (m *Model) addTask() {
 		ColumnName:  columnName,
 	}
 
-	_, err := m.daemonClient.CreateTask(ctx, m.board.ID, createReq)
+	task, err := m.daemonClient.CreateTask(ctx, m.board.ID, createReq)
 	if err != nil {
 		// Handle error (for now, just return)
 		return
 	}
 
+	// Put the task in the focused lane
+	if m.lanes != nil && m.swimlanes.Movable() && m.focusedLane < len(m.lanes) {
+		laneKey := m.lanes[m.focusedLane].Key
+		if m.swimlanes.LaneOf(task.Tags, task.Priority, task.ParentID, task.ProjectID) != laneKey {
+			swimlanes := ""
+			if m.fixedSwimlanes {
+				swimlanes = m.swimlanes.String()
+			}
+			_, _ = m.daemonClient.MoveTaskToLane(ctx, m.board.ID, task.ID, swimlanes, laneKey)
+		}
+	}
+
 	// Reload the board to get updated state
 	updatedBoard, err := m.daemonClient.GetBoard(ctx, m.board.ID)
 	if err != nil {
@@ -268,6 +402,7 @@ func (m *Model) addTask() {
 	}
 
 	m.board = updatedBoard
+	m.regroup()
 
 	// Ensure scroll offsets array matches board columns
 	if len(m.scrollOffsets) != len(m.board.Columns) {
@@ -275,15 +410,10 @@ func (m *Model) addTask() {
 	}
 
 	// Focus the new task
-	m.focusedTask = len(m.board.Columns[m.focusedColumn].Tasks) - 1
+	m.focusedTask = m.currentColumnTaskCount() - 1
 
 	// Update scroll to show the new task
-	availableTaskHeight := m.height - 8
-	maxVisibleTasks := availableTaskHeight / 6
-	if maxVisibleTasks < 1 {
-		maxVisibleTasks = 1
-	}
-	m.updateScroll(maxVisibleTasks)
+	m.updateScroll(m.visibleTaskCount())
 }
 
 // reloadBoard reloads the board from the daemon
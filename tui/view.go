@@ -9,28 +9,29 @@ import (
 	"mkanban/tui/style"
 )
 
-// View renders the UI
-func (m Model) View() string {
-	if m.width == 0 {
-		return "Loading..."
-	}
+// boardLayout is the width of the columns and which of them are shown
+type boardLayout struct {
+	columnWidth         int // content width, not including borders/padding
+	renderedColumnWidth int
+	startCol            int
+	endCol              int
+}
 
-	// Calculate column width - account for borders, padding, and spacing
-	totalColumns := len(m.board.Columns)
-	if totalColumns == 0 {
-		return "No columns"
-	}
+// Column width constraints (content width, not including borders/padding)
+const minColumnWidth = 25 // Minimum content width
+const maxColumnWidth = 60 // Maximum content width
+const columnSpacing = 2   // Space between columns
 
-	// Column width constraints (content width, not including borders/padding)
-	const minColumnWidth = 25   // Minimum content width
-	const maxColumnWidth = 60   // Maximum content width
-	const columnSpacing = 2     // Space between columns
+// Column overhead: borders (2 chars) + horizontal padding (2*2 = 4 chars)
+const columnOverhead = 6
 
-	// Column overhead: borders (2 chars) + horizontal padding (2*2 = 4 chars)
-	const columnOverhead = 6
+// Reserve space for scroll indicators when needed
+const indicatorWidth = 5
 
-	// Reserve space for scroll indicators when needed
-	indicatorWidth := 5
+// layout calculates the column width and the visible columns for the
+// terminal width
+func (m Model) layout() boardLayout {
+	totalColumns := len(m.board.Columns)
 	availableWidthForColumns := m.width - (indicatorWidth * 2)
 
 	// Calculate optimal column width to fit all columns if possible
@@ -41,7 +42,7 @@ func (m Model) View() string {
 	minRenderedWidth := minColumnWidth + columnOverhead
 	minSpaceNeeded := (minRenderedWidth * totalColumns) + spacingNeeded
 
-	if availableWidthForColumns >= minSpaceNeeded {
+	if totalColumns > 0 && availableWidthForColumns >= minSpaceNeeded {
 		// All columns can fit - calculate width to distribute remaining space
 		spaceForColumns := availableWidthForColumns - spacingNeeded
 		proposedRenderedWidth := spaceForColumns / totalColumns
@@ -79,6 +80,31 @@ func (m Model) View() string {
 		endCol = totalColumns
 	}
 
+	return boardLayout{
+		columnWidth:         columnWidth,
+		renderedColumnWidth: renderedColumnWidth,
+		startCol:            startCol,
+		endCol:              endCol,
+	}
+}
+
+// View renders the UI
+func (m Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	// Calculate column width - account for borders, padding, and spacing
+	totalColumns := len(m.board.Columns)
+	if totalColumns == 0 {
+		return "No columns"
+	}
+
+	layout := m.layout()
+	columnWidth := layout.columnWidth
+	startCol, endCol := layout.startCol, layout.endCol
+	maxVisibleColumns := endCol - startCol
+
 	// Show scroll indicators
 	showLeftIndicator := startCol > 0
 	showRightIndicator := endCol < totalColumns
@@ -102,10 +128,14 @@ func (m Model) View() string {
 		columnsToRender = append(columnsToRender, indicator)
 	}
 
-	// Render only visible columns
-	for i := startCol; i < endCol; i++ {
-		col := m.board.Columns[i]
-		columnsToRender = append(columnsToRender, m.renderColumn(col, i, columnWidth, availableTaskHeight))
+	// Render only visible columns, or the lanes across them
+	if m.lanes != nil {
+		columnsToRender = append(columnsToRender, m.renderSwimlanes(layout))
+	} else {
+		for i := startCol; i < endCol; i++ {
+			col := m.board.Columns[i]
+			columnsToRender = append(columnsToRender, m.renderColumn(col, i, columnWidth, availableTaskHeight))
+		}
 	}
 
 	// Add right scroll indicator if needed
@@ -126,7 +156,7 @@ func (m Model) View() string {
 	// Center the board if there's extra space on the right
 	// Calculate actual width used by visible columns
 	numVisibleColumns := endCol - startCol
-	totalColumnWidth := (renderedColumnWidth * numVisibleColumns) + (columnSpacing * (numVisibleColumns - 1))
+	totalColumnWidth := (layout.renderedColumnWidth * numVisibleColumns) + (columnSpacing * (numVisibleColumns - 1))
 
 	// Add indicator widths if they're shown
 	if showLeftIndicator {
@@ -246,14 +276,166 @@ func (m Model) renderColumn(col dto.ColumnDTO, colIndex int, width int, viewport
 	return style.ColumnStyle.Height(m.height - 6).Render(content)
 }
 
+// renderSwimlanes renders the visible columns split into lanes: a row of
+// column titles, then each lane as a heading over a row of cells, or just
+// the heading when collapsed
+func (m Model) renderSwimlanes(layout boardLayout) string {
+	var titles []string
+	for i := layout.startCol; i < layout.endCol; i++ {
+		titles = append(titles, lipgloss.NewStyle().
+			Width(laneCellWidth(layout)+style.ColumnStyle.GetHorizontalFrameSize()).
+			Render(style.ColumnTitleStyle.Width(layout.columnWidth).Render(" "+m.board.Columns[i].Name)))
+	}
+	rows := []string{lipgloss.JoinHorizontal(lipgloss.Top, titles...)}
+
+	if m.laneOffset > 0 {
+		rows = append(rows, style.ScrollIndicatorStyle.Render(fmt.Sprintf("▲ %d more lanes", m.laneOffset)))
+	}
+
+	// Show lanes from the first visible one while they fit, and always the
+	// focused one
+	endLane := m.laneOffset
+	for endLane < len(m.lanes) {
+		if endLane > m.focusedLane && m.lanesHeight(m.laneOffset, endLane) > m.lanesViewportHeight() {
+			break
+		}
+		rows = append(rows, m.renderLane(endLane, layout))
+		endLane++
+	}
+
+	if endLane < len(m.lanes) {
+		rows = append(rows, style.ScrollIndicatorStyle.Render(fmt.Sprintf("▼ %d more lanes", len(m.lanes)-endLane)))
+	}
+
+	return lipgloss.NewStyle().Height(m.height - 6).Render(strings.Join(rows, "\n"))
+}
+
+// renderLane renders one lane: its heading and, unless it's collapsed, a row
+// with a cell of cards for each visible column
+func (m Model) renderLane(laneIndex int, layout boardLayout) string {
+	lane := m.lanes[laneIndex]
+	isFocusedLane := laneIndex == m.focusedLane
+	collapsed := m.collapsedLanes[lane.Key]
+
+	marker := "▾"
+	if collapsed {
+		marker = "▸"
+	}
+	titleStyle := style.SwimlaneTitleStyle
+	if isFocusedLane {
+		titleStyle = titleStyle.Underline(true)
+	}
+	heading := titleStyle.Render(fmt.Sprintf("%s %s (%d)", marker, lane.Title, lane.TaskCount))
+	if collapsed {
+		return heading
+	}
+
+	maxVisibleTasks := m.visibleTaskCount()
+	var cells []string
+	for i := layout.startCol; i < layout.endCol; i++ {
+		tasks := lane.Columns[i].Tasks
+		isFocused := isFocusedLane && i == m.focusedColumn
+
+		// Only the focused cell scrolls; the others show their first cards
+		scrollOffset := 0
+		if isFocused && i < len(m.scrollOffsets) {
+			scrollOffset = m.scrollOffsets[i]
+		}
+		endIdx := scrollOffset + maxVisibleTasks
+		if endIdx > len(tasks) {
+			endIdx = len(tasks)
+		}
+
+		var cards []string
+		if scrollOffset > 0 {
+			cards = append(cards, style.ScrollIndicatorStyle.Width(layout.columnWidth).Render("▲ more above ▲"))
+		}
+		for j := scrollOffset; j < endIdx; j++ {
+			cards = append(cards, renderTaskCard(tasks[j], layout.columnWidth, isFocused && j == m.focusedTask, m.config))
+		}
+		if endIdx < len(tasks) {
+			cards = append(cards, style.ScrollIndicatorStyle.Width(layout.columnWidth).Render("▼ more below ▼"))
+		}
+		if len(tasks) == 0 {
+			emptyStyle := style.TaskStyle.Width(layout.columnWidth).Faint(true)
+			cards = append(cards, emptyStyle.Render("(empty)"))
+		}
+		cells = append(cells, strings.Join(cards, "\n"))
+	}
+
+	// Give every cell of the lane the height of the tallest
+	cellHeight := 0
+	for _, cell := range cells {
+		cellHeight = max(cellHeight, lipgloss.Height(cell))
+	}
+	for c, cell := range cells {
+		cellStyle := style.ColumnStyle
+		if isFocusedLane && layout.startCol+c == m.focusedColumn {
+			cellStyle = style.FocusedColumnStyle
+		}
+		cell = lipgloss.NewStyle().Width(laneCellWidth(layout)).Height(cellHeight).Render(cell)
+		cells[c] = cellStyle.Render(cell)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, heading, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+}
+
+// laneCellWidth is the content width of a lane cell: a task card with its
+// border
+func laneCellWidth(layout boardLayout) int {
+	return layout.columnWidth + 2
+}
+
+// laneHeight estimates the lines a lane takes up (rough estimate: ~6 lines
+// per task card)
+func (m Model) laneHeight(laneIndex int) int {
+	lane := m.lanes[laneIndex]
+	if m.collapsedLanes[lane.Key] {
+		return 1
+	}
+	cards := 1
+	for _, col := range lane.Columns {
+		cards = max(cards, min(len(col.Tasks), m.visibleTaskCount()))
+	}
+	// Heading, cards and cell borders
+	return 1 + cards*6 + 2
+}
+
+// lanesHeight estimates the lines lanes from through to take up
+func (m Model) lanesHeight(from, to int) int {
+	height := 0
+	for i := from; i <= to && i < len(m.lanes); i++ {
+		height += m.laneHeight(i)
+	}
+	return height
+}
+
+// lanesViewportHeight is the height left for lanes below the column titles,
+// help and lane scroll indicators
+func (m Model) lanesViewportHeight() int {
+	return m.height - 9
+}
+
 // renderHelp renders the help text at the bottom
 func (m Model) renderHelp() string {
 	helpText := []string{
 		"Navigation: ←/h,→/l (columns)  ↑/k,↓/j (tasks)",
 		"Actions: a (add)  d (delete)  m/enter (move)  q (quit)",
 	}
+	if m.lanes != nil {
+		helpText = append(helpText, "Lanes: K/J (move task)  z (collapse)")
+	}
+
+	help := style.HelpStyle.Render(strings.Join(helpText, "  •  "))
+	if m.notice == "" {
+		return help
+	}
 
-	return style.HelpStyle.Render(strings.Join(helpText, "  •  "))
+	noticeStyle := style.OverdueStyle
+	if !m.noticeIsError {
+		noticeStyle = style.DueDateStyle.Foreground(lipgloss.Color(m.config.TUI.Styles.DueDateUrgency.DueSoon))
+	}
+	return noticeStyle.Render(m.notice) + "\n" + help
 }
 
 // statusMessage for debugging (optional)